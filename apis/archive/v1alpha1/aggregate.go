@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // AggregateOperator describe the operator type for aggregate
@@ -34,6 +35,12 @@ const (
 	AggregateOperatorSum AggregateOperator = "sum"
 	// AggregateOperatorCount is the operator for count
 	AggregateOperatorCount AggregateOperator = "count"
+	// AggregateOperatorAvg is the operator for average
+	AggregateOperatorAvg AggregateOperator = "avg"
+	// AggregateOperatorCountDistinct is the operator for counting distinct values
+	AggregateOperatorCountDistinct AggregateOperator = "countDistinct"
+	// AggregateOperatorPercentile is the operator for continuous percentile
+	AggregateOperatorPercentile AggregateOperator = "percentile"
 )
 
 // AggregateField is a field for aggregate
@@ -41,6 +48,37 @@ type AggregateField struct {
 	Field `json:",inline"`
 	// Operator is the operator for aggregate
 	Operator AggregateOperator `json:"operator"`
+	// Percentile is the fraction between 0 and 1 used by the percentile operator,
+	// e.g. 0.95 for p95
+	Percentile float64 `json:"percentile,omitempty"`
+}
+
+// TimeBucketUnit describe the unit of a time bucket
+type TimeBucketUnit string
+
+const (
+	// TimeBucketUnitHour groups timestamps by hour
+	TimeBucketUnitHour TimeBucketUnit = "hour"
+	// TimeBucketUnitDay groups timestamps by day
+	TimeBucketUnitDay TimeBucketUnit = "day"
+	// TimeBucketUnitWeek groups timestamps by week, weeks start on Monday
+	TimeBucketUnitWeek TimeBucketUnit = "week"
+	// TimeBucketUnitMonth groups timestamps by month
+	TimeBucketUnitMonth TimeBucketUnit = "month"
+)
+
+// TimeBucketLayout is the layout of time bucket values in aggregate results.
+// The value is the start of the bucket in the time zone of the bucket.
+const TimeBucketLayout = "2006-01-02T15:04:05"
+
+// TimeBucket groups a timestamp field into time buckets.
+// The field must be a unix timestamp in seconds, such as creationTimestamp.
+type TimeBucket struct {
+	Field `json:",inline"`
+	// Unit is the unit of the bucket
+	Unit TimeBucketUnit `json:"unit"`
+	// TimeZone is the IANA time zone name used to truncate timestamps, defaults to UTC
+	TimeZone string `json:"timezone,omitempty"`
 }
 
 // AggregateQuery describe the query params for aggregate
@@ -51,6 +89,8 @@ type AggregateQuery struct {
 	GroupFields []Field `json:"group_fields"`
 	// AggregateFields is the fields for aggregate
 	AggregateFields []AggregateField `json:"aggregate_fields"`
+	// TimeBuckets is the time buckets for group by, they are grouped after GroupFields
+	TimeBuckets []TimeBucket `json:"time_buckets,omitempty"`
 }
 
 // Max generate aggregate field with max operator
@@ -85,6 +125,73 @@ func Count(alias string) AggregateField {
 	}
 }
 
+// Avg generate aggregate field with avg operator
+func Avg(name, alias string) AggregateField {
+	return AggregateField{
+		Field:    Field{Name: name, Alias: alias},
+		Operator: AggregateOperatorAvg,
+	}
+}
+
+// CountDistinct generate aggregate field with count distinct operator
+func CountDistinct(name, alias string) AggregateField {
+	return AggregateField{
+		Field:    Field{Name: name, Alias: alias},
+		Operator: AggregateOperatorCountDistinct,
+	}
+}
+
+// Percentile generate aggregate field with percentile operator,
+// percentile is a fraction between 0 and 1
+func Percentile(name, alias string, percentile float64) AggregateField {
+	return AggregateField{
+		Field:      Field{Name: name, Alias: alias},
+		Operator:   AggregateOperatorPercentile,
+		Percentile: percentile,
+	}
+}
+
+// Bucket generate time bucket of a timestamp field
+func Bucket(name, alias string, unit TimeBucketUnit, timezone string) TimeBucket {
+	return TimeBucket{
+		Field:    Field{Name: name, Alias: alias},
+		Unit:     unit,
+		TimeZone: timezone,
+	}
+}
+
+// Location returns the location of the time zone, UTC is returned if not set
+func (b TimeBucket) Location() (*time.Location, error) {
+	if b.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(b.TimeZone)
+}
+
+// Truncate returns the start of the bucket containing t in the location
+func (b TimeBucket) Truncate(t time.Time, loc *time.Location) (time.Time, error) {
+	t = t.In(loc)
+	year, month, day := t.Date()
+	switch b.Unit {
+	case TimeBucketUnitHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc), nil
+	case TimeBucketUnitDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case TimeBucketUnitWeek:
+		// weekday of Monday is 0
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc), nil
+	case TimeBucketUnitMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported time bucket unit %q", b.Unit)
+}
+
+// ParseTimeBucket parses a time bucket value of aggregate result in the location
+func ParseTimeBucket(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(TimeBucketLayout, value, loc)
+}
+
 // AggregateResult describe the result of aggregate query
 type AggregateResult []map[string]interface{}
 
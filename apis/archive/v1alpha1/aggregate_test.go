@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 )
@@ -107,3 +108,60 @@ func TestAggregateFunc(t *testing.T) {
 		Operator: AggregateOperatorSum,
 	}))
 }
+
+func TestAggregateFunc_Extended(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(Avg("test-key", "test-key-alias")).To(gomega.Equal(AggregateField{
+		Field:    Field{Name: "test-key", Alias: "test-key-alias"},
+		Operator: AggregateOperatorAvg,
+	}))
+	g.Expect(CountDistinct("test-key", "test-key-alias")).To(gomega.Equal(AggregateField{
+		Field:    Field{Name: "test-key", Alias: "test-key-alias"},
+		Operator: AggregateOperatorCountDistinct,
+	}))
+	g.Expect(Percentile("test-key", "test-key-alias", 0.95)).To(gomega.Equal(AggregateField{
+		Field:      Field{Name: "test-key", Alias: "test-key-alias"},
+		Operator:   AggregateOperatorPercentile,
+		Percentile: 0.95,
+	}))
+	g.Expect(Bucket("test-key", "test-key-alias", TimeBucketUnitDay, "UTC")).To(gomega.Equal(TimeBucket{
+		Field:    Field{Name: "test-key", Alias: "test-key-alias"},
+		Unit:     TimeBucketUnitDay,
+		TimeZone: "UTC",
+	}))
+}
+
+func TestTimeBucket_Truncate(t *testing.T) {
+	loc, err := TimeBucket{TimeZone: "Asia/Shanghai"}.Location()
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	// 2023-05-03 01:30 in Asia/Shanghai, which is Wednesday
+	ts := time.Date(2023, 5, 2, 17, 30, 0, 0, time.UTC)
+	tests := map[TimeBucketUnit]string{
+		TimeBucketUnitHour:  "2023-05-03T01:00:00",
+		TimeBucketUnitDay:   "2023-05-03T00:00:00",
+		TimeBucketUnitWeek:  "2023-05-01T00:00:00",
+		TimeBucketUnitMonth: "2023-05-01T00:00:00",
+	}
+	for unit, want := range tests {
+		t.Run(string(unit), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			start, err := TimeBucket{Unit: unit}.Truncate(ts, loc)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(start.Format(TimeBucketLayout)).To(gomega.Equal(want))
+
+			parsed, err := ParseTimeBucket(want, loc)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(parsed.Equal(start)).To(gomega.BeTrue())
+		})
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	_, err = TimeBucket{Unit: "year"}.Truncate(ts, loc)
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	loc, err = TimeBucket{}.Location()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(loc).To(gomega.Equal(time.UTC))
+}
@@ -165,6 +165,25 @@ func Like(key, value string) Condition {
 	}
 }
 
+// JSONPathMatch generate condition with jsonpath operator,
+// the values selected by path from the value of key are compared using operator and value
+func JSONPathMatch(key, path string, operator ConditionOperator, value interface{}) Condition {
+	return Condition{
+		Key:      key,
+		Operator: ConditionOperatorJSONPath,
+		Value:    JSONPathCondition{Path: path, Operator: operator, Value: value},
+	}
+}
+
+// FullText generate condition with fulltext operator
+func FullText(key, text string) Condition {
+	return Condition{
+		Key:      key,
+		Operator: ConditionOperatorFullText,
+		Value:    text,
+	}
+}
+
 // NotEqual generate condition with not equal operator
 func NotEqual(key, value string) Condition {
 	return Condition{Key: key, Operator: ConditionOperatorNotEqual, Value: value}
@@ -214,3 +233,12 @@ func Exist(key string) Condition {
 		Value:    "",
 	}
 }
+
+// NotExist generate condition with NotExist operator
+func NotExist(key string) Condition {
+	return Condition{
+		Key:      key,
+		Operator: ConditionOperatorNotExist,
+		Value:    "",
+	}
+}
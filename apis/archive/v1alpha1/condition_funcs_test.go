@@ -57,6 +57,16 @@ func TestFieldCondition(t *testing.T) {
 		Operator: ConditionOperatorNotEqual,
 		Value:    "value",
 	}))
+	g.Expect(FullText("key", "value")).To(gomega.Equal(Condition{
+		Key:      "key",
+		Operator: ConditionOperatorFullText,
+		Value:    "value",
+	}))
+	g.Expect(JSONPathMatch("key", "a.b", ConditionOperatorEqual, "value")).To(gomega.Equal(Condition{
+		Key:      "key",
+		Operator: ConditionOperatorJSONPath,
+		Value:    JSONPathCondition{Path: "a.b", Operator: ConditionOperatorEqual, Value: "value"},
+	}))
 }
 
 func TestFieldCondition_equal(t *testing.T) {
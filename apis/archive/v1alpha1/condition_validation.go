@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// supportedConditionOperators are all the operators could be used in a condition
+var supportedConditionOperators = []string{
+	string(ConditionOperatorAnd), string(ConditionOperatorOr), string(ConditionOperatorIn), string(ConditionOperatorExist),
+	string(ConditionOperatorNotExist), string(ConditionOperatorEqual), string(ConditionOperatorEqualColumn),
+	string(ConditionOperatorNotEqual),
+	string(ConditionOperatorGt), string(ConditionOperatorGte), string(ConditionOperatorLt), string(ConditionOperatorLte),
+	string(ConditionOperatorLike), string(ConditionOperatorJSONPath), string(ConditionOperatorFullText),
+}
+
+// ValidateConditions validates a list of conditions
+func ValidateConditions(conditions []Condition, path *field.Path) (errs field.ErrorList) {
+	errs = field.ErrorList{}
+	for i := range conditions {
+		errs = append(errs, conditions[i].Validate(path.Index(i))...)
+	}
+	return
+}
+
+// Validate make sure the condition is legitimate, nested conditions are validated as well
+func (p *Condition) Validate(path *field.Path) (errs field.ErrorList) {
+	errs = field.ErrorList{}
+	switch p.Operator {
+	case ConditionOperatorAnd, ConditionOperatorOr:
+		subs, err := subConditions(*p)
+		if err != nil {
+			return append(errs, field.Invalid(path.Child("value"), p.Value, err.Error()))
+		}
+		return append(errs, ValidateConditions(subs, path.Child("value"))...)
+	case ConditionOperatorJSONPath:
+		errs = append(errs, validateKey(p.Key, path.Child("key"))...)
+		jsonPathCond, err := jsonPathConditionValue(*p)
+		if err != nil {
+			return append(errs, field.Invalid(path.Child("value"), p.Value, err.Error()))
+		}
+		return append(errs, jsonPathCond.Validate(path.Child("value"))...)
+	case ConditionOperatorIn, ConditionOperatorExist, ConditionOperatorNotExist, ConditionOperatorEqual, ConditionOperatorEqualColumn,
+		ConditionOperatorNotEqual, ConditionOperatorGt, ConditionOperatorGte, ConditionOperatorLt,
+		ConditionOperatorLte, ConditionOperatorLike, ConditionOperatorFullText:
+		errs = append(errs, validateKey(p.Key, path.Child("key"))...)
+		return append(errs, validateOperatorValue(p.Operator, p.Value, path.Child("value"))...)
+	}
+	return append(errs, field.NotSupported(path.Child("operator"), p.Operator, supportedConditionOperators))
+}
+
+// Validate make sure the json path condition is legitimate
+func (p *JSONPathCondition) Validate(path *field.Path) (errs field.ErrorList) {
+	errs = field.ErrorList{}
+	if _, err := ParseJSONPath(p.Path); err != nil {
+		errs = append(errs, field.Invalid(path.Child("path"), p.Path, err.Error()))
+	}
+	if !jsonPathOperators[p.Operator] {
+		supported := make([]string, 0, len(jsonPathOperators))
+		for operator := range jsonPathOperators {
+			supported = append(supported, string(operator))
+		}
+		sort.Strings(supported)
+		return append(errs, field.NotSupported(path.Child("operator"), p.Operator, supported))
+	}
+	return append(errs, validateOperatorValue(p.Operator, p.Value, path.Child("value"))...)
+}
+
+func validateKey(key string, path *field.Path) field.ErrorList {
+	if key == "" {
+		return field.ErrorList{field.Required(path, "key of condition is required")}
+	}
+	return nil
+}
+
+// validateOperatorValue validates the value according to the operator
+func validateOperatorValue(operator ConditionOperator, value interface{}, path *field.Path) field.ErrorList {
+	switch operator {
+	case ConditionOperatorIn:
+		if value == nil {
+			return nil
+		}
+		if kind := reflect.TypeOf(value).Kind(); kind != reflect.Slice && kind != reflect.Array {
+			return field.ErrorList{field.Invalid(path, value, "should be a list")}
+		}
+	case ConditionOperatorFullText:
+		text, ok := value.(string)
+		if !ok {
+			return field.ErrorList{field.Invalid(path, value, "should be a string")}
+		}
+		if strings.TrimSpace(text) == "" {
+			return field.ErrorList{field.Required(path, "text of full-text search is required")}
+		}
+	}
+	return nil
+}
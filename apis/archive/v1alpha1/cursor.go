@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+)
+
+// cursorPositionPrefix is the prefix of keys in the ext data of cursor
+// which store the values of the last returned record
+const cursorPositionPrefix = "position."
+
+// CursorOrders returns orders used by cursor based pagination.
+// The id field is appended as a tie breaker to keep the order stable.
+func CursorOrders(orders []Order) []Order {
+	for _, order := range orders {
+		if order.Field == IDField {
+			return orders
+		}
+	}
+	result := make([]Order, 0, len(orders)+1)
+	result = append(result, orders...)
+	return append(result, Order{Field: IDField, Asc: true})
+}
+
+// CursorConditions returns conditions selecting records after the position of the cursor.
+// Nil will be returned for a cursor without position, which means the first page.
+// Null values are ordered before all the other values, like the default of sqlite and mysql.
+func CursorConditions(cursor *metav1alpha1.TimeCursor, orders []Order) ([]Condition, error) {
+	if cursor == nil || len(cursor.ExtData) == 0 {
+		return nil, nil
+	}
+
+	orders = CursorOrders(orders)
+	values := make([]interface{}, 0, len(orders))
+	for _, order := range orders {
+		position, ok := cursor.ExtData[cursorPositionPrefix+order.Field]
+		if !ok {
+			return nil, fmt.Errorf("cursor has no position of order field %q", order.Field)
+		}
+		v, err := decodeCursorPosition(position)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position of order field %q in cursor: %w", order.Field, err)
+		}
+		values = append(values, v)
+	}
+
+	// (a > v1) or (a = v1 and b > v2) or ...
+	branches := make([]Condition, 0, len(orders))
+	for i, order := range orders {
+		after, ok := cursorAfter(order, values[i])
+		if !ok {
+			// nothing is after null in descending order
+			continue
+		}
+		conditions := make([]Condition, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, cursorEqual(orders[j].Field, values[j]))
+		}
+		conditions = append(conditions, after)
+		branches = append(branches, And(conditions...))
+	}
+	return []Condition{Or(branches...)}, nil
+}
+
+// cursorEqual returns the condition selecting records with the same value of field as the position
+func cursorEqual(field string, v interface{}) Condition {
+	if v == nil {
+		return NotExist(field)
+	}
+	return Condition{Key: field, Operator: ConditionOperatorEqual, Value: v}
+}
+
+// cursorAfter returns the condition selecting records after the position in the order,
+// false will be returned if no record could be after the position.
+func cursorAfter(order Order, v interface{}) (Condition, bool) {
+	switch {
+	case v == nil && order.Asc:
+		return Exist(order.Field), true
+	case v == nil:
+		return Condition{}, false
+	case order.Asc:
+		return Condition{Key: order.Field, Operator: ConditionOperatorGt, Value: v}, true
+	default:
+		return Or(Condition{Key: order.Field, Operator: ConditionOperatorLt, Value: v}, NotExist(order.Field)), true
+	}
+}
+
+// NextCursor returns a cursor positioned at the record, which is
+// the last one of the current page.
+// Values of the order fields are stored as json, and missing values are stored as null.
+func NextCursor(cursor *metav1alpha1.TimeCursor, orders []Order, spec *RecordSpec) *metav1alpha1.TimeCursor {
+	next := &metav1alpha1.TimeCursor{}
+	if cursor != nil {
+		cursor.DeepCopyInto(next)
+	}
+	if next.QueryStartAt == 0 {
+		next.QueryStartAt = time.Now().Unix()
+	}
+	next.ExtData = map[string]string{}
+	for _, order := range CursorOrders(orders) {
+		v, ok := spec.FieldValue(order.Field)
+		if !ok {
+			v = nil
+		}
+		next.ExtData[cursorPositionPrefix+order.Field] = encodeCursorPosition(v)
+	}
+	return next
+}
+
+func encodeCursorPosition(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+// decodeCursorPosition decodes a json position, numbers are decoded as int64 or float64
+func decodeCursorPosition(position string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(position))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return v, nil
+}
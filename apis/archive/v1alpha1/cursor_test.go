@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/onsi/gomega"
+)
+
+func TestCursorOrders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(CursorOrders(nil)).To(gomega.Equal([]Order{{Field: IDField, Asc: true}}))
+
+	orders := []Order{{Field: CreationTimestampField}}
+	g.Expect(CursorOrders(orders)).To(gomega.Equal([]Order{
+		{Field: CreationTimestampField},
+		{Field: IDField, Asc: true},
+	}))
+
+	orders = []Order{{Field: IDField}}
+	g.Expect(CursorOrders(orders)).To(gomega.Equal(orders))
+}
+
+func TestCursorConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	orders := []Order{{Field: CreationTimestampField}}
+
+	conditions, err := CursorConditions(&metav1alpha1.TimeCursor{}, orders)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(conditions).To(gomega.BeNil())
+
+	cursor := NextCursor(nil, orders, &RecordSpec{ID: 3, CreationTimestamp: 100})
+	g.Expect(cursor.QueryStartAt).NotTo(gomega.BeZero())
+	conditions, err = CursorConditions(cursor, orders)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(conditions).To(gomega.Equal([]Condition{
+		Or(
+			And(Or(Condition{Key: CreationTimestampField, Operator: ConditionOperatorLt, Value: int64(100)}, NotExist(CreationTimestampField))),
+			And(
+				Condition{Key: CreationTimestampField, Operator: ConditionOperatorEqual, Value: int64(100)},
+				Condition{Key: IDField, Operator: ConditionOperatorGt, Value: int64(3)},
+			),
+		),
+	}))
+
+	// null positions are ordered before all the other values
+	orders = []Order{{Field: MetadataKey("status"), Asc: true}, {Field: MetadataKey("reason")}}
+	cursor = NextCursor(nil, orders, &RecordSpec{ID: 3, Metadata: map[string]string{"status": "True"}})
+	g.Expect(cursor.ExtData).To(gomega.Equal(map[string]string{
+		cursorPositionPrefix + MetadataKey("status"): `"True"`,
+		cursorPositionPrefix + MetadataKey("reason"): "null",
+		cursorPositionPrefix + IDField:               "3",
+	}))
+	conditions, err = CursorConditions(cursor, orders)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(conditions).To(gomega.Equal([]Condition{
+		Or(
+			And(Condition{Key: MetadataKey("status"), Operator: ConditionOperatorGt, Value: "True"}),
+			And(
+				Condition{Key: MetadataKey("status"), Operator: ConditionOperatorEqual, Value: "True"},
+				NotExist(MetadataKey("reason")),
+				Condition{Key: IDField, Operator: ConditionOperatorGt, Value: int64(3)},
+			),
+		),
+	}))
+
+	// the cursor is matched against records in the same order
+	specs := []*RecordSpec{
+		{ID: 1, Metadata: map[string]string{"status": "True", "reason": "Failed"}},
+		{ID: 2, Metadata: map[string]string{"status": "True"}},
+		{ID: 4, Metadata: map[string]string{"status": "True"}},
+		{ID: 5, Metadata: map[string]string{"status": "Unknown"}},
+	}
+	matched := []uint{}
+	for _, spec := range specs {
+		ok, err := MatchConditions(spec, conditions)
+		g.Expect(err).To(gomega.BeNil())
+		if ok {
+			matched = append(matched, spec.ID)
+		}
+	}
+	g.Expect(matched).To(gomega.Equal([]uint{4, 5}))
+
+	// invalid position
+	_, err = CursorConditions(&metav1alpha1.TimeCursor{ExtData: map[string]string{cursorPositionPrefix + IDField: "<nil>"}}, nil)
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	// the position of an order field is missing
+	_, err = CursorConditions(cursor, []Order{{Field: NameField}})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestNextCursor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cursor := &metav1alpha1.TimeCursor{QueryStartAt: 1, Pager: metav1alpha1.Pager{ItemsPerPage: 10}}
+	next := NextCursor(cursor, nil, &RecordSpec{ID: 5})
+	g.Expect(next.QueryStartAt).To(gomega.Equal(int64(1)))
+	g.Expect(next.ItemsPerPage).To(gomega.Equal(10))
+	g.Expect(next.ExtData).To(gomega.Equal(map[string]string{"position.id": "5"}))
+	g.Expect(cursor.ExtData).To(gomega.BeNil())
+
+	parsed, err := metav1alpha1.ParseTimeCursor(next.Encode())
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed).To(gomega.Equal(next))
+}
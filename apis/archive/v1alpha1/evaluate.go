@@ -0,0 +1,313 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatchConditions returns true if the spec matches all the conditions.
+// It is the reference evaluator of conditions, storage plugins which could not
+// translate some conditions to their native queries may use it to filter records.
+func MatchConditions(spec *RecordSpec, conditions []Condition) (bool, error) {
+	for _, cond := range conditions {
+		matched, err := MatchCondition(spec, cond)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// MatchCondition evaluates a single condition against the spec.
+// Comparisons with a missing value never match, the same as NULL in sql.
+func MatchCondition(spec *RecordSpec, cond Condition) (bool, error) {
+	switch cond.Operator {
+	case ConditionOperatorAnd:
+		subs, err := subConditions(cond)
+		if err != nil {
+			return false, err
+		}
+		return MatchConditions(spec, subs)
+	case ConditionOperatorOr:
+		subs, err := subConditions(cond)
+		if err != nil {
+			return false, err
+		}
+		for _, sub := range subs {
+			matched, err := MatchCondition(spec, sub)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	case ConditionOperatorEqualColumn:
+		v, ok := spec.FieldValue(cond.Key)
+		if !ok {
+			return false, nil
+		}
+		other, ok := spec.FieldValue(fmt.Sprint(cond.Value))
+		if !ok {
+			return false, nil
+		}
+		return CompareValues(v, other) == 0, nil
+	case ConditionOperatorJSONPath:
+		jsonPathCond, err := jsonPathConditionValue(cond)
+		if err != nil {
+			return false, err
+		}
+		path, err := ParseJSONPath(jsonPathCond.Path)
+		if err != nil {
+			return false, err
+		}
+		if !jsonPathOperators[jsonPathCond.Operator] {
+			return false, fmt.Errorf("unsupported operator %q of json path condition", jsonPathCond.Operator)
+		}
+		v, ok := spec.FieldValue(cond.Key)
+		if !ok {
+			return false, nil
+		}
+		for _, item := range path.Select(v) {
+			matched, err := matchValue(item, true, jsonPathCond.Operator, jsonPathCond.Value)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	}
+	v, ok := spec.FieldValue(cond.Key)
+	return matchValue(v, ok, cond.Operator, cond.Value)
+}
+
+// matchValue compares a value with the operator and value of a condition
+func matchValue(v interface{}, exist bool, operator ConditionOperator, value interface{}) (bool, error) {
+	switch operator {
+	case ConditionOperatorExist:
+		return exist, nil
+	case ConditionOperatorNotExist:
+		return !exist || v == nil, nil
+	case ConditionOperatorIn:
+		if value == nil {
+			return false, nil
+		}
+		if kind := reflect.TypeOf(value).Kind(); kind != reflect.Slice && kind != reflect.Array {
+			return false, fmt.Errorf("value of operator %q must be a list, got %T", operator, value)
+		}
+		if !exist {
+			return false, nil
+		}
+		for _, item := range ToInterfaceSlice(value) {
+			if CompareValues(v, item) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case ConditionOperatorLike:
+		if !exist {
+			return false, nil
+		}
+		return likePattern(fmt.Sprint(value)).MatchString(StringValue(v)), nil
+	case ConditionOperatorFullText:
+		terms := strings.Fields(strings.ToLower(fmt.Sprint(value)))
+		if len(terms) == 0 {
+			return false, fmt.Errorf("value of operator %q must not be empty", operator)
+		}
+		if !exist {
+			return false, nil
+		}
+		text := strings.ToLower(fullText(v))
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case ConditionOperatorEqual,
+		ConditionOperatorNotEqual,
+		ConditionOperatorGt,
+		ConditionOperatorGte,
+		ConditionOperatorLt,
+		ConditionOperatorLte:
+		if !exist {
+			return false, nil
+		}
+		result := CompareValues(v, value)
+		switch operator {
+		case ConditionOperatorEqual:
+			return result == 0, nil
+		case ConditionOperatorNotEqual:
+			return result != 0, nil
+		case ConditionOperatorGt:
+			return result > 0, nil
+		case ConditionOperatorGte:
+			return result >= 0, nil
+		case ConditionOperatorLt:
+			return result < 0, nil
+		default:
+			return result <= 0, nil
+		}
+	}
+	return false, fmt.Errorf("unsupported condition operator %q", operator)
+}
+
+// subConditions returns the nested conditions of and/or operators
+func subConditions(cond Condition) ([]Condition, error) {
+	switch v := cond.Value.(type) {
+	case []Condition:
+		return v, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("value of operator %q must be a list of conditions, got %T", cond.Operator, cond.Value)
+	}
+}
+
+// jsonPathConditionValue returns the value of a condition with jsonpath operator
+func jsonPathConditionValue(cond Condition) (*JSONPathCondition, error) {
+	switch v := cond.Value.(type) {
+	case JSONPathCondition:
+		return &v, nil
+	case *JSONPathCondition:
+		if v != nil {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("value of operator %q must be a json path condition, got %T", cond.Operator, cond.Value)
+}
+
+// CompareValues compares two values, numeric values are compared as numbers
+// and other values are compared as strings.
+// The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+func CompareValues(a, b interface{}) int {
+	fa, okA := NumericValue(a)
+	fb, okB := NumericValue(b)
+	if okA && okB {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(StringValue(a), StringValue(b))
+}
+
+// NumericValue converts numbers and numeric strings to float64
+func NumericValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// StringValue converts a value to string, objects and arrays are converted to json
+func StringValue(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case nil:
+		return ""
+	case map[string]interface{}, map[string]string, []interface{}:
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// fullText joins all the scalar values of v with spaces, keys of objects are not included
+func fullText(v interface{}) string {
+	var builder strings.Builder
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch obj := v.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedKeys(obj) {
+				walk(obj[key])
+			}
+		case map[string]string:
+			for _, key := range sortedKeys(obj) {
+				walk(obj[key])
+			}
+		case []interface{}:
+			for _, item := range obj {
+				walk(item)
+			}
+		case nil:
+		default:
+			builder.WriteString(StringValue(obj))
+			builder.WriteString(" ")
+		}
+	}
+	walk(v)
+	return builder.String()
+}
+
+// likePattern converts a sql like pattern to a regular expression.
+// `%` matches any sequence of characters and `_` matches a single character.
+func likePattern(pattern string) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			builder.WriteString(".*")
+		case r == '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newEvaluateSpec() *RecordSpec {
+	return &RecordSpec{
+		Name:              "run-1",
+		Namespace:         "default",
+		CreationTimestamp: 100,
+		Metadata:          map[string]string{"status": "False", "duration": "30"},
+		Data: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"timeout": nil,
+				"params": []interface{}{
+					map[string]interface{}{"name": "image", "value": "Registry.example.com/app:v1"},
+					map[string]interface{}{"name": "retries", "value": float64(3)},
+				},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "reason": "Failed", "message": "step build exited with code 1"},
+				},
+			},
+		},
+	}
+}
+
+func TestMatchCondition(t *testing.T) {
+	spec := newEvaluateSpec()
+	tests := map[string]struct {
+		cond    Condition
+		want    bool
+		wantErr bool
+	}{
+		"eq":             {cond: Name("run-1"), want: true},
+		"ne":             {cond: NotEqual(NameField, "run-1"), want: false},
+		"numeric gt":     {cond: Gt(CreationTimestampField, "99"), want: true},
+		"lte":            {cond: Lte(MetadataKey("duration"), "29"), want: false},
+		"in":             {cond: In(MetadataKey("status"), "True", "False"), want: true},
+		"like":           {cond: Like(NameField, "run-_"), want: true},
+		"exist":          {cond: Exist(MetadataKey("status")), want: true},
+		"not exist":      {cond: NotExist(MetadataKey("reason")), want: true},
+		"not exist null": {cond: NotExist("data.spec.timeout"), want: true},
+		"missing field":  {cond: Equal(MetadataKey("reason"), ""), want: false},
+		"eqcol":          {cond: EqualColumn(NamespaceField, NamespaceField), want: true},
+		"or":             {cond: Or(Name("other"), CompletedStatus()), want: true},
+		"and":            {cond: And(Name("other"), CompletedStatus()), want: false},
+		"unknown":        {cond: Condition{Key: NameField, Operator: "regexp"}, wantErr: true},
+		"in not a list":  {cond: Condition{Key: NameField, Operator: ConditionOperatorIn, Value: "a"}, wantErr: true},
+		"or not a list":  {cond: Condition{Operator: ConditionOperatorOr, Value: "a"}, wantErr: true},
+		"jsonpath eq":    {cond: JSONPathMatch(DataField, "status.conditions[*].reason", ConditionOperatorEqual, "Failed"), want: true},
+		"jsonpath index": {cond: JSONPathMatch(DataField, "spec.params[1].value", ConditionOperatorGte, 3), want: true},
+		"jsonpath in":    {cond: JSONPathMatch(DataField, "status.conditions[*].reason", ConditionOperatorIn, []string{"Timeout"}), want: false},
+		"jsonpath like":  {cond: JSONPathMatch(DataField, "spec.params[*].value", ConditionOperatorLike, "%app:v1"), want: true},
+		"jsonpath exist": {cond: JSONPathMatch(DataField, "status.conditions[0].message", ConditionOperatorExist, nil), want: true},
+		"jsonpath missing": {
+			cond: JSONPathMatch(DataField, "status.conditions[*].missing", ConditionOperatorExist, nil),
+			want: false,
+		},
+		"jsonpath of sub key":  {cond: JSONPathMatch("data.status", "conditions[*].type", ConditionOperatorEqual, "Succeeded"), want: true},
+		"jsonpath of metadata": {cond: JSONPathMatch(MetadataFiled, `["duration"]`, ConditionOperatorGt, 20), want: true},
+		"jsonpath fulltext": {
+			cond: JSONPathMatch(DataField, "status.conditions[*].message", ConditionOperatorFullText, "BUILD exited"),
+			want: true,
+		},
+		"jsonpath pointer value": {
+			cond: Condition{Key: DataField, Operator: ConditionOperatorJSONPath, Value: &JSONPathCondition{Path: "spec.params[0].name", Operator: ConditionOperatorEqual, Value: "image"}},
+			want: true,
+		},
+		"jsonpath invalid path": {cond: JSONPathMatch(DataField, "spec..params", ConditionOperatorExist, nil), wantErr: true},
+		"jsonpath invalid operator": {
+			cond:    JSONPathMatch(DataField, "spec", ConditionOperatorJSONPath, nil),
+			wantErr: true,
+		},
+		"jsonpath invalid value": {cond: Condition{Key: DataField, Operator: ConditionOperatorJSONPath, Value: "spec"}, wantErr: true},
+		"fulltext":               {cond: FullText(DataField, "registry.example.com failed"), want: true},
+		"fulltext not matched":   {cond: FullText(DataField, "failed cancelled"), want: false},
+		"fulltext keys ignored":  {cond: FullText(DataField, "conditions"), want: false},
+		"fulltext empty":         {cond: FullText(DataField, " "), wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			got, err := MatchCondition(spec, tt.cond)
+			if tt.wantErr {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestMatchConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := newEvaluateSpec()
+
+	matched, err := MatchConditions(spec, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(matched).To(gomega.BeTrue())
+
+	matched, err = MatchConditions(spec, []Condition{Name("run-1"), FullText(MetadataFiled, "false")})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(matched).To(gomega.BeTrue())
+
+	matched, err = MatchConditions(spec, []Condition{Name("run-2"), {Key: NameField, Operator: "unknown"}})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(matched).To(gomega.BeFalse())
+}
+
+func TestCompareValues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(CompareValues("10", 9)).To(gomega.Equal(1))
+	g.Expect(CompareValues(int64(1), "1.0")).To(gomega.Equal(0))
+	g.Expect(CompareValues("a", "b")).To(gomega.Equal(-1))
+	g.Expect(CompareValues(nil, "")).To(gomega.Equal(0))
+	g.Expect(StringValue(map[string]string{"a": "b"})).To(gomega.Equal(`{"a":"b"}`))
+}
+
+func TestCondition_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cond Condition
+		errs int
+	}{
+		"valid":                     {cond: And(Name("a"), Or(CompletedStatus()), In(UIDField, "uid"))},
+		"valid jsonpath":            {cond: JSONPathMatch(DataField, "$.status.conditions[*].reason", ConditionOperatorIn, []string{"Failed"})},
+		"valid fulltext":            {cond: FullText(DataField, "failed")},
+		"unknown operator":          {cond: Condition{Key: NameField, Operator: "regexp"}, errs: 1},
+		"missing key":               {cond: Equal("", "a"), errs: 1},
+		"nested errors":             {cond: Or(Equal("", "a"), Condition{Key: NameField, Operator: ConditionOperatorIn, Value: "a"}), errs: 2},
+		"invalid nested value":      {cond: Condition{Operator: ConditionOperatorAnd, Value: "a"}, errs: 1},
+		"invalid jsonpath":          {cond: JSONPathMatch(DataField, "a[x]", ConditionOperatorLike, "a"), errs: 1},
+		"invalid jsonpath value":    {cond: Condition{Key: DataField, Operator: ConditionOperatorJSONPath, Value: "a"}, errs: 1},
+		"invalid jsonpath operator": {cond: JSONPathMatch(DataField, "a", ConditionOperatorAnd, nil), errs: 1},
+		"invalid jsonpath in":       {cond: JSONPathMatch("", "a", ConditionOperatorIn, "a"), errs: 2},
+		"empty fulltext":            {cond: FullText(DataField, ""), errs: 1},
+		"non string fulltext":       {cond: Condition{Key: DataField, Operator: ConditionOperatorFullText, Value: 1}, errs: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(tt.cond.Validate(field.NewPath("conditions"))).To(gomega.HaveLen(tt.errs))
+		})
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(ValidateConditions([]Condition{Name("a"), Equal("", "a")}, field.NewPath("conditions"))).To(gomega.HaveLen(1))
+}
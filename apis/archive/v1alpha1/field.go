@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1alpha1
 
+import "strings"
+
 const (
 	// IDField the name of id field, usually the primary key id of the storage table
 	IDField = "id"
@@ -72,3 +74,93 @@ const (
 func MetadataKey(key string) string {
 	return MetadataFiled + "." + "\"" + key + "\""
 }
+
+// FieldValue returns the value of a field key and whether it exists.
+// Besides the column fields, keys of metadata and data are supported
+// in the form of `metadata."key"` and `data.path.to.key`,
+// and the whole metadata or data is returned for `metadata` or `data`.
+func (p *RecordSpec) FieldValue(key string) (interface{}, bool) {
+	switch key {
+	case IDField:
+		return p.ID, p.ID != 0
+	case TopOwnerClusterField:
+		return p.TopOwnerCluster, p.TopOwnerCluster != ""
+	case TopClusterField:
+		return p.TopCluster, p.TopCluster != ""
+	case ParentClusterField:
+		return p.ParentCluster, p.ParentCluster != ""
+	case ClusterField:
+		return p.Cluster, true
+	case TopOwnerUIDField:
+		return p.TopOwnerUID, p.TopOwnerUID != ""
+	case TopUIDField:
+		return p.TopUID, p.TopUID != ""
+	case ParentUIDField:
+		return p.ParentUID, p.ParentUID != ""
+	case UIDField:
+		return p.UID, true
+	case TopOwnerNamespaceField:
+		return p.TopOwnerNamespace, p.TopOwnerNamespace != ""
+	case TopNamespaceField:
+		return p.TopNamespace, p.TopNamespace != ""
+	case ParentNamespaceField:
+		return p.ParentNamespace, p.ParentNamespace != ""
+	case NamespaceField:
+		return p.Namespace, true
+	case TopOwnerNameField:
+		return p.TopOwnerName, p.TopOwnerName != ""
+	case TopNameField:
+		return p.TopName, p.TopName != ""
+	case ParentNameField:
+		return p.ParentName, p.ParentName != ""
+	case NameField:
+		return p.Name, true
+	case GroupField:
+		return p.Group, true
+	case VersionField:
+		return p.Version, true
+	case KindField:
+		return p.Kind, true
+	case CreationTimestampField:
+		return p.CreationTimestamp, p.CreationTimestamp != 0
+	case CleanupTimeField:
+		return p.CleanupTime, p.CleanupTime != 0
+	case MetadataFiled:
+		return p.Metadata, p.Metadata != nil
+	case DataField:
+		return p.Data, p.Data != nil
+	}
+
+	if subKey, ok := trimFieldPrefix(key, MetadataFiled); ok {
+		v, exist := p.Metadata[unquote(subKey)]
+		return v, exist
+	}
+
+	if subKey, ok := trimFieldPrefix(key, DataField); ok {
+		var current interface{} = p.Data
+		for _, segment := range strings.Split(subKey, ".") {
+			m, isMap := current.(map[string]interface{})
+			if !isMap {
+				return nil, false
+			}
+			if current, ok = m[unquote(segment)]; !ok {
+				return nil, false
+			}
+		}
+		return current, current != nil
+	}
+
+	return nil, false
+}
+
+func trimFieldPrefix(key, field string) (string, bool) {
+	prefix := field + "."
+	if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, prefix), true
+}
+
+func unquote(s string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(s, "\""), "\"")
+}
@@ -26,3 +26,41 @@ func TestMetadataKey(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	g.Expect(MetadataKey("test.cpaas.io")).To(gomega.Equal("metadata.\"test.cpaas.io\""))
 }
+
+func TestRecordSpec_FieldValue(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := &RecordSpec{
+		ID:                1,
+		Name:              "name",
+		CreationTimestamp: 100,
+		Metadata:          map[string]string{"test.cpaas.io": "value"},
+		Data: map[string]interface{}{
+			"spec": map[string]interface{}{"timeout": "1h"},
+		},
+	}
+
+	tests := map[string]struct {
+		value interface{}
+		exist bool
+	}{
+		IDField:                      {value: uint(1), exist: true},
+		NameField:                    {value: "name", exist: true},
+		TopUIDField:                  {value: "", exist: false},
+		CreationTimestampField:       {value: int64(100), exist: true},
+		MetadataKey("test.cpaas.io"): {value: "value", exist: true},
+		MetadataKey("missing"):       {value: "", exist: false},
+		"data.spec.timeout":          {value: "1h", exist: true},
+		"data.spec.timeout.duration": {value: nil, exist: false},
+		"data.status":                {value: nil, exist: false},
+		"unknown":                    {value: nil, exist: false},
+	}
+	for key, want := range tests {
+		value, exist := spec.FieldValue(key)
+		if want.value == nil {
+			g.Expect(value).To(gomega.BeNil(), key)
+		} else {
+			g.Expect(value).To(gomega.Equal(want.value), key)
+		}
+		g.Expect(exist).To(gomega.Equal(want.exist), key)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPathCondition describe the value of a condition with jsonpath operator.
+// The condition matches if any value selected by the path matches the operator and value.
+type JSONPathCondition struct {
+	// Path is the json path relative to the value of the condition key,
+	// e.g. `status.conditions[*].reason` or `$.spec.params[0]["name"]`
+	Path string `json:"path"`
+	// Operator is the operator to compare the selected values,
+	// only eq, ne, gt, gte, lt, lte, like, in, exist and fulltext are supported
+	Operator ConditionOperator `json:"operator"`
+	// Value is the value to compare with the selected values
+	Value interface{} `json:"value,omitempty"`
+}
+
+// jsonPathOperators are the operators supported in JSONPathCondition
+var jsonPathOperators = map[ConditionOperator]bool{
+	ConditionOperatorEqual:    true,
+	ConditionOperatorNotEqual: true,
+	ConditionOperatorGt:       true,
+	ConditionOperatorGte:      true,
+	ConditionOperatorLt:       true,
+	ConditionOperatorLte:      true,
+	ConditionOperatorLike:     true,
+	ConditionOperatorIn:       true,
+	ConditionOperatorExist:    true,
+	ConditionOperatorFullText: true,
+}
+
+// JSONPathSegment is a segment of a json path
+type JSONPathSegment struct {
+	// Key is the key of an object, it is empty for index and wildcard segments
+	Key string
+	// Index is the index of an array, it is only valid when IsIndex is true
+	Index int
+	// IsIndex describe the segment is an array index
+	IsIndex bool
+	// Wildcard selects all the items of an array or object
+	Wildcard bool
+}
+
+// JSONPath is a parsed json path
+type JSONPath []JSONPathSegment
+
+// ParseJSONPath parses a json path.
+// The supported syntax is a subset of JSONPath:
+// an optional `$` root, `.key` or `["key"]` for object keys,
+// `[n]` for array indexes, and `.*` or `[*]` for all items.
+// The leading dot can be omitted, e.g. `spec.params[*].name`.
+func ParseJSONPath(path string) (JSONPath, error) {
+	s := strings.TrimPrefix(path, "$")
+	if s == "" {
+		return nil, fmt.Errorf("json path %q should select at least one segment", path)
+	}
+	if s != path && s[0] != '.' && s[0] != '[' {
+		return nil, fmt.Errorf("invalid json path %q: unexpected character %q after $", path, s[0])
+	}
+
+	result := JSONPath{}
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid json path %q: missing ]", path)
+			}
+			segment, err := parseBracket(s[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid json path %q: %w", path, err)
+			}
+			result = append(result, segment)
+			i += end + 1
+		default:
+			if s[i] == '.' {
+				i++
+			} else if i > 0 {
+				return nil, fmt.Errorf("invalid json path %q: unexpected character %q", path, s[i])
+			}
+			end := i
+			for end < len(s) && s[end] != '.' && s[end] != '[' {
+				end++
+			}
+			key := s[i:end]
+			switch {
+			case key == "*":
+				result = append(result, JSONPathSegment{Wildcard: true})
+			case isJSONPathKey(key):
+				result = append(result, JSONPathSegment{Key: key})
+			default:
+				return nil, fmt.Errorf("invalid json path %q: invalid key %q", path, key)
+			}
+			i = end
+		}
+	}
+	return result, nil
+}
+
+// parseBracket parses the content between brackets
+func parseBracket(s string) (JSONPathSegment, error) {
+	if s == "*" {
+		return JSONPathSegment{Wildcard: true}, nil
+	}
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return JSONPathSegment{Key: s[1 : len(s)-1]}, nil
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil || index < 0 {
+		return JSONPathSegment{}, fmt.Errorf("invalid index %q", s)
+	}
+	return JSONPathSegment{Index: index, IsIndex: true}, nil
+}
+
+func isJSONPathKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if r != '_' && r != '-' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns all the values selected by the path from v
+func (p JSONPath) Select(v interface{}) []interface{} {
+	current := []interface{}{v}
+	for _, segment := range p {
+		next := make([]interface{}, 0, len(current))
+		for _, item := range current {
+			next = append(next, segment.selectFrom(item)...)
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+func (s JSONPathSegment) selectFrom(v interface{}) []interface{} {
+	switch obj := v.(type) {
+	case map[string]interface{}:
+		if s.Wildcard {
+			values := make([]interface{}, 0, len(obj))
+			for _, key := range sortedKeys(obj) {
+				values = append(values, obj[key])
+			}
+			return values
+		}
+		if item, ok := obj[s.Key]; ok && !s.IsIndex {
+			return []interface{}{item}
+		}
+	case map[string]string:
+		if s.Wildcard {
+			values := make([]interface{}, 0, len(obj))
+			for _, key := range sortedKeys(obj) {
+				values = append(values, obj[key])
+			}
+			return values
+		}
+		if item, ok := obj[s.Key]; ok && !s.IsIndex {
+			return []interface{}{item}
+		}
+	case []interface{}:
+		if s.Wildcard {
+			return obj
+		}
+		if s.IsIndex && s.Index < len(obj) {
+			return []interface{}{obj[s.Index]}
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
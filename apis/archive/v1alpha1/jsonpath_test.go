@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := map[string]struct {
+		want    JSONPath
+		wantErr bool
+	}{
+		"spec.timeout":  {want: JSONPath{{Key: "spec"}, {Key: "timeout"}}},
+		"$.spec":        {want: JSONPath{{Key: "spec"}}},
+		"$[0]":          {want: JSONPath{{Index: 0, IsIndex: true}}},
+		"a[*].b":        {want: JSONPath{{Key: "a"}, {Wildcard: true}, {Key: "b"}}},
+		"a.*":           {want: JSONPath{{Key: "a"}, {Wildcard: true}}},
+		`a["b.c"][2]`:   {want: JSONPath{{Key: "a"}, {Key: "b.c"}, {Index: 2, IsIndex: true}}},
+		`['x-y'].z_1`:   {want: JSONPath{{Key: "x-y"}, {Key: "z_1"}}},
+		"":              {wantErr: true},
+		"$":             {wantErr: true},
+		"$spec":         {wantErr: true},
+		"a..b":          {wantErr: true},
+		"a.":            {wantErr: true},
+		"a[1":           {wantErr: true},
+		"a[-1]":         {wantErr: true},
+		"a[b]":          {wantErr: true},
+		"a b":           {wantErr: true},
+		"a[0]b":         {wantErr: true},
+		"a.b'); DROP x": {wantErr: true},
+	}
+	for path, tt := range tests {
+		t.Run(path, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			got, err := ParseJSONPath(path)
+			if tt.wantErr {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestJSONPath_Select(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	data := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Succeeded", "reason": "Failed"},
+				map[string]interface{}{"type": "Ready", "reason": "Timeout"},
+			},
+		},
+		"labels": map[string]string{"b": "2", "a": "1"},
+	}
+
+	tests := map[string][]interface{}{
+		"status.conditions[*].reason": {"Failed", "Timeout"},
+		"status.conditions[1].type":   {"Ready"},
+		"status.conditions[2].type":   nil,
+		"status.conditions.type":      nil,
+		"labels.*":                    {"1", "2"},
+		"labels.a":                    {"1"},
+		"labels[0]":                   nil,
+		"missing[*]":                  nil,
+	}
+	for path, want := range tests {
+		parsed, err := ParseJSONPath(path)
+		g.Expect(err).To(gomega.BeNil(), path)
+		if want == nil {
+			g.Expect(parsed.Select(data)).To(gomega.BeEmpty(), path)
+		} else {
+			g.Expect(parsed.Select(data)).To(gomega.Equal(want), path)
+		}
+	}
+}
@@ -34,6 +34,8 @@ const (
 	ConditionOperatorIn ConditionOperator = "in"
 	// ConditionOperatorExist is the operator for exist
 	ConditionOperatorExist ConditionOperator = "exist"
+	// ConditionOperatorNotExist is the operator for not exist, null values are treated as not exist
+	ConditionOperatorNotExist ConditionOperator = "notexist"
 
 	// ConditionOperatorEqual is the operator for equal
 	ConditionOperatorEqual ConditionOperator = "eq"
@@ -51,6 +53,13 @@ const (
 	ConditionOperatorLte ConditionOperator = "lte"
 	// ConditionOperatorLike is the operator for like
 	ConditionOperatorLike ConditionOperator = "like"
+
+	// ConditionOperatorJSONPath is the operator for comparing values selected by a json path,
+	// the value of the condition should be a JSONPathCondition
+	ConditionOperatorJSONPath ConditionOperator = "jsonpath"
+	// ConditionOperatorFullText is the operator for full-text search,
+	// it matches if all the words of the value are contained in the text of the key case-insensitively
+	ConditionOperatorFullText ConditionOperator = "fulltext"
 )
 
 // Query describe the query params
@@ -100,6 +109,12 @@ func (p *Condition) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		p.Value = v
+	case ConditionOperatorJSONPath:
+		v := JSONPathCondition{}
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return err
+		}
+		p.Value = v
 	default:
 		var v interface{}
 		if err := json.Unmarshal(c.Value, &v); err != nil {
@@ -135,6 +150,11 @@ type ListOptions struct {
 
 	// WithDeletedData describe the deleted data should be returned
 	WithDeletedData bool
+
+	// Cursor enables cursor based pagination when it is not nil, the Page of Pager is ignored.
+	// Use an empty cursor for the first page and parse the Continue of the returned list for next pages.
+	// +optional
+	Cursor *metav1alpha1.TimeCursor `json:"cursor,omitempty"`
 }
 
 // Field describe the field to be returned
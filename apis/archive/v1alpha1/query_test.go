@@ -44,4 +44,15 @@ func TestCondition(t *testing.T) {
 	checkCondition("testdata/condition.eq.column.golden.yaml", t)
 	checkCondition("testdata/condition.and.golden.yaml", t)
 	checkCondition("testdata/condition.in.string.golden.yaml", t)
+	checkCondition("testdata/condition.jsonpath.golden.yaml", t)
+}
+
+func TestCondition_UnmarshalJSONPath(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cond := Condition{}
+	ktesting.MustLoadYaml("testdata/condition.jsonpath.golden.yaml", &cond)
+	g.Expect(cond).To(gomega.Equal(JSONPathMatch(DataField, "status.conditions[*].reason", ConditionOperatorIn, []interface{}{"Failed", "Timeout"})))
+
+	err := json.Unmarshal([]byte(`{"key":"data","operator":"jsonpath","value":"status"}`), &cond)
+	g.Expect(err).NotTo(gomega.BeNil())
 }
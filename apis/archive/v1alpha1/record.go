@@ -28,11 +28,15 @@ import (
 // RecordList is a list of Record
 type RecordList struct {
 	metav1.TypeMeta `json:",inline"`
+	// Continue is set when cursor based pagination is used and more records may exist,
+	// it is an encoded cursor for fetching the next page.
+	metav1.ListMeta `json:"metadata,omitempty"`
 
 	Items []Record `json:"items"`
 }
 
-// Record describe the archive record of a resource
+// Record describe the archive record of a resource.
+// The deletion timestamp in metadata is set if the record is soft deleted.
 type Record struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// RetentionGroupBy describe how records are grouped when counting
+type RetentionGroupBy string
+
+const (
+	// RetentionGroupByTopOwner groups records by their top owner resource
+	RetentionGroupByTopOwner RetentionGroupBy = "topOwner"
+	// RetentionGroupByNamespace groups records by their namespace
+	RetentionGroupByNamespace RetentionGroupBy = "namespace"
+)
+
+// RetentionPolicy describe which archived records should be cleaned up.
+// Like HistoryLimits, only completed records are taken into account.
+type RetentionPolicy struct {
+	// Name is the name of the policy
+	Name string `json:"name"`
+
+	// Conditions select the records managed by this policy
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// MaxAge deletes records created before the duration
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MaxCount deletes records exceeding the count of each group, the oldest are deleted first
+	// +optional
+	MaxCount *RetentionCountLimit `json:"maxCount,omitempty"`
+
+	// KeepLastPerStatus keeps the latest records of each status in each group,
+	// even if they are selected by MaxAge or MaxCount
+	// +optional
+	KeepLastPerStatus *RetentionCountLimit `json:"keepLastPerStatus,omitempty"`
+
+	// Direct deletes records directly instead of soft delete
+	// +optional
+	Direct bool `json:"direct,omitempty"`
+}
+
+// RetentionCountLimit limits the number of records in each group
+type RetentionCountLimit struct {
+	metav1alpha1.HistoryLimits `json:",inline"`
+
+	// GroupBy describe how records are grouped, defaults to topOwner
+	// +optional
+	GroupBy RetentionGroupBy `json:"groupBy,omitempty"`
+}
+
+// GetGroupBy returns the group by of the limit with default value
+func (r *RetentionCountLimit) GetGroupBy() RetentionGroupBy {
+	if r == nil || r.GroupBy == "" {
+		return RetentionGroupByTopOwner
+	}
+	return r.GroupBy
+}
+
+// Validate make sure the limit is legitimate
+func (r *RetentionCountLimit) Validate(path *field.Path) (errs field.ErrorList) {
+	errs = field.ErrorList{}
+	if r == nil {
+		return
+	}
+	errs = append(errs, r.HistoryLimits.Validate(path)...)
+	switch r.GroupBy {
+	case "", RetentionGroupByTopOwner, RetentionGroupByNamespace:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("groupBy"), r.GroupBy,
+			[]string{string(RetentionGroupByTopOwner), string(RetentionGroupByNamespace)}))
+	}
+	return
+}
+
+// Validate make sure the policy is legitimate
+func (r *RetentionPolicy) Validate(path *field.Path) (errs field.ErrorList) {
+	errs = field.ErrorList{}
+	if r.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "name of retention policy is required"))
+	}
+	if r.MaxAge != nil && r.MaxAge.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("maxAge"), r.MaxAge.Duration.String(), "should be greater than zero"))
+	}
+	errs = append(errs, r.MaxCount.Validate(path.Child("maxCount"))...)
+	errs = append(errs, r.KeepLastPerStatus.Validate(path.Child("keepLastPerStatus"))...)
+	return
+}
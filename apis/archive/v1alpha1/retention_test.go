@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+func TestRetentionCountLimit_GetGroupBy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var limit *RetentionCountLimit
+	g.Expect(limit.GetGroupBy()).To(gomega.Equal(RetentionGroupByTopOwner))
+	g.Expect((&RetentionCountLimit{}).GetGroupBy()).To(gomega.Equal(RetentionGroupByTopOwner))
+	g.Expect((&RetentionCountLimit{GroupBy: RetentionGroupByNamespace}).GetGroupBy()).To(gomega.Equal(RetentionGroupByNamespace))
+}
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	tests := map[string]struct {
+		policy RetentionPolicy
+		errs   int
+	}{
+		"valid policy": {
+			policy: RetentionPolicy{
+				Name:              "default",
+				MaxAge:            &metav1.Duration{Duration: time.Hour},
+				MaxCount:          &RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(10)}},
+				KeepLastPerStatus: &RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(1)}, GroupBy: RetentionGroupByNamespace},
+			},
+		},
+		"missing name": {
+			policy: RetentionPolicy{},
+			errs:   1,
+		},
+		"invalid values": {
+			policy: RetentionPolicy{
+				Name:              "invalid",
+				MaxAge:            &metav1.Duration{},
+				MaxCount:          &RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(-1)}},
+				KeepLastPerStatus: &RetentionCountLimit{GroupBy: "cluster"},
+			},
+			errs: 3,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			errs := tt.policy.Validate(field.NewPath("spec"))
+			g.Expect(errs).To(gomega.HaveLen(tt.errs))
+		})
+	}
+}
@@ -52,4 +52,21 @@ type CodeLintIssues struct {
 
 	// Count the number of detected issues
 	Count int `json:"count"`
+
+	// Rules number of detected issues by rule and level
+	// +optional
+	Rules []CodeLintRuleIssues `json:"rules,omitempty"`
+}
+
+// CodeLintRuleIssues number of issues detected by a rule with the same level
+type CodeLintRuleIssues struct {
+	// Rule id of the rule
+	Rule string `json:"rule"`
+
+	// Level severity level of the issues, e.g. error, warning and note
+	// +optional
+	Level string `json:"level,omitempty"`
+
+	// Count the number of detected issues
+	Count int `json:"count"`
 }
@@ -49,3 +49,47 @@ func (CodeLintIssues) GetObjectWithValues(ctx context.Context, path *field.Path,
 	}
 	return
 }
+
+// Add adds issues detected by a rule with the level
+func (c *CodeLintIssues) Add(rule, level string, count int) {
+	c.Count += count
+	for i, item := range c.Rules {
+		if item.Rule == rule && item.Level == level {
+			c.Rules[i].Count += count
+			return
+		}
+	}
+	c.Rules = append(c.Rules, CodeLintRuleIssues{Rule: rule, Level: level, Count: count})
+}
+
+// Merge adds all issues of another CodeLintIssues
+// issues without rules are only added to the total count
+func (c *CodeLintIssues) Merge(other *CodeLintIssues) {
+	if other == nil {
+		return
+	}
+	ruleCount := 0
+	for _, item := range other.Rules {
+		c.Add(item.Rule, item.Level, item.Count)
+		ruleCount += item.Count
+	}
+	c.Count += other.Count - ruleCount
+}
+
+// CountByLevel returns the number of issues by level
+func (c *CodeLintIssues) CountByLevel() map[string]int {
+	counts := map[string]int{}
+	for _, item := range c.Rules {
+		counts[item.Level] += item.Count
+	}
+	return counts
+}
+
+// CountByRule returns the number of issues by rule
+func (c *CodeLintIssues) CountByRule() map[string]int {
+	counts := map[string]int{}
+	for _, item := range c.Rules {
+		counts[item.Rule] += item.Count
+	}
+	return counts
+}
@@ -94,3 +94,38 @@ func TestCodeLintResultIsEmpty(t *testing.T) {
 		g.Expect(object.IsEmpty()).To(gomega.BeFalse())
 	})
 }
+
+func TestCodeLintIssuesAdd(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	issues := &CodeLintIssues{}
+	issues.Add("no-unused-vars", "warning", 2)
+	issues.Add("no-undef", "error", 1)
+	issues.Add("no-unused-vars", "warning", 1)
+	issues.Add("no-unused-vars", "error", 1)
+
+	g.Expect(issues.Count).To(gomega.Equal(5))
+	g.Expect(issues.Rules).To(gomega.Equal([]CodeLintRuleIssues{
+		{Rule: "no-unused-vars", Level: "warning", Count: 3},
+		{Rule: "no-undef", Level: "error", Count: 1},
+		{Rule: "no-unused-vars", Level: "error", Count: 1},
+	}))
+	g.Expect(issues.CountByLevel()).To(gomega.Equal(map[string]int{"warning": 3, "error": 2}))
+	g.Expect(issues.CountByRule()).To(gomega.Equal(map[string]int{"no-unused-vars": 4, "no-undef": 1}))
+}
+
+func TestCodeLintIssuesMerge(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	issues := &CodeLintIssues{}
+	issues.Add("no-undef", "error", 1)
+
+	issues.Merge(nil)
+	g.Expect(issues.Count).To(gomega.Equal(1))
+
+	// issues without rules only changes the total count
+	issues.Merge(&CodeLintIssues{Count: 3})
+	g.Expect(issues.Count).To(gomega.Equal(4))
+
+	issues.Merge(&CodeLintIssues{Count: 2, Rules: []CodeLintRuleIssues{{Rule: "no-undef", Level: "error", Count: 2}}})
+	g.Expect(issues.Count).To(gomega.Equal(6))
+	g.Expect(issues.Rules).To(gomega.Equal([]CodeLintRuleIssues{{Rule: "no-undef", Level: "error", Count: 3}}))
+}
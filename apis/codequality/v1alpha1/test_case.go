@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestCaseStatus status of a single test case
+type TestCaseStatus string
+
+const (
+	// TestCaseStatusPassed the test case passed
+	TestCaseStatusPassed TestCaseStatus = "passed"
+	// TestCaseStatusFailed the test case failed on an assertion
+	TestCaseStatusFailed TestCaseStatus = "failed"
+	// TestCaseStatusError the test case could not complete due to an unexpected error
+	TestCaseStatusError TestCaseStatus = "error"
+	// TestCaseStatusSkipped the test case was skipped or not executed
+	TestCaseStatusSkipped TestCaseStatus = "skipped"
+)
+
+// TestCaseResult result of a single test case
+type TestCaseResult struct {
+	// Suite name of the suite or package the test case belongs to
+	// +optional
+	Suite string `json:"suite,omitempty"`
+
+	// Name of the test case
+	Name string `json:"name"`
+
+	// ClassName additional descriptor for the hierarchy of the test case
+	// +optional
+	ClassName string `json:"className,omitempty"`
+
+	// Duration time taken to run the test case
+	// +optional
+	Duration metav1.Duration `json:"duration"`
+
+	// Status of the test case
+	Status TestCaseStatus `json:"status"`
+
+	// Message failure, error or skip message of the test case
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Stack stack trace or detailed output of a failure
+	// +optional
+	Stack string `json:"stack,omitempty"`
+
+	// Retries number of times the test case was rerun
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// Flaky indicates the test case both passed and failed across reruns
+	// +optional
+	Flaky bool `json:"flaky,omitempty"`
+}
+
+// TestCaseHighlights test cases worth attention in a test run
+type TestCaseHighlights struct {
+	// Slowest test cases ordered by duration descending
+	// +optional
+	Slowest []TestCaseResult `json:"slowest,omitempty"`
+
+	// Flaky test cases ordered by retries descending
+	// +optional
+	Flaky []TestCaseResult `json:"flaky,omitempty"`
+}
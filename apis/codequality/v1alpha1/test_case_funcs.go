@@ -0,0 +1,34 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "strings"
+
+// Key returns the identity of the test case used to match it across reruns
+func (t TestCaseResult) Key() string {
+	return strings.Join([]string{t.Suite, t.ClassName, t.Name}, "/")
+}
+
+// IsFailed returns true if the test case failed or errored
+func (t TestCaseResult) IsFailed() bool {
+	return t.Status == TestCaseStatusFailed || t.Status == TestCaseStatusError
+}
+
+// IsEmpty returns true if no test case is highlighted
+func (t *TestCaseHighlights) IsEmpty() bool {
+	return t == nil || (len(t.Slowest) == 0 && len(t.Flaky) == 0)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestTestCaseResultKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(TestCaseResult{Suite: "suite", ClassName: "class", Name: "name"}.Key()).To(gomega.Equal("suite/class/name"))
+	g.Expect(TestCaseResult{Suite: "a", Name: "b"}.Key()).NotTo(gomega.Equal(TestCaseResult{ClassName: "a", Name: "b"}.Key()))
+}
+
+func TestTestCaseResultIsFailed(t *testing.T) {
+	table := map[TestCaseStatus]bool{
+		TestCaseStatusPassed:  false,
+		TestCaseStatusFailed:  true,
+		TestCaseStatusError:   true,
+		TestCaseStatusSkipped: false,
+	}
+	for status, expected := range table {
+		t.Run(string(status), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(TestCaseResult{Status: status}.IsFailed()).To(gomega.Equal(expected))
+		})
+	}
+}
+
+func TestTestCaseHighlightsIsEmpty(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var highlights *TestCaseHighlights
+	g.Expect(highlights.IsEmpty()).To(gomega.BeTrue())
+	g.Expect((&TestCaseHighlights{}).IsEmpty()).To(gomega.BeTrue())
+	g.Expect((&TestCaseHighlights{Flaky: []TestCaseResult{{Name: "a"}}}).IsEmpty()).To(gomega.BeFalse())
+}
@@ -147,6 +147,11 @@ func (in *CodeChangeRates) DeepCopy() *CodeChangeRates {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CodeLintIssues) DeepCopyInto(out *CodeLintIssues) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]CodeLintRuleIssues, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodeLintIssues.
@@ -165,7 +170,7 @@ func (in *CodeLintResult) DeepCopyInto(out *CodeLintResult) {
 	if in.Issues != nil {
 		in, out := &in.Issues, &out.Issues
 		*out = new(CodeLintIssues)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -179,6 +184,21 @@ func (in *CodeLintResult) DeepCopy() *CodeLintResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CodeLintRuleIssues) DeepCopyInto(out *CodeLintRuleIssues) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodeLintRuleIssues.
+func (in *CodeLintRuleIssues) DeepCopy() *CodeLintRuleIssues {
+	if in == nil {
+		return nil
+	}
+	out := new(CodeLintRuleIssues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CodeSize) DeepCopyInto(out *CodeSize) {
 	*out = *in
@@ -336,6 +356,47 @@ func (in *ReportFile) DeepCopy() *ReportFile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestCaseHighlights) DeepCopyInto(out *TestCaseHighlights) {
+	*out = *in
+	if in.Slowest != nil {
+		in, out := &in.Slowest, &out.Slowest
+		*out = make([]TestCaseResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flaky != nil {
+		in, out := &in.Flaky, &out.Flaky
+		*out = make([]TestCaseResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCaseHighlights.
+func (in *TestCaseHighlights) DeepCopy() *TestCaseHighlights {
+	if in == nil {
+		return nil
+	}
+	out := new(TestCaseHighlights)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestCaseResult) DeepCopyInto(out *TestCaseResult) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCaseResult.
+func (in *TestCaseResult) DeepCopy() *TestCaseResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TestCaseResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestCoverage) DeepCopyInto(out *TestCoverage) {
 	*out = *in
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ArtifactPromotionMode how an artifact is promoted
+type ArtifactPromotionMode string
+
+const (
+	// ArtifactPromotionCopy copies the artifact and keeps the source artifact
+	ArtifactPromotionCopy ArtifactPromotionMode = "copy"
+	// ArtifactPromotionMove copies the artifact and deletes the source artifact
+	ArtifactPromotionMove ArtifactPromotionMode = "move"
+)
+
+// ArtifactPromotionTarget where an artifact is promoted to
+type ArtifactPromotionTarget struct {
+	// Registry address of the target registry or instance inside the tool,
+	// the registry of the source artifact is used if empty
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// Project name of the target project
+	Project string `json:"project"`
+
+	// Repository name of the target repository
+	Repository string `json:"repository"`
+}
+
+// PromoteArtifactPayload payload for promoting an artifact to another project or registry
+type PromoteArtifactPayload struct {
+	// Target where the artifact is promoted to
+	Target ArtifactPromotionTarget `json:"target"`
+
+	// Mode how the artifact is promoted, defaults to copy
+	// +optional
+	Mode ArtifactPromotionMode `json:"mode,omitempty"`
+
+	// Tags of the promoted artifact, the tags of the source artifact are kept if empty
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Properties extended properties merged into the properties of the promoted artifact
+	// +optional
+	Properties *runtime.RawExtension `json:"properties,omitempty"`
+
+	// Overwrite replaces the artifact with the same tag in the target,
+	// otherwise the promotion fails with a conflict
+	// +optional
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// PromotionMode returns the mode of the promotion, defaults to copy
+func (p *PromoteArtifactPayload) PromotionMode() ArtifactPromotionMode {
+	if p.Mode == "" {
+		return ArtifactPromotionCopy
+	}
+	return p.Mode
+}
@@ -40,3 +40,26 @@ func validateURI(uri string, path *field.Path) (errs field.ErrorList) {
 	}
 	return
 }
+
+// Validate validates the target and the mode of the promotion
+func (p *PromoteArtifactPayload) Validate(path *field.Path) (errs field.ErrorList) {
+	target := path.Child("target")
+	if p.Target.Project == "" {
+		errs = append(errs, field.Required(target.Child("project"), validation.EmptyError()))
+	}
+	if p.Target.Repository == "" {
+		errs = append(errs, field.Required(target.Child("repository"), validation.EmptyError()))
+	}
+	switch p.PromotionMode() {
+	case ArtifactPromotionCopy, ArtifactPromotionMove:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("mode"), p.Mode,
+			[]string{string(ArtifactPromotionCopy), string(ArtifactPromotionMove)}))
+	}
+	for i, tag := range p.Tags {
+		if tag == "" {
+			errs = append(errs, field.Required(path.Child("tags").Index(i), validation.EmptyError()))
+		}
+	}
+	return
+}
@@ -86,3 +86,44 @@ func TestArtifactParameterSpec_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPromoteArtifactPayload_Validate(t *testing.T) {
+	tests := map[string]struct {
+		payload PromoteArtifactPayload
+		fields  []string
+	}{
+		"copy by default": {
+			payload: PromoteArtifactPayload{Target: ArtifactPromotionTarget{Project: "release", Repository: "app"}},
+		},
+		"move with tags": {
+			payload: PromoteArtifactPayload{
+				Target: ArtifactPromotionTarget{Registry: "prod", Project: "release", Repository: "app"},
+				Mode:   ArtifactPromotionMove,
+				Tags:   []string{"v1.0.0", "latest"},
+			},
+		},
+		"missing target": {
+			payload: PromoteArtifactPayload{},
+			fields:  []string{"test.target.project", "test.target.repository"},
+		},
+		"unknown mode and empty tag": {
+			payload: PromoteArtifactPayload{
+				Target: ArtifactPromotionTarget{Project: "release", Repository: "app"},
+				Mode:   "link",
+				Tags:   []string{"v1.0.0", ""},
+			},
+			fields: []string{"test.mode", "test.tags[1]"},
+		},
+	}
+
+	for name, item := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			errs := item.payload.Validate(field.NewPath("test"))
+			g.Expect(errs).To(HaveLen(len(item.fields)))
+			for i, err := range errs {
+				g.Expect(err.Field).To(Equal(item.fields[i]))
+			}
+		})
+	}
+}
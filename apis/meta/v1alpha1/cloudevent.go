@@ -116,6 +116,8 @@ const (
 	CloudEventExtWebhookType         = "webhooktype"
 	// CloudEventExtRevisionSubmitter indicates email of revision submitter
 	CloudEventExtRevisionSubmitter = "revisionsubmitter"
+	// CloudEventExtGitProvider extension of the git provider emitting the event, e.g. github
+	CloudEventExtGitProvider = "provider"
 
 	// Triggered when a pull request's head branch is updated.
 	// For example, when the head branch is updated from the base branch, when new commits are pushed to the head branch, or when the base branch is changed.
@@ -141,6 +143,9 @@ const (
 	// action of delete branch
 	// Used for matching events in trigger filter
 	CloudEventExtBranchActionDelete = "delete"
+	// action of pushing commits to a branch
+	// Used for matching events in trigger filter
+	CloudEventExtBranchActionPush = "push"
 
 	// action of create tag
 	// Used for matching events in trigger filter
@@ -51,6 +51,14 @@ type CreateBranchPayload struct {
 	CreateBranchParams
 }
 
+// UpdateBranchProtectionPayload payload for setting protection rules of a branch
+type UpdateBranchProtectionPayload struct {
+	GitRepo
+	// Branch name of the protected branch
+	Branch string `json:"branch"`
+	GitBranchProtectionRules
+}
+
 // CreatePullRequestPayload option for create PullRequest
 type CreatePullRequestPayload struct {
 	Source      GitBranchBaseInfo `json:"source"`
@@ -82,6 +90,55 @@ type UpdatePullRequestCommentPayload struct {
 	CommentID int `json:"commentID"`
 }
 
+// CreatePullRequestReviewParam param for submitting a review of pr
+type CreatePullRequestReviewParam struct {
+	// State of the review, one of approved, changes_requested and commented
+	State PullRequestReviewState `json:"state"`
+	// Body review content, required when requesting changes or commenting
+	Body string `json:"body,omitempty"`
+	// CommitSHA the commit which is reviewed, defaults to the latest commit of the source branch
+	CommitSHA string `json:"commitSha,omitempty"`
+}
+
+// CreatePullRequestReviewPayload payload for submitting a review of pr
+type CreatePullRequestReviewPayload struct {
+	GitRepo
+	CreatePullRequestReviewParam
+	Index int `json:"index"`
+}
+
+// RequestPullRequestReviewersParam param for requesting reviewers of pr
+type RequestPullRequestReviewersParam struct {
+	// Reviewers users requested to review the pr
+	Reviewers []GitUserBaseInfo `json:"reviewers"`
+}
+
+// RequestPullRequestReviewersPayload payload for requesting reviewers of pr
+type RequestPullRequestReviewersPayload struct {
+	GitRepo
+	RequestPullRequestReviewersParam
+	Index int `json:"index"`
+}
+
+// MergePullRequestParam param for merging pr
+type MergePullRequestParam struct {
+	// Strategy to merge the pr, defaults to merge
+	Strategy MergeStrategy `json:"strategy,omitempty"`
+	// CommitMessage message of the merge or squash commit
+	CommitMessage string `json:"commitMessage,omitempty"`
+	// SHA if set, the pr is merged only if the source branch still points to it
+	SHA string `json:"sha,omitempty"`
+	// RemoveSourceBranch overrides whether the source branch is deleted after merging
+	RemoveSourceBranch *bool `json:"removeSourceBranch,omitempty"`
+}
+
+// MergePullRequestPayload payload for merging pr
+type MergePullRequestPayload struct {
+	GitRepo
+	MergePullRequestParam
+	Index int `json:"index"`
+}
+
 // CreateCommitCommentParam param for create commit's comment
 type CreateCommitCommentParam struct {
 	Note     *string `json:"note,omitempty"`
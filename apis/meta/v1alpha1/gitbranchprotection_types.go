@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	GitBranchProtectionGVK = GroupVersion.WithKind("GitBranchProtection")
+)
+
+// GitBranchProtection protection rules of a branch
+type GitBranchProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitBranchProtectionSpec `json:"spec"`
+}
+
+// GitBranchProtectionSpec spec of branch protection
+type GitBranchProtectionSpec struct {
+	GitBranchBaseInfo        `json:",inline"`
+	GitBranchProtectionRules `json:",inline"`
+}
+
+// GitBranchProtectionRules rules applied to a protected branch
+type GitBranchProtectionRules struct {
+	// RequiredApprovals number of approvals required before pull requests could be merged
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// DismissStaleApprovals approvals are dismissed when new commits are pushed
+	DismissStaleApprovals bool `json:"dismissStaleApprovals,omitempty"`
+	// RequiredStatusChecks contexts of commit statuses which must succeed before pull requests could be merged
+	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+	// AllowForcePushes the branch could be force pushed
+	AllowForcePushes bool `json:"allowForcePushes,omitempty"`
+	// AllowDeletions the branch could be deleted
+	AllowDeletions bool `json:"allowDeletions,omitempty"`
+	// DevelopersCanPush developer can push to this branch
+	DevelopersCanPush *bool `json:"developersCanPush,omitempty"`
+	// DevelopersCanMerge developer can merge to this branch
+	DevelopersCanMerge *bool `json:"developersCanMerge,omitempty"`
+}
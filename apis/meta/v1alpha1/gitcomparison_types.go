@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	GitCommitComparisonGVK = GroupVersion.WithKind("GitCommitComparison")
+)
+
+// GitComparisonStatus relation between the head and the base of a comparison
+type GitComparisonStatus string
+
+const (
+	// GitComparisonIdentical indicates that the head and the base are the same commit
+	GitComparisonIdentical GitComparisonStatus = "identical"
+	// GitComparisonAhead indicates that the head contains all commits of the base
+	GitComparisonAhead GitComparisonStatus = "ahead"
+	// GitComparisonBehind indicates that the base contains all commits of the head
+	GitComparisonBehind GitComparisonStatus = "behind"
+	// GitComparisonDiverged indicates that both the head and the base have commits the other does not
+	GitComparisonDiverged GitComparisonStatus = "diverged"
+)
+
+// ComparisonStatusOf returns the status of a comparison by the number of commits
+// the head is ahead of and behind the base
+func ComparisonStatusOf(aheadBy, behindBy int) GitComparisonStatus {
+	switch {
+	case aheadBy == 0 && behindBy == 0:
+		return GitComparisonIdentical
+	case behindBy == 0:
+		return GitComparisonAhead
+	case aheadBy == 0:
+		return GitComparisonBehind
+	default:
+		return GitComparisonDiverged
+	}
+}
+
+// GitCommitComparison changes between two revisions of a repository
+type GitCommitComparison struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitCommitComparisonSpec `json:"spec"`
+}
+
+// GitCommitComparisonSpec spec of commit comparison
+type GitCommitComparisonSpec struct {
+	GitRepo `json:",inline"`
+	// Base revision compared from
+	Base string `json:"base"`
+	// Head revision compared to
+	Head string `json:"head"`
+	// BaseSHA commit sha of the base
+	BaseSHA string `json:"baseSha"`
+	// HeadSHA commit sha of the head
+	HeadSHA string `json:"headSha"`
+	// MergeBaseSHA best common ancestor of the base and the head, empty for unrelated histories
+	MergeBaseSHA string `json:"mergeBaseSha,omitempty"`
+	// Status relation between the head and the base
+	Status GitComparisonStatus `json:"status"`
+	// AheadBy number of commits reachable from the head but not from the base
+	AheadBy int `json:"aheadBy"`
+	// BehindBy number of commits reachable from the base but not from the head
+	BehindBy int `json:"behindBy"`
+	// Commits reachable from the head but not from the base, oldest first.
+	// Plugins could truncate the list, AheadBy is always the total number.
+	Commits []GitCommit `json:"commits,omitempty"`
+	// Files changed from the merge base to the head
+	Files []GitPullRequestFileSpec `json:"files,omitempty"`
+}
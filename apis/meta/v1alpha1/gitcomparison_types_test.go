@@ -0,0 +1,32 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("ComparisonStatusOf",
+	func(aheadBy, behindBy int, expected GitComparisonStatus) {
+		Expect(ComparisonStatusOf(aheadBy, behindBy)).To(Equal(expected))
+	},
+	Entry("same commit", 0, 0, GitComparisonIdentical),
+	Entry("head has new commits", 2, 0, GitComparisonAhead),
+	Entry("base has new commits", 0, 3, GitComparisonBehind),
+	Entry("both have new commits", 1, 1, GitComparisonDiverged),
+)
@@ -0,0 +1,465 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitEventType type of a normalized git cloud event. The types and the data
+// are provider-neutral, so triggers could filter on the same event shape
+// regardless of whether the source is GitLab, GitHub, Gitea or Bitbucket.
+type GitEventType string
+
+const (
+	// GitEventTypePrefix prefix of the normalized git event types
+	GitEventTypePrefix = CloudEventPrefix + ".git"
+
+	// GitPushEventType commits are pushed to a branch, a branch is created or deleted
+	GitPushEventType GitEventType = GitEventTypePrefix + ".push"
+	// GitTagEventType a tag is created or deleted
+	GitTagEventType GitEventType = GitEventTypePrefix + ".tag"
+	// GitPullRequestOpenedEventType a pull request is opened or reopened
+	GitPullRequestOpenedEventType GitEventType = GitEventTypePrefix + ".pullrequest.opened"
+	// GitPullRequestUpdatedEventType commits are pushed to the source branch,
+	// or the title, description or target branch of a pull request is changed
+	GitPullRequestUpdatedEventType GitEventType = GitEventTypePrefix + ".pullrequest.updated"
+	// GitPullRequestMergedEventType a pull request is merged
+	GitPullRequestMergedEventType GitEventType = GitEventTypePrefix + ".pullrequest.merged"
+	// GitPullRequestClosedEventType a pull request is closed without merging
+	GitPullRequestClosedEventType GitEventType = GitEventTypePrefix + ".pullrequest.closed"
+	// GitCommentEventType a comment is made on a commit or a pull request
+	GitCommentEventType GitEventType = GitEventTypePrefix + ".comment"
+	// GitReviewEventType a review of a pull request is submitted
+	GitReviewEventType GitEventType = GitEventTypePrefix + ".review"
+)
+
+var gitEventTypes = []GitEventType{
+	GitPushEventType,
+	GitTagEventType,
+	GitPullRequestOpenedEventType,
+	GitPullRequestUpdatedEventType,
+	GitPullRequestMergedEventType,
+	GitPullRequestClosedEventType,
+	GitCommentEventType,
+	GitReviewEventType,
+}
+
+// GitEventTypes returns all the normalized git event types
+func GitEventTypes() []GitEventType {
+	return append([]GitEventType{}, gitEventTypes...)
+}
+
+func (t GitEventType) String() string {
+	return string(t)
+}
+
+// IsValid returns true if the type is a normalized git event type
+func (t GitEventType) IsValid() bool {
+	for _, item := range gitEventTypes {
+		if item == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPullRequest returns true if the type is an event of pull request state changes
+func (t GitEventType) IsPullRequest() bool {
+	switch t {
+	case GitPullRequestOpenedEventType, GitPullRequestUpdatedEventType,
+		GitPullRequestMergedEventType, GitPullRequestClosedEventType:
+		return true
+	}
+	return false
+}
+
+// GitEventData data of a normalized git event
+// +k8s:deepcopy-gen=false
+type GitEventData interface {
+	// EventType returns the type of the event
+	EventType() GitEventType
+	// EventSubject returns the subject of the event, e.g. the branch or the pull request number
+	EventSubject() string
+	// EventExtensions returns the cloud event extensions used for matching events in trigger filter
+	EventExtensions() map[string]string
+}
+
+// GitEventRepository repository where a git event occurs
+type GitEventRepository struct {
+	GitRepo `json:",inline"`
+
+	// WebURL web address of the repository
+	// +optional
+	WebURL string `json:"webURL,omitempty"`
+
+	// CloneURL http clone address of the repository
+	// +optional
+	CloneURL string `json:"cloneURL,omitempty"`
+
+	// DefaultBranch default branch of the repository
+	// +optional
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+}
+
+// GitEventMeta common fields of all the normalized git events
+type GitEventMeta struct {
+	// Provider git provider emitting the event, e.g. github, gitlab, gitea, bitbucket
+	Provider string `json:"provider"`
+
+	// Repository where the event occurs
+	Repository GitEventRepository `json:"repository"`
+
+	// Sender user who triggers the event
+	Sender GitUserBaseInfo `json:"sender"`
+}
+
+// extensions returns the extensions of the common fields
+func (m *GitEventMeta) extensions(action string) map[string]string {
+	return map[string]string{
+		CloudEventExtGitProvider:    m.Provider,
+		CloudEventExtCodeRepository: m.Repository.String(),
+		CloudEventExtSender:         m.Sender.Name,
+		CloudEventExtAction:         action,
+	}
+}
+
+// GitEventCommit commit carried by a git event
+type GitEventCommit struct {
+	// SHA of the commit
+	SHA string `json:"sha"`
+
+	// Message of the commit
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Author of the commit
+	// +optional
+	Author GitUserBaseInfo `json:"author"`
+
+	// WebURL web address of the commit
+	// +optional
+	WebURL string `json:"webURL,omitempty"`
+
+	// CreatedAt time when the commit is created
+	// +optional
+	CreatedAt metav1.Time `json:"createdAt"`
+}
+
+// GitEventPullRequest pull request carried by a git event
+type GitEventPullRequest struct {
+	// Number of the pull request
+	Number int `json:"number"`
+
+	// Title of the pull request
+	Title string `json:"title"`
+
+	// Description of the pull request
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Source branch of the pull request
+	Source string `json:"source"`
+
+	// SourceRepository repository of the source branch if the pull request is from a fork
+	// +optional
+	SourceRepository *GitRepo `json:"sourceRepository,omitempty"`
+
+	// Target branch of the pull request
+	Target string `json:"target"`
+
+	// HeadSHA latest commit of the source branch
+	// +optional
+	HeadSHA string `json:"headSHA,omitempty"`
+
+	// BaseSHA commit of the target branch the pull request is based on
+	// +optional
+	BaseSHA string `json:"baseSHA,omitempty"`
+
+	// Draft the pull request is a draft
+	// +optional
+	Draft bool `json:"draft,omitempty"`
+
+	// WebURL web address of the pull request
+	// +optional
+	WebURL string `json:"webURL,omitempty"`
+
+	// Author of the pull request
+	Author GitUserBaseInfo `json:"author"`
+}
+
+// extensions returns the extensions of the pull request
+func (pr *GitEventPullRequest) extensions(values map[string]string) map[string]string {
+	values[CloudEventExtPullRequestNumber] = strconv.Itoa(pr.Number)
+	values[CloudEventExtGitPullRequestTitle] = pr.Title
+	values[CloudEventExtGitSourceBranch] = pr.Source
+	values[CloudEventExtGitTargetBranch] = pr.Target
+	if pr.HeadSHA != "" {
+		values[CloudEventExtGitCommitID] = pr.HeadSHA
+	}
+	return values
+}
+
+// GitPushEventData data of GitPushEventType
+type GitPushEventData struct {
+	GitEventMeta `json:",inline"`
+
+	// Action of the push, one of push, create or delete
+	Action string `json:"action"`
+
+	// Ref full reference pushed to, e.g. refs/heads/main
+	Ref string `json:"ref"`
+
+	// Branch name pushed to
+	Branch string `json:"branch"`
+
+	// Before sha of the branch before the push, empty if the branch is created
+	// +optional
+	Before string `json:"before,omitempty"`
+
+	// After sha of the branch after the push, empty if the branch is deleted
+	// +optional
+	After string `json:"after,omitempty"`
+
+	// Commits pushed to the branch, the latest one is the last
+	// +optional
+	Commits []GitEventCommit `json:"commits,omitempty"`
+}
+
+// EventType returns the type of the event
+func (d *GitPushEventData) EventType() GitEventType {
+	return GitPushEventType
+}
+
+// EventSubject returns the branch pushed to
+func (d *GitPushEventData) EventSubject() string {
+	return d.Branch
+}
+
+// EventExtensions returns the extensions used for matching events in trigger filter
+func (d *GitPushEventData) EventExtensions() map[string]string {
+	values := d.extensions(d.Action)
+	values[CloudEventExtGitReference] = d.Ref
+	values[CloudEventExtGitBranch] = d.Branch
+	if d.After != "" {
+		values[CloudEventExtGitCommitID] = d.After
+	}
+	if len(d.Commits) > 0 {
+		last := d.Commits[len(d.Commits)-1]
+		values[CloudEventExtGitCommitMessage] = last.Message
+		values[CloudEventExtRevisionSubmitter] = last.Author.Email
+	}
+	return values
+}
+
+// GitTagEventData data of GitTagEventType
+type GitTagEventData struct {
+	GitEventMeta `json:",inline"`
+
+	// Action of the tag, one of create or delete
+	Action string `json:"action"`
+
+	// Ref full reference of the tag, e.g. refs/tags/v1.0.0
+	Ref string `json:"ref"`
+
+	// Tag name
+	Tag string `json:"tag"`
+
+	// SHA commit the tag points to, empty if the tag is deleted
+	// +optional
+	SHA string `json:"sha,omitempty"`
+
+	// Message of the annotated tag
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// EventType returns the type of the event
+func (d *GitTagEventData) EventType() GitEventType {
+	return GitTagEventType
+}
+
+// EventSubject returns the tag
+func (d *GitTagEventData) EventSubject() string {
+	return d.Tag
+}
+
+// EventExtensions returns the extensions used for matching events in trigger filter
+func (d *GitTagEventData) EventExtensions() map[string]string {
+	values := d.extensions(d.Action)
+	values[CloudEventExtGitReference] = d.Ref
+	values[CloudEventExtGitTag] = d.Tag
+	if d.SHA != "" {
+		values[CloudEventExtGitCommitID] = d.SHA
+	}
+	return values
+}
+
+// GitPullRequestEventData data of the pull request event types
+type GitPullRequestEventData struct {
+	GitEventMeta `json:",inline"`
+
+	// Type of the event, one of the pull request event types
+	Type GitEventType `json:"type"`
+
+	// Action detailed action of the event, one of opened, reopened, synchronize, edited, merged or closed
+	Action string `json:"action"`
+
+	// PullRequest the event occurs on
+	PullRequest GitEventPullRequest `json:"pullRequest"`
+
+	// MergedBy user who merges the pull request
+	// +optional
+	MergedBy *GitUserBaseInfo `json:"mergedBy,omitempty"`
+
+	// MergeCommitSHA commit created by merging the pull request
+	// +optional
+	MergeCommitSHA string `json:"mergeCommitSHA,omitempty"`
+}
+
+// EventType returns the type of the event
+func (d *GitPullRequestEventData) EventType() GitEventType {
+	return d.Type
+}
+
+// EventSubject returns the number of the pull request
+func (d *GitPullRequestEventData) EventSubject() string {
+	return strconv.Itoa(d.PullRequest.Number)
+}
+
+// EventExtensions returns the extensions used for matching events in trigger filter
+func (d *GitPullRequestEventData) EventExtensions() map[string]string {
+	values := d.PullRequest.extensions(d.extensions(d.Action))
+	values[CloudEventExtGitBranch] = d.PullRequest.Source
+	values[CloudEventExtRevisionSubmitter] = d.PullRequest.Author.Email
+	return values
+}
+
+// GitCommentTargetType what a comment is made on
+type GitCommentTargetType string
+
+const (
+	// GitCommentTargetCommit comment on a commit
+	GitCommentTargetCommit GitCommentTargetType = "commit"
+	// GitCommentTargetPullRequest comment on a pull request
+	GitCommentTargetPullRequest GitCommentTargetType = "pullrequest"
+)
+
+// GitCommentEventData data of GitCommentEventType
+type GitCommentEventData struct {
+	GitEventMeta `json:",inline"`
+
+	// Action of the comment, one of created, edited or deleted
+	Action string `json:"action"`
+
+	// TargetType what the comment is made on
+	TargetType GitCommentTargetType `json:"targetType"`
+
+	// Body of the comment
+	Body string `json:"body"`
+
+	// Author of the comment
+	Author GitUserBaseInfo `json:"author"`
+
+	// CommitSHA commit the comment is made on
+	// +optional
+	CommitSHA string `json:"commitSHA,omitempty"`
+
+	// PullRequest the comment is made on
+	// +optional
+	PullRequest *GitEventPullRequest `json:"pullRequest,omitempty"`
+
+	// Path of the file if the comment is made on a line
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Line number if the comment is made on a line
+	// +optional
+	Line int `json:"line,omitempty"`
+
+	// WebURL web address of the comment
+	// +optional
+	WebURL string `json:"webURL,omitempty"`
+}
+
+// EventType returns the type of the event
+func (d *GitCommentEventData) EventType() GitEventType {
+	return GitCommentEventType
+}
+
+// EventSubject returns the number of the pull request or the sha of the commit
+func (d *GitCommentEventData) EventSubject() string {
+	if d.PullRequest != nil {
+		return strconv.Itoa(d.PullRequest.Number)
+	}
+	return d.CommitSHA
+}
+
+// EventExtensions returns the extensions used for matching events in trigger filter
+func (d *GitCommentEventData) EventExtensions() map[string]string {
+	values := d.extensions(d.Action)
+	if d.PullRequest != nil {
+		values = d.PullRequest.extensions(values)
+	}
+	if d.CommitSHA != "" {
+		values[CloudEventExtGitCommitID] = d.CommitSHA
+	}
+	return values
+}
+
+// GitReviewEventData data of GitReviewEventType
+type GitReviewEventData struct {
+	GitEventMeta `json:",inline"`
+
+	// PullRequest the review is submitted to
+	PullRequest GitEventPullRequest `json:"pullRequest"`
+
+	// State of the review
+	State PullRequestReviewState `json:"state"`
+
+	// Body of the review
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Reviewer who submits the review
+	Reviewer GitUserBaseInfo `json:"reviewer"`
+
+	// CommitSHA commit the review is submitted on
+	// +optional
+	CommitSHA string `json:"commitSHA,omitempty"`
+}
+
+// EventType returns the type of the event
+func (d *GitReviewEventData) EventType() GitEventType {
+	return GitReviewEventType
+}
+
+// EventSubject returns the number of the pull request
+func (d *GitReviewEventData) EventSubject() string {
+	return strconv.Itoa(d.PullRequest.Number)
+}
+
+// EventExtensions returns the extensions used for matching events in trigger filter
+func (d *GitReviewEventData) EventExtensions() map[string]string {
+	values := d.PullRequest.extensions(d.extensions(string(d.State)))
+	if d.CommitSHA != "" {
+		values[CloudEventExtGitCommitID] = d.CommitSHA
+	}
+	return values
+}
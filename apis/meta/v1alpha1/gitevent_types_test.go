@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("GitEventType",
+	func(eventType GitEventType, valid, pullRequest bool) {
+		Expect(eventType.IsValid()).To(Equal(valid))
+		Expect(eventType.IsPullRequest()).To(Equal(pullRequest))
+	},
+	Entry("push", GitPushEventType, true, false),
+	Entry("tag", GitTagEventType, true, false),
+	Entry("pull request opened", GitPullRequestOpenedEventType, true, true),
+	Entry("pull request merged", GitPullRequestMergedEventType, true, true),
+	Entry("review", GitReviewEventType, true, false),
+	Entry("provider specific type", GitEventType("dev.katanomi.cloudevents.gitlab.Push Hook"), false, false),
+)
+
+var _ = Describe("GitEventTypes", func() {
+	It("returns a copy of all the types", func() {
+		types := GitEventTypes()
+		Expect(types).To(HaveLen(8))
+		types[0] = "changed"
+		Expect(GitEventTypes()[0]).To(Equal(GitPushEventType))
+	})
+})
+
+var _ = Describe("GitCommentEventData", func() {
+	It("uses the pull request as subject if the comment is made on a pull request", func() {
+		data := &GitCommentEventData{
+			CommitSHA:   "abc",
+			PullRequest: &GitEventPullRequest{Number: 5, Source: "feature", Target: "main"},
+		}
+		Expect(data.EventSubject()).To(Equal("5"))
+		Expect(data.EventExtensions()).To(HaveKeyWithValue(CloudEventExtPullRequestNumber, "5"))
+		Expect(data.EventExtensions()).To(HaveKeyWithValue(CloudEventExtGitCommitID, "abc"))
+	})
+})
@@ -45,6 +45,15 @@ type GitCommitOption struct {
 	GitCommitBasicInfo
 }
 
+// GitCommitCompareOption option for comparing two revisions
+type GitCommitCompareOption struct {
+	GitRepo
+	// Base revision compared from, e.g. branch, tag or commit sha
+	Base string `json:"base"`
+	// Head revision compared to, e.g. branch, tag or commit sha
+	Head string `json:"head"`
+}
+
 // GitBranchOption option for list branch
 type GitBranchOption struct {
 	GitRepo
@@ -57,6 +66,13 @@ type GitPullRequestOption struct {
 	Index int `json:"Index"`
 }
 
+// GitPullRequestDiffOption option for getting the diff of pr
+type GitPullRequestDiffOption struct {
+	GitPullRequestOption
+	// Path limits the diff to the file, all files are included if empty
+	Path string `json:"path,omitempty"`
+}
+
 type GitPullRequestListOption struct {
 	GitRepo
 	// State indicattes pullrequest state.
@@ -121,6 +121,8 @@ type GitPullRequestSpec struct {
 	OriginMergeStatus string `json:"originMergeStatus,omitempty"`
 	// MergedBy indicates pr was merged by user use email
 	MergedBy GitUserBaseInfo `json:"mergedBy,omitempty"`
+	// Reviewers users requested to review the pr
+	Reviewers []GitUserBaseInfo `json:"reviewers,omitempty"`
 }
 
 // GitPullRequestList list of pr
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	GitPullRequestFileGVK         = GroupVersion.WithKind("GitPullRequestFile")
+	GitPullRequestFileListGVK     = GroupVersion.WithKind("GitPullRequestFileList")
+	GitPullRequestDiffGVK         = GroupVersion.WithKind("GitPullRequestDiff")
+	GitPullRequestReviewGVK       = GroupVersion.WithKind("GitPullRequestReview")
+	GitPullRequestReviewListGVK   = GroupVersion.WithKind("GitPullRequestReviewList")
+	GitPullRequestMergeabilityGVK = GroupVersion.WithKind("GitPullRequestMergeability")
+)
+
+// FileChangeStatus is the kind of change made to a file in a pull request
+type FileChangeStatus string
+
+const (
+	// FileChangeStatusAdded indicates that the file is added
+	FileChangeStatusAdded FileChangeStatus = "added"
+	// FileChangeStatusModified indicates that the content of the file is changed
+	FileChangeStatusModified FileChangeStatus = "modified"
+	// FileChangeStatusDeleted indicates that the file is deleted
+	FileChangeStatusDeleted FileChangeStatus = "deleted"
+	// FileChangeStatusRenamed indicates that the file is moved from OldPath
+	FileChangeStatusRenamed FileChangeStatus = "renamed"
+)
+
+// GitPullRequestFile a file changed by a pull request
+type GitPullRequestFile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitPullRequestFileSpec `json:"spec"`
+}
+
+// GitPullRequestFileSpec spec for a changed file of pull request
+type GitPullRequestFileSpec struct {
+	// Path of the file in the source branch
+	Path string `json:"path"`
+	// OldPath of the file in the target branch, only set when the file is renamed
+	OldPath string `json:"oldPath,omitempty"`
+	// Status of the change
+	Status FileChangeStatus `json:"status"`
+	// Additions number of added lines
+	Additions int `json:"additions"`
+	// Deletions number of deleted lines
+	Deletions int `json:"deletions"`
+	// Binary indicates the file is a binary file and has no line changes
+	Binary bool `json:"binary,omitempty"`
+}
+
+// GitPullRequestFileList list of changed files of pull request
+type GitPullRequestFileList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        `json:"metadata,omitempty"`
+
+	Items []GitPullRequestFile `json:"items"`
+}
+
+// GitPullRequestDiff the diff between the target and source branches of pull request
+type GitPullRequestDiff struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitPullRequestDiffSpec `json:"spec"`
+}
+
+// GitPullRequestDiffSpec spec for diff of pull request
+type GitPullRequestDiffSpec struct {
+	// BaseSHA the commit which the diff is compared from,
+	// normally the merge base of the source and target branches
+	BaseSHA string `json:"baseSha"`
+	// HeadSHA the latest commit of the source branch
+	HeadSHA string `json:"headSha"`
+	// Diff changes in unified diff format
+	Diff string `json:"diff"`
+}
+
+// PullRequestReviewState is the state of a pull request review
+type PullRequestReviewState string
+
+// IsValid returns true if the PullRequestReviewState could be submitted.
+func (s PullRequestReviewState) IsValid() bool {
+	return slices.Contains(possiblePullRequestReviewStates, s)
+}
+
+// possiblePullRequestReviewStates is a list of states which could be submitted.
+var possiblePullRequestReviewStates = []PullRequestReviewState{
+	PullRequestReviewApproved,
+	PullRequestReviewChangesRequested,
+	PullRequestReviewCommented,
+}
+
+const (
+	// PullRequestReviewApproved indicates the reviewer approves the changes
+	PullRequestReviewApproved PullRequestReviewState = "approved"
+	// PullRequestReviewChangesRequested indicates the reviewer requests changes before merging
+	PullRequestReviewChangesRequested PullRequestReviewState = "changes_requested"
+	// PullRequestReviewCommented indicates the reviewer comments without an explicit decision
+	PullRequestReviewCommented PullRequestReviewState = "commented"
+	// PullRequestReviewDismissed indicates the review is dismissed and no longer counted.
+	// Note: This state could not be submitted.
+	PullRequestReviewDismissed PullRequestReviewState = "dismissed"
+)
+
+// GitPullRequestReview review of pull request
+type GitPullRequestReview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitPullRequestReviewSpec `json:"spec"`
+}
+
+// GitPullRequestReviewSpec spec for review of pull request
+type GitPullRequestReviewSpec struct {
+	// ID review id
+	ID int `json:"id"`
+	// State review state
+	State PullRequestReviewState `json:"state"`
+	// Body review content
+	Body string `json:"body,omitempty"`
+	// CommitSHA the commit which is reviewed
+	CommitSHA string `json:"commitSha,omitempty"`
+	// Author review author
+	Author GitUserBaseInfo `json:"author"`
+	// SubmittedAt review submit time
+	SubmittedAt metav1.Time `json:"submittedAt"`
+}
+
+// GitPullRequestReviewList list of reviews of pull request
+type GitPullRequestReviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        `json:"metadata,omitempty"`
+
+	Items []GitPullRequestReview `json:"items"`
+}
+
+// MergeStrategy is the way to merge a pull request
+type MergeStrategy string
+
+// IsValid returns true if the MergeStrategy is one of the possible values.
+func (s MergeStrategy) IsValid() bool {
+	return slices.Contains(possibleMergeStrategies, s)
+}
+
+// possibleMergeStrategies is a list of valid MergeStrategy values.
+var possibleMergeStrategies = []MergeStrategy{
+	MergeStrategyMerge,
+	MergeStrategySquash,
+	MergeStrategyRebase,
+}
+
+const (
+	// MergeStrategyMerge creates a merge commit on the target branch
+	MergeStrategyMerge MergeStrategy = "merge"
+	// MergeStrategySquash squashes all commits into one commit on the target branch
+	MergeStrategySquash MergeStrategy = "squash"
+	// MergeStrategyRebase replays the commits on the target branch without a merge commit
+	MergeStrategyRebase MergeStrategy = "rebase"
+)
+
+// GitPullRequestMergeability whether a pull request could be merged
+type GitPullRequestMergeability struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GitPullRequestMergeabilitySpec `json:"spec"`
+}
+
+// GitPullRequestMergeabilitySpec spec for mergeability of pull request
+type GitPullRequestMergeabilitySpec struct {
+	// MergeStatus indicates if the pull request could be merged
+	MergeStatus MergeStatus `json:"mergeStatus"`
+	// HasConflicts means source and target branch has conflict change
+	HasConflicts bool `json:"hasConflicts,omitempty"`
+	// SourceSHA the latest commit of the source branch
+	SourceSHA string `json:"sourceSha,omitempty"`
+	// TargetSHA the latest commit of the target branch
+	TargetSHA string `json:"targetSha,omitempty"`
+	// Strategies merge strategies allowed by the tool
+	Strategies []MergeStrategy `json:"strategies,omitempty"`
+	// Reasons why the pull request could not be merged, e.g. missing approvals
+	Reasons []string `json:"reasons,omitempty"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("PullRequestReviewState.IsValid",
+	func(state PullRequestReviewState, expected bool) {
+		Expect(state.IsValid()).To(Equal(expected), "state: %v should equal: %t", state, expected)
+	},
+	Entry("approved", PullRequestReviewApproved, true),
+	Entry("changes_requested", PullRequestReviewChangesRequested, true),
+	Entry("commented", PullRequestReviewCommented, true),
+	Entry("dismissed could not be submitted", PullRequestReviewDismissed, false),
+	Entry("invalid", PullRequestReviewState("invalid"), false),
+)
+
+var _ = DescribeTable("MergeStrategy.IsValid",
+	func(strategy MergeStrategy, expected bool) {
+		Expect(strategy.IsValid()).To(Equal(expected), "strategy: %v should equal: %t", strategy, expected)
+	},
+	Entry("merge", MergeStrategyMerge, true),
+	Entry("squash", MergeStrategySquash, true),
+	Entry("rebase", MergeStrategyRebase, true),
+	Entry("empty", MergeStrategy(""), false),
+	Entry("invalid", MergeStrategy("invalid"), false),
+)
@@ -90,14 +90,26 @@ type IssueProject struct {
 }
 
 type RelateIssue struct {
+	// Relate issue id
+	// +optional
+	Id string `json:"id,omitempty"`
+
 	// Relate issue subject
 	Subject string `json:"subject"`
 
+	// LinkType how the issue relates to the relate issue
+	// +optional
+	LinkType IssueLinkType `json:"linkType,omitempty"`
+
 	// Relate issue access
 	Access *duckv1.Addressable `json:"access"`
 }
 
 type Comment struct {
+	// Issue comment id
+	// +optional
+	Id string `json:"id,omitempty"`
+
 	// Issue comment by user
 	User UserSpec `json:"author"`
 
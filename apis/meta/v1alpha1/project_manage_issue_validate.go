@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	kvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// HasType returns true if the issue type is defined by the attribute
+func (a *AttributeSpec) HasType(id string) bool {
+	for _, t := range a.Types {
+		if t.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPriority returns true if the issue priority is defined by the attribute
+func (a *AttributeSpec) HasPriority(id string) bool {
+	for _, p := range a.Priorities {
+		if p.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasStatus returns true if the issue status is defined by the attribute
+func (a *AttributeSpec) HasStatus(id string) bool {
+	for _, s := range a.Statuses {
+		if s.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAttributes validates the ids are defined by the attribute, nothing is checked without attribute
+func validateAttributes(attribute *AttributeSpec, path *field.Path, priority, status *string) (errs field.ErrorList) {
+	if attribute == nil {
+		return
+	}
+	if priority != nil && *priority != "" && !attribute.HasPriority(*priority) {
+		errs = append(errs, field.NotSupported(path.Child("priority"), *priority, priorityIDs(attribute)))
+	}
+	if status != nil && *status != "" && !attribute.HasStatus(*status) {
+		errs = append(errs, field.NotSupported(path.Child("status"), *status, statusIDs(attribute)))
+	}
+	return
+}
+
+func priorityIDs(attribute *AttributeSpec) []string {
+	ids := make([]string, 0, len(attribute.Priorities))
+	for _, p := range attribute.Priorities {
+		ids = append(ids, p.Id)
+	}
+	return ids
+}
+
+func statusIDs(attribute *AttributeSpec) []string {
+	ids := make([]string, 0, len(attribute.Statuses))
+	for _, s := range attribute.Statuses {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+// Validate validates the payload, the type, priority and status
+// must be defined by the attribute of the project if it is provided
+func (p *CreateIssuePayload) Validate(attribute *AttributeSpec, path *field.Path) (errs field.ErrorList) {
+	if p.Subject == "" {
+		errs = append(errs, field.Required(path.Child("subject"), kvalidation.EmptyError()))
+	}
+	if p.Type == "" {
+		errs = append(errs, field.Required(path.Child("type"), kvalidation.EmptyError()))
+	} else if attribute != nil && !attribute.HasType(p.Type) {
+		ids := make([]string, 0, len(attribute.Types))
+		for _, t := range attribute.Types {
+			ids = append(ids, t.Id)
+		}
+		errs = append(errs, field.NotSupported(path.Child("type"), p.Type, ids))
+	}
+	errs = append(errs, validateAttributes(attribute, path, &p.Priority, &p.Status)...)
+	return
+}
+
+// Validate validates the payload updates at least one field, the priority and status
+// must be defined by the attribute of the project if it is provided
+func (p *UpdateIssuePayload) Validate(attribute *AttributeSpec, path *field.Path) (errs field.ErrorList) {
+	if p.Subject == nil && p.Priority == nil && p.Status == nil && p.Assign == nil && p.Description == nil {
+		errs = append(errs, field.Required(path, "at least one field should be updated"))
+	}
+	if p.Subject != nil && *p.Subject == "" {
+		errs = append(errs, field.Required(path.Child("subject"), kvalidation.EmptyError()))
+	}
+	if p.Status != nil && *p.Status == "" {
+		errs = append(errs, field.Required(path.Child("status"), kvalidation.EmptyError()))
+	}
+	errs = append(errs, validateAttributes(attribute, path, p.Priority, p.Status)...)
+	return
+}
+
+// Validate validates the comment is not empty
+func (p *CreateIssueCommentPayload) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.Detail == "" {
+		errs = append(errs, field.Required(path.Child("detail"), kvalidation.EmptyError()))
+	}
+	return
+}
+
+// Validate validates the linked issue and the link type
+func (p *IssueLinkPayload) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.IssueId == "" {
+		errs = append(errs, field.Required(path.Child("issueId"), kvalidation.EmptyError()))
+	}
+	if !p.Type.IsValid() {
+		errs = append(errs, field.NotSupported(path.Child("type"), p.Type, []string{
+			string(IssueLinkRelates), string(IssueLinkBlocks), string(IssueLinkBlockedBy),
+			string(IssueLinkDuplicates), string(IssueLinkDuplicatedBy),
+		}))
+	}
+	return
+}
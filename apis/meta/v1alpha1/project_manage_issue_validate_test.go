@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("Issue payload validation", func() {
+	attribute := &AttributeSpec{
+		Types:      []IssueType{{Id: "bug", Name: "Bug"}},
+		Priorities: []IssuePriority{{Id: "p1", Name: "High"}},
+		Statuses:   []AttributeStatus{{ID: "open", Name: "Open"}, {ID: "closed", Name: "Closed"}},
+	}
+
+	DescribeTable("CreateIssuePayload",
+		func(payload CreateIssuePayload, attribute *AttributeSpec, fields []string) {
+			errs := payload.Validate(attribute, field.NewPath("payload"))
+			Expect(errs).To(HaveLen(len(fields)))
+			for i, err := range errs {
+				Expect(err.Field).To(Equal(fields[i]))
+			}
+		},
+		Entry("valid payload", CreateIssuePayload{Subject: "fail", Type: "bug", Priority: "p1", Status: "open"}, attribute, nil),
+		Entry("without attribute", CreateIssuePayload{Subject: "fail", Type: "task", Priority: "p9"}, nil, nil),
+		Entry("missing subject and type", CreateIssuePayload{}, attribute, []string{"payload.subject", "payload.type"}),
+		Entry("undefined attributes", CreateIssuePayload{Subject: "fail", Type: "task", Priority: "p9", Status: "done"}, attribute,
+			[]string{"payload.type", "payload.priority", "payload.status"}),
+	)
+
+	DescribeTable("UpdateIssuePayload",
+		func(payload UpdateIssuePayload, attribute *AttributeSpec, fields []string) {
+			errs := payload.Validate(attribute, field.NewPath("payload"))
+			Expect(errs).To(HaveLen(len(fields)))
+			for i, err := range errs {
+				Expect(err.Field).To(Equal(fields[i]))
+			}
+		},
+		Entry("transition status", UpdateIssuePayload{Status: pointer.String("closed")}, attribute, nil),
+		Entry("unassign", UpdateIssuePayload{Assign: &UserSpec{}}, attribute, nil),
+		Entry("nothing to update", UpdateIssuePayload{}, attribute, []string{"payload"}),
+		Entry("empty subject and status", UpdateIssuePayload{Subject: pointer.String(""), Status: pointer.String("")}, attribute,
+			[]string{"payload.subject", "payload.status"}),
+		Entry("undefined status", UpdateIssuePayload{Priority: pointer.String("p1"), Status: pointer.String("done")}, attribute, []string{"payload.status"}),
+	)
+
+	DescribeTable("IssueLinkPayload",
+		func(payload IssueLinkPayload, fields []string) {
+			errs := payload.Validate(field.NewPath("payload"))
+			Expect(errs).To(HaveLen(len(fields)))
+			for i, err := range errs {
+				Expect(err.Field).To(Equal(fields[i]))
+			}
+		},
+		Entry("valid payload", IssueLinkPayload{IssueId: "2", Type: IssueLinkBlocks}, nil),
+		Entry("missing issue", IssueLinkPayload{Type: IssueLinkRelates}, []string{"payload.issueId"}),
+		Entry("unknown type", IssueLinkPayload{IssueId: "2", Type: "parent"}, []string{"payload.type"}),
+	)
+
+	It("validates the comment is not empty", func() {
+		Expect((&CreateIssueCommentPayload{}).Validate(field.NewPath("payload"))).To(HaveLen(1))
+		Expect((&CreateIssueCommentPayload{Detail: "failed"}).Validate(field.NewPath("payload"))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var IssueCommentListGVK = GroupVersion.WithKind("IssueCommentList")
+
+// IssueLinkType how an issue relates to another issue
+type IssueLinkType string
+
+const (
+	// IssueLinkRelates the issues are related
+	IssueLinkRelates IssueLinkType = "relates"
+	// IssueLinkBlocks the issue blocks the other issue
+	IssueLinkBlocks IssueLinkType = "blocks"
+	// IssueLinkBlockedBy the issue is blocked by the other issue
+	IssueLinkBlockedBy IssueLinkType = "blocked_by"
+	// IssueLinkDuplicates the issue duplicates the other issue
+	IssueLinkDuplicates IssueLinkType = "duplicates"
+	// IssueLinkDuplicatedBy the issue is duplicated by the other issue
+	IssueLinkDuplicatedBy IssueLinkType = "duplicated_by"
+)
+
+// IsValid returns true if the link type is supported
+func (t IssueLinkType) IsValid() bool {
+	switch t {
+	case IssueLinkRelates, IssueLinkBlocks, IssueLinkBlockedBy, IssueLinkDuplicates, IssueLinkDuplicatedBy:
+		return true
+	}
+	return false
+}
+
+// CreateIssuePayload payload for creating an issue.
+// Type, priority and status are ids defined by the attribute of the project.
+type CreateIssuePayload struct {
+	// Issue subject
+	Subject string `json:"subject"`
+
+	// Issue type
+	Type string `json:"type"`
+
+	// Issue subtype
+	// +optional
+	SubType string `json:"subType,omitempty"`
+
+	// Issue priority
+	// +optional
+	Priority string `json:"priority,omitempty"`
+
+	// Issue initial status, the default status of the tool is used if empty
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Issue assign to someone
+	// +optional
+	Assign *UserSpec `json:"assign,omitempty"`
+
+	// Issue description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Parent id of the parent issue, the issue is created as a subtask of it if provided
+	// +optional
+	Parent string `json:"parent,omitempty"`
+}
+
+// UpdateIssuePayload payload for updating an issue, only the provided fields are updated.
+// Priority and status are ids defined by the attribute of the project,
+// updating the status transitions the issue to the status.
+type UpdateIssuePayload struct {
+	// Issue subject
+	// +optional
+	Subject *string `json:"subject,omitempty"`
+
+	// Issue priority
+	// +optional
+	Priority *string `json:"priority,omitempty"`
+
+	// Issue status
+	// +optional
+	Status *string `json:"status,omitempty"`
+
+	// Issue assign to someone, the issue is unassigned if the user is empty
+	// +optional
+	Assign *UserSpec `json:"assign,omitempty"`
+
+	// Issue description
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// CreateIssueCommentPayload payload for commenting an issue
+type CreateIssueCommentPayload struct {
+	// Issue comment message
+	Detail string `json:"detail"`
+}
+
+// IssueLinkPayload payload for linking an issue to another issue
+type IssueLinkPayload struct {
+	// IssueId id of the linked issue
+	IssueId string `json:"issueId"`
+
+	// Type how the issue relates to the linked issue
+	Type IssueLinkType `json:"type"`
+}
+
+// IssueCommentList list of issue comments
+type IssueCommentList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        `json:"metadata,omitempty"`
+
+	Items []Comment `json:"items"`
+}
@@ -355,6 +355,21 @@ func (in *ArtifactParameterSpec) DeepCopy() *ArtifactParameterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactPromotionTarget) DeepCopyInto(out *ArtifactPromotionTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactPromotionTarget.
+func (in *ArtifactPromotionTarget) DeepCopy() *ArtifactPromotionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactPromotionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactSpec) DeepCopyInto(out *ArtifactSpec) {
 	*out = *in
@@ -1480,6 +1495,41 @@ func (in *CreateGitTagPayload) DeepCopy() *CreateGitTagPayload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateIssueCommentPayload) DeepCopyInto(out *CreateIssueCommentPayload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateIssueCommentPayload.
+func (in *CreateIssueCommentPayload) DeepCopy() *CreateIssueCommentPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateIssueCommentPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateIssuePayload) DeepCopyInto(out *CreateIssuePayload) {
+	*out = *in
+	if in.Assign != nil {
+		in, out := &in.Assign, &out.Assign
+		*out = new(UserSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateIssuePayload.
+func (in *CreateIssuePayload) DeepCopy() *CreateIssuePayload {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateIssuePayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CreatePullRequestCommentParam) DeepCopyInto(out *CreatePullRequestCommentParam) {
 	*out = *in
@@ -1529,6 +1579,38 @@ func (in *CreatePullRequestPayload) DeepCopy() *CreatePullRequestPayload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreatePullRequestReviewParam) DeepCopyInto(out *CreatePullRequestReviewParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreatePullRequestReviewParam.
+func (in *CreatePullRequestReviewParam) DeepCopy() *CreatePullRequestReviewParam {
+	if in == nil {
+		return nil
+	}
+	out := new(CreatePullRequestReviewParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreatePullRequestReviewPayload) DeepCopyInto(out *CreatePullRequestReviewPayload) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	out.CreatePullRequestReviewParam = in.CreatePullRequestReviewParam
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreatePullRequestReviewPayload.
+func (in *CreatePullRequestReviewPayload) DeepCopy() *CreatePullRequestReviewPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(CreatePullRequestReviewPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CreateRepoFileParams) DeepCopyInto(out *CreateRepoFileParams) {
 	*out = *in
@@ -1802,6 +1884,71 @@ func (in *GitBranchOption) DeepCopy() *GitBranchOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitBranchProtection) DeepCopyInto(out *GitBranchProtection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitBranchProtection.
+func (in *GitBranchProtection) DeepCopy() *GitBranchProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(GitBranchProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitBranchProtectionRules) DeepCopyInto(out *GitBranchProtectionRules) {
+	*out = *in
+	if in.RequiredStatusChecks != nil {
+		in, out := &in.RequiredStatusChecks, &out.RequiredStatusChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DevelopersCanPush != nil {
+		in, out := &in.DevelopersCanPush, &out.DevelopersCanPush
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DevelopersCanMerge != nil {
+		in, out := &in.DevelopersCanMerge, &out.DevelopersCanMerge
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitBranchProtectionRules.
+func (in *GitBranchProtectionRules) DeepCopy() *GitBranchProtectionRules {
+	if in == nil {
+		return nil
+	}
+	out := new(GitBranchProtectionRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitBranchProtectionSpec) DeepCopyInto(out *GitBranchProtectionSpec) {
+	*out = *in
+	out.GitBranchBaseInfo = in.GitBranchBaseInfo
+	in.GitBranchProtectionRules.DeepCopyInto(&out.GitBranchProtectionRules)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitBranchProtectionSpec.
+func (in *GitBranchProtectionSpec) DeepCopy() *GitBranchProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitBranchProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitBranchSpec) DeepCopyInto(out *GitBranchSpec) {
 	*out = *in
@@ -1845,6 +1992,28 @@ func (in *GitBranchSpec) DeepCopy() *GitBranchSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommentEventData) DeepCopyInto(out *GitCommentEventData) {
+	*out = *in
+	out.GitEventMeta = in.GitEventMeta
+	out.Author = in.Author
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitEventPullRequest)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommentEventData.
+func (in *GitCommentEventData) DeepCopy() *GitCommentEventData {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommentEventData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitCommit) DeepCopyInto(out *GitCommit) {
 	*out = *in
@@ -1951,6 +2120,68 @@ func (in *GitCommitCommentSpec) DeepCopy() *GitCommitCommentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommitCompareOption) DeepCopyInto(out *GitCommitCompareOption) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommitCompareOption.
+func (in *GitCommitCompareOption) DeepCopy() *GitCommitCompareOption {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommitCompareOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommitComparison) DeepCopyInto(out *GitCommitComparison) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommitComparison.
+func (in *GitCommitComparison) DeepCopy() *GitCommitComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommitComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommitComparisonSpec) DeepCopyInto(out *GitCommitComparisonSpec) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	if in.Commits != nil {
+		in, out := &in.Commits, &out.Commits
+		*out = make([]GitCommit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]GitPullRequestFileSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommitComparisonSpec.
+func (in *GitCommitComparisonSpec) DeepCopy() *GitCommitComparisonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommitComparisonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitCommitInfo) DeepCopyInto(out *GitCommitInfo) {
 	*out = *in
@@ -2221,6 +2452,77 @@ func (in *GitCommitStatusStatus) DeepCopy() *GitCommitStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitEventCommit) DeepCopyInto(out *GitEventCommit) {
+	*out = *in
+	out.Author = in.Author
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitEventCommit.
+func (in *GitEventCommit) DeepCopy() *GitEventCommit {
+	if in == nil {
+		return nil
+	}
+	out := new(GitEventCommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitEventMeta) DeepCopyInto(out *GitEventMeta) {
+	*out = *in
+	out.Repository = in.Repository
+	out.Sender = in.Sender
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitEventMeta.
+func (in *GitEventMeta) DeepCopy() *GitEventMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(GitEventMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitEventPullRequest) DeepCopyInto(out *GitEventPullRequest) {
+	*out = *in
+	if in.SourceRepository != nil {
+		in, out := &in.SourceRepository, &out.SourceRepository
+		*out = new(GitRepo)
+		**out = **in
+	}
+	out.Author = in.Author
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitEventPullRequest.
+func (in *GitEventPullRequest) DeepCopy() *GitEventPullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GitEventPullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitEventRepository) DeepCopyInto(out *GitEventRepository) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitEventRepository.
+func (in *GitEventRepository) DeepCopy() *GitEventRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(GitEventRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOperateLogBaseInfo) DeepCopyInto(out *GitOperateLogBaseInfo) {
 	*out = *in
@@ -2264,76 +2566,247 @@ func (in *GitPullRequest) DeepCopy() *GitPullRequest {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitPullRequestList) DeepCopyInto(out *GitPullRequestList) {
+func (in *GitPullRequestDiff) DeepCopyInto(out *GitPullRequestDiff) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GitPullRequest, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestList.
-func (in *GitPullRequestList) DeepCopy() *GitPullRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestDiff.
+func (in *GitPullRequestDiff) DeepCopy() *GitPullRequestDiff {
 	if in == nil {
 		return nil
 	}
-	out := new(GitPullRequestList)
+	out := new(GitPullRequestDiff)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitPullRequestListOption) DeepCopyInto(out *GitPullRequestListOption) {
+func (in *GitPullRequestDiffOption) DeepCopyInto(out *GitPullRequestDiffOption) {
 	*out = *in
-	out.GitRepo = in.GitRepo
-	if in.State != nil {
-		in, out := &in.State, &out.State
-		*out = new(PullRequestState)
-		**out = **in
-	}
+	out.GitPullRequestOption = in.GitPullRequestOption
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestListOption.
-func (in *GitPullRequestListOption) DeepCopy() *GitPullRequestListOption {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestDiffOption.
+func (in *GitPullRequestDiffOption) DeepCopy() *GitPullRequestDiffOption {
 	if in == nil {
 		return nil
 	}
-	out := new(GitPullRequestListOption)
+	out := new(GitPullRequestDiffOption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitPullRequestNote) DeepCopyInto(out *GitPullRequestNote) {
+func (in *GitPullRequestDiffSpec) DeepCopyInto(out *GitPullRequestDiffSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestNote.
-func (in *GitPullRequestNote) DeepCopy() *GitPullRequestNote {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestDiffSpec.
+func (in *GitPullRequestDiffSpec) DeepCopy() *GitPullRequestDiffSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GitPullRequestNote)
+	out := new(GitPullRequestDiffSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitPullRequestNoteList) DeepCopyInto(out *GitPullRequestNoteList) {
+func (in *GitPullRequestEventData) DeepCopyInto(out *GitPullRequestEventData) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GitPullRequestNote, len(*in))
+	out.GitEventMeta = in.GitEventMeta
+	in.PullRequest.DeepCopyInto(&out.PullRequest)
+	if in.MergedBy != nil {
+		in, out := &in.MergedBy, &out.MergedBy
+		*out = new(GitUserBaseInfo)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestEventData.
+func (in *GitPullRequestEventData) DeepCopy() *GitPullRequestEventData {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestEventData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestFile) DeepCopyInto(out *GitPullRequestFile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestFile.
+func (in *GitPullRequestFile) DeepCopy() *GitPullRequestFile {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestFileList) DeepCopyInto(out *GitPullRequestFileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitPullRequestFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestFileList.
+func (in *GitPullRequestFileList) DeepCopy() *GitPullRequestFileList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestFileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestFileSpec) DeepCopyInto(out *GitPullRequestFileSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestFileSpec.
+func (in *GitPullRequestFileSpec) DeepCopy() *GitPullRequestFileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestFileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestList) DeepCopyInto(out *GitPullRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitPullRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestList.
+func (in *GitPullRequestList) DeepCopy() *GitPullRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestListOption) DeepCopyInto(out *GitPullRequestListOption) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(PullRequestState)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestListOption.
+func (in *GitPullRequestListOption) DeepCopy() *GitPullRequestListOption {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestListOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestMergeability) DeepCopyInto(out *GitPullRequestMergeability) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestMergeability.
+func (in *GitPullRequestMergeability) DeepCopy() *GitPullRequestMergeability {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestMergeability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestMergeabilitySpec) DeepCopyInto(out *GitPullRequestMergeabilitySpec) {
+	*out = *in
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make([]MergeStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestMergeabilitySpec.
+func (in *GitPullRequestMergeabilitySpec) DeepCopy() *GitPullRequestMergeabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestMergeabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestNote) DeepCopyInto(out *GitPullRequestNote) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestNote.
+func (in *GitPullRequestNote) DeepCopy() *GitPullRequestNote {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestNote)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestNoteList) DeepCopyInto(out *GitPullRequestNoteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitPullRequestNote, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2386,6 +2859,65 @@ func (in *GitPullRequestOption) DeepCopy() *GitPullRequestOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestReview) DeepCopyInto(out *GitPullRequestReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestReview.
+func (in *GitPullRequestReview) DeepCopy() *GitPullRequestReview {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestReviewList) DeepCopyInto(out *GitPullRequestReviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitPullRequestReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestReviewList.
+func (in *GitPullRequestReviewList) DeepCopy() *GitPullRequestReviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestReviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestReviewSpec) DeepCopyInto(out *GitPullRequestReviewSpec) {
+	*out = *in
+	out.Author = in.Author
+	in.SubmittedAt.DeepCopyInto(&out.SubmittedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestReviewSpec.
+func (in *GitPullRequestReviewSpec) DeepCopy() *GitPullRequestReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitPullRequestSpec) DeepCopyInto(out *GitPullRequestSpec) {
 	*out = *in
@@ -2413,6 +2945,11 @@ func (in *GitPullRequestSpec) DeepCopyInto(out *GitPullRequestSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.MergedBy = in.MergedBy
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]GitUserBaseInfo, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestSpec.
@@ -2425,6 +2962,29 @@ func (in *GitPullRequestSpec) DeepCopy() *GitPullRequestSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPushEventData) DeepCopyInto(out *GitPushEventData) {
+	*out = *in
+	out.GitEventMeta = in.GitEventMeta
+	if in.Commits != nil {
+		in, out := &in.Commits, &out.Commits
+		*out = make([]GitEventCommit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPushEventData.
+func (in *GitPushEventData) DeepCopy() *GitPushEventData {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPushEventData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRepo) DeepCopyInto(out *GitRepo) {
 	*out = *in
@@ -2748,6 +3308,24 @@ func (in *GitRepositoryTagSpec) DeepCopy() *GitRepositoryTagSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitReviewEventData) DeepCopyInto(out *GitReviewEventData) {
+	*out = *in
+	out.GitEventMeta = in.GitEventMeta
+	in.PullRequest.DeepCopyInto(&out.PullRequest)
+	out.Reviewer = in.Reviewer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitReviewEventData.
+func (in *GitReviewEventData) DeepCopy() *GitReviewEventData {
+	if in == nil {
+		return nil
+	}
+	out := new(GitReviewEventData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRevision) DeepCopyInto(out *GitRevision) {
 	*out = *in
@@ -2844,6 +3422,22 @@ func (in *GitTag) DeepCopy() *GitTag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitTagEventData) DeepCopyInto(out *GitTagEventData) {
+	*out = *in
+	out.GitEventMeta = in.GitEventMeta
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitTagEventData.
+func (in *GitTagEventData) DeepCopy() *GitTagEventData {
+	if in == nil {
+		return nil
+	}
+	out := new(GitTagEventData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitUserBaseInfo) DeepCopyInto(out *GitUserBaseInfo) {
 	*out = *in
@@ -2897,6 +3491,30 @@ func (in *Issue) DeepCopy() *Issue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssueCommentList) DeepCopyInto(out *IssueCommentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Comment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssueCommentList.
+func (in *IssueCommentList) DeepCopy() *IssueCommentList {
+	if in == nil {
+		return nil
+	}
+	out := new(IssueCommentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssueInfo) DeepCopyInto(out *IssueInfo) {
 	*out = *in
@@ -2912,6 +3530,21 @@ func (in *IssueInfo) DeepCopy() *IssueInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssueLinkPayload) DeepCopyInto(out *IssueLinkPayload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssueLinkPayload.
+func (in *IssueLinkPayload) DeepCopy() *IssueLinkPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(IssueLinkPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssueList) DeepCopyInto(out *IssueList) {
 	*out = *in
@@ -3110,6 +3743,43 @@ func (in *ListOptions) DeepCopy() *ListOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergePullRequestParam) DeepCopyInto(out *MergePullRequestParam) {
+	*out = *in
+	if in.RemoveSourceBranch != nil {
+		in, out := &in.RemoveSourceBranch, &out.RemoveSourceBranch
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergePullRequestParam.
+func (in *MergePullRequestParam) DeepCopy() *MergePullRequestParam {
+	if in == nil {
+		return nil
+	}
+	out := new(MergePullRequestParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergePullRequestPayload) DeepCopyInto(out *MergePullRequestPayload) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	in.MergePullRequestParam.DeepCopyInto(&out.MergePullRequestParam)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergePullRequestPayload.
+func (in *MergePullRequestPayload) DeepCopy() *MergePullRequestPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(MergePullRequestPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -3358,6 +4028,32 @@ func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromoteArtifactPayload) DeepCopyInto(out *PromoteArtifactPayload) {
+	*out = *in
+	out.Target = in.Target
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromoteArtifactPayload.
+func (in *PromoteArtifactPayload) DeepCopy() *PromoteArtifactPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(PromoteArtifactPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RelateIssue) DeepCopyInto(out *RelateIssue) {
 	*out = *in
@@ -3471,6 +4167,43 @@ func (in *RepositorySpec) DeepCopy() *RepositorySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestPullRequestReviewersParam) DeepCopyInto(out *RequestPullRequestReviewersParam) {
+	*out = *in
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]GitUserBaseInfo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestPullRequestReviewersParam.
+func (in *RequestPullRequestReviewersParam) DeepCopy() *RequestPullRequestReviewersParam {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestPullRequestReviewersParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestPullRequestReviewersPayload) DeepCopyInto(out *RequestPullRequestReviewersPayload) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	in.RequestPullRequestReviewersParam.DeepCopyInto(&out.RequestPullRequestReviewersParam)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestPullRequestReviewersPayload.
+func (in *RequestPullRequestReviewersPayload) DeepCopy() *RequestPullRequestReviewersPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestPullRequestReviewersPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceURI) DeepCopyInto(out *ResourceURI) {
 	*out = *in
@@ -4102,6 +4835,63 @@ func (in *TriggeredBy) DeepCopy() *TriggeredBy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateBranchProtectionPayload) DeepCopyInto(out *UpdateBranchProtectionPayload) {
+	*out = *in
+	out.GitRepo = in.GitRepo
+	in.GitBranchProtectionRules.DeepCopyInto(&out.GitBranchProtectionRules)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateBranchProtectionPayload.
+func (in *UpdateBranchProtectionPayload) DeepCopy() *UpdateBranchProtectionPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateBranchProtectionPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateIssuePayload) DeepCopyInto(out *UpdateIssuePayload) {
+	*out = *in
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(string)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(string)
+		**out = **in
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(string)
+		**out = **in
+	}
+	if in.Assign != nil {
+		in, out := &in.Assign, &out.Assign
+		*out = new(UserSpec)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateIssuePayload.
+func (in *UpdateIssuePayload) DeepCopy() *UpdateIssuePayload {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateIssuePayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdatePullRequestCommentPayload) DeepCopyInto(out *UpdatePullRequestCommentPayload) {
 	*out = *in
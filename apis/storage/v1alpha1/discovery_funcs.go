@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// GetCapability returns the capability of the name and version or nil if it is not served
+func (in *StorageDiscovery) GetCapability(name, version string) *StorageCapability {
+	if in == nil {
+		return nil
+	}
+	for i := range in.Capabilities {
+		if in.Capabilities[i].Name == name && in.Capabilities[i].Version == version {
+			return &in.Capabilities[i]
+		}
+	}
+	return nil
+}
+
+// HasFeature returns true if the capability supports the feature
+func (in *StorageCapability) HasFeature(feature string) bool {
+	if in == nil {
+		return false
+	}
+	for _, item := range in.Features {
+		if item == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingFeatures returns the features which are not supported by the capability
+func (in *StorageCapability) MissingFeatures(features ...string) []string {
+	var missing []string
+	for _, feature := range features {
+		if !in.HasFeature(feature) {
+			missing = append(missing, feature)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStorageDiscovery_GetCapability(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	discovery := &StorageDiscovery{Capabilities: []StorageCapability{
+		{Name: "file-store", Version: "v1alpha1", Features: []string{"rangedRead"}},
+		{Name: "archive", Version: "v1alpha1"},
+	}}
+
+	capability := discovery.GetCapability("file-store", "v1alpha1")
+	g.Expect(capability).NotTo(BeNil())
+	g.Expect(capability.HasFeature("rangedRead")).To(BeTrue())
+	g.Expect(capability.MissingFeatures("rangedRead", "resumableUpload")).To(Equal([]string{"resumableUpload"}))
+
+	g.Expect(discovery.GetCapability("file-store", "v1beta1")).To(BeNil())
+	g.Expect(discovery.GetCapability("archive", "v1alpha1").MissingFeatures("aggregate")).To(Equal([]string{"aggregate"}))
+
+	var missing *StorageCapability
+	g.Expect(missing.HasFeature("aggregate")).To(BeFalse())
+	var empty *StorageDiscovery
+	g.Expect(empty.GetCapability("archive", "v1alpha1")).To(BeNil())
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageDiscoveryGVK for GVK of StorageDiscovery
+var StorageDiscoveryGVK = GroupVersion.WithKind("StorageDiscovery")
+
+// StorageDiscovery describes the capabilities served by a storage plugin
+type StorageDiscovery struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Capabilities are the versioned capabilities served by the storage plugin
+	// +optional
+	Capabilities []StorageCapability `json:"capabilities,omitempty"`
+}
+
+// StorageCapability describes a versioned capability and its optional features
+type StorageCapability struct {
+	// Name of the capability, it is the api group of the capability, e.g. file-store
+	Name string `json:"name"`
+
+	// Version of the capability, e.g. v1alpha1
+	Version string `json:"version"`
+
+	// Features are the optional features of the capability supported by the storage plugin
+	// +optional
+	Features []string `json:"features,omitempty"`
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GetChunk returns the uploaded chunk of index or nil if it is not uploaded yet
+func (in *FileUpload) GetChunk(index int) *FileUploadChunk {
+	for i := range in.Status.Chunks {
+		if in.Status.Chunks[i].Index == index {
+			return &in.Status.Chunks[i]
+		}
+	}
+	return nil
+}
+
+// ChunkChecksum returns the hex encoded sha256 checksum of data
+func ChunkChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestFileUpload_GetChunk(t *testing.T) {
+	upload := &FileUpload{Status: FileUploadStatus{Chunks: []FileUploadChunk{
+		{Index: 0, Size: 5, SHA256: ChunkChecksum([]byte("hello"))},
+		{Index: 2, Size: 5, SHA256: ChunkChecksum([]byte("world"))},
+	}}}
+
+	if chunk := upload.GetChunk(2); chunk == nil || chunk.SHA256 != ChunkChecksum([]byte("world")) {
+		t.Errorf("GetChunk(2) = %v, want chunk of world", chunk)
+	}
+	if chunk := upload.GetChunk(1); chunk != nil {
+		t.Errorf("GetChunk(1) = %v, want nil", chunk)
+	}
+}
+
+func TestChunkChecksum(t *testing.T) {
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if got := ChunkChecksum([]byte("hello")); got != want {
+		t.Errorf("ChunkChecksum() = %v, want %v", got, want)
+	}
+}
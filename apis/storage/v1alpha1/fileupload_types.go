@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FileUploadGVK for GVK of FileUpload
+var FileUploadGVK = GroupVersion.WithKind("FileUpload")
+
+// FileUpload is a resumable upload session of a file object.
+// The content is uploaded in chunks which are assembled into the file object
+// when the upload is completed, the name of the upload is the upload id.
+type FileUpload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FileUploadSpec `json:"spec"`
+
+	// +optional
+	Status FileUploadStatus `json:"status,omitempty"`
+}
+
+// FileUploadSpec spec for FileUpload
+type FileUploadSpec struct {
+	// FileMeta is the meta of the file object to be created, the name of it is the object name
+	FileMeta FileMeta `json:"fileMeta"`
+}
+
+// FileUploadStatus status for FileUpload
+type FileUploadStatus struct {
+	// Chunks are the uploaded chunks ordered by index
+	// +optional
+	Chunks []FileUploadChunk `json:"chunks,omitempty"`
+
+	// Size is the total size of uploaded chunks
+	// +optional
+	Size int64 `json:"size,omitempty"`
+}
+
+// FileUploadChunk describe an uploaded chunk
+type FileUploadChunk struct {
+	// Index is the position of the chunk in the file, starts from 0
+	Index int `json:"index"`
+
+	// Size is the size of the chunk
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex encoded sha256 checksum of the chunk content
+	SHA256 string `json:"sha256"`
+}
+
+// FileUploadCompleteOptions for options of completing a FileUpload
+type FileUploadCompleteOptions struct {
+	// SHA256 is the hex encoded sha256 checksum of the whole file,
+	// the upload fails if it is set and does not match the assembled content
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// FileUploadResourceAttributes returns a ResourceAttribute object to be used in a filter
+func FileUploadResourceAttributes(verb string) authv1.ResourceAttributes {
+	return authv1.ResourceAttributes{
+		Group:    GroupVersion.Group,
+		Version:  GroupVersion.Version,
+		Resource: "fileuploads",
+		Verb:     verb,
+	}
+}
@@ -27,3 +27,6 @@ const HeaderFileAnnotationPrefix = "x-katanomi-annotation-"
 
 // HeaderFilePath is the file path to saved
 const HeaderFilePath = "x-katanomi-file-path"
+
+// HeaderChunkSHA256 is the header name of the sha256 checksum of an upload chunk
+const HeaderChunkSHA256 = "x-katanomi-chunk-sha256"
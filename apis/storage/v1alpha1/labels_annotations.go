@@ -30,3 +30,6 @@ const StorageEntryAnnotation = "storage.katanomi.dev/entry"
 
 // StorageAnnotationPrefix is prefix of user-defined annotations
 const StorageAnnotationPrefix = "storage.katanomi.dev/annotation."
+
+// ContentDigestAnnotation for recording the digest of file content, e.g. sha256:{hex}
+const ContentDigestAnnotation = "storage.katanomi.dev/contentDigest"
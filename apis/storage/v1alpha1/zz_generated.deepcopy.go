@@ -96,6 +96,91 @@ func (in *FileMetaSpec) DeepCopy() *FileMetaSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileUpload) DeepCopyInto(out *FileUpload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileUpload.
+func (in *FileUpload) DeepCopy() *FileUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(FileUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileUploadChunk) DeepCopyInto(out *FileUploadChunk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileUploadChunk.
+func (in *FileUploadChunk) DeepCopy() *FileUploadChunk {
+	if in == nil {
+		return nil
+	}
+	out := new(FileUploadChunk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileUploadCompleteOptions) DeepCopyInto(out *FileUploadCompleteOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileUploadCompleteOptions.
+func (in *FileUploadCompleteOptions) DeepCopy() *FileUploadCompleteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(FileUploadCompleteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileUploadSpec) DeepCopyInto(out *FileUploadSpec) {
+	*out = *in
+	in.FileMeta.DeepCopyInto(&out.FileMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileUploadSpec.
+func (in *FileUploadSpec) DeepCopy() *FileUploadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FileUploadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileUploadStatus) DeepCopyInto(out *FileUploadStatus) {
+	*out = *in
+	if in.Chunks != nil {
+		in, out := &in.Chunks, &out.Chunks
+		*out = make([]FileUploadChunk, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileUploadStatus.
+func (in *FileUploadStatus) DeepCopy() *FileUploadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FileUploadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageAuthCheckRequest) DeepCopyInto(out *StorageAuthCheckRequest) {
 	*out = *in
@@ -117,3 +202,46 @@ func (in *StorageAuthCheckRequest) DeepCopy() *StorageAuthCheckRequest {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageCapability) DeepCopyInto(out *StorageCapability) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageCapability.
+func (in *StorageCapability) DeepCopy() *StorageCapability {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageCapability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageDiscovery) DeepCopyInto(out *StorageDiscovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]StorageCapability, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageDiscovery.
+func (in *StorageDiscovery) DeepCopy() *StorageDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/katanomi/pkg/command/qualitygate"
 	"github.com/katanomi/pkg/command/validators"
 	"github.com/katanomi/pkg/pointer"
+	"github.com/katanomi/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -68,7 +69,7 @@ func (c *CodeLinterOption) Validate(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
-// WriteResult save quality gate result
+// WriteResult save quality gate result with the number of issues in total, by level and by rule
 func (c *CodeLinterOption) WriteResult(err error, w io.Writer) {
 	if err != nil && !errors.Is(err, qualitygate.QualityGateCheckFailedErr) {
 		// Some exceptions occurred, skipping write the results
@@ -81,10 +82,44 @@ func (c *CodeLinterOption) WriteResult(err error, w io.Writer) {
 		"result":       c.Result,
 		"issues.count": strconv.Itoa(c.Issues.Count),
 	}
+	for level, count := range c.Issues.CountByLevel() {
+		if level != "" {
+			data["issues.levels."+level] = strconv.Itoa(count)
+		}
+	}
+	for rule, count := range c.Issues.CountByRule() {
+		data["issues.rules."+rule] = strconv.Itoa(count)
+	}
 	resultData, _ := json.Marshal(data)
 	w.Write(resultData)
 }
 
+// MergeCodeLintResult adds the issues of a code lint result, e.g. parsed from reports
+func (c *CodeLinterOption) MergeCodeLintResult(result v1alpha1.CodeLintResult) {
+	if c.Issues == nil {
+		c.Issues = &v1alpha1.CodeLintIssues{}
+	}
+	c.Issues.Merge(result.Issues)
+}
+
+// MergeReports adds the issues of all reports, reports are merged in the order of their report types.
+// CodeLintResultDefaultParser is used if the ReportParsers of reports is not set
+func (c *CodeLinterOption) MergeReports(parentPath string, reports *ReportPathsByTypesOption) error {
+	results, err := reports.CodeLintResultsByType(parentPath)
+	if err != nil {
+		return err
+	}
+	for _, reportType := range reports.sortedReportTypes() {
+		c.MergeCodeLintResult(results[reportType])
+	}
+	return nil
+}
+
+// WriteSarif renders the issues as sarif
+func (c *CodeLinterOption) WriteSarif(tool report.SarifToolComponent, w io.Writer) error {
+	return report.NewSarifLogFromCodeLintResult(tool, c.CodeLintResult).Write(w)
+}
+
 func (c *CodeLinterOption) ValidateQualityGate(ctx context.Context) (errs field.ErrorList) {
 	logger := logger.NewLoggerFromContext(ctx)
 
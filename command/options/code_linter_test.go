@@ -27,6 +27,7 @@ import (
 
 	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
 	"github.com/katanomi/pkg/command/qualitygate"
+	"github.com/katanomi/pkg/report"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/pflag"
@@ -205,4 +206,67 @@ var _ = Describe("Test.CodeLinterOption.WriteResult", func() {
 			Expect(writer.(*strings.Builder).String()).To(MatchJSON(`{"result":"Successed","issues.count":"10"}`))
 		})
 	})
+	Context("write result with issues of rules", func() {
+		It("should get counts by level and by rule", func() {
+			obj.Issues = &v1alpha1.CodeLintIssues{}
+			obj.Issues.Add("no-undef", "error", 2)
+			obj.Issues.Add("no-undef", "warning", 1)
+			obj.Issues.Add("eqeqeq", "warning", 3)
+			obj.WriteResult(nil, writer)
+			Expect(writer.(*strings.Builder).String()).To(MatchJSON(`{
+				"result": "Successed",
+				"issues.count": "6",
+				"issues.levels.error": "2",
+				"issues.levels.warning": "4",
+				"issues.rules.no-undef": "3",
+				"issues.rules.eqeqeq": "3"
+			}`))
+		})
+	})
 })
+
+func TestCodeLinterOption_MergeCodeLintResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := CodeLinterOption{}
+	obj.MergeCodeLintResult(v1alpha1.CodeLintResult{
+		Issues: &v1alpha1.CodeLintIssues{Count: 2, Rules: []v1alpha1.CodeLintRuleIssues{{Rule: "a", Level: "error", Count: 2}}},
+	})
+	obj.MergeCodeLintResult(v1alpha1.CodeLintResult{
+		Issues: &v1alpha1.CodeLintIssues{Count: 1, Rules: []v1alpha1.CodeLintRuleIssues{{Rule: "a", Level: "error", Count: 1}}},
+	})
+	obj.MergeCodeLintResult(v1alpha1.CodeLintResult{})
+	g.Expect(obj.Issues).To(Equal(&v1alpha1.CodeLintIssues{
+		Count: 3,
+		Rules: []v1alpha1.CodeLintRuleIssues{{Rule: "a", Level: "error", Count: 3}},
+	}))
+}
+
+func TestCodeLinterOption_MergeReports(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := CodeLinterOption{}
+	reports := &ReportPathsByTypesOption{
+		ReportPathByTypes: map[report.ReportType]string{report.TypeSarif: "results.sarif"},
+	}
+
+	g.Expect(obj.MergeReports("./testdata/reports", reports)).To(Succeed())
+	g.Expect(obj.Issues.Count).To(Equal(6))
+	g.Expect(obj.Issues.CountByRule()).To(HaveKeyWithValue("js/sql-injection", 1))
+	g.Expect(obj.Issues.CountByLevel()).To(HaveKeyWithValue("note", 1))
+
+	reports.ReportPathByTypes = map[report.ReportType]string{report.TypeSarif: "not-found.sarif"}
+	g.Expect(obj.MergeReports("./testdata/reports", reports)).NotTo(Succeed())
+}
+
+func TestCodeLinterOption_WriteSarif(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := CodeLinterOption{}
+	obj.MergeCodeLintResult(v1alpha1.CodeLintResult{
+		Issues: &v1alpha1.CodeLintIssues{Count: 2, Rules: []v1alpha1.CodeLintRuleIssues{{Rule: "no-undef", Level: "error", Count: 2}}},
+	})
+
+	buf := &strings.Builder{}
+	g.Expect(obj.WriteSarif(report.SarifToolComponent{Name: "eslint"}, buf)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring(`"version": "2.1.0"`))
+	g.Expect(buf.String()).To(ContainSubstring(`"ruleId": "no-undef"`))
+	g.Expect(buf.String()).To(ContainSubstring(`"name": "eslint"`))
+}
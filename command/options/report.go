@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"sort"
 
 	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
 	pkgargs "github.com/katanomi/pkg/command/args"
 	"github.com/katanomi/pkg/report"
 	"github.com/spf13/cobra"
@@ -52,7 +54,26 @@ func (m *ReportPathOption) Validate(path *field.Path) (errs field.ErrorList) {
 }
 
 // AutomatedTestResultDefaultParser AutomatedTestResult default parser
-var AutomatedTestResultDefaultParser = map[report.ReportType]report.ReportParser{report.TypeJunitXml: &report.JunitParser{}}
+var AutomatedTestResultDefaultParser = map[report.ReportType]report.ReportParser{
+	report.TypeJunitXml:   &report.JunitParser{},
+	report.TypeGoTestJson: &report.GoTestJsonParser{},
+	report.TypeTap:        &report.TapParser{},
+	report.TypeNUnitXml:   &report.NUnitParser{},
+	report.TypeXUnitXml:   &report.XUnitParser{},
+	report.TypeTrx:        &report.TrxParser{},
+}
+
+// CodeLintResultDefaultParser CodeLintResult default parser
+var CodeLintResultDefaultParser = map[report.ReportType]report.ReportParser{
+	report.TypeSarif: &report.SarifParser{},
+}
+
+// VulnScanResultDefaultParser VulnScanResult default parser
+var VulnScanResultDefaultParser = map[report.ReportType]report.ReportParser{
+	report.TypeSarif:     &report.SarifParser{},
+	report.TypeTrivyJson: &report.TrivyJsonParser{},
+	report.TypeGrypeJson: &report.GrypeJsonParser{},
+}
 
 // ReportPathsByTypesOption is the option for multiple report types with its report paths
 type ReportPathsByTypesOption struct {
@@ -98,33 +119,131 @@ func (r *ReportPathsByTypesOption) Setup(ctx context.Context, cmd *cobra.Command
 // TestSummariesByType gets test summaries by report type
 func (r *ReportPathsByTypesOption) TestSummariesByType(parentPath string) (summaries report.SummariesByType,
 	err error) {
-	// Avoid using the current function when no setup is called.
-	if r.ReportParsers == nil {
-		r.ReportParsers = AutomatedTestResultDefaultParser
-	}
+	return convertReportsByType(r, parentPath, AutomatedTestResultDefaultParser, "ConvertToAutomatedTestResult",
+		func(result interface{}) (summary v1alpha1.AutomatedTestResult, ok bool) {
+			converter, ok := result.(report.ConvertToAutomatedTestResult)
+			if ok {
+				summary = converter.ConvertToAutomatedTestResult()
+			}
+			return
+		})
+}
 
-	summaries = map[report.ReportType]v1alpha1.AutomatedTestResult{}
+// CodeLintResultsByType gets code lint results by report type
+// CodeLintResultDefaultParser is used if the ReportParsers is not set
+func (r *ReportPathsByTypesOption) CodeLintResultsByType(parentPath string) (results map[report.ReportType]v1alpha1.CodeLintResult,
+	err error) {
+	return convertReportsByType(r, parentPath, CodeLintResultDefaultParser, "ConvertToCodeLintResult",
+		func(result interface{}) (lintResult v1alpha1.CodeLintResult, ok bool) {
+			converter, ok := result.(report.ConvertToCodeLintResult)
+			if ok {
+				lintResult = converter.ConvertToCodeLintResult()
+			}
+			return
+		})
+}
+
+// VulnScanResultsByType gets vulnerability scan results by report type
+// VulnScanResultDefaultParser is used if the ReportParsers is not set
+func (r *ReportPathsByTypesOption) VulnScanResultsByType(parentPath string) (results map[report.ReportType]securityv1alpha1.VulnScanResult,
+	err error) {
+	return convertReportsByType(r, parentPath, VulnScanResultDefaultParser, "ConvertToVulnScanResult",
+		func(result interface{}) (scanResult securityv1alpha1.VulnScanResult, ok bool) {
+			converter, ok := result.(report.ConvertToVulnScanResult)
+			if ok {
+				scanResult = converter.ConvertToVulnScanResult()
+			}
+			return
+		})
+}
+
+// convertReportsByType parses reports of all report types and converts the results using convert
+// defaultParsers are used if the ReportParsers is not set,
+// converterName is the name of the interface used by convert, used in errors
+func convertReportsByType[T any](r *ReportPathsByTypesOption, parentPath string,
+	defaultParsers map[report.ReportType]report.ReportParser, converterName string,
+	convert func(result interface{}) (T, bool)) (map[report.ReportType]T, error) {
+	results := map[report.ReportType]T{}
 	var errs field.ErrorList
 	base := field.NewPath("reportType")
-	for reportType, reportPath := range r.ReportPathByTypes {
-		parser, ok := r.ReportParsers[reportType]
+	for _, reportType := range r.sortedReportTypes() {
+		result, parseErr := r.parseReport(base, reportType, parentPath, defaultParsers)
+		if parseErr != nil {
+			errs = append(errs, parseErr)
+			continue
+		}
+
+		converted, ok := convert(result)
 		if !ok {
-			errs = append(errs, field.Invalid(base, reportType, "parser for report type not found"))
+			errs = append(errs, field.TypeInvalid(base.Child(string(reportType)), result, converterName+" interface is not implemented"))
 			continue
 		}
+		results[reportType] = converted
+	}
+	return results, errs.ToAggregate()
+}
 
-		result, err := parser.Parse(path.Join(parentPath, reportPath))
-		if err != nil {
-			errs = append(errs, field.InternalError(base.Child(string(reportType)),
-				fmt.Errorf("failed to parse report. err: %s", err.Error())))
+// TestCaseHighlights gets the slowest and flaky test cases of all reports.
+// Reruns are only detected within the report of a report type, as reports of different
+// report types, e.g. junit-xml and go-test-json, may describe the same run, their test cases
+// are not merged and the same test case may be listed once for each report type.
+// At most limit test cases are returned for each highlight, a non-positive limit returns all.
+func (r *ReportPathsByTypesOption) TestCaseHighlights(parentPath string, limit int) (highlights v1alpha1.TestCaseHighlights,
+	err error) {
+	cases := []v1alpha1.TestCaseResult{}
+	var errs field.ErrorList
+	base := field.NewPath("reportType")
+	for _, reportType := range r.sortedReportTypes() {
+		result, parseErr := r.parseReport(base, reportType, parentPath, AutomatedTestResultDefaultParser)
+		if parseErr != nil {
+			errs = append(errs, parseErr)
 			continue
 		}
 
-		converter, ok := result.(report.ConvertToAutomatedTestResult)
+		converter, ok := result.(report.ConvertToTestCases)
 		if !ok {
-			errs = append(errs, field.TypeInvalid(base.Child(string(reportType)), converter, "ConvertToAutomatedTestResult interface is not implemented"))
+			// per test case results are optional for report parsers
+			continue
 		}
-		summaries[reportType] = converter.ConvertToAutomatedTestResult()
+		cases = append(cases, report.MarkFlakyTestCases(converter.ConvertToTestCases())...)
+	}
+	highlights = v1alpha1.TestCaseHighlights{
+		Slowest: report.SlowestTestCases(cases, limit),
+		Flaky:   report.FlakyTestCases(cases, limit),
+	}
+	return highlights, errs.ToAggregate()
+}
+
+// parseReport parses the report of the report type
+// defaultParsers are used if the ReportParsers is not set, e.g. no setup is called
+func (r *ReportPathsByTypesOption) parseReport(base *field.Path, reportType report.ReportType, parentPath string,
+	defaultParsers map[report.ReportType]report.ReportParser) (result interface{}, err *field.Error) {
+	parsers := r.ReportParsers
+	if parsers == nil {
+		parsers = defaultParsers
+	}
+
+	parser, ok := parsers[reportType]
+	if !ok {
+		return nil, field.Invalid(base, reportType, "parser for report type not found")
+	}
+
+	result, parseErr := parser.Parse(path.Join(parentPath, r.ReportPathByTypes[reportType]))
+	if parseErr != nil {
+		return nil, field.InternalError(base.Child(string(reportType)),
+			fmt.Errorf("failed to parse report. err: %s", parseErr.Error()))
+	}
+	return result, nil
+}
+
+// sortedReportTypes returns report types in a stable order
+func (r *ReportPathsByTypesOption) sortedReportTypes() []report.ReportType {
+	types := make([]report.ReportType, 0, len(r.ReportPathByTypes))
+	for reportType := range r.ReportPathByTypes {
+		types = append(types, reportType)
 	}
-	return summaries, errs.ToAggregate()
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
 }
@@ -19,11 +19,15 @@ package options
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
 	"github.com/katanomi/pkg/report"
 	pkgTesting "github.com/katanomi/pkg/testing"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -65,7 +69,9 @@ func TestSummariesByType(t *testing.T) {
 
 	// valid type
 	obj.ReportPathByTypes = map[report.ReportType]string{
-		report.TypeJunitXml: "junit.xml",
+		report.TypeJunitXml:   "junit.xml",
+		report.TypeGoTestJson: "go-test.json",
+		report.TypeTap:        "results.tap",
 	}
 	var expectedResult report.SummariesByType
 	pkgTesting.MustLoadJSON("./testdata/summary.byType.golden.json", &expectedResult)
@@ -76,6 +82,113 @@ func TestSummariesByType(t *testing.T) {
 	g.Expect(diff).To(BeEmpty())
 }
 
+func TestCodeLintResultsByType(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := ReportPathsByTypesOption{
+		ReportPathByTypes: map[report.ReportType]string{report.TypeSarif: "results.sarif"},
+	}
+
+	results, err := obj.CodeLintResultsByType("./testdata/reports")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(results).To(HaveKey(report.TypeSarif))
+	issues := results[report.TypeSarif].Issues
+	g.Expect(issues.Count).To(Equal(6))
+	g.Expect(issues.CountByLevel()).To(Equal(map[string]int{"error": 3, "warning": 2, "note": 1}))
+
+	// parser not implementing ConvertToCodeLintResult
+	obj.ReportPathByTypes = map[report.ReportType]string{report.TypeJunitXml: "junit.xml"}
+	obj.ReportParsers = AutomatedTestResultDefaultParser
+	_, err = obj.CodeLintResultsByType("./testdata/reports")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestVulnScanResultsByType(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := ReportPathsByTypesOption{
+		ReportPathByTypes: map[report.ReportType]string{report.TypeSarif: "results.sarif"},
+	}
+
+	results, err := obj.VulnScanResultsByType("./testdata/reports")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	targets := results[report.TypeSarif].Targets
+	g.Expect(targets).To(HaveLen(3))
+	g.Expect(targets[0].Type).To(Equal(securityv1alpha1.VulnScanTargetTypeImage))
+	g.Expect(targets[0].Cvss.Score).To(Equal("9.8"))
+}
+
+func TestTestCaseHighlights(t *testing.T) {
+	g := NewGomegaWithT(t)
+	reportPath := "./testdata/reports"
+
+	obj := struct {
+		ReportPathsByTypesOption
+	}{}
+
+	// empty types
+	obj.ReportPathByTypes = map[report.ReportType]string{}
+	g.Expect(obj.TestCaseHighlights(reportPath, 3)).To(Equal(v1alpha1.TestCaseHighlights{
+		Slowest: []v1alpha1.TestCaseResult{},
+	}))
+
+	obj.ReportPathByTypes = map[report.ReportType]string{
+		report.TypeJunitXml:   "junit.xml",
+		report.TypeGoTestJson: "go-test-rerun.json",
+	}
+	highlights, err := obj.TestCaseHighlights(reportPath, 3)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(highlights.Slowest).To(HaveLen(3))
+	g.Expect(highlights.Slowest[0].Name).To(Equal("TestSub"))
+	g.Expect(highlights.Slowest[0].Duration.Duration).To(Equal(8500 * time.Millisecond))
+	g.Expect(highlights.Slowest[1].Duration.Duration).To(BeNumerically(">=", highlights.Slowest[2].Duration.Duration))
+	g.Expect(highlights.Flaky).To(Equal([]v1alpha1.TestCaseResult{{
+		Suite:    "example.com/demo",
+		Name:     "TestSub",
+		Duration: metav1.Duration{Duration: 8500 * time.Millisecond},
+		Status:   v1alpha1.TestCaseStatusPassed,
+		Message:  "calc_test.go:20: timeout waiting for server",
+		Stack:    "    calc_test.go:20: timeout waiting for server",
+		Retries:  1,
+		Flaky:    true,
+	}}))
+
+	// report parse failed
+	obj.ReportPathByTypes = map[report.ReportType]string{
+		report.TypeJunitXml: "not-found.xml",
+	}
+	_, err = obj.TestCaseHighlights(reportPath, 3)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestTestCaseHighlights_reportTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	failed := v1alpha1.TestCaseResult{Suite: "demo", Name: "TestAdd", Status: v1alpha1.TestCaseStatusFailed}
+	passed := v1alpha1.TestCaseResult{Suite: "demo", Name: "TestAdd", Status: v1alpha1.TestCaseStatusPassed}
+	obj := ReportPathsByTypesOption{
+		ReportPathByTypes: map[report.ReportType]string{
+			report.TypeJunitXml:   "junit.xml",
+			report.TypeGoTestJson: "go-test.json",
+		},
+		ReportParsers: map[report.ReportType]report.ReportParser{
+			report.TypeJunitXml:   testCasesParser{failed, passed},
+			report.TypeGoTestJson: testCasesParser{failed},
+		},
+	}
+
+	// the same test case in reports of different types is not a rerun
+	highlights, err := obj.TestCaseHighlights("", 0)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(highlights.Slowest).To(HaveLen(2))
+	g.Expect(highlights.Flaky).To(HaveLen(1))
+	g.Expect(highlights.Flaky[0].Retries).To(Equal(1))
+}
+
+// testCasesParser returns the test cases regardless of the report
+type testCasesParser []v1alpha1.TestCaseResult
+
+func (p testCasesParser) Parse(string) (interface{}, error) {
+	return report.TestCases{Cases: p}, nil
+}
+
 func TestReportPathsByTypesOption_Validate(t *testing.T) {
 	t.Run("Validate passed", func(t *testing.T) {
 		g := NewGomegaWithT(t)
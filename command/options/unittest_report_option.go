@@ -20,19 +20,32 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
 	"github.com/katanomi/pkg/command/io"
 	"github.com/katanomi/pkg/encoding"
+	"github.com/katanomi/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+const (
+	// TestCaseHighlightsResultKey the key of the slowest and flaky test cases in task results
+	TestCaseHighlightsResultKey = "testCaseHighlights"
+	// DefaultTestCaseHighlightsLimit the default max number of the slowest and of the flaky test cases in task results
+	DefaultTestCaseHighlightsLimit = 5
+)
+
 // UnitTestReportOption unittest report option
 type UnitTestReportOption struct {
 	ReportPathOption
 	ReportTypeOption
 
 	ResultPathOption
+
+	// HighlightsLimit the max number of the slowest and of the flaky test cases saved to results,
+	// DefaultTestCaseHighlightsLimit is used if it is not positive
+	HighlightsLimit int
 }
 
 // AddFlags add flags to options
@@ -60,7 +73,27 @@ func (m *UnitTestReportOption) ParseReport() (interface{}, error) {
 	return m.ReportTypeOption.Parse(m.ReportPath)
 }
 
+// TestCaseHighlights parses the report and returns its slowest and flaky test cases,
+// the highlights are empty if the report type does not provide the results of each test case
+func (m *UnitTestReportOption) TestCaseHighlights() (highlights v1alpha1.TestCaseHighlights, err error) {
+	result, err := m.ParseReport()
+	if err != nil {
+		return
+	}
+	converter, ok := result.(report.ConvertToTestCases)
+	if !ok {
+		return
+	}
+	limit := m.HighlightsLimit
+	if limit <= 0 {
+		limit = DefaultTestCaseHighlightsLimit
+	}
+	return report.NewTestCaseHighlights(limit, converter.ConvertToTestCases()), nil
+}
+
 // WriteResult save data to result path
+// together with the slowest and flaky test cases of the report under TestCaseHighlightsResultKey.
+// Stacks of the test cases are left out to keep the task results small.
 func (c *UnitTestReportOption) WriteResult(obj interface{}) error {
 	if c.ResultPath == "" {
 		return nil
@@ -68,6 +101,20 @@ func (c *UnitTestReportOption) WriteResult(obj interface{}) error {
 
 	jsonPath := encoding.NewJsonPath()
 	data := jsonPath.Encode(obj)
+	highlights, err := c.TestCaseHighlights()
+	if err != nil {
+		return err
+	}
+	if !highlights.IsEmpty() {
+		for _, cases := range [][]v1alpha1.TestCaseResult{highlights.Slowest, highlights.Flaky} {
+			for i := range cases {
+				cases[i].Stack = ""
+			}
+		}
+		for key, value := range jsonPath.Encode(highlights) {
+			data[TestCaseHighlightsResultKey+"."+key] = value
+		}
+	}
 	content, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -18,8 +18,13 @@ package options
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	"github.com/katanomi/pkg/report"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -105,4 +110,38 @@ func TestUnitTestReportOption(t *testing.T) {
 		g.Expect(obj.Validate(base)).To(HaveLen(1), "validate failed")
 	})
 
+	t.Run("write result with test case highlights", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		obj := UnitTestReportOption{HighlightsLimit: 2}
+		obj.ReportType = string(report.TypeJestJson)
+		obj.SupportedType = report.DefaultReportParsers
+		obj.ReportPath = "testdata/reports/jest.json"
+		obj.ResultPath = filepath.Join(t.TempDir(), "result")
+		g.Expect(obj.WriteResult(v1alpha1.TestResult{Passed: 2, Failed: 1})).To(Succeed())
+
+		content, err := os.ReadFile(obj.ResultPath)
+		g.Expect(err).To(Succeed())
+		data := map[string]string{}
+		g.Expect(json.Unmarshal(content, &data)).To(Succeed())
+		g.Expect(data).To(HaveKeyWithValue("passed", "2"))
+		g.Expect(data).To(HaveKeyWithValue("testCaseHighlights.slowest[0].name", "divides"))
+		g.Expect(data).To(HaveKeyWithValue("testCaseHighlights.slowest[1].name", "adds"))
+		g.Expect(data).NotTo(HaveKey("testCaseHighlights.slowest[2].name"))
+		g.Expect(data).To(HaveKeyWithValue("testCaseHighlights.flaky[0].name", "divides"))
+		g.Expect(data).To(HaveKeyWithValue("testCaseHighlights.flaky[0].retries", "1"))
+		g.Expect(data).To(HaveKeyWithValue("testCaseHighlights.flaky[0].stack", ""))
+	})
+
+	t.Run("write result without report type", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		obj := UnitTestReportOption{}
+		obj.ResultPath = filepath.Join(t.TempDir(), "result")
+		g.Expect(obj.WriteResult(map[string]string{"lines": "80.00"})).To(Succeed())
+
+		content, err := os.ReadFile(obj.ResultPath)
+		g.Expect(err).To(Succeed())
+		g.Expect(string(content)).To(Equal(`{"lines":"80.00"}`))
+	})
 }
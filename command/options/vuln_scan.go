@@ -30,6 +30,7 @@ import (
 	"github.com/katanomi/pkg/command/validators"
 	"github.com/katanomi/pkg/encoding"
 	"github.com/katanomi/pkg/pointer"
+	"github.com/katanomi/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -112,6 +113,29 @@ func (c *VulnScanOption) WriteMetricsResult(err error, w io.Writer) {
 	w.Write(resultData)
 }
 
+// MergeVulnScanResult adds the targets of a vulnerability scan result, e.g. parsed from reports
+func (c *VulnScanOption) MergeVulnScanResult(result securityv1alpha1.VulnScanResult) {
+	c.Targets = append(c.Targets, result.Targets...)
+}
+
+// MergeReports adds the targets of all reports, reports are merged in the order of their report types.
+// VulnScanResultDefaultParser is used if the ReportParsers of reports is not set
+func (c *VulnScanOption) MergeReports(parentPath string, reports *ReportPathsByTypesOption) error {
+	results, err := reports.VulnScanResultsByType(parentPath)
+	if err != nil {
+		return err
+	}
+	for _, reportType := range reports.sortedReportTypes() {
+		c.MergeVulnScanResult(results[reportType])
+	}
+	return nil
+}
+
+// WriteSarif renders the scan result as sarif
+func (c *VulnScanOption) WriteSarif(tool report.SarifToolComponent, w io.Writer) error {
+	return report.NewSarifLogFromVulnScanResult(tool, c.VulnScanResult).Write(w)
+}
+
 func (c *VulnScanOption) ValidateQualityGate(ctx context.Context) (errs field.ErrorList) {
 	logger := logger.NewLoggerFromContext(ctx)
 
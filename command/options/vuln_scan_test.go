@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+	"github.com/katanomi/pkg/report"
+	. "github.com/onsi/gomega"
+)
+
+func TestVulnScanOption_MergeVulnScanResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := VulnScanOption{}
+	obj.MergeVulnScanResult(securityv1alpha1.VulnScanResult{
+		Targets: []securityv1alpha1.VulnScanTarget{{Uri: "alpine:3.17", Type: securityv1alpha1.VulnScanTargetTypeImage}},
+	})
+	obj.MergeVulnScanResult(securityv1alpha1.VulnScanResult{
+		Targets: []securityv1alpha1.VulnScanTarget{{Uri: "/workspace", Type: securityv1alpha1.VulnScanTargetTypeFileSystem}},
+	})
+	g.Expect(obj.Targets).To(HaveLen(2))
+	g.Expect(obj.Targets[1].Uri).To(Equal("/workspace"))
+}
+
+func TestVulnScanOption_WriteSarif(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := VulnScanOption{}
+	obj.MergeVulnScanResult(securityv1alpha1.VulnScanResult{
+		Targets: []securityv1alpha1.VulnScanTarget{{
+			Uri:           "alpine:3.17",
+			Type:          securityv1alpha1.VulnScanTargetTypeImage,
+			Cvss:          securityv1alpha1.CVSS{Severity: "High", Score: "7.5"},
+			VulnStatistic: securityv1alpha1.VulnStatistic{HighCount: 1},
+		}},
+	})
+
+	buf := &strings.Builder{}
+	g.Expect(obj.WriteSarif(report.SarifToolComponent{Name: "trivy"}, buf)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring(`"imageName": "alpine:3.17"`))
+	g.Expect(buf.String()).To(ContainSubstring(`"ruleId": "high-vulnerabilities"`))
+	g.Expect(buf.String()).To(ContainSubstring(`"security-severity": "7.5"`))
+}
+
+func TestVulnScanOption_MergeReports(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := VulnScanOption{}
+	reports := &ReportPathsByTypesOption{
+		ReportPathByTypes: map[report.ReportType]string{
+			report.TypeTrivyJson: "trivy.json",
+			report.TypeGrypeJson: "grype.json",
+		},
+	}
+
+	g.Expect(obj.MergeReports("./testdata/reports", reports)).To(Succeed())
+	g.Expect(obj.Targets).To(Equal([]securityv1alpha1.VulnScanTarget{
+		{
+			Uri:           "/workspace/source",
+			Type:          securityv1alpha1.VulnScanTargetTypeFileSystem,
+			Cvss:          securityv1alpha1.CVSS{Source: "nvd", Severity: "Medium", Score: "6.5"},
+			VulnStatistic: securityv1alpha1.VulnStatistic{MediumCount: 1},
+		},
+		{
+			Uri:  "alpine:3.17",
+			Type: securityv1alpha1.VulnScanTargetTypeImage,
+			Cvss: securityv1alpha1.CVSS{Source: "ghsa", Severity: "Critical", Score: "9.8"},
+			VulnStatistic: securityv1alpha1.VulnStatistic{
+				CriticalCount: 1, HighCount: 2, MediumCount: 1, UnknownCount: 1,
+			},
+		},
+	}))
+
+	// the score gate uses the cvss score of the reports
+	obj.QualityGate = true
+	obj.QualityGateRules = map[string]string{gateRuleVulnScore: "9"}
+	g.Expect(obj.ValidateQualityGate(context.Background())).To(HaveLen(1))
+
+	reports.ReportPathByTypes = map[report.ReportType]string{report.TypeTrivyJson: "not-found.json"}
+	g.Expect(obj.MergeReports("./testdata/reports", reports)).NotTo(Succeed())
+}
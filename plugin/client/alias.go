@@ -151,6 +151,33 @@ type BuildOptions = base.BuildOptions
 // ClientOpts adds a custom client build options for plugin client
 var ClientOpts = base.ClientOpts
 
+// RetryPolicy policy to retry the idempotent requests
+type RetryPolicy = base.RetryPolicy
+
+// CircuitBreakerPolicy policy of the circuit breaker of each class address
+type CircuitBreakerPolicy = base.CircuitBreakerPolicy
+
+// RateLimitPolicy token bucket rate limit of each class address
+type RateLimitPolicy = base.RateLimitPolicy
+
+// RetryOpts retries the idempotent requests with the policy
+var RetryOpts = base.RetryOpts
+
+// CircuitBreakerOpts enables the circuit breaker of each class address with the policy
+var CircuitBreakerOpts = base.CircuitBreakerOpts
+
+// RateLimitOpts limits the requests to each class address with the policy
+var RateLimitOpts = base.RateLimitOpts
+
+// ResponseCache caches GET responses of plugins in memory
+type ResponseCache = base.ResponseCache
+
+// NewResponseCache returns a response cache bounded by the total bytes of the bodies and the ttl
+var NewResponseCache = base.NewResponseCache
+
+// CacheOpts caches GET responses of plugins with the cache
+var CacheOpts = base.CacheOpts
+
 // DefaultOptions for default plugin client options
 var DefaultOptions = base.DefaultOptions
 
@@ -122,6 +122,9 @@ type WebhookResourceDiffer = types.WebhookResourceDiffer
 // WebhookReceiver receives a webhook request with validation and transform it into a cloud event
 type WebhookReceiver = types.WebhookReceiver
 
+// WebhookVerifierGetter provides a verifier for the webhook requests
+type WebhookVerifierGetter = types.WebhookVerifierGetter
+
 // GitPullRequestCommentCreator create pull request comment functions
 type GitPullRequestCommentCreator = types.GitPullRequestCommentCreator
 
@@ -134,6 +137,27 @@ type GitPullRequestCommentLister = types.GitPullRequestCommentLister
 // GitPullRequestHandler list, get and create pr function
 type GitPullRequestHandler = types.GitPullRequestHandler
 
+// GitPullRequestFileLister list files changed by a pull request
+type GitPullRequestFileLister = types.GitPullRequestFileLister
+
+// GitPullRequestDiffGetter get the diff of a pull request
+type GitPullRequestDiffGetter = types.GitPullRequestDiffGetter
+
+// GitPullRequestReviewLister list reviews of a pull request
+type GitPullRequestReviewLister = types.GitPullRequestReviewLister
+
+// GitPullRequestReviewCreator submit a review or an approval of a pull request
+type GitPullRequestReviewCreator = types.GitPullRequestReviewCreator
+
+// GitPullRequestReviewerRequester request users to review a pull request
+type GitPullRequestReviewerRequester = types.GitPullRequestReviewerRequester
+
+// GitPullRequestMergeabilityGetter check whether a pull request could be merged
+type GitPullRequestMergeabilityGetter = types.GitPullRequestMergeabilityGetter
+
+// GitPullRequestMerger merge a pull request with a merge strategy
+type GitPullRequestMerger = types.GitPullRequestMerger
+
 // GitCommitGetter get git commit
 type GitCommitGetter = types.GitCommitGetter
 
@@ -143,6 +167,9 @@ type GitCommitCreator = types.GitCommitCreator
 // GitCommitLister List git commit
 type GitCommitLister = types.GitCommitLister
 
+// GitCommitComparer compare two revisions of git repository
+type GitCommitComparer = types.GitCommitComparer
+
 // GitBranchLister List git branch
 type GitBranchLister = types.GitBranchLister
 
@@ -152,6 +179,18 @@ type GitBranchGetter = types.GitBranchGetter
 // GitBranchCreator create git branch,github, gogs don't support create branch
 type GitBranchCreator = types.GitBranchCreator
 
+// GitBranchDeleter delete git branch
+type GitBranchDeleter = types.GitBranchDeleter
+
+// GitBranchProtectionGetter get protection rules of git branch
+type GitBranchProtectionGetter = types.GitBranchProtectionGetter
+
+// GitBranchProtectionUpdater protect git branch or replace its protection rules
+type GitBranchProtectionUpdater = types.GitBranchProtectionUpdater
+
+// GitBranchProtectionDeleter remove protection rules of git branch
+type GitBranchProtectionDeleter = types.GitBranchProtectionDeleter
+
 // GitRepoFileGetter used to get a file content
 type GitRepoFileGetter = types.GitRepoFileGetter
 
@@ -198,6 +237,9 @@ type CodeQualityGetter = types.CodeQualityGetter
 
 type BlobStoreLister = types.BlobStoreLister
 
+// ArtifactPromoter promote an artifact
+type ArtifactPromoter = types.ArtifactPromoter
+
 // ArtifactTriggerRegister used to register ArtifactTrigger
 type ArtifactTriggerRegister = types.ArtifactTriggerRegister
 
@@ -214,6 +256,21 @@ type IssueBranchDeleter = types.IssueBranchDeleter
 
 type IssueAttributeGetter = types.IssueAttributeGetter
 
+// IssueCreator create an issue
+type IssueCreator = types.IssueCreator
+
+// IssueUpdater update an issue
+type IssueUpdater = types.IssueUpdater
+
+// IssueCommentLister list issue comments
+type IssueCommentLister = types.IssueCommentLister
+
+// IssueCommentCreator comment an issue
+type IssueCommentCreator = types.IssueCommentCreator
+
+// IssueLinker link issues
+type IssueLinker = types.IssueLinker
+
 type ProjectUserLister = types.ProjectUserLister
 
 // TestPlanLister list test plans
@@ -29,6 +29,7 @@ type ClientArtifact interface {
 	List(ctx context.Context, baseURL *duckv1.Addressable, project string, repository string, options ...OptionFunc) (*metav1alpha1.ArtifactList, error)
 	Delete(ctx context.Context, baseURL *duckv1.Addressable, project string, repository string, artifact string, options ...OptionFunc) error
 	DeleteTag(ctx context.Context, baseURL *duckv1.Addressable, project string, repository string, artifact string, tag string, options ...OptionFunc) error
+	Promote(ctx context.Context, baseURL *duckv1.Addressable, project string, repository string, artifact string, payload metav1alpha1.PromoteArtifactPayload, options ...OptionFunc) (*metav1alpha1.Artifact, error)
 }
 
 type artifact struct {
@@ -98,3 +99,21 @@ func (p *artifact) DeleteTag(ctx context.Context,
 
 	return nil
 }
+
+// Promote artifact to another project or registry using plugin
+func (p *artifact) Promote(ctx context.Context,
+	baseURL *duckv1.Addressable,
+	project, repository, artifactName string,
+	payload metav1alpha1.PromoteArtifactPayload,
+	options ...OptionFunc) (*metav1alpha1.Artifact, error) {
+
+	artifact := &metav1alpha1.Artifact{}
+
+	uri := fmt.Sprintf("projects/%s/repositories/%s/artifacts/%s/promote", project, repository, artifactName)
+	options = append(options, BodyOpts(payload), ResultOpts(artifact))
+	if err := p.client.Post(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
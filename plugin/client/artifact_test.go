@@ -139,3 +139,40 @@ func TestClientArtifactDeleteTag(t *testing.T) {
 
 	g.Expect(err).To(BeNil())
 }
+
+func TestClientArtifactPromote(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+
+	expected := &metav1alpha1.Artifact{Spec: metav1alpha1.ArtifactSpec{Type: "ContainerImage", Version: "v1.0.0"}}
+	responder, _ := httpmock.NewJsonResponder(200, expected)
+
+	fakeUrl := "https://example.com/projects/devops/repositories/katanomi/artifacts/artifact/promote"
+	httpmock.RegisterResponder("POST", fakeUrl, responder)
+
+	mate := Meta{Version: "v1.3.4", BaseURL: "http://plugin.com"}
+	secret := corev1.Secret{
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{"username": []byte("username")},
+	}
+
+	RESTClient := resty.New()
+	httpmock.ActivateNonDefault(RESTClient.GetClient())
+
+	client := NewPluginClient(ClientOpts(RESTClient))
+	artifactClient := client.Artifact(mate, secret)
+
+	url, _ := apis.ParseURL("https://example.com/")
+	artifact, err := artifactClient.Promote(
+		context.Background(),
+		&duckv1.Addressable{URL: url},
+		"devops",
+		"katanomi",
+		"artifact",
+		metav1alpha1.PromoteArtifactPayload{Target: metav1alpha1.ArtifactPromotionTarget{Project: "release", Repository: "katanomi"}},
+		client.Secret(corev1.Secret{Type: corev1.SecretTypeBasicAuth, Data: map[string][]byte{"username": []byte("username")}}),
+	)
+
+	g.Expect(err).To(BeNil())
+	g.Expect(artifact).To(Equal(expected))
+}
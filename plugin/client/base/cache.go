@@ -0,0 +1,335 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// cacheKeyHeaders headers identifying the tool, the credential and the content of the response
+var cacheKeyHeaders = []string{
+	PluginMetaHeader, PluginAuthHeader, PluginSecretHeader, PluginSubresourcesHeader, "Authorization", "Accept",
+}
+
+// ResponseCache caches GET responses of plugins in memory, keyed by url, meta and secret.
+// Fresh responses are served from the cache following the max-age of Cache-Control,
+// stale responses are revalidated with their ETag or Last-Modified headers.
+// Responses with Cache-Control: no-store, and those with neither max-age nor validators are not cached.
+type ResponseCache struct {
+	lock sync.Mutex
+
+	// maxSize max total bytes of the cached bodies
+	maxSize int64
+	// ttl max duration to keep an entry, even if it is still fresh
+	ttl time.Duration
+
+	size    int64
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key          string
+	header       http.Header
+	body         []byte
+	freshUntil   time.Time
+	expiresAt    time.Time
+	etag         string
+	lastModified string
+}
+
+// NewResponseCache returns a response cache bounded by the total bytes of the bodies,
+// entries are evicted when the ttl passes or the least recently used first when exceeding the size
+func NewResponseCache(maxSize int64, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// CacheOpts caches GET responses of plugins with the cache,
+// the same cache could be shared by multiple clients
+func CacheOpts(cache *ResponseCache) BuildOptions {
+	return func(client *PluginClient) {
+		client.cache = cache
+	}
+}
+
+// Len returns the number of the cached responses
+func (c *ResponseCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+type responseCacheKey struct{}
+
+// withResponseCache returns a context with the cache used by the cache transport
+func withResponseCache(ctx context.Context, cache *ResponseCache) context.Context {
+	return context.WithValue(ctx, responseCacheKey{}, cache)
+}
+
+func responseCacheFrom(ctx context.Context) *ResponseCache {
+	cache, _ := ctx.Value(responseCacheKey{}).(*ResponseCache)
+	return cache
+}
+
+// wrapCacheTransport wraps the transport of the client to serve GET requests from the cache in the request context.
+// The transport is shared by the plugin clients built with the same resty client, so it is only wrapped once
+func wrapCacheTransport(client *resty.Client) {
+	transport := client.GetClient().Transport
+	if _, ok := transport.(*cacheTransport); ok {
+		return
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.SetTransport(&cacheTransport{next: transport})
+}
+
+// cacheTransport serves GET requests from the cache in the request context
+type cacheTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip serves the request from the cache if possible
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cache := responseCacheFrom(req.Context())
+	if cache == nil || !cacheableRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry, fresh := cache.get(key)
+	if entry == nil {
+		cacheRequestsTotal.WithLabelValues("miss").Inc()
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+		return cache.store(key, req, resp)
+	}
+	if fresh {
+		cacheRequestsTotal.WithLabelValues("hit").Inc()
+		return entry.response(req), nil
+	}
+
+	revalidate := req.Clone(req.Context())
+	if entry.etag != "" {
+		revalidate.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		revalidate.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	resp, err := t.next.RoundTrip(revalidate)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		cacheRequestsTotal.WithLabelValues("revalidated").Inc()
+		resp.Body.Close()
+		cache.refresh(entry, resp.Header)
+		return entry.response(req), nil
+	}
+	cacheRequestsTotal.WithLabelValues("miss").Inc()
+	return cache.store(key, req, resp)
+}
+
+// cacheableRequest returns true for GET requests without conditional or range headers set by the caller
+func cacheableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	for _, h := range []string{"If-None-Match", "If-Modified-Since", "Range"} {
+		if req.Header.Get(h) != "" {
+			return false
+		}
+	}
+	return !parseCacheControl(req.Header).noStore
+}
+
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.String()))
+	for _, name := range cacheKeyHeaders {
+		h.Write([]byte{0})
+		h.Write([]byte(req.Header.Get(name)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the entry of the key and whether it is fresh, expired entries are removed
+func (c *ResponseCache) get(key string) (*cacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	now := c.now()
+	if !now.Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry, now.Before(entry.freshUntil)
+}
+
+// store caches the response if cacheable, and returns a response with the same content
+func (c *ResponseCache) store(key string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	cacheControl := parseCacheControl(resp.Header)
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if cacheControl.noStore || (cacheControl.maxAge <= 0 && etag == "" && lastModified == "") {
+		c.delete(key)
+		return resp, nil
+	}
+	if resp.ContentLength > c.maxSize {
+		c.delete(key)
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxSize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > c.maxSize {
+		// streams the rest of the body without caching
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		c.delete(key)
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	entry := &cacheEntry{
+		key:          key,
+		header:       resp.Header.Clone(),
+		body:         body,
+		etag:         etag,
+		lastModified: lastModified,
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	entry.expiresAt = now.Add(c.ttl)
+	entry.freshUntil = cacheControl.freshUntil(now, entry.expiresAt)
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += int64(len(body))
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	return entry.response(req), nil
+}
+
+// refresh updates the freshness of the entry revalidated by a 304 response
+func (c *ResponseCache) refresh(entry *cacheEntry, header http.Header) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry.freshUntil = parseCacheControl(header).freshUntil(c.now(), entry.expiresAt)
+}
+
+func (c *ResponseCache) delete(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *ResponseCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.body))
+}
+
+// response returns a response of the cached content
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+type cacheControl struct {
+	noStore bool
+	noCache bool
+	maxAge  time.Duration
+}
+
+func parseCacheControl(header http.Header) (cc cacheControl) {
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.ToLower(strings.TrimSpace(directive))
+			switch {
+			case directive == "no-store":
+				cc.noStore = true
+			case directive == "no-cache":
+				cc.noCache = true
+			case strings.HasPrefix(directive, "max-age="):
+				if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+					cc.maxAge = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+	}
+	return
+}
+
+// freshUntil returns the time until which the response is fresh, no later than the expiration
+func (cc cacheControl) freshUntil(now, expiresAt time.Time) time.Time {
+	if cc.noCache || cc.maxAge <= 0 {
+		return now
+	}
+	if fresh := now.Add(cc.maxAge); fresh.Before(expiresAt) {
+		return fresh
+	}
+	return expiresAt
+}
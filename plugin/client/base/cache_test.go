@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type cacheServer struct {
+	calls       int
+	conditional []string
+	header      http.Header
+	status      int
+}
+
+func (s *cacheServer) responder(r *http.Request) (*http.Response, error) {
+	s.calls++
+	s.conditional = append(s.conditional, r.Header.Get("If-None-Match"))
+	if s.header.Get("ETag") != "" && r.Header.Get("If-None-Match") == s.header.Get("ETag") {
+		resp := httpmock.NewStringResponse(http.StatusNotModified, "")
+		resp.Header = s.header.Clone()
+		return resp, nil
+	}
+	status := s.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp, err := httpmock.NewJsonResponse(status, Body{Message: r.URL.Path, Code: s.calls})
+	for key := range s.header {
+		resp.Header.Set(key, s.header.Get(key))
+	}
+	return resp, err
+}
+
+func TestPluginClientCache(t *testing.T) {
+	tests := map[string]struct {
+		header      http.Header
+		status      int
+		calls       int
+		conditional []string
+		code        int
+	}{
+		"fresh within max-age": {
+			header: http.Header{"Cache-Control": {"max-age=60"}},
+			calls:  1, conditional: []string{""}, code: 1,
+		},
+		"revalidate with etag": {
+			header: http.Header{"Etag": {`"v1"`}},
+			calls:  2, conditional: []string{"", `"v1"`}, code: 1,
+		},
+		"revalidate no-cache": {
+			header: http.Header{"Etag": {`"v1"`}, "Cache-Control": {"no-cache, max-age=60"}},
+			calls:  2, conditional: []string{"", `"v1"`}, code: 1,
+		},
+		"no-store": {
+			header: http.Header{"Etag": {`"v1"`}, "Cache-Control": {"no-store"}},
+			calls:  2, conditional: []string{"", ""}, code: 2,
+		},
+		"without validators": {
+			header: http.Header{},
+			calls:  2, conditional: []string{"", ""}, code: 2,
+		},
+		"error response": {
+			header: http.Header{"Cache-Control": {"max-age=60"}},
+			status: http.StatusInternalServerError,
+			calls:  2, conditional: []string{"", ""},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			httpmock.Reset()
+			server := &cacheServer{header: tt.header, status: tt.status}
+			httpmock.RegisterResponder(http.MethodGet, "https://cache.example.com/api/projects", server.responder)
+
+			client := newResilienceTestClient(CacheOpts(NewResponseCache(1024, time.Hour)))
+			address := addressable("https://cache.example.com/api")
+
+			var result Body
+			for i := 0; i < 2; i++ {
+				result = Body{}
+				err := client.Get(context.Background(), address, "projects", ResultOpts(&result))
+				if tt.status == 0 {
+					g.Expect(err).To(Succeed())
+				}
+			}
+			g.Expect(server.calls).To(Equal(tt.calls))
+			g.Expect(server.conditional).To(Equal(tt.conditional))
+			if tt.code != 0 {
+				g.Expect(result).To(Equal(Body{Message: "/api/projects", Code: tt.code}))
+			}
+		})
+	}
+}
+
+func TestPluginClientCache_key(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+	server := &cacheServer{header: http.Header{"Cache-Control": {"max-age=60"}}}
+	httpmock.RegisterResponder(http.MethodGet, "https://cache.example.com/api/projects", server.responder)
+	httpmock.RegisterResponder(http.MethodPost, "https://cache.example.com/api/projects", server.responder)
+
+	cache := NewResponseCache(1024, time.Hour)
+	client := newResilienceTestClient(CacheOpts(cache))
+	address := addressable("https://cache.example.com/api")
+	ctx := context.Background()
+	alice := corev1.Secret{Type: corev1.SecretTypeBasicAuth, Data: map[string][]byte{"username": []byte("alice")}}
+	bob := corev1.Secret{Type: corev1.SecretTypeBasicAuth, Data: map[string][]byte{"username": []byte("bob")}}
+
+	g.Expect(client.Get(ctx, address, "projects", SecretOpts(alice))).To(Succeed())
+	g.Expect(client.Get(ctx, address, "projects", SecretOpts(alice))).To(Succeed())
+	g.Expect(server.calls).To(Equal(1))
+	g.Expect(client.Get(ctx, address, "projects", SecretOpts(bob))).To(Succeed())
+	g.Expect(server.calls).To(Equal(2))
+	g.Expect(client.Get(ctx, address, "projects", SecretOpts(alice), MetaOpts(Meta{BaseURL: "https://gitlab.com"}))).To(Succeed())
+	g.Expect(server.calls).To(Equal(3))
+	g.Expect(client.Get(ctx, address, "projects", SecretOpts(alice), QueryOpts(map[string]string{"page": "2"}))).To(Succeed())
+	g.Expect(server.calls).To(Equal(4))
+	g.Expect(cache.Len()).To(Equal(4))
+
+	g.Expect(client.Post(ctx, address, "projects", SecretOpts(alice))).To(Succeed())
+	g.Expect(server.calls).To(Equal(5))
+
+	// clients without cache share the transport but are not cached
+	uncached := NewPluginClient(ClientOpts(client.client))
+	g.Expect(uncached.Get(ctx, address, "projects", SecretOpts(alice))).To(Succeed())
+	g.Expect(server.calls).To(Equal(6))
+}
+
+func TestResponseCache_bounds(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+	server := &cacheServer{header: http.Header{"Cache-Control": {"max-age=3600"}}}
+	httpmock.RegisterResponder(http.MethodGet, `=~^https://cache\.example\.com/api/`, server.responder)
+
+	now := time.Now()
+	// each body is about 30 bytes, so only two bodies fit in the cache
+	cache := NewResponseCache(70, time.Minute)
+	cache.now = func() time.Time { return now }
+	client := newResilienceTestClient(CacheOpts(cache))
+	address := addressable("https://cache.example.com/api")
+	ctx := context.Background()
+
+	g.Expect(client.Get(ctx, address, "a")).To(Succeed())
+	g.Expect(client.Get(ctx, address, "b")).To(Succeed())
+	g.Expect(client.Get(ctx, address, "a")).To(Succeed())
+	g.Expect(server.calls).To(Equal(2))
+	g.Expect(client.Get(ctx, address, "c")).To(Succeed())
+	g.Expect(cache.Len()).To(Equal(2))
+
+	// b is the least recently used one
+	g.Expect(client.Get(ctx, address, "a")).To(Succeed())
+	g.Expect(server.calls).To(Equal(3))
+	g.Expect(client.Get(ctx, address, "b")).To(Succeed())
+	g.Expect(server.calls).To(Equal(4))
+
+	// expired by ttl though max-age is longer
+	now = now.Add(time.Minute)
+	g.Expect(client.Get(ctx, address, "b")).To(Succeed())
+	g.Expect(server.calls).To(Equal(5))
+
+	// larger than the cache
+	cache.maxSize = 10
+	g.Expect(client.Get(ctx, address, "d")).To(Succeed())
+	g.Expect(client.Get(ctx, address, "d")).To(Succeed())
+	g.Expect(server.calls).To(Equal(7))
+}
@@ -19,6 +19,7 @@ package base
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/emicklei/go-restful/v3"
@@ -60,6 +61,22 @@ type PluginClient struct {
 
 	// requestOptions options to wrap resty request
 	requestOptions []OptionFunc
+
+	// retryPolicy policy to retry the idempotent requests
+	// +optional
+	retryPolicy *RetryPolicy
+
+	// circuitBreakerPolicy policy of the circuit breaker of each class address
+	// +optional
+	circuitBreakerPolicy *CircuitBreakerPolicy
+
+	// rateLimitPolicy policy to limit the requests to each class address
+	// +optional
+	rateLimitPolicy *RateLimitPolicy
+
+	// cache caches the GET responses
+	// +optional
+	cache *ResponseCache
 }
 
 // BuildOptions Options to build the plugin client
@@ -78,7 +95,12 @@ func NewPluginClient(opts ...BuildOptions) *PluginClient {
 		op(pluginClient)
 	}
 
-	tracing.WrapTransportForRestyClient(pluginClient.client)
+	if _, ok := pluginClient.client.GetClient().Transport.(*cacheTransport); !ok {
+		tracing.WrapTransportForRestyClient(pluginClient.client)
+	}
+	if pluginClient.cache != nil {
+		wrapCacheTransport(pluginClient.client)
+	}
 	return pluginClient
 }
 
@@ -142,49 +164,30 @@ func (p *PluginClient) builtinOptions() []OptionFunc {
 
 // Get performs a GET request using defined options
 func (p *PluginClient) Get(ctx context.Context, baseURL *duckv1.Addressable, path string, options ...OptionFunc) error {
-	options = append(p.builtinOptions(), options...)
-
-	request := p.R(ctx, options...)
-	response, err := request.Get(p.FullUrl(baseURL, path))
-
+	response, err := p.execute(ctx, http.MethodGet, baseURL, path, options...)
 	return p.HandleError(response, err)
 }
 
 // GetResponse performs a GET request using defined options and return response
 func (p *PluginClient) GetResponse(ctx context.Context, baseURL *duckv1.Addressable, path string, options ...OptionFunc) (*resty.Response, error) {
-	options = append(p.builtinOptions(), options...)
-
-	request := p.R(ctx, options...)
-	return request.Get(p.FullUrl(baseURL, path))
+	return p.execute(ctx, http.MethodGet, baseURL, path, options...)
 }
 
 // Post performs a POST request with the given parameters
 func (p *PluginClient) Post(ctx context.Context, baseURL *duckv1.Addressable, path string, options ...OptionFunc) error {
-	options = append(p.builtinOptions(), options...)
-
-	request := p.R(ctx, options...)
-	response, err := request.Post(p.FullUrl(baseURL, path))
-
+	response, err := p.execute(ctx, http.MethodPost, baseURL, path, options...)
 	return p.HandleError(response, err)
 }
 
 // Put performs a PUT request with the given parameters
 func (p *PluginClient) Put(ctx context.Context, baseURL *duckv1.Addressable, path string, options ...OptionFunc) error {
-	options = append(p.builtinOptions(), options...)
-
-	request := p.R(ctx, options...)
-	response, err := request.Put(p.FullUrl(baseURL, path))
-
+	response, err := p.execute(ctx, http.MethodPut, baseURL, path, options...)
 	return p.HandleError(response, err)
 }
 
 // Delete performs a DELETE request with the given parameters
 func (p *PluginClient) Delete(ctx context.Context, baseURL *duckv1.Addressable, path string, options ...OptionFunc) error {
-	options = append(p.builtinOptions(), options...)
-
-	request := p.R(ctx, options...)
-	response, err := request.Delete(p.FullUrl(baseURL, path))
-
+	response, err := p.execute(ctx, http.MethodDelete, baseURL, path, options...)
 	return p.HandleError(response, err)
 }
 
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// requestsTotal counts the requests to plugins, partitioned by class address, method and status code
+var requestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "plugin_client",
+		Name:      "requests_total",
+		Help:      "How many requests sent to plugins, partitioned by class address, HTTP method and status code.",
+	},
+	[]string{"class", "method", "code"},
+)
+
+// retriesTotal counts the retries of the requests to plugins
+var retriesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "plugin_client",
+		Name:      "retries_total",
+		Help:      "How many requests retried, partitioned by class address and HTTP method.",
+	},
+	[]string{"class", "method"},
+)
+
+// circuitBreakerStateGauge state of the circuit breakers
+var circuitBreakerStateGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: "plugin_client",
+		Name:      "circuit_breaker_state",
+		Help:      "State of the circuit breaker of the class address, 0 closed, 1 open and 2 half-open.",
+	},
+	[]string{"class"},
+)
+
+// rateLimitWaitSeconds records the duration requests wait for the rate limiters
+var rateLimitWaitSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: "plugin_client",
+		Name:      "rate_limit_wait_seconds",
+		Help:      "The duration in seconds requests wait for the rate limiter of the class address.",
+	},
+	[]string{"class"},
+)
+
+// cacheRequestsTotal counts the GET requests to plugins handled by the response cache
+var cacheRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "plugin_client",
+		Name:      "cache_requests_total",
+		Help:      "How many GET requests handled by the response cache, partitioned by result: hit, revalidated or miss.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, retriesTotal, circuitBreakerStateGauge, rateLimitWaitSeconds, cacheRequestsTotal)
+}
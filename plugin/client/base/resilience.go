@@ -0,0 +1,336 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	perrors "github.com/katanomi/pkg/errors"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// RetryPolicy policy to retry the idempotent requests (GET, HEAD, PUT and DELETE)
+// failed with network errors, 429 or 5xx responses except 501.
+type RetryPolicy struct {
+	// MaxRetries max retries of a request, requests are not retried if zero
+	MaxRetries int
+
+	// MinBackoff backoff before the first retry, doubled for every following retry
+	MinBackoff time.Duration
+
+	// MaxBackoff max backoff of the retries, also caps the Retry-After header of the responses
+	MaxBackoff time.Duration
+}
+
+// CircuitBreakerPolicy policy of the circuit breaker shared by the clients requesting the same class address.
+// The breaker opens after consecutive failures, requests are rejected with a ToolServiceUnavailable error
+// until the OpenDuration passes, then a trial request decides whether to close the breaker.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold consecutive network errors or 5xx responses to open the breaker
+	FailureThreshold int
+
+	// OpenDuration duration to reject requests after the breaker opens
+	OpenDuration time.Duration
+}
+
+// RateLimitPolicy token bucket rate limit shared by the clients requesting the same class address
+type RateLimitPolicy struct {
+	// QPS requests per second, no limit if it is not positive
+	QPS float64
+
+	// Burst size of the token bucket, defaults to QPS rounded up when it is zero
+	Burst int
+}
+
+// limit returns the rate limit and burst of the token bucket with defaults applied
+func (p RateLimitPolicy) limit() (rate.Limit, int) {
+	if p.QPS <= 0 {
+		return rate.Inf, p.Burst
+	}
+	burst := p.Burst
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(p.QPS)))
+	}
+	return rate.Limit(p.QPS), burst
+}
+
+// RetryOpts retries the idempotent requests with the policy
+func RetryOpts(policy RetryPolicy) BuildOptions {
+	return func(client *PluginClient) {
+		client.retryPolicy = &policy
+	}
+}
+
+// CircuitBreakerOpts enables the circuit breaker of each class address with the policy
+func CircuitBreakerOpts(policy CircuitBreakerPolicy) BuildOptions {
+	return func(client *PluginClient) {
+		client.circuitBreakerPolicy = &policy
+	}
+}
+
+// RateLimitOpts limits the requests to each class address with the policy
+func RateLimitOpts(policy RateLimitPolicy) BuildOptions {
+	return func(client *PluginClient) {
+		client.rateLimitPolicy = &policy
+	}
+}
+
+// execute performs the request with the rate limit, the circuit breaker and the retry policy of the client
+func (p *PluginClient) execute(ctx context.Context, method string, baseURL *duckv1.Addressable, path string, options ...OptionFunc) (*resty.Response, error) {
+	options = append(p.builtinOptions(), options...)
+	url := p.FullUrl(baseURL, path)
+	if p.cache != nil {
+		ctx = withResponseCache(ctx, p.cache)
+	}
+	class := baseURL.URL.String()
+
+	var limiter *rate.Limiter
+	if p.rateLimitPolicy != nil {
+		limiter = defaultResilienceRegistry.limiter(class, *p.rateLimitPolicy)
+	}
+	var breaker *circuitBreaker
+	if p.circuitBreakerPolicy != nil {
+		breaker = defaultResilienceRegistry.circuitBreaker(class, *p.circuitBreakerPolicy)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			start := time.Now()
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+			rateLimitWaitSeconds.WithLabelValues(class).Observe(time.Since(start).Seconds())
+		}
+		if breaker != nil && !breaker.Allow() {
+			return nil, circuitOpenError(class)
+		}
+
+		request := p.R(ctx, options...)
+		response, err := request.Execute(method, url)
+		requestsTotal.WithLabelValues(class, method, responseCode(response, err)).Inc()
+		if breaker != nil {
+			breaker.Record(!isServiceFailure(response, err))
+		}
+
+		if !p.shouldRetry(ctx, request, attempt, response, err) {
+			return response, err
+		}
+		select {
+		case <-ctx.Done():
+			return response, err
+		case <-time.After(p.retryPolicy.backoff(attempt, response)):
+		}
+		if response != nil && response.RawBody() != nil {
+			// discards the body of the response not parsed
+			response.RawBody().Close()
+		}
+		retriesTotal.WithLabelValues(class, method).Inc()
+	}
+}
+
+func (p *PluginClient) shouldRetry(ctx context.Context, request *resty.Request, attempt int, response *resty.Response, err error) bool {
+	if p.retryPolicy == nil || attempt >= p.retryPolicy.MaxRetries || ctx.Err() != nil {
+		return false
+	}
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if _, ok := request.Body.(io.Reader); ok {
+		// the body could not be read again
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	code := response.StatusCode()
+	return code == http.StatusTooManyRequests || (code >= http.StatusInternalServerError && code != http.StatusNotImplemented)
+}
+
+// backoff returns the duration to wait before the retry following the attempt
+func (r *RetryPolicy) backoff(attempt int, response *resty.Response) time.Duration {
+	if response != nil {
+		if seconds, err := strconv.Atoi(response.Header().Get("Retry-After")); err == nil && seconds > 0 {
+			return r.capped(time.Duration(seconds) * time.Second)
+		}
+	}
+	backoff := r.MinBackoff
+	for i := 0; i < attempt && backoff > 0 && (r.MaxBackoff <= 0 || backoff < r.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	backoff = r.capped(backoff)
+	if backoff <= 0 {
+		return 0
+	}
+	// jitter in [backoff/2, backoff) to spread the retries of concurrent clients
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+func (r *RetryPolicy) capped(backoff time.Duration) time.Duration {
+	if r.MaxBackoff > 0 && (backoff > r.MaxBackoff || backoff < 0) {
+		return r.MaxBackoff
+	}
+	return backoff
+}
+
+// isServiceFailure returns true if the tool service fails to serve the request
+func isServiceFailure(response *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return response.StatusCode() >= http.StatusInternalServerError && response.StatusCode() != http.StatusNotImplemented
+}
+
+func responseCode(response *resty.Response, err error) string {
+	if err != nil || response == nil {
+		return "error"
+	}
+	return strconv.Itoa(response.StatusCode())
+}
+
+// circuitOpenError returns the error of the requests rejected by the open circuit breaker
+func circuitOpenError(class string) error {
+	return &errors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusServiceUnavailable,
+		Reason:  perrors.StatusReasonToolServiceUnavailable,
+		Message: fmt.Sprintf("circuit breaker of %s is open", class),
+	}}
+}
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+// circuitBreaker circuit breaker of a class address
+type circuitBreaker struct {
+	lock     sync.Mutex
+	class    string
+	policy   CircuitBreakerPolicy
+	state    circuitBreakerState
+	failures int
+	// openedAt time when the breaker opens or the trial request starts
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// Allow returns true if the request could be sent
+func (b *circuitBreaker) Allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case circuitBreakerClosed:
+		return true
+	default:
+		// allows a trial request after the open duration, and another one
+		// if the trial does not record its result in time
+		if b.now().Sub(b.openedAt) < b.policy.OpenDuration {
+			return false
+		}
+		b.setState(circuitBreakerHalfOpen)
+		b.openedAt = b.now()
+		return true
+	}
+}
+
+// Record records the result of a request
+func (b *circuitBreaker) Record(success bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if success {
+		b.failures = 0
+		b.setState(circuitBreakerClosed)
+		return
+	}
+	b.failures++
+	if b.state == circuitBreakerHalfOpen || b.failures >= b.policy.FailureThreshold {
+		b.openedAt = b.now()
+		b.setState(circuitBreakerOpen)
+	}
+}
+
+func (b *circuitBreaker) setState(state circuitBreakerState) {
+	b.state = state
+	circuitBreakerStateGauge.WithLabelValues(b.class).Set(float64(state))
+}
+
+// resilienceRegistry circuit breakers and rate limiters shared by the clients, keyed by class address
+type resilienceRegistry struct {
+	lock     sync.Mutex
+	breakers map[string]*circuitBreaker
+	limiters map[string]*rate.Limiter
+}
+
+var defaultResilienceRegistry = &resilienceRegistry{
+	breakers: map[string]*circuitBreaker{},
+	limiters: map[string]*rate.Limiter{},
+}
+
+// circuitBreaker returns the circuit breaker of the class, the policy is updated if changed
+func (r *resilienceRegistry) circuitBreaker(class string, policy CircuitBreakerPolicy) *circuitBreaker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	breaker, ok := r.breakers[class]
+	if !ok {
+		breaker = &circuitBreaker{class: class, now: time.Now}
+		r.breakers[class] = breaker
+	}
+	breaker.lock.Lock()
+	breaker.policy = policy
+	breaker.lock.Unlock()
+	return breaker
+}
+
+// limiter returns the rate limiter of the class, the policy is updated if changed
+func (r *resilienceRegistry) limiter(class string, policy RateLimitPolicy) *rate.Limiter {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	limit, burst := policy.limit()
+	limiter, ok := r.limiters[class]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		r.limiters[class] = limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
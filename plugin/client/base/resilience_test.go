@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	perrors "github.com/katanomi/pkg/errors"
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func newResilienceTestClient(opts ...BuildOptions) *PluginClient {
+	RESTClient := resty.New()
+	httpmock.ActivateNonDefault(RESTClient.GetClient())
+	return NewPluginClient(append([]BuildOptions{ClientOpts(RESTClient)}, opts...)...)
+}
+
+func addressable(url string) *duckv1.Addressable {
+	u, _ := apis.ParseURL(url)
+	return &duckv1.Addressable{URL: u}
+}
+
+func sequenceResponder(calls *int, codes ...int) httpmock.Responder {
+	return func(r *http.Request) (*http.Response, error) {
+		code := codes[len(codes)-1]
+		if *calls < len(codes) {
+			code = codes[*calls]
+		}
+		*calls++
+		return httpmock.NewJsonResponse(code, Body{Code: code})
+	}
+}
+
+func TestPluginClientRetry(t *testing.T) {
+	policy := RetryOpts(RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+	tests := map[string]struct {
+		method string
+		codes  []int
+		calls  int
+		code   int
+	}{
+		"retry until success": {method: http.MethodGet, codes: []int{503, 502, 200}, calls: 3, code: 200},
+		"retry rate limited":  {method: http.MethodDelete, codes: []int{429, 200}, calls: 2, code: 200},
+		"retries exhausted":   {method: http.MethodPut, codes: []int{500}, calls: 3, code: 500},
+		"not idempotent":      {method: http.MethodPost, codes: []int{503, 200}, calls: 1, code: 503},
+		"client error":        {method: http.MethodGet, codes: []int{404, 200}, calls: 1, code: 404},
+		"not implemented":     {method: http.MethodGet, codes: []int{501, 200}, calls: 1, code: 501},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			httpmock.Reset()
+			calls := 0
+			httpmock.RegisterResponder(tt.method, "https://retry.example.com/api/projects", sequenceResponder(&calls, tt.codes...))
+
+			client := newResilienceTestClient(policy)
+			address := addressable("https://retry.example.com/api")
+			var err error
+			switch tt.method {
+			case http.MethodGet:
+				err = client.Get(context.Background(), address, "projects")
+			case http.MethodPost:
+				err = client.Post(context.Background(), address, "projects")
+			case http.MethodPut:
+				err = client.Put(context.Background(), address, "projects")
+			case http.MethodDelete:
+				err = client.Delete(context.Background(), address, "projects")
+			}
+
+			g.Expect(calls).To(Equal(tt.calls))
+			if tt.code == 200 {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(errors.ReasonForError(err)).NotTo(BeEmpty())
+				g.Expect(perrors.AsStatusCode(err)).To(Equal(tt.code))
+			}
+		})
+	}
+}
+
+func TestPluginClientCircuitBreaker(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+	calls := 0
+	httpmock.RegisterResponder(http.MethodGet, "https://breaker.example.com/api/projects", sequenceResponder(&calls, 503, 503, 200))
+
+	policy := CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: time.Minute}
+	now := time.Now()
+	breaker := defaultResilienceRegistry.circuitBreaker("https://breaker.example.com/api", policy)
+	breaker.now = func() time.Time { return now }
+
+	client := newResilienceTestClient(CircuitBreakerOpts(policy))
+	address := addressable("https://breaker.example.com/api")
+
+	g.Expect(client.Get(context.Background(), address, "projects")).NotTo(Succeed())
+	g.Expect(client.Get(context.Background(), address, "projects")).NotTo(Succeed())
+	g.Expect(calls).To(Equal(2))
+
+	err := client.Get(context.Background(), address, "projects")
+	g.Expect(perrors.IsToolServiceUnavailable(err)).To(BeTrue())
+	g.Expect(calls).To(Equal(2))
+
+	now = now.Add(time.Minute)
+	g.Expect(client.Get(context.Background(), address, "projects")).To(Succeed())
+	g.Expect(calls).To(Equal(3))
+	g.Expect(client.Get(context.Background(), address, "projects")).To(Succeed())
+	g.Expect(calls).To(Equal(4))
+}
+
+func TestCircuitBreaker_halfOpen(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+	breaker := &circuitBreaker{class: "test", policy: CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute}, now: func() time.Time { return now }}
+
+	breaker.Record(false)
+	g.Expect(breaker.Allow()).To(BeFalse())
+
+	now = now.Add(time.Minute)
+	g.Expect(breaker.Allow()).To(BeTrue())
+	// only one trial request is allowed
+	g.Expect(breaker.Allow()).To(BeFalse())
+
+	breaker.Record(false)
+	g.Expect(breaker.state).To(Equal(circuitBreakerOpen))
+	now = now.Add(30 * time.Second)
+	g.Expect(breaker.Allow()).To(BeFalse())
+}
+
+func TestPluginClientRateLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+	calls := 0
+	httpmock.RegisterResponder(http.MethodGet, "https://ratelimit.example.com/api/projects", sequenceResponder(&calls, 200))
+
+	client := newResilienceTestClient(RateLimitOpts(RateLimitPolicy{QPS: 0.1, Burst: 1}))
+	address := addressable("https://ratelimit.example.com/api")
+
+	g.Expect(client.Get(context.Background(), address, "projects")).To(Succeed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	g.Expect(client.Get(ctx, address, "projects")).NotTo(Succeed())
+	g.Expect(calls).To(Equal(1))
+}
+
+func TestResilienceRegistry_limiter(t *testing.T) {
+	g := NewGomegaWithT(t)
+	registry := &resilienceRegistry{limiters: map[string]*rate.Limiter{}}
+
+	limiter := registry.limiter("zero-burst", RateLimitPolicy{QPS: 10})
+	g.Expect(limiter.Limit()).To(Equal(rate.Limit(10)))
+	g.Expect(limiter.Burst()).To(Equal(10))
+	g.Expect(limiter.Allow()).To(BeTrue())
+
+	limiter = registry.limiter("low-qps", RateLimitPolicy{QPS: 0.1})
+	g.Expect(limiter.Burst()).To(Equal(1))
+	g.Expect(limiter.Allow()).To(BeTrue())
+	g.Expect(limiter.Allow()).To(BeFalse())
+
+	limiter = registry.limiter("no-limit", RateLimitPolicy{})
+	g.Expect(limiter.Limit()).To(Equal(rate.Inf))
+	for i := 0; i < 100; i++ {
+		g.Expect(limiter.Allow()).To(BeTrue())
+	}
+
+	// the policy of an existing class is updated
+	limiter = registry.limiter("zero-burst", RateLimitPolicy{QPS: 2.5})
+	g.Expect(limiter.Limit()).To(Equal(rate.Limit(2.5)))
+	g.Expect(limiter.Burst()).To(Equal(3))
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	g := NewGomegaWithT(t)
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	g.Expect(policy.backoff(0, nil)).To(And(BeNumerically(">=", 50*time.Millisecond), BeNumerically("<=", 100*time.Millisecond)))
+	g.Expect(policy.backoff(2, nil)).To(And(BeNumerically(">=", 200*time.Millisecond), BeNumerically("<=", 400*time.Millisecond)))
+	g.Expect(policy.backoff(10, nil)).To(BeNumerically("<=", time.Second))
+	g.Expect(policy.backoff(100, nil)).To(BeNumerically(">=", 500*time.Millisecond))
+
+	response := &resty.Response{RawResponse: &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}}
+	g.Expect(policy.backoff(0, response)).To(Equal(time.Second))
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/path"
+	"k8s.io/apimachinery/pkg/api/errors"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// ClientGitPullRequestReview is interface for reviewing and merging pull requests
+//
+//go:generate mockgen -source=gitpullrequestreview.go -destination=../../testing/mock/github.com/katanomi/pkg/plugin/client/gitpullrequestreview.go -package=client ClientGitPullRequestReview
+type ClientGitPullRequestReview interface {
+	ListFiles(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		option metav1alpha1.GitPullRequestOption,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequestFileList, error)
+	GetDiff(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		option metav1alpha1.GitPullRequestDiffOption,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequestDiff, error)
+	ListReviews(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		option metav1alpha1.GitPullRequestOption,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequestReviewList, error)
+	CreateReview(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		payload metav1alpha1.CreatePullRequestReviewPayload,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequestReview, error)
+	RequestReviewers(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		payload metav1alpha1.RequestPullRequestReviewersPayload,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequest, error)
+	GetMergeability(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		option metav1alpha1.GitPullRequestOption,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequestMergeability, error)
+	Merge(
+		ctx context.Context,
+		baseURL *duckv1.Addressable,
+		payload metav1alpha1.MergePullRequestPayload,
+		options ...OptionFunc,
+	) (*metav1alpha1.GitPullRequest, error)
+}
+
+type gitPullRequestReview struct {
+	client Client
+}
+
+func newGitPullRequestReview(client Client) ClientGitPullRequestReview {
+	return &gitPullRequestReview{
+		client: client,
+	}
+}
+
+// pullRequestURI returns the uri of the pull request or its sub resource
+func pullRequestURI(repo metav1alpha1.GitRepo, index int, subResource string) (string, error) {
+	if repo.Repository == "" {
+		return "", errors.NewBadRequest("repo is empty string")
+	}
+	if index < 1 {
+		return "", errors.NewBadRequest("pr's index is unknown")
+	}
+	return path.Format("projects/%s/coderepositories/%s/pulls/%s/"+subResource, repo.Project, repo.Repository, strconv.Itoa(index)), nil
+}
+
+// ListFiles list files changed by pr
+func (g *gitPullRequestReview) ListFiles(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	option metav1alpha1.GitPullRequestOption,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequestFileList, error) {
+	fileList := &metav1alpha1.GitPullRequestFileList{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "files")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, ResultOpts(fileList))
+	if err = g.client.Get(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return fileList, nil
+}
+
+// GetDiff get the diff of pr
+func (g *gitPullRequestReview) GetDiff(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	option metav1alpha1.GitPullRequestDiffOption,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequestDiff, error) {
+	diff := &metav1alpha1.GitPullRequestDiff{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "diff")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, ResultOpts(diff))
+	if option.Path != "" {
+		options = append(options, QueryOpts(map[string]string{"path": option.Path}))
+	}
+	if err = g.client.Get(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// ListReviews list reviews of pr
+func (g *gitPullRequestReview) ListReviews(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	option metav1alpha1.GitPullRequestOption,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequestReviewList, error) {
+	reviewList := &metav1alpha1.GitPullRequestReviewList{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "reviews")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, ResultOpts(reviewList))
+	if err = g.client.Get(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return reviewList, nil
+}
+
+// CreateReview submit a review of pr
+func (g *gitPullRequestReview) CreateReview(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	payload metav1alpha1.CreatePullRequestReviewPayload,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequestReview, error) {
+	review := &metav1alpha1.GitPullRequestReview{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "reviews")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, BodyOpts(payload.CreatePullRequestReviewParam), ResultOpts(review))
+	if err = g.client.Post(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// RequestReviewers request reviewers of pr
+func (g *gitPullRequestReview) RequestReviewers(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	payload metav1alpha1.RequestPullRequestReviewersPayload,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequest, error) {
+	pr := &metav1alpha1.GitPullRequest{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "reviewers")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, BodyOpts(payload.RequestPullRequestReviewersParam), ResultOpts(pr))
+	if err = g.client.Post(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// GetMergeability get the mergeability of pr
+func (g *gitPullRequestReview) GetMergeability(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	option metav1alpha1.GitPullRequestOption,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequestMergeability, error) {
+	mergeability := &metav1alpha1.GitPullRequestMergeability{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "mergeability")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, ResultOpts(mergeability))
+	if err = g.client.Get(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return mergeability, nil
+}
+
+// Merge merge pr
+func (g *gitPullRequestReview) Merge(
+	ctx context.Context,
+	baseURL *duckv1.Addressable,
+	payload metav1alpha1.MergePullRequestPayload,
+	options ...OptionFunc,
+) (*metav1alpha1.GitPullRequest, error) {
+	pr := &metav1alpha1.GitPullRequest{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "merge")
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, BodyOpts(payload.MergePullRequestParam), ResultOpts(pr))
+	if err = g.client.Post(ctx, baseURL, uri, options...); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
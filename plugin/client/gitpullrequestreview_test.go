@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func newTestGitPullRequestReview() (ClientGitPullRequestReview, *duckv1.Addressable) {
+	RESTClient := resty.New()
+	httpmock.ActivateNonDefault(RESTClient.GetClient())
+	client := NewPluginClient(ClientOpts(RESTClient))
+	url, _ := apis.ParseURL("https://example.com/")
+	return client.GitPullRequestReview(Meta{BaseURL: "http://plugin.com"}, corev1.Secret{}), &duckv1.Addressable{URL: url}
+}
+
+func TestClientGitPullRequestReviewGetDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+
+	expected := &metav1alpha1.GitPullRequestDiff{Spec: metav1alpha1.GitPullRequestDiffSpec{BaseSHA: "base", HeadSHA: "head", Diff: "diff"}}
+	responder, _ := httpmock.NewJsonResponder(200, expected)
+	httpmock.RegisterResponder("GET", "https://example.com/projects/devops/coderepositories/repo/pulls/1/diff?path=README.md", responder)
+
+	reviewClient, address := newTestGitPullRequestReview()
+	diff, err := reviewClient.GetDiff(context.Background(), address, metav1alpha1.GitPullRequestDiffOption{
+		GitPullRequestOption: metav1alpha1.GitPullRequestOption{
+			GitRepo: metav1alpha1.GitRepo{Project: "devops", Repository: "repo"},
+			Index:   1,
+		},
+		Path: "README.md",
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(diff).To(Equal(expected))
+}
+
+func TestClientGitPullRequestReviewMerge(t *testing.T) {
+	g := NewGomegaWithT(t)
+	httpmock.Reset()
+
+	expected := &metav1alpha1.GitPullRequest{Spec: metav1alpha1.GitPullRequestSpec{State: metav1alpha1.PullRequestMergedState}}
+	responder, _ := httpmock.NewJsonResponder(200, expected)
+	httpmock.RegisterResponder("POST", "https://example.com/projects/devops/coderepositories/repo/pulls/1/merge", responder)
+
+	reviewClient, address := newTestGitPullRequestReview()
+	payload := metav1alpha1.MergePullRequestPayload{
+		GitRepo:               metav1alpha1.GitRepo{Project: "devops", Repository: "repo"},
+		Index:                 1,
+		MergePullRequestParam: metav1alpha1.MergePullRequestParam{Strategy: metav1alpha1.MergeStrategyRebase},
+	}
+	pr, err := reviewClient.Merge(context.Background(), address, payload)
+	g.Expect(err).To(BeNil())
+	g.Expect(pr.Spec.State).To(Equal(metav1alpha1.PullRequestMergedState))
+
+	payload.Index = 0
+	_, err = reviewClient.Merge(context.Background(), address, payload)
+	g.Expect(errors.IsBadRequest(err)).To(BeTrue())
+}
@@ -228,6 +228,19 @@ func (p *PluginClient) NewGitPullRequest() GitPullRequestCRUClient {
 	return newGitPullRequest(p)
 }
 
+// GitPullRequestReview get pr review client
+func (p *PluginClient) GitPullRequestReview(meta Meta, secret corev1.Secret) ClientGitPullRequestReview {
+	clone := p.Clone().WithMeta(meta).WithSecret(secret)
+
+	return newGitPullRequestReview(clone)
+}
+
+// NewGitPullRequestReview get pr review client
+// Use the internal meta and secret to generate the client, please assign in advance.
+func (p *PluginClient) NewGitPullRequestReview() ClientGitPullRequestReview {
+	return newGitPullRequestReview(p)
+}
+
 // GitCommit get pr client
 func (p *PluginClient) GitCommit(meta Meta, secret corev1.Secret) ClientGitCommit {
 	clone := p.Clone().WithMeta(meta).WithSecret(secret)
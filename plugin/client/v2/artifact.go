@@ -68,3 +68,16 @@ func (p *PluginClient) DeleteArtifactTag(ctx context.Context, params metav1alpha
 	}
 	return nil
 }
+
+// PromoteArtifact promote an artifact to another project or registry
+func (p *PluginClient) PromoteArtifact(ctx context.Context, params metav1alpha1.ArtifactOptions, payload metav1alpha1.PromoteArtifactPayload) (*metav1alpha1.Artifact, error) {
+	artifact := &metav1alpha1.Artifact{}
+
+	uri := fmt.Sprintf("projects/%s/repositories/%s/artifacts/%s/promote", params.Project, params.Repository, params.Artifact)
+	options := []base.OptionFunc{base.BodyOpts(payload), base.ResultOpts(artifact)}
+	if err := p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
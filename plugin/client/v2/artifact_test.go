@@ -76,3 +76,21 @@ var _ = Describe("Test DeleteArtifact", func() {
 		Expect(err).To(Succeed())
 	})
 })
+
+var _ = Describe("Test PromoteArtifact", func() {
+	It("should send the payload and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.Artifact]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/repositories/test-repo/artifacts/test-artifact/promote",
+			bodyContains(`"target":{"project":"release","repository":"test-repo"}`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.PromoteArtifact(context.Background(), artifactOption, metav1alpha1.PromoteArtifactPayload{
+			Target: metav1alpha1.ArtifactPromotionTarget{Project: "release", Repository: "test-repo"},
+			Mode:   metav1alpha1.ArtifactPromotionMove,
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, expected)).To(BeEmpty())
+	})
+})
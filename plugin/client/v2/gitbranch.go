@@ -72,3 +72,60 @@ func (p *PluginClient) CreateGitBranch(ctx context.Context, payload metav1alpha1
 
 	return branchObj, nil
 }
+
+// DeleteGitBranch delete git branch
+func (p *PluginClient) DeleteGitBranch(ctx context.Context, repo metav1alpha1.GitRepo, branch string) error {
+	if repo.Repository == "" {
+		return errors.NewBadRequest("repo is empty string")
+	}
+	if branch == "" {
+		return errors.NewBadRequest("branch is empty string")
+	}
+	uri := path.Format("projects/%s/coderepositories/%s/branches/%s", repo.Project, repo.Repository, branch)
+	return p.Delete(ctx, p.ClassAddress, uri)
+}
+
+// GetGitBranchProtection get protection rules of git branch
+func (p *PluginClient) GetGitBranchProtection(ctx context.Context, repo metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranchProtection, error) {
+	protection := metav1alpha1.GitBranchProtection{}
+	if repo.Repository == "" {
+		return protection, errors.NewBadRequest("repo is empty string")
+	}
+	if branch == "" {
+		return protection, errors.NewBadRequest("branch is empty string")
+	}
+	uri := path.Format("projects/%s/coderepositories/%s/branches/%s/protection", repo.Project, repo.Repository, branch)
+	if err := p.Get(ctx, p.ClassAddress, uri, base.ResultOpts(&protection)); err != nil {
+		return protection, err
+	}
+	return protection, nil
+}
+
+// UpdateGitBranchProtection protect git branch or replace its protection rules
+func (p *PluginClient) UpdateGitBranchProtection(ctx context.Context, payload metav1alpha1.UpdateBranchProtectionPayload) (metav1alpha1.GitBranchProtection, error) {
+	protection := metav1alpha1.GitBranchProtection{}
+	if payload.Repository == "" {
+		return protection, errors.NewBadRequest("repo is empty string")
+	}
+	if payload.Branch == "" {
+		return protection, errors.NewBadRequest("branch is empty string")
+	}
+	options := []base.OptionFunc{base.BodyOpts(payload.GitBranchProtectionRules), base.ResultOpts(&protection)}
+	uri := path.Format("projects/%s/coderepositories/%s/branches/%s/protection", payload.Project, payload.Repository, payload.Branch)
+	if err := p.Put(ctx, p.ClassAddress, uri, options...); err != nil {
+		return protection, err
+	}
+	return protection, nil
+}
+
+// DeleteGitBranchProtection remove protection rules of git branch
+func (p *PluginClient) DeleteGitBranchProtection(ctx context.Context, repo metav1alpha1.GitRepo, branch string) error {
+	if repo.Repository == "" {
+		return errors.NewBadRequest("repo is empty string")
+	}
+	if branch == "" {
+		return errors.NewBadRequest("branch is empty string")
+	}
+	uri := path.Format("projects/%s/coderepositories/%s/branches/%s/protection", repo.Project, repo.Repository, branch)
+	return p.Delete(ctx, p.ClassAddress, uri)
+}
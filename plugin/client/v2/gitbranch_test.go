@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+
+	"github.com/jarcoal/httpmock"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var branchRepo = metav1alpha1.GitRepo{Project: "test-project", Repository: "repo"}
+
+var _ = Describe("Test DeleteGitBranch", func() {
+	It("should generate the correct url", func() {
+		httpmock.RegisterResponder(
+			"DELETE",
+			"https://example.com/projects/test-project/coderepositories/repo/branches/feature",
+			httpmock.NewStringResponder(200, ""),
+		)
+
+		Expect(pluginClient.DeleteGitBranch(context.Background(), branchRepo, "feature")).To(Succeed())
+	})
+
+	It("should return the error of the plugin", func() {
+		httpmock.RegisterResponder(
+			"DELETE",
+			"https://example.com/projects/test-project/coderepositories/repo/branches/main",
+			httpmock.NewStringResponder(400, `{"message":"default branch could not be deleted"}`),
+		)
+
+		err := pluginClient.DeleteGitBranch(context.Background(), branchRepo, "main")
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("should return bad request when branch is empty", func() {
+		err := pluginClient.DeleteGitBranch(context.Background(), branchRepo, "")
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Test GetGitBranchProtection", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitBranchProtection]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/coderepositories/repo/branches/main/protection",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.GetGitBranchProtection(context.Background(), branchRepo, "main")
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test UpdateGitBranchProtection", func() {
+	It("should send the rules and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitBranchProtection]()
+		httpmock.RegisterResponder(
+			"PUT",
+			"https://example.com/projects/test-project/coderepositories/repo/branches/main/protection",
+			bodyContains(`"requiredStatusChecks":["ci/build"]`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.UpdateGitBranchProtection(context.Background(), metav1alpha1.UpdateBranchProtectionPayload{
+			GitRepo: branchRepo,
+			Branch:  "main",
+			GitBranchProtectionRules: metav1alpha1.GitBranchProtectionRules{
+				RequiredApprovals:    1,
+				RequiredStatusChecks: []string{"ci/build"},
+			},
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+
+	It("should return bad request when branch is empty", func() {
+		_, err := pluginClient.UpdateGitBranchProtection(context.Background(), metav1alpha1.UpdateBranchProtectionPayload{GitRepo: branchRepo})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Test DeleteGitBranchProtection", func() {
+	It("should generate the correct url", func() {
+		httpmock.RegisterResponder(
+			"DELETE",
+			"https://example.com/projects/test-project/coderepositories/repo/branches/main/protection",
+			httpmock.NewStringResponder(200, ""),
+		)
+
+		Expect(pluginClient.DeleteGitBranchProtection(context.Background(), branchRepo, "main")).To(Succeed())
+	})
+})
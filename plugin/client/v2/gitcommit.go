@@ -84,3 +84,23 @@ func (p *PluginClient) ListGitCommit(ctx context.Context, option metav1alpha1.Gi
 	}
 	return commitList, nil
 }
+
+// CompareGitCommits compare two revisions of git repository
+func (p *PluginClient) CompareGitCommits(ctx context.Context, option metav1alpha1.GitCommitCompareOption) (metav1alpha1.GitCommitComparison, error) {
+	comparison := metav1alpha1.GitCommitComparison{}
+	if option.Repository == "" {
+		return comparison, errors.NewBadRequest("repo is empty string")
+	}
+	if option.Base == "" || option.Head == "" {
+		return comparison, errors.NewBadRequest("base and head are required")
+	}
+	options := []base.OptionFunc{
+		base.ResultOpts(&comparison),
+		base.QueryOpts(map[string]string{"base": option.Base, "head": option.Head}),
+	}
+	uri := path.Format("projects/%s/coderepositories/%s/compare", option.Project, option.Repository)
+	if err := p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return comparison, err
+	}
+	return comparison, nil
+}
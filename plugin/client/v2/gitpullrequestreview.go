@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"strconv"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client/base"
+	"github.com/katanomi/pkg/plugin/path"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// pullRequestURI returns the uri of the pull request sub resource
+func pullRequestURI(repo metav1alpha1.GitRepo, index int, subResource string) (string, error) {
+	if repo.Repository == "" {
+		return "", errors.NewBadRequest("repo is empty string")
+	}
+	if index < 1 {
+		return "", errors.NewBadRequest("pr's index is unknown")
+	}
+	return path.Format("projects/%s/coderepositories/%s/pulls/%s/"+subResource, repo.Project, repo.Repository, strconv.Itoa(index)), nil
+}
+
+// ListPullRequestFiles list files changed by a pull request
+func (p *PluginClient) ListPullRequestFiles(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestFileList, error) {
+	fileList := metav1alpha1.GitPullRequestFileList{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "files")
+	if err != nil {
+		return fileList, err
+	}
+	options := []base.OptionFunc{base.ResultOpts(&fileList), base.ListOpts(listOption)}
+	if err = p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return fileList, err
+	}
+	return fileList, nil
+}
+
+// GetPullRequestDiff get the diff of a pull request
+func (p *PluginClient) GetPullRequestDiff(ctx context.Context, option metav1alpha1.GitPullRequestDiffOption) (metav1alpha1.GitPullRequestDiff, error) {
+	diff := metav1alpha1.GitPullRequestDiff{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "diff")
+	if err != nil {
+		return diff, err
+	}
+	options := []base.OptionFunc{base.ResultOpts(&diff)}
+	if option.Path != "" {
+		options = append(options, base.QueryOpts(map[string]string{"path": option.Path}))
+	}
+	if err = p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return diff, err
+	}
+	return diff, nil
+}
+
+// ListPullRequestReviews list reviews of a pull request
+func (p *PluginClient) ListPullRequestReviews(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestReviewList, error) {
+	reviewList := metav1alpha1.GitPullRequestReviewList{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "reviews")
+	if err != nil {
+		return reviewList, err
+	}
+	options := []base.OptionFunc{base.ResultOpts(&reviewList), base.ListOpts(listOption)}
+	if err = p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return reviewList, err
+	}
+	return reviewList, nil
+}
+
+// CreatePullRequestReview submit a review of a pull request
+func (p *PluginClient) CreatePullRequestReview(ctx context.Context, payload metav1alpha1.CreatePullRequestReviewPayload) (metav1alpha1.GitPullRequestReview, error) {
+	review := metav1alpha1.GitPullRequestReview{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "reviews")
+	if err != nil {
+		return review, err
+	}
+	options := []base.OptionFunc{base.BodyOpts(payload.CreatePullRequestReviewParam), base.ResultOpts(&review)}
+	if err = p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return review, err
+	}
+	return review, nil
+}
+
+// RequestPullRequestReviewers request reviewers of a pull request
+func (p *PluginClient) RequestPullRequestReviewers(ctx context.Context, payload metav1alpha1.RequestPullRequestReviewersPayload) (metav1alpha1.GitPullRequest, error) {
+	pr := metav1alpha1.GitPullRequest{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "reviewers")
+	if err != nil {
+		return pr, err
+	}
+	options := []base.OptionFunc{base.BodyOpts(payload.RequestPullRequestReviewersParam), base.ResultOpts(&pr)}
+	if err = p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return pr, err
+	}
+	return pr, nil
+}
+
+// GetPullRequestMergeability get the mergeability of a pull request
+func (p *PluginClient) GetPullRequestMergeability(ctx context.Context, option metav1alpha1.GitPullRequestOption) (metav1alpha1.GitPullRequestMergeability, error) {
+	mergeability := metav1alpha1.GitPullRequestMergeability{}
+	uri, err := pullRequestURI(option.GitRepo, option.Index, "mergeability")
+	if err != nil {
+		return mergeability, err
+	}
+	options := []base.OptionFunc{base.ResultOpts(&mergeability)}
+	if err = p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return mergeability, err
+	}
+	return mergeability, nil
+}
+
+// MergePullRequest merge a pull request
+func (p *PluginClient) MergePullRequest(ctx context.Context, payload metav1alpha1.MergePullRequestPayload) (metav1alpha1.GitPullRequest, error) {
+	pr := metav1alpha1.GitPullRequest{}
+	uri, err := pullRequestURI(payload.GitRepo, payload.Index, "merge")
+	if err != nil {
+		return pr, err
+	}
+	options := []base.OptionFunc{base.BodyOpts(payload.MergePullRequestParam), base.ResultOpts(&pr)}
+	if err = p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return pr, err
+	}
+	return pr, nil
+}
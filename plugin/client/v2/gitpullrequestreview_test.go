@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var pullRequestOption = metav1alpha1.GitPullRequestOption{
+	GitRepo: metav1alpha1.GitRepo{Project: "test-project", Repository: "repo"},
+	Index:   3,
+}
+
+// bodyContains responds only if the request body contains the substring
+func bodyContains(substr string, responder httpmock.Responder) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(string(body), substr) {
+			return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected body "+string(body)), nil
+		}
+		return responder(req)
+	}
+}
+
+var _ = Describe("Test ListPullRequestFiles", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequestFileList]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/files?itemsPerPage=3&page=2",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.ListPullRequestFiles(context.Background(), pullRequestOption, listOption)
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+
+	It("should return bad request when index is unknown", func() {
+		_, err := pluginClient.ListPullRequestFiles(context.Background(), metav1alpha1.GitPullRequestOption{GitRepo: pullRequestOption.GitRepo}, listOption)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Test GetPullRequestDiff", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequestDiff]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/diff?path=docs%2FREADME.md",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.GetPullRequestDiff(context.Background(), metav1alpha1.GitPullRequestDiffOption{
+			GitPullRequestOption: pullRequestOption,
+			Path:                 "docs/README.md",
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test ListPullRequestReviews", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequestReviewList]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/reviews?itemsPerPage=3&page=2",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.ListPullRequestReviews(context.Background(), pullRequestOption, listOption)
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test CreatePullRequestReview", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequestReview]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/reviews",
+			bodyContains(`"state":"approved"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.CreatePullRequestReview(context.Background(), metav1alpha1.CreatePullRequestReviewPayload{
+			GitRepo:                      pullRequestOption.GitRepo,
+			Index:                        pullRequestOption.Index,
+			CreatePullRequestReviewParam: metav1alpha1.CreatePullRequestReviewParam{State: metav1alpha1.PullRequestReviewApproved},
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test RequestPullRequestReviewers", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequest]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/reviewers",
+			bodyContains(`"name":"alice"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.RequestPullRequestReviewers(context.Background(), metav1alpha1.RequestPullRequestReviewersPayload{
+			GitRepo: pullRequestOption.GitRepo,
+			Index:   pullRequestOption.Index,
+			RequestPullRequestReviewersParam: metav1alpha1.RequestPullRequestReviewersParam{
+				Reviewers: []metav1alpha1.GitUserBaseInfo{{Name: "alice"}},
+			},
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test GetPullRequestMergeability", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequestMergeability]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/mergeability",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.GetPullRequestMergeability(context.Background(), pullRequestOption)
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test MergePullRequest", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.GitPullRequest]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/coderepositories/repo/pulls/3/merge",
+			bodyContains(`"strategy":"squash"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.MergePullRequest(context.Background(), metav1alpha1.MergePullRequestPayload{
+			GitRepo:               pullRequestOption.GitRepo,
+			Index:                 pullRequestOption.Index,
+			MergePullRequestParam: metav1alpha1.MergePullRequestParam{Strategy: metav1alpha1.MergeStrategySquash},
+		})
+		Expect(err).To(Succeed())
+		Expect(diff(got, *expected)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client/base"
+	"github.com/katanomi/pkg/plugin/path"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// issueURI returns the uri of the issue sub resource
+func issueURI(params metav1alpha1.IssueOptions, subResource string) (string, error) {
+	if params.Identity == "" {
+		return "", errors.NewBadRequest("project is empty string")
+	}
+	if params.IssueId == "" {
+		return "", errors.NewBadRequest("issue id is empty string")
+	}
+	uri := path.Format("projects/%s/issues/%s", params.Identity, params.IssueId)
+	if subResource != "" {
+		uri += "/" + subResource
+	}
+	return uri, nil
+}
+
+// CreateIssue create an issue in the project
+func (p *PluginClient) CreateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssuePayload) (*metav1alpha1.Issue, error) {
+	if params.Identity == "" {
+		return nil, errors.NewBadRequest("project is empty string")
+	}
+	issue := &metav1alpha1.Issue{}
+	options := []base.OptionFunc{base.BodyOpts(payload), base.ResultOpts(issue)}
+	uri := path.Format("projects/%s/issues", params.Identity)
+	if err := p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// UpdateIssue update fields of an issue
+func (p *PluginClient) UpdateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.UpdateIssuePayload) (*metav1alpha1.Issue, error) {
+	uri, err := issueURI(params, "")
+	if err != nil {
+		return nil, err
+	}
+	issue := &metav1alpha1.Issue{}
+	options := []base.OptionFunc{base.BodyOpts(payload), base.ResultOpts(issue)}
+	if err = p.Put(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// ListIssueComments list comments of an issue
+func (p *PluginClient) ListIssueComments(ctx context.Context, params metav1alpha1.IssueOptions, option metav1alpha1.ListOptions) (*metav1alpha1.IssueCommentList, error) {
+	uri, err := issueURI(params, "comments")
+	if err != nil {
+		return nil, err
+	}
+	list := &metav1alpha1.IssueCommentList{}
+	options := []base.OptionFunc{base.ResultOpts(list), base.ListOpts(option)}
+	if err = p.Get(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateIssueComment comment an issue
+func (p *PluginClient) CreateIssueComment(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssueCommentPayload) (*metav1alpha1.Comment, error) {
+	uri, err := issueURI(params, "comments")
+	if err != nil {
+		return nil, err
+	}
+	comment := &metav1alpha1.Comment{}
+	options := []base.OptionFunc{base.BodyOpts(payload), base.ResultOpts(comment)}
+	if err = p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// LinkIssue link an issue to another issue
+func (p *PluginClient) LinkIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.IssueLinkPayload) (*metav1alpha1.Issue, error) {
+	uri, err := issueURI(params, "links")
+	if err != nil {
+		return nil, err
+	}
+	issue := &metav1alpha1.Issue{}
+	options := []base.OptionFunc{base.BodyOpts(payload), base.ResultOpts(issue)}
+	if err = p.Post(ctx, p.ClassAddress, uri, options...); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+
+	"github.com/jarcoal/httpmock"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+)
+
+var issueParams = metav1alpha1.IssueOptions{Identity: "test-project", IssueId: "1"}
+
+var _ = Describe("Test CreateIssue", func() {
+	It("should send the payload and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.Issue]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/issues",
+			bodyContains(`"subject":"pipeline failed"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.CreateIssue(context.Background(), issueParams,
+			metav1alpha1.CreateIssuePayload{Subject: "pipeline failed", Type: "bug"})
+		Expect(err).To(Succeed())
+		Expect(diff(*got, *expected)).To(BeEmpty())
+	})
+
+	It("should return bad request when project is empty", func() {
+		_, err := pluginClient.CreateIssue(context.Background(), metav1alpha1.IssueOptions{}, metav1alpha1.CreateIssuePayload{})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Test UpdateIssue", func() {
+	It("should send the payload and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.Issue]()
+		httpmock.RegisterResponder(
+			"PUT",
+			"https://example.com/projects/test-project/issues/1",
+			bodyContains(`"status":"closed"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.UpdateIssue(context.Background(), issueParams,
+			metav1alpha1.UpdateIssuePayload{Status: pointer.String("closed")})
+		Expect(err).To(Succeed())
+		Expect(diff(*got, *expected)).To(BeEmpty())
+	})
+
+	It("should return the error of the plugin", func() {
+		httpmock.RegisterResponder(
+			"PUT",
+			"https://example.com/projects/test-project/issues/2",
+			httpmock.NewStringResponder(404, `{"message":"issue not found"}`),
+		)
+
+		_, err := pluginClient.UpdateIssue(context.Background(), metav1alpha1.IssueOptions{Identity: "test-project", IssueId: "2"},
+			metav1alpha1.UpdateIssuePayload{Status: pointer.String("closed")})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should return bad request when issue id is empty", func() {
+		_, err := pluginClient.UpdateIssue(context.Background(), metav1alpha1.IssueOptions{Identity: "test-project"}, metav1alpha1.UpdateIssuePayload{})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Test ListIssueComments", func() {
+	It("should generate the correct url and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.IssueCommentList]()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://example.com/projects/test-project/issues/1/comments",
+			httpmock.NewJsonResponderOrPanic(200, expected),
+		)
+
+		got, err := pluginClient.ListIssueComments(context.Background(), issueParams, metav1alpha1.ListOptions{})
+		Expect(err).To(Succeed())
+		Expect(diff(*got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test CreateIssueComment", func() {
+	It("should send the payload and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.Comment]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/issues/1/comments",
+			bodyContains(`"detail":"build 3 failed"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.CreateIssueComment(context.Background(), issueParams,
+			metav1alpha1.CreateIssueCommentPayload{Detail: "build 3 failed"})
+		Expect(err).To(Succeed())
+		Expect(diff(*got, *expected)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test LinkIssue", func() {
+	It("should send the payload and got expected response", func() {
+		expected := fakeStruct[metav1alpha1.Issue]()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://example.com/projects/test-project/issues/1/links",
+			bodyContains(`"type":"blocks"`, httpmock.NewJsonResponderOrPanic(200, expected)),
+		)
+
+		got, err := pluginClient.LinkIssue(context.Background(), issueParams,
+			metav1alpha1.IssueLinkPayload{IssueId: "2", Type: metav1alpha1.IssueLinkBlocks})
+		Expect(err).To(Succeed())
+		Expect(diff(*got, *expected)).To(BeEmpty())
+	})
+})
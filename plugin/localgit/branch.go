@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	branchPrefix = "refs/heads/"
+	tagPrefix    = "refs/tags/"
+)
+
+var branchResource = metav1alpha1.GroupVersion.WithResource("gitbranches").GroupResource()
+
+// forEachRef lists the references matching the pattern sorted by name,
+// the values of the fields are returned in order for each reference.
+func (r *repository) forEachRef(ctx context.Context, pattern string, fields ...string) ([][]string, error) {
+	// fields are separated by NUL and references by NUL and a new line,
+	// contents of tags could contain new lines but never NUL.
+	format := strings.Join(fields, "%00") + "%00"
+	out, err := r.git(ctx, "for-each-ref", "--sort=refname", "--format="+format, pattern)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([][]string, 0)
+	for _, record := range strings.Split(out, "\x00\n") {
+		values := strings.Split(record, "\x00")
+		if len(values) == len(fields) {
+			refs = append(refs, values)
+		}
+	}
+	return refs, nil
+}
+
+// defaultBranch returns the branch HEAD points to, the branch may not exist yet
+func (r *repository) defaultBranch(ctx context.Context) string {
+	out, err := r.git(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// validateBranchName returns a bad request error if the name is not a valid branch name
+func (r *repository) validateBranchName(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.NewBadRequest("branch is empty")
+	}
+	if _, err := r.git(ctx, "check-ref-format", branchPrefix+name); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("invalid branch name %q", name))
+	}
+	return nil
+}
+
+// branchFields are the fields of branches listed by forEachRef
+var branchFields = []string{"%(refname:lstrip=2)", "%(objectname)", "%(committerdate:iso-strict)"}
+
+func (r *repository) newBranch(values []string, defaultBranch string) metav1alpha1.GitBranch {
+	name, sha := values[0], values[1]
+	return metav1alpha1.GitBranch{
+		TypeMeta:   typeMeta(metav1alpha1.GitBranchGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: metav1alpha1.GitBranchSpec{
+			GitBranchBaseInfo: metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: name},
+			Protected:         pointer.Bool(false),
+			Default:           pointer.Bool(name == defaultBranch),
+			Commit: metav1alpha1.GitCommitInfo{
+				SHA:       pointer.String(sha),
+				CreatedAt: parseTime(values[2]),
+			},
+		},
+	}
+}
+
+// ListGitBranch lists branches of the repository, branches are filtered by the keyword if provided
+func (g *GitStore) ListGitBranch(ctx context.Context, branchOption metav1alpha1.GitBranchOption, option metav1alpha1.ListOptions) (metav1alpha1.GitBranchList, error) {
+	r, err := g.repository(branchOption.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitBranchList{}, err
+	}
+	refs, err := r.forEachRef(ctx, branchPrefix, branchFields...)
+	if err != nil {
+		return metav1alpha1.GitBranchList{}, err
+	}
+
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitBranchList{}, err
+	}
+
+	head := r.defaultBranch(ctx)
+	branches := make([]metav1alpha1.GitBranch, 0, len(refs))
+	for _, values := range refs {
+		if strings.Contains(values[0], branchOption.Keyword) {
+			branches = append(branches, withProtection(r.newBranch(values, head), meta))
+		}
+	}
+	items, listMeta := page(branches, option)
+	return metav1alpha1.GitBranchList{
+		TypeMeta: typeMeta(metav1alpha1.GitBranchListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// GetGitBranch gets a branch of the repository
+func (g *GitStore) GetGitBranch(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranch, error) {
+	r, err := g.repository(repoOption)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	gitBranch, err := r.getBranch(ctx, branch)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	return withProtection(gitBranch, meta), nil
+}
+
+// withProtection returns the branch with the settings of its protection rules
+func withProtection(branch metav1alpha1.GitBranch, meta *repositoryMeta) metav1alpha1.GitBranch {
+	rules, ok := meta.BranchProtections[branch.Spec.Name]
+	if !ok {
+		return branch
+	}
+	branch.Spec.Protected = pointer.Bool(true)
+	branch.Spec.DevelopersCanPush = rules.DevelopersCanPush
+	branch.Spec.DevelopersCanMerge = rules.DevelopersCanMerge
+	return branch
+}
+
+func (r *repository) getBranch(ctx context.Context, branch string) (metav1alpha1.GitBranch, error) {
+	if err := r.validateBranchName(ctx, branch); err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	refs, err := r.forEachRef(ctx, branchPrefix+branch, branchFields...)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	for _, values := range refs {
+		// patterns also match references under the branch name
+		if values[0] == branch {
+			return r.newBranch(values, r.defaultBranch(ctx)), nil
+		}
+	}
+	return metav1alpha1.GitBranch{}, errors.NewNotFound(branchResource, branch)
+}
+
+// CreateGitBranch creates a branch from the ref, the default branch is used if the ref is empty
+func (g *GitStore) CreateGitBranch(ctx context.Context, payload metav1alpha1.CreateBranchPayload) (metav1alpha1.GitBranch, error) {
+	r, err := g.repository(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	if err = r.validateBranchName(ctx, payload.Branch); err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	ref := payload.Ref
+	if ref == "" {
+		ref = r.defaultBranch(ctx)
+	}
+	sha, err := r.resolve(ctx, ref)
+	if err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	if err = r.updateBranch(ctx, payload.Branch, sha, ""); err != nil {
+		return metav1alpha1.GitBranch{}, err
+	}
+	return r.getBranch(ctx, payload.Branch)
+}
+
+// updateBranch moves the branch from old to sha atomically,
+// the branch must not exist if old is empty.
+func (r *repository) updateBranch(ctx context.Context, branch, sha, old string) error {
+	if _, err := r.git(ctx, "update-ref", branchPrefix+branch, sha, old); err != nil {
+		if old == "" {
+			return errors.NewAlreadyExists(branchResource, branch)
+		}
+		return errors.NewConflict(branchResource, branch, fmt.Errorf("branch was updated concurrently: %w", err))
+	}
+	return nil
+}
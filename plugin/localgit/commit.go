@@ -0,0 +1,429 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// actions of files supported when creating commits
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+	actionMove   = "move"
+)
+
+// encodings of file contents supported when creating commits
+const (
+	encodingText   = "text"
+	encodingBase64 = "base64"
+)
+
+const (
+	// defaultFileMode is the mode of created files
+	defaultFileMode = "100644"
+	// zeroSHA removes an entry when updating the index
+	zeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// commitFormat prints the fields of commits separated by NUL,
+// commits are also separated by NUL when logging with -z.
+const commitFormat = "%H%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%B"
+
+const commitFields = 8
+
+// resolve returns the sha of the commit a revision points to
+func (r *repository) resolve(ctx context.Context, rev string) (string, error) {
+	out, err := r.git(ctx, "rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{commit}")
+	if err != nil || rev == "" {
+		return "", errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitcommits").GroupResource(), rev)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// resolveIfExists returns the sha of the commit a revision points to,
+// an empty sha is returned if the revision does not exist.
+func (r *repository) resolveIfExists(ctx context.Context, rev string) (string, error) {
+	sha, err := r.resolve(ctx, rev)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	return sha, err
+}
+
+// log returns commits logged with the arguments
+func (r *repository) log(ctx context.Context, args ...string) ([]metav1alpha1.GitCommit, error) {
+	out, err := r.git(ctx, append([]string{"log", "-z", "--format=" + commitFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	values := strings.Split(out, "\x00")
+	commits := make([]metav1alpha1.GitCommit, 0, len(values)/commitFields)
+	for i := 0; i+commitFields <= len(values); i += commitFields {
+		commits = append(commits, r.newCommit(values[i:i+commitFields]))
+	}
+	return commits, nil
+}
+
+func (r *repository) newCommit(values []string) metav1alpha1.GitCommit {
+	sha := values[0]
+	return metav1alpha1.GitCommit{
+		TypeMeta:   typeMeta(metav1alpha1.GitCommitGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: sha},
+		Spec: metav1alpha1.GitCommitSpec{
+			GitCommitInfo: metav1alpha1.GitCommitInfo{
+				SHA:       pointer.String(sha),
+				CreatedAt: parseTime(values[6]),
+			},
+			Author:    &metav1alpha1.GitUserBaseInfo{Name: values[1], Email: values[2]},
+			Committer: &metav1alpha1.GitUserBaseInfo{Name: values[4], Email: values[5]},
+			Message:   pointer.String(strings.TrimSuffix(values[7], "\n")),
+		},
+	}
+}
+
+func (r *repository) getCommit(ctx context.Context, rev string) (metav1alpha1.GitCommit, error) {
+	sha, err := r.resolve(ctx, rev)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	commits, err := r.log(ctx, "-1", sha)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	if len(commits) == 0 {
+		return metav1alpha1.GitCommit{}, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitcommits").GroupResource(), rev)
+	}
+	return commits[0], nil
+}
+
+// GetGitCommit gets a commit by its sha or any revision pointing to it
+func (g *GitStore) GetGitCommit(ctx context.Context, option metav1alpha1.GitCommitOption) (metav1alpha1.GitCommit, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	if option.SHA == nil || *option.SHA == "" {
+		return metav1alpha1.GitCommit{}, errors.NewBadRequest("commit sha is empty")
+	}
+	return r.getCommit(ctx, *option.SHA)
+}
+
+// ListGitCommit lists commits reachable from the ref, the default branch is used if the ref is empty
+func (g *GitStore) ListGitCommit(ctx context.Context, option metav1alpha1.GitCommitListOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitCommitList, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitCommitList{}, err
+	}
+	list := metav1alpha1.GitCommitList{TypeMeta: typeMeta(metav1alpha1.GitCommitListGVK)}
+
+	ref := option.Ref
+	if ref == "" {
+		ref = r.defaultBranch(ctx)
+	}
+	sha, err := r.resolveIfExists(ctx, ref)
+	if err != nil {
+		return list, err
+	}
+	if sha == "" {
+		if option.Ref != "" {
+			return list, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitcommits").GroupResource(), option.Ref)
+		}
+		// the default branch of an empty repository has no commits
+		list.Items = []metav1alpha1.GitCommit{}
+		return list, nil
+	}
+
+	args := []string{}
+	if option.Since != nil {
+		args = append(args, "--since="+option.Since.Format(time.RFC3339))
+	}
+	if option.Until != nil {
+		args = append(args, "--until="+option.Until.Format(time.RFC3339))
+	}
+	commits, err := r.log(ctx, append(args, sha)...)
+	if err != nil {
+		return list, err
+	}
+	list.Items, list.ListMeta = page(commits, listOption)
+	return list, nil
+}
+
+// CreateGitCommit creates a commit applying the actions on the branch.
+// The branch is created from the start branch, sha or tag if it does not exist,
+// the default branch is used if none of them is provided.
+// A pull request from the branch to the start branch or the default branch
+// is opened if it is requested.
+func (g *GitStore) CreateGitCommit(ctx context.Context, option coderepositoryv1alpha1.CreateGitCommitOption) (metav1alpha1.GitCommit, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	spec := option.Spec
+	if errs := option.GitCreateCommit.Validate(ctx); len(errs) > 0 {
+		return metav1alpha1.GitCommit{}, errors.NewBadRequest(errs.ToAggregate().Error())
+	}
+
+	commit, err := g.createCommit(ctx, r, spec)
+	if err != nil || !spec.CreatePullRequest {
+		return commit, err
+	}
+
+	target := spec.StartBranch
+	if target == "" {
+		target = r.defaultBranch(ctx)
+	}
+	_, err = g.createPullRequest(ctx, r, metav1alpha1.CreatePullRequestPayload{
+		Source:             metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: spec.Branch},
+		Target:             metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: target},
+		Title:              strings.SplitN(spec.Message, "\n", 2)[0],
+		RemoveSourceBranch: spec.RemoveSourceBranch,
+	})
+	return commit, err
+}
+
+// createCommit applies the actions on the tree of the parent commit in a temporary index
+// and commits the tree on the branch.
+func (g *GitStore) createCommit(ctx context.Context, r *repository, spec coderepositoryv1alpha1.GitCreateCommitSpec) (metav1alpha1.GitCommit, error) {
+	if err := r.validateBranchName(ctx, spec.Branch); err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	old, err := r.resolveIfExists(ctx, branchPrefix+spec.Branch)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+
+	parent := old
+	if old == "" {
+		start := ""
+		switch {
+		case spec.StartBranch != "":
+			start = branchPrefix + spec.StartBranch
+		case spec.StartTag != "":
+			start = tagPrefix + spec.StartTag
+		case spec.StartSHA != "":
+			start = spec.StartSHA
+		}
+		if start != "" {
+			parent, err = r.resolve(ctx, start)
+		} else {
+			// commits to an empty repository are root commits
+			parent, err = r.resolveIfExists(ctx, branchPrefix+r.defaultBranch(ctx))
+		}
+		if err != nil {
+			return metav1alpha1.GitCommit{}, err
+		}
+	}
+
+	indexDir, err := os.MkdirTemp(r.dir, "index-")
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	defer os.RemoveAll(indexDir)
+	index := &index{repository: r, env: []string{"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index")}}
+
+	tree := "--empty"
+	if parent != "" {
+		tree = parent
+	}
+	if _, err = index.git(ctx, nil, "read-tree", tree); err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	for i, action := range spec.Actions {
+		if err = index.apply(ctx, action); err != nil {
+			return metav1alpha1.GitCommit{}, fmt.Errorf("action %d: %w", i, err)
+		}
+	}
+	out, err := index.git(ctx, nil, "write-tree")
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+
+	author := g.committer
+	if spec.Author != nil && spec.Author.Name != "" {
+		author = *spec.Author
+	}
+	args := []string{"commit-tree", strings.TrimSpace(out), "-F", "-"}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	env := g.userEnv(author)
+	out, err = runGit(ctx, r.dir, env, strings.NewReader(spec.Message), args...)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	sha := strings.TrimSpace(out)
+	if err = r.updateBranch(ctx, spec.Branch, sha, old); err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	return r.getCommit(ctx, sha)
+}
+
+// userEnv returns the environment variables of the author and committer of commits
+func (g *GitStore) userEnv(author metav1alpha1.GitUserBaseInfo) []string {
+	now := g.now().Format(time.RFC3339)
+	return append([]string{
+		"GIT_AUTHOR_NAME=" + author.Name,
+		"GIT_AUTHOR_EMAIL=" + author.Email,
+		"GIT_AUTHOR_DATE=" + now,
+	}, g.committerEnv()...)
+}
+
+// committerEnv returns the environment variables of the committer of commits,
+// authors of existing commits are kept when they are replayed with it.
+func (g *GitStore) committerEnv() []string {
+	return []string{
+		"GIT_COMMITTER_NAME=" + g.committer.Name,
+		"GIT_COMMITTER_EMAIL=" + g.committer.Email,
+		"GIT_COMMITTER_DATE=" + g.now().Format(time.RFC3339),
+	}
+}
+
+// index is a temporary index of a repository used to build trees
+type index struct {
+	*repository
+	env []string
+}
+
+func (i *index) git(ctx context.Context, stdin io.Reader, args ...string) (string, error) {
+	return runGit(ctx, i.dir, i.env, stdin, args...)
+}
+
+// entry returns the mode and sha of a file in the index, empty values are returned if it does not exist
+func (i *index) entry(ctx context.Context, file string) (mode, sha string, err error) {
+	out, err := i.git(ctx, nil, "ls-files", "-z", "--stage", "--", file)
+	if err != nil {
+		return "", "", err
+	}
+	for _, line := range strings.Split(out, "\x00") {
+		// <mode> SP <sha> SP <stage> TAB <path>
+		info, name, found := strings.Cut(line, "\t")
+		fields := strings.Fields(info)
+		if found && name == file && len(fields) == 3 {
+			return fields[0], fields[1], nil
+		}
+	}
+	return "", "", nil
+}
+
+// set adds or replaces a file in the index, the file is removed if the sha is zeroSHA
+func (i *index) set(ctx context.Context, file, mode, sha string) error {
+	_, err := i.git(ctx, strings.NewReader(fmt.Sprintf("%s %s\t%s\x00", mode, sha, file)), "update-index", "-z", "--add", "--index-info")
+	return err
+}
+
+// hash writes the content of an action as a blob and returns its sha
+func (i *index) hash(ctx context.Context, action coderepositoryv1alpha1.CreateCommitAction) (string, error) {
+	content := []byte(action.Content)
+	switch action.Encoding {
+	case "", encodingText:
+	case encodingBase64:
+		decoded, err := base64.StdEncoding.DecodeString(action.Content)
+		if err != nil {
+			return "", errors.NewBadRequest(fmt.Sprintf("invalid base64 content of %q: %s", action.FilePath, err))
+		}
+		content = decoded
+	default:
+		return "", errors.NewBadRequest(fmt.Sprintf("unsupported encoding %q", action.Encoding))
+	}
+	out, err := i.git(ctx, bytes.NewReader(content), "hash-object", "-w", "--stdin")
+	return strings.TrimSpace(out), err
+}
+
+// apply applies a file action on the index
+func (i *index) apply(ctx context.Context, action coderepositoryv1alpha1.CreateCommitAction) error {
+	file, err := cleanFilePath(action.FilePath)
+	if err != nil {
+		return err
+	}
+	mode, sha, err := i.entry(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	switch action.Action {
+	case actionCreate, actionUpdate:
+		if action.Action == actionCreate && sha != "" {
+			return errors.NewBadRequest(fmt.Sprintf("file %q already exists", file))
+		}
+		if action.Action == actionUpdate && sha == "" {
+			return errors.NewBadRequest(fmt.Sprintf("file %q does not exist", file))
+		}
+		if mode == "" {
+			mode = defaultFileMode
+		}
+		if sha, err = i.hash(ctx, action); err != nil {
+			return err
+		}
+		return i.set(ctx, file, mode, sha)
+	case actionDelete:
+		if sha == "" {
+			return errors.NewBadRequest(fmt.Sprintf("file %q does not exist", file))
+		}
+		return i.set(ctx, file, "0", zeroSHA)
+	case actionMove:
+		if sha != "" {
+			return errors.NewBadRequest(fmt.Sprintf("file %q already exists", file))
+		}
+		previous, err := cleanFilePath(action.PreviousPath)
+		if err != nil {
+			return err
+		}
+		if mode, sha, err = i.entry(ctx, previous); err != nil {
+			return err
+		}
+		if sha == "" {
+			return errors.NewBadRequest(fmt.Sprintf("file %q does not exist", previous))
+		}
+		if action.Content != "" {
+			if sha, err = i.hash(ctx, action); err != nil {
+				return err
+			}
+		}
+		if err = i.set(ctx, previous, "0", zeroSHA); err != nil {
+			return err
+		}
+		return i.set(ctx, file, mode, sha)
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("unsupported action %q", action.Action))
+	}
+}
+
+// cleanFilePath returns the path of a file relative to the root of the repository
+func cleanFilePath(file string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(file, "/"))
+	if file == "" || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.Contains(cleaned, "\x00") {
+		return "", errors.NewBadRequest(fmt.Sprintf("invalid file path %q", file))
+	}
+	return cleaned, nil
+}
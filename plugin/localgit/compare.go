@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxComparedCommits is the maximum number of commits returned by comparisons
+const maxComparedCommits = 250
+
+// count returns the number of commits in the revision range
+func (r *repository) count(ctx context.Context, revisionRange string) (int, error) {
+	out, err := r.git(ctx, "rev-list", "--count", revisionRange)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(out))
+}
+
+// CompareGitCommits compares the head to the base.
+// Commits are those reachable from the head but not from the base,
+// and files are changed from their merge base to the head like pull requests.
+func (g *GitStore) CompareGitCommits(ctx context.Context, option metav1alpha1.GitCommitCompareOption) (metav1alpha1.GitCommitComparison, error) {
+	if option.Base == "" || option.Head == "" {
+		return metav1alpha1.GitCommitComparison{}, errors.NewBadRequest("base and head are required")
+	}
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	spec := metav1alpha1.GitCommitComparisonSpec{GitRepo: r.GitRepo, Base: option.Base, Head: option.Head}
+	if spec.BaseSHA, err = r.resolve(ctx, option.Base); err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	if spec.HeadSHA, err = r.resolve(ctx, option.Head); err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	if spec.AheadBy, err = r.count(ctx, spec.BaseSHA+".."+spec.HeadSHA); err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	if spec.BehindBy, err = r.count(ctx, spec.HeadSHA+".."+spec.BaseSHA); err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	spec.Status = metav1alpha1.ComparisonStatusOf(spec.AheadBy, spec.BehindBy)
+
+	// histories are unrelated without a merge base
+	base := r.mergeBase(ctx, spec.BaseSHA, spec.HeadSHA)
+	if base != emptyTree {
+		spec.MergeBaseSHA = base
+	}
+	commits, err := r.log(ctx, "--reverse", spec.BaseSHA+".."+spec.HeadSHA)
+	if err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	if len(commits) > maxComparedCommits {
+		commits = commits[:maxComparedCommits]
+	}
+	spec.Commits = commits
+	files, err := r.diffFiles(ctx, base, spec.HeadSHA)
+	if err != nil {
+		return metav1alpha1.GitCommitComparison{}, err
+	}
+	for _, file := range files {
+		spec.Files = append(spec.Files, file.Spec)
+	}
+
+	return metav1alpha1.GitCommitComparison{
+		TypeMeta:   typeMeta(metav1alpha1.GitCommitComparisonGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: spec.BaseSHA + "..." + spec.HeadSHA},
+		Spec:       spec,
+	}, nil
+}
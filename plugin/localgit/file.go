@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// treeEntry is an entry of a tree listed by ls-tree
+type treeEntry struct {
+	mode       string
+	objectType string
+	sha        string
+	size       string
+	path       string
+}
+
+// lsTree lists the entries of a tree, sizes are only listed for blobs
+func (r *repository) lsTree(ctx context.Context, args ...string) ([]treeEntry, error) {
+	out, err := r.git(ctx, append([]string{"ls-tree", "-z", "--long"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]treeEntry, 0)
+	for _, line := range strings.Split(out, "\x00") {
+		// <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		info, name, found := strings.Cut(line, "\t")
+		fields := strings.Fields(info)
+		if !found || len(fields) != 4 {
+			continue
+		}
+		entries = append(entries, treeEntry{mode: fields[0], objectType: fields[1], sha: fields[2], size: fields[3], path: name})
+	}
+	return entries, nil
+}
+
+// objectType returns the type of an object, an empty type is returned if it does not exist
+func (r *repository) objectType(ctx context.Context, object string) string {
+	out, err := r.git(ctx, "cat-file", "-t", "--end-of-options", object)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// GetGitRepoFile gets a file at the ref, the default branch is used if the ref is empty.
+// The content is returned encoded in base64.
+func (g *GitStore) GetGitRepoFile(ctx context.Context, option metav1alpha1.GitRepoFileOption) (metav1alpha1.GitRepoFile, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitRepoFile{}, err
+	}
+	file, err := cleanFilePath(option.Path)
+	if err != nil {
+		return metav1alpha1.GitRepoFile{}, err
+	}
+	ref := option.Ref
+	if ref == "" {
+		ref = r.defaultBranch(ctx)
+	}
+	sha, err := r.resolve(ctx, ref)
+	if err != nil {
+		return metav1alpha1.GitRepoFile{}, err
+	}
+
+	entries, err := r.lsTree(ctx, "--full-tree", sha, "--", file)
+	if err != nil {
+		return metav1alpha1.GitRepoFile{}, err
+	}
+	if len(entries) == 0 || entries[0].path != file {
+		return metav1alpha1.GitRepoFile{}, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitrepofiles").GroupResource(), file)
+	}
+	entry := entries[0]
+	if entry.objectType != "blob" {
+		return metav1alpha1.GitRepoFile{}, errors.NewBadRequest(fmt.Sprintf("%q is not a file", file))
+	}
+	content, err := r.git(ctx, "cat-file", "blob", entry.sha)
+	if err != nil {
+		return metav1alpha1.GitRepoFile{}, err
+	}
+	size, _ := strconv.ParseInt(entry.size, 10, 64)
+
+	return metav1alpha1.GitRepoFile{
+		TypeMeta:   typeMeta(metav1alpha1.GitRepoFileGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: file},
+		Spec: metav1alpha1.GitRepoFileSpec{
+			GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: pointer.String(sha)},
+			FileName:           path.Base(file),
+			FilePath:           file,
+			Size:               size,
+			Encoding:           pointer.String(encodingBase64),
+			Content:            []byte(base64.StdEncoding.EncodeToString([]byte(content))),
+			NodeSHA:            entry.sha,
+		},
+	}, nil
+}
+
+// CreateGitRepoFile commits a new file on the branch, the content of the payload is encoded in base64.
+// The branch is created from the start branch or the default branch if it does not exist.
+func (g *GitStore) CreateGitRepoFile(ctx context.Context, payload metav1alpha1.CreateRepoFilePayload) (metav1alpha1.GitCommit, error) {
+	r, err := g.repository(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitCommit{}, err
+	}
+	if payload.Message == "" {
+		return metav1alpha1.GitCommit{}, errors.NewBadRequest("commit message is required")
+	}
+	return g.createCommit(ctx, r, coderepositoryv1alpha1.GitCreateCommitSpec{
+		Branch:      payload.Branch,
+		StartBranch: payload.StartBranch,
+		Message:     payload.Message,
+		Author:      payload.Author,
+		Actions: []coderepositoryv1alpha1.CreateCommitAction{{
+			Action:   actionCreate,
+			FilePath: payload.FilePath,
+			Encoding: encodingBase64,
+			Content:  string(payload.Content),
+		}},
+	})
+}
+
+// GetGitRepositoryFileTree lists the entries under the path of the tree sha,
+// which could be any revision or tree, the default branch is used if it is empty.
+func (g *GitStore) GetGitRepositoryFileTree(ctx context.Context, option metav1alpha1.GitRepoFileTreeOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitRepositoryFileTree, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitRepositoryFileTree{}, err
+	}
+	treeSha := option.TreeSha
+	if treeSha == "" {
+		treeSha = r.defaultBranch(ctx)
+	}
+	dir := strings.Trim(option.Path, "/")
+	if dir != "" {
+		if dir, err = cleanFilePath(dir); err != nil {
+			return metav1alpha1.GitRepositoryFileTree{}, err
+		}
+	}
+
+	tree := treeSha + ":" + dir
+	switch r.objectType(ctx, tree) {
+	case "tree":
+	case "":
+		return metav1alpha1.GitRepositoryFileTree{}, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitrepositoryfiletrees").GroupResource(), tree)
+	default:
+		return metav1alpha1.GitRepositoryFileTree{}, errors.NewBadRequest(fmt.Sprintf("%q is not a directory", dir))
+	}
+
+	args := []string{}
+	if option.Recursive {
+		args = append(args, "-r", "-t")
+	}
+	entries, err := r.lsTree(ctx, append(args, "--end-of-options", tree)...)
+	if err != nil {
+		return metav1alpha1.GitRepositoryFileTree{}, err
+	}
+	nodes := make([]metav1alpha1.GitRepositoryFileTreeNode, 0, len(entries))
+	for _, entry := range entries {
+		nodes = append(nodes, metav1alpha1.GitRepositoryFileTreeNode{
+			Sha:  entry.sha,
+			Name: path.Base(entry.path),
+			Path: path.Join(dir, entry.path),
+			Type: metav1alpha1.GitRepositoryFileTreeNodeType(entry.objectType),
+			Mode: entry.mode,
+		})
+	}
+	nodes, _ = page(nodes, listOption)
+	return metav1alpha1.GitRepositoryFileTree{
+		TypeMeta: typeMeta(metav1alpha1.GitRepositoryFileTreeGVK),
+		Spec:     metav1alpha1.GitRepositoryFileTreeSpec{Tree: nodes},
+	}, nil
+}
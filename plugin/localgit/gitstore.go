@@ -0,0 +1,340 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/common"
+	"github.com/katanomi/pkg/plugin/types"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	_ types.GitPluginClientSet   = &GitStore{}
+	_ types.GitRepositoryCreator = &GitStore{}
+	_ types.GitRepositoryDeleter = &GitStore{}
+
+	_ types.GitPullRequestFileLister         = &GitStore{}
+	_ types.GitPullRequestDiffGetter         = &GitStore{}
+	_ types.GitPullRequestReviewLister       = &GitStore{}
+	_ types.GitPullRequestReviewCreator      = &GitStore{}
+	_ types.GitPullRequestReviewerRequester  = &GitStore{}
+	_ types.GitPullRequestMergeabilityGetter = &GitStore{}
+	_ types.GitPullRequestMerger             = &GitStore{}
+
+	_ types.GitBranchDeleter           = &GitStore{}
+	_ types.GitBranchProtectionGetter  = &GitStore{}
+	_ types.GitBranchProtectionUpdater = &GitStore{}
+	_ types.GitBranchProtectionDeleter = &GitStore{}
+	_ types.GitCommitComparer          = &GitStore{}
+)
+
+const (
+	// repositorySuffix is the suffix of bare repository directories
+	repositorySuffix = ".git"
+	// metaSuffix is the suffix of the metadata files of repositories
+	metaSuffix = ".json"
+	// defaultBranch is the initial branch of created repositories
+	defaultBranch = "main"
+)
+
+// gitEnv isolates git commands from the configuration of the host
+var gitEnv = []string{
+	"GIT_CONFIG_NOSYSTEM=1",
+	"GIT_CONFIG_GLOBAL=" + os.DevNull,
+	"GIT_TERMINAL_PROMPT=0",
+	"GIT_LITERAL_PATHSPECS=1",
+}
+
+// GitStore is a git plugin implementation on bare repositories in a local directory.
+// The repository of a project is stored as <root>/<project>/<repository>.git,
+// pull requests, commit statuses and comments which are not stored by git
+// are kept in <root>/<project>/<repository>.json next to it.
+// Branches are updated with compare and swap, so concurrent writers never lose commits,
+// and updates of the metadata are serialized in the same process.
+// It implements client.Interface and can be registered as a plugin.
+type GitStore struct {
+	path string
+	root string
+
+	// committer is the author and committer of commits when none is provided,
+	// it is also the author of statuses, comments and pull requests.
+	committer metav1alpha1.GitUserBaseInfo
+	// lock serializes updates of the metadata of repositories
+	lock sync.Mutex
+	// now returns the current time, used for timestamps of commits and metadata
+	now func() time.Time
+}
+
+// NewGitStore returns a git plugin served under path storing repositories in root
+func NewGitStore(path, root string) *GitStore {
+	return &GitStore{
+		path:      path,
+		root:      root,
+		committer: metav1alpha1.GitUserBaseInfo{Name: "katanomi", Email: "katanomi@localhost"},
+		now:       time.Now,
+	}
+}
+
+// WithCommitter sets the default author and committer
+func (g *GitStore) WithCommitter(user metav1alpha1.GitUserBaseInfo) *GitStore {
+	g.committer = user
+	return g
+}
+
+// Path returns the path of the plugin
+func (g *GitStore) Path() string {
+	return g.path
+}
+
+// Setup checks the git binary and creates the root directory
+func (g *GitStore) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return err
+	}
+	return os.MkdirAll(g.root, 0o755)
+}
+
+// repository is a repository resolved on the local disk
+type repository struct {
+	metav1alpha1.GitRepo
+	// dir is the directory of the bare repository
+	dir string
+	// meta is the metadata file of the repository
+	meta string
+}
+
+// repositoryPath validates the names of the repository and returns where it is stored
+func (g *GitStore) repositoryPath(repo metav1alpha1.GitRepo) (*repository, error) {
+	if err := validateProject(repo.Project); err != nil {
+		return nil, err
+	}
+	if err := validateSegment("repository", repo.Repository); err != nil {
+		return nil, err
+	}
+	base := filepath.Join(g.root, filepath.FromSlash(repo.Project), repo.Repository)
+	return &repository{
+		GitRepo: repo,
+		dir:     base + repositorySuffix,
+		meta:    base + metaSuffix,
+	}, nil
+}
+
+// repository returns an existing repository
+func (g *GitStore) repository(repo metav1alpha1.GitRepo) (*repository, error) {
+	r, err := g.repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(r.dir); err != nil || !info.IsDir() {
+		return nil, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitrepositories").GroupResource(), repo.String())
+	}
+	return r, nil
+}
+
+func validateProject(project string) error {
+	if project == "" {
+		return errors.NewBadRequest("project is empty")
+	}
+	for _, segment := range strings.Split(project, "/") {
+		if err := validateSegment("project", segment); err != nil {
+			return err
+		}
+		// projects could not be nested in repositories
+		if strings.HasSuffix(segment, repositorySuffix) {
+			return errors.NewBadRequest(fmt.Sprintf("invalid project name %q", project))
+		}
+	}
+	return nil
+}
+
+func validateSegment(kind, name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+		return errors.NewBadRequest(fmt.Sprintf("invalid %s name %q", kind, name))
+	}
+	return nil
+}
+
+// gitError is returned when a git command fails
+type gitError struct {
+	args     []string
+	stderr   string
+	exitCode int
+}
+
+func (e *gitError) Error() string {
+	return fmt.Sprintf("git %s failed with exit code %d: %s", strings.Join(e.args, " "), e.exitCode, e.stderr)
+}
+
+// exitCode returns the exit code of a failed git command, -1 is returned for other errors
+func exitCode(err error) int {
+	gitErr := &gitError{}
+	if goerrors.As(err, &gitErr) {
+		return gitErr.exitCode
+	}
+	return -1
+}
+
+// runGit runs a git command and returns its standard output.
+// The command runs in the git directory if it is not empty.
+func runGit(ctx context.Context, gitDir string, env []string, stdin io.Reader, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(append(os.Environ(), gitEnv...), env...)
+	if gitDir != "" {
+		cmd.Dir = gitDir
+		cmd.Env = append(cmd.Env, "GIT_DIR="+gitDir)
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		exitErr := &exec.ExitError{}
+		if !goerrors.As(err, &exitErr) {
+			return "", err
+		}
+		return "", &gitError{args: args, stderr: strings.TrimSpace(stderr.String()), exitCode: exitErr.ExitCode()}
+	}
+	return stdout.String(), nil
+}
+
+// git runs a git command in the repository and returns its standard output
+func (r *repository) git(ctx context.Context, args ...string) (string, error) {
+	return runGit(ctx, r.dir, nil, nil, args...)
+}
+
+// repositoryMeta is the metadata of a repository which is not stored by git
+type repositoryMeta struct {
+	CreatedAt metav1.Time `json:"createdAt"`
+	// LastID is the last id allocated to statuses and comments
+	LastID int `json:"lastID"`
+
+	PullRequests       []metav1alpha1.GitPullRequest                 `json:"pullRequests,omitempty"`
+	PullRequestNotes   map[int64][]metav1alpha1.GitPullRequestNote   `json:"pullRequestNotes,omitempty"`
+	PullRequestReviews map[int64][]metav1alpha1.GitPullRequestReview `json:"pullRequestReviews,omitempty"`
+	CommitStatuses     map[string][]metav1alpha1.GitCommitStatus     `json:"commitStatuses,omitempty"`
+	CommitComments     map[string][]metav1alpha1.GitCommitComment    `json:"commitComments,omitempty"`
+	// BranchProtections are the protection rules of protected branches by their names
+	BranchProtections map[string]metav1alpha1.GitBranchProtectionRules `json:"branchProtections,omitempty"`
+}
+
+// nextID allocates an id for statuses and comments
+func (m *repositoryMeta) nextID() int {
+	m.LastID++
+	return m.LastID
+}
+
+// readMeta reads the metadata of the repository, the caller should hold the lock
+func (g *GitStore) readMeta(r *repository) (*repositoryMeta, error) {
+	meta := &repositoryMeta{}
+	data, err := os.ReadFile(r.meta)
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// writeMeta writes the metadata of the repository atomically, the caller should hold the lock
+func (g *GitStore) writeMeta(r *repository, meta *repositoryMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(r.meta), filepath.Base(r.meta)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), r.meta)
+}
+
+// getMeta returns the metadata of the repository
+func (g *GitStore) getMeta(r *repository) (*repositoryMeta, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.readMeta(r)
+}
+
+// updateMeta updates the metadata of the repository with update,
+// nothing is written if update returns an error.
+func (g *GitStore) updateMeta(r *repository, update func(meta *repositoryMeta) error) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	meta, err := g.readMeta(r)
+	if err != nil {
+		return err
+	}
+	if err = update(meta); err != nil {
+		return err
+	}
+	return g.writeMeta(r, meta)
+}
+
+// page returns the items in the page of the list options
+func page[T any](items []T, option metav1alpha1.ListOptions) ([]T, metav1alpha1.ListMeta) {
+	meta := metav1alpha1.ListMeta{TotalItems: len(items)}
+	if items == nil {
+		items = []T{}
+	}
+	if option.All {
+		return items, meta
+	}
+	begin, end := common.Paginate(len(items), option.ItemsPerPage, option.Page)
+	return items[begin:end], meta
+}
+
+func typeMeta(gvk schema.GroupVersionKind) metav1.TypeMeta {
+	return metav1.TypeMeta{Kind: gvk.Kind, APIVersion: gvk.GroupVersion().String()}
+}
+
+// parseTime parses a strict ISO 8601 timestamp of git
+func parseTime(value string) metav1.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(t)
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testRepo = metav1alpha1.GitRepo{Project: "group/sub", Repository: "demo"}
+
+// newTestStore returns a git store with a repository initialized with a README.md on main
+func newTestStore(ctx context.Context) *GitStore {
+	gitStore := NewGitStore("local-git", GinkgoT().TempDir())
+	Expect(gitStore.Setup(ctx, nil)).To(Succeed())
+	_, err := gitStore.CreateGitRepository(ctx, metav1alpha1.CreateGitRepositoryPayload{GitRepo: testRepo, AutoInit: true})
+	Expect(err).To(BeNil())
+	return gitStore
+}
+
+func commitFiles(ctx context.Context, gitStore *GitStore, branch string, actions ...coderepositoryv1alpha1.CreateCommitAction) metav1alpha1.GitCommit {
+	commit, err := gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+		GitRepo: testRepo,
+		GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+			Branch:  branch,
+			Message: fmt.Sprintf("update %s", branch),
+			Actions: actions,
+		}},
+	})
+	Expect(err).To(BeNil())
+	return commit
+}
+
+func readFile(ctx context.Context, gitStore *GitStore, ref, file string) string {
+	got, err := gitStore.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{GitRepo: testRepo, Ref: ref, Path: file})
+	Expect(err).To(BeNil())
+	content, err := base64.StdEncoding.DecodeString(string(got.Spec.Content))
+	Expect(err).To(BeNil())
+	return string(content)
+}
+
+var _ = Describe("Test.GitStore", func() {
+	var (
+		ctx      context.Context
+		gitStore *GitStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gitStore = newTestStore(ctx)
+	})
+
+	It("creates, lists and deletes repositories", func() {
+		repo, err := gitStore.GetGitRepository(ctx, testRepo)
+		Expect(err).To(BeNil())
+		Expect(repo.Spec.Name).To(Equal("demo"))
+		Expect(repo.Spec.DefaultBranch).To(Equal("main"))
+		Expect(repo.Spec.HttpCloneURL).To(HavePrefix("file://"))
+		Expect(repo.Spec.CreatedAt.IsZero()).To(BeFalse())
+
+		_, err = gitStore.CreateGitRepository(ctx, metav1alpha1.CreateGitRepositoryPayload{GitRepo: testRepo})
+		Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+		empty := metav1alpha1.GitRepo{Project: "group/sub", Repository: "empty"}
+		_, err = gitStore.CreateGitRepository(ctx, metav1alpha1.CreateGitRepositoryPayload{GitRepo: empty})
+		Expect(err).To(BeNil())
+
+		list, err := gitStore.ListGitRepository(ctx, "group/sub", "", metav1alpha1.GitGroupProjectSubType, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(2))
+		Expect(list.Items[0].Name).To(Equal("demo"))
+		Expect(list.Items[1].Name).To(Equal("empty"))
+		list, err = gitStore.ListGitRepository(ctx, "group/sub", "emp", "", metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		list, err = gitStore.ListGitRepository(ctx, "missing", "", "", metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(BeEmpty())
+
+		Expect(gitStore.DeleteGitRepository(ctx, empty)).To(Succeed())
+		_, err = gitStore.GetGitRepository(ctx, empty)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(errors.IsNotFound(gitStore.DeleteGitRepository(ctx, empty))).To(BeTrue())
+	})
+
+	It("rejects invalid repository names", func() {
+		for _, repo := range []metav1alpha1.GitRepo{
+			{Project: "", Repository: "demo"},
+			{Project: "../escape", Repository: "demo"},
+			{Project: "group//sub", Repository: "demo"},
+			{Project: "group/demo.git", Repository: "demo"},
+			{Project: "group", Repository: "a/b"},
+			{Project: "group", Repository: ".."},
+		} {
+			_, err := gitStore.GetGitRepository(ctx, repo)
+			Expect(errors.IsBadRequest(err)).To(BeTrue(), repo.String())
+		}
+	})
+
+	It("creates, gets and lists branches", func() {
+		branch, err := gitStore.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+			GitRepo:            testRepo,
+			CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: "feature/a"},
+		})
+		Expect(err).To(BeNil())
+		Expect(branch.Spec.Name).To(Equal("feature/a"))
+		Expect(branch.IsDefault()).To(BeFalse())
+
+		main, err := gitStore.GetGitBranch(ctx, testRepo, "main")
+		Expect(err).To(BeNil())
+		Expect(main.IsDefault()).To(BeTrue())
+		Expect(*main.Spec.Commit.SHA).To(Equal(*branch.Spec.Commit.SHA))
+
+		_, err = gitStore.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+			GitRepo:            testRepo,
+			CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: "feature/a", Ref: "main"},
+		})
+		Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+		_, err = gitStore.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+			GitRepo:            testRepo,
+			CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: "bad..name"},
+		})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		_, err = gitStore.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+			GitRepo:            testRepo,
+			CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: "other", Ref: "missing"},
+		})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = gitStore.GetGitBranch(ctx, testRepo, "feature")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		list, err := gitStore.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(2))
+		Expect(list.Items[0].Name).To(Equal("feature/a"))
+		list, err = gitStore.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: testRepo, Keyword: "mai"}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		list, err = gitStore.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: testRepo}, metav1alpha1.ListOptions{Page: 2, ItemsPerPage: 1})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(2))
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("main"))
+	})
+
+	It("creates commits with file actions", func() {
+		commit := commitFiles(ctx, gitStore, "main",
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "docs/a.txt", Content: "a"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "/docs/b.bin", Encoding: "base64",
+				Content: base64.StdEncoding.EncodeToString([]byte{0, 1, 2})},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "README.md", Content: "updated"},
+		)
+		Expect(*commit.Spec.Message).To(Equal("update main"))
+		Expect(commit.Spec.Author.Name).To(Equal("katanomi"))
+		Expect(readFile(ctx, gitStore, "", "docs/a.txt")).To(Equal("a"))
+		Expect(readFile(ctx, gitStore, *commit.Spec.SHA, "docs/b.bin")).To(Equal("\x00\x01\x02"))
+		Expect(readFile(ctx, gitStore, "main", "README.md")).To(Equal("updated"))
+
+		next := commitFiles(ctx, gitStore, "main",
+			coderepositoryv1alpha1.CreateCommitAction{Action: "move", FilePath: "docs/c.txt", PreviousPath: "docs/a.txt"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "delete", FilePath: "docs/b.bin"},
+		)
+		Expect(readFile(ctx, gitStore, "main", "docs/c.txt")).To(Equal("a"))
+		_, err := gitStore.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{GitRepo: testRepo, Path: "docs/a.txt"})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = gitStore.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{GitRepo: testRepo, Path: "docs"})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		got, err := gitStore.GetGitCommit(ctx, metav1alpha1.GitCommitOption{GitRepo: testRepo,
+			GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: next.Spec.SHA}})
+		Expect(err).To(BeNil())
+		Expect(got).To(Equal(next))
+
+		for _, action := range []coderepositoryv1alpha1.CreateCommitAction{
+			{Action: "create", FilePath: "README.md", Content: "exists"},
+			{Action: "update", FilePath: "missing.txt", Content: "missing"},
+			{Action: "delete", FilePath: "missing.txt"},
+			{Action: "chmod", FilePath: "README.md"},
+			{Action: "create", FilePath: "../escape.txt"},
+			{Action: "create", FilePath: "bad.txt", Encoding: "base64", Content: "!"},
+		} {
+			_, err = gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+				GitRepo: testRepo,
+				GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+					Branch: "main", Message: "invalid", Actions: []coderepositoryv1alpha1.CreateCommitAction{action},
+				}},
+			})
+			Expect(errors.IsBadRequest(err)).To(BeTrue(), action.Action+" "+action.FilePath)
+		}
+		_, err = gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{GitRepo: testRepo})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		list, err := gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(3))
+		Expect(list.Items[0]).To(Equal(next))
+		Expect(*list.Items[2].Spec.Message).To(Equal("Initial commit"))
+	})
+
+	It("creates branches of commits from the start branch", func() {
+		base := commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt", Content: "a"})
+		commit, err := gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+			GitRepo: testRepo,
+			GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+				Branch:      "feature",
+				StartSHA:    *base.Spec.SHA,
+				Message:     "feature",
+				Author:      &metav1alpha1.GitUserBaseInfo{Name: "dev", Email: "dev@example.com"},
+				Actions:     []coderepositoryv1alpha1.CreateCommitAction{{Action: "update", FilePath: "a.txt", Content: "b"}},
+				StartBranch: "",
+			}},
+		})
+		Expect(err).To(BeNil())
+		Expect(commit.Spec.Author.Name).To(Equal("dev"))
+		Expect(commit.Spec.Committer.Name).To(Equal("katanomi"))
+		Expect(readFile(ctx, gitStore, "feature", "a.txt")).To(Equal("b"))
+		Expect(readFile(ctx, gitStore, "main", "a.txt")).To(Equal("a"))
+
+		list, err := gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo, Ref: "feature"}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(3))
+		future := metav1.NewTime(time.Now().Add(time.Hour))
+		list, err = gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo, Since: &future}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(BeEmpty())
+		_, err = gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo, Ref: "missing"}, metav1alpha1.ListOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		// files are created with base64 encoded content as clients send them
+		commit, err = gitStore.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+			GitRepo:  testRepo,
+			FilePath: "new/file.txt",
+			CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{
+				Branch: "other", StartBranch: "feature", Message: "add file",
+				Content: []byte(base64.StdEncoding.EncodeToString([]byte("hello"))),
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(*commit.Spec.Message).To(Equal("add file"))
+		Expect(readFile(ctx, gitStore, "other", "new/file.txt")).To(Equal("hello"))
+		Expect(readFile(ctx, gitStore, "other", "a.txt")).To(Equal("b"))
+	})
+
+	It("commits to empty repositories", func() {
+		empty := metav1alpha1.GitRepo{Project: "group", Repository: "empty"}
+		_, err := gitStore.CreateGitRepository(ctx, metav1alpha1.CreateGitRepositoryPayload{GitRepo: empty})
+		Expect(err).To(BeNil())
+		list, err := gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: empty}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(BeEmpty())
+
+		_, err = gitStore.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+			GitRepo:              empty,
+			FilePath:             "a.txt",
+			CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{Branch: "main", Message: "first"},
+		})
+		Expect(err).To(BeNil())
+		list, err = gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: empty}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+	})
+
+	It("never loses commits of concurrent writers", func() {
+		wg := sync.WaitGroup{}
+		succeeded := make(chan string, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				commit, err := gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+					GitRepo: testRepo,
+					GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+						Branch:  "main",
+						Message: fmt.Sprintf("commit %d", i),
+						Actions: []coderepositoryv1alpha1.CreateCommitAction{{Action: "create", FilePath: fmt.Sprintf("%d.txt", i)}},
+					}},
+				})
+				if err != nil {
+					Expect(errors.IsConflict(err)).To(BeTrue(), err.Error())
+					return
+				}
+				succeeded <- *commit.Spec.SHA
+			}(i)
+		}
+		wg.Wait()
+		close(succeeded)
+
+		list, err := gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo}, metav1alpha1.ListOptions{All: true})
+		Expect(err).To(BeNil())
+		shas := []string{}
+		for _, commit := range list.Items {
+			shas = append(shas, *commit.Spec.SHA)
+		}
+		for sha := range succeeded {
+			Expect(shas).To(ContainElement(sha))
+		}
+		entries, err := filepath.Glob(filepath.Join(gitStore.root, "group", "sub", "demo.git", "index-*"))
+		Expect(err).To(BeNil())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("lists file trees", func() {
+		commitFiles(ctx, gitStore, "main",
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "src/main.go", Content: "package main"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "src/pkg/lib.go", Content: "package pkg"},
+		)
+		tree, err := gitStore.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(tree.Spec.Tree).To(HaveLen(2))
+		Expect(tree.Spec.Tree[0].Path).To(Equal("README.md"))
+		Expect(tree.Spec.Tree[0].Type).To(Equal(metav1alpha1.TreeNodeBlobType))
+		Expect(tree.Spec.Tree[1].Path).To(Equal("src"))
+		Expect(tree.Spec.Tree[1].Type).To(Equal(metav1alpha1.TreeNodeTreeType))
+
+		tree, err = gitStore.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{
+			GitRepo: testRepo, Path: "src", TreeSha: "main", Recursive: true,
+		}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		paths := []string{}
+		for _, node := range tree.Spec.Tree {
+			paths = append(paths, node.Path)
+		}
+		Expect(paths).To(Equal([]string{"src/main.go", "src/pkg", "src/pkg/lib.go"}))
+		Expect(tree.Spec.Tree[2].Name).To(Equal("lib.go"))
+		Expect(tree.Spec.Tree[2].Mode).To(Equal("100644"))
+
+		_, err = gitStore.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{GitRepo: testRepo, Path: "missing"}, metav1alpha1.ListOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = gitStore.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{GitRepo: testRepo, Path: "README.md"}, metav1alpha1.ListOptions{})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		// tree shas are never parsed as options
+		for _, treeSha := range []string{"--name-only", "--end-of-options", "-r"} {
+			_, err = gitStore.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{GitRepo: testRepo, TreeSha: treeSha}, metav1alpha1.ListOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue(), treeSha)
+		}
+	})
+
+	It("gets and lists tags", func() {
+		r, err := gitStore.repository(testRepo)
+		Expect(err).To(BeNil())
+		head, err := r.resolve(ctx, "main")
+		Expect(err).To(BeNil())
+		_, err = r.git(ctx, "tag", "v1.0.0", "main")
+		Expect(err).To(BeNil())
+		_, err = runGit(ctx, r.dir, gitStore.userEnv(gitStore.committer), nil, "tag", "-a", "-m", "release\n\nnotes", "v2.0.0", "main")
+		Expect(err).To(BeNil())
+
+		tag, err := gitStore.GetGitRepositoryTag(ctx, metav1alpha1.GitTag{GitRepo: testRepo, Tag: "v1.0.0"})
+		Expect(err).To(BeNil())
+		Expect(*tag.Spec.SHA).To(Equal(head))
+		Expect(tag.Spec.Message).To(BeNil())
+		tag, err = gitStore.GetGitRepositoryTag(ctx, metav1alpha1.GitTag{GitRepo: testRepo, Tag: "v2.0.0"})
+		Expect(err).To(BeNil())
+		Expect(*tag.Spec.SHA).To(Equal(head))
+		Expect(*tag.Spec.Message).To(Equal("release\n\nnotes"))
+		_, err = gitStore.GetGitRepositoryTag(ctx, metav1alpha1.GitTag{GitRepo: testRepo, Tag: "v1"})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		list, err := gitStore.ListGitRepositoryTag(ctx, metav1alpha1.GitRepositoryTagListOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(2))
+		Expect(list.Items[1].Name).To(Equal("v2.0.0"))
+
+		// tags could be used as the ref of files
+		Expect(readFile(ctx, gitStore, "v2.0.0", "README.md")).To(Equal("# demo\n"))
+	})
+
+	It("returns not found for missing repositories", func() {
+		missing := metav1alpha1.GitRepo{Project: "group", Repository: "missing"}
+		_, err := gitStore.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: missing}, metav1alpha1.ListOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = gitStore.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{GitRepo: missing, Path: "README.md"})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = os.Stat(filepath.Join(gitStore.root, "group", "missing.git"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,282 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mergeStrategies are the strategies supported by the store
+var mergeStrategies = []metav1alpha1.MergeStrategy{
+	metav1alpha1.MergeStrategyMerge,
+	metav1alpha1.MergeStrategySquash,
+	metav1alpha1.MergeStrategyRebase,
+}
+
+// mergeability checks if the pull request could be merged.
+// Pull requests could not be merged if they are not opened, have conflicts,
+// or changes are requested by the latest review of any reviewer.
+func (r *repository) mergeability(ctx context.Context, meta *repositoryMeta, pr metav1alpha1.GitPullRequest) (metav1alpha1.GitPullRequestMergeabilitySpec, error) {
+	spec := metav1alpha1.GitPullRequestMergeabilitySpec{MergeStatus: metav1alpha1.MergeStatusCannotBeMerged}
+	if pr.Spec.State != metav1alpha1.PullRequestOpenedState {
+		spec.Reasons = append(spec.Reasons, fmt.Sprintf("pull request is %s", pr.Spec.State))
+		return spec, nil
+	}
+	var err error
+	if spec.SourceSHA, err = r.resolve(ctx, branchPrefix+pr.Spec.Source.Name); err != nil {
+		return spec, err
+	}
+	if spec.TargetSHA, err = r.resolve(ctx, branchPrefix+pr.Spec.Target.Name); err != nil {
+		return spec, err
+	}
+	if _, spec.HasConflicts, err = r.mergeTree(ctx, spec.TargetSHA, spec.SourceSHA); err != nil {
+		return spec, err
+	}
+	if spec.HasConflicts {
+		spec.Reasons = append(spec.Reasons, "source branch has conflicts with the target branch")
+	}
+
+	latest := map[string]metav1alpha1.PullRequestReviewState{}
+	reviewers := []string{}
+	for _, review := range meta.PullRequestReviews[pr.Spec.Number] {
+		if _, ok := latest[review.Spec.Author.Name]; !ok {
+			reviewers = append(reviewers, review.Spec.Author.Name)
+		}
+		latest[review.Spec.Author.Name] = review.Spec.State
+	}
+	for _, reviewer := range reviewers {
+		if latest[reviewer] == metav1alpha1.PullRequestReviewChangesRequested {
+			spec.Reasons = append(spec.Reasons, fmt.Sprintf("changes are requested by %s", reviewer))
+		}
+	}
+
+	spec.Reasons = append(spec.Reasons, protectionReasons(meta, pr, spec.SourceSHA)...)
+
+	// merge commits could not be replayed on the target branch
+	merges, err := r.git(ctx, "rev-list", "--merges", spec.TargetSHA+".."+spec.SourceSHA)
+	if err != nil {
+		return spec, err
+	}
+	for _, strategy := range mergeStrategies {
+		if strategy != metav1alpha1.MergeStrategyRebase || strings.TrimSpace(merges) == "" {
+			spec.Strategies = append(spec.Strategies, strategy)
+		}
+	}
+	if len(spec.Reasons) == 0 {
+		spec.MergeStatus = metav1alpha1.MergeStatusCanBeMerged
+	}
+	return spec, nil
+}
+
+// GetPullRequestMergeability checks if the pull request could be merged and by which strategies
+func (g *GitStore) GetPullRequestMergeability(ctx context.Context, option metav1alpha1.GitPullRequestOption) (metav1alpha1.GitPullRequestMergeability, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestMergeability{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequestMergeability{}, err
+	}
+	i, err := findPullRequest(meta, option.Index)
+	if err != nil {
+		return metav1alpha1.GitPullRequestMergeability{}, err
+	}
+	spec, err := r.mergeability(ctx, meta, meta.PullRequests[i])
+	if err != nil {
+		return metav1alpha1.GitPullRequestMergeability{}, err
+	}
+	return metav1alpha1.GitPullRequestMergeability{
+		TypeMeta:   typeMeta(metav1alpha1.GitPullRequestMergeabilityGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: meta.PullRequests[i].Name},
+		Spec:       spec,
+	}, nil
+}
+
+// MergePullRequest merges the source branch of an opened pull request into the target branch.
+// The target branch is updated only if it was not changed during the merge,
+// and the source branch must be at the commit if it is provided.
+func (g *GitStore) MergePullRequest(ctx context.Context, payload metav1alpha1.MergePullRequestPayload) (metav1alpha1.GitPullRequest, error) {
+	param := payload.MergePullRequestParam
+	strategy := param.Strategy
+	if strategy == "" {
+		strategy = metav1alpha1.MergeStrategyMerge
+	}
+	if !strategy.IsValid() {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest(fmt.Sprintf("invalid merge strategy %q", strategy))
+	}
+	r, err := g.repository(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	i, err := findPullRequest(meta, payload.Index)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	pr := meta.PullRequests[i]
+	if pr.Spec.State != metav1alpha1.PullRequestOpenedState {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest(fmt.Sprintf("pull request is %s", pr.Spec.State))
+	}
+	spec, err := r.mergeability(ctx, meta, pr)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	if param.SHA != "" && param.SHA != spec.SourceSHA {
+		return metav1alpha1.GitPullRequest{}, errors.NewConflict(pullRequestResource, pr.Name,
+			fmt.Errorf("source branch is at %s instead of %s", spec.SourceSHA, param.SHA))
+	}
+	if len(spec.Reasons) != 0 {
+		return metav1alpha1.GitPullRequest{}, errors.NewConflict(pullRequestResource, pr.Name,
+			fmt.Errorf("pull request could not be merged: %s", strings.Join(spec.Reasons, ", ")))
+	}
+
+	var merged string
+	switch strategy {
+	case metav1alpha1.MergeStrategySquash:
+		merged, err = g.squash(ctx, r, pr, spec, param.CommitMessage)
+	case metav1alpha1.MergeStrategyRebase:
+		merged, err = g.rebase(ctx, r, pr, spec)
+	default:
+		merged, err = g.merge(ctx, r, pr, spec, param.CommitMessage)
+	}
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	if err = r.updateBranch(ctx, pr.Spec.Target.Name, merged, spec.TargetSHA); err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+
+	props := properties(pr)
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		i, err := findPullRequest(meta, payload.Index)
+		if err != nil {
+			return err
+		}
+		now := metav1.NewTime(g.now())
+		pr = meta.PullRequests[i]
+		pr.Spec.State = metav1alpha1.PullRequestMergedState
+		pr.Spec.MergeStatus = ""
+		pr.Spec.HasConflicts = false
+		pr.Spec.MergedBy = g.committer
+		pr.Spec.MergeLog = &metav1alpha1.GitOperateLogBaseInfo{User: &pr.Spec.MergedBy, Time: &now}
+		pr.Spec.UpdateAt = &now
+		props = properties(pr)
+		props.BaseSHA = r.mergeBase(ctx, spec.TargetSHA, spec.SourceSHA)
+		props.HeadSHA = spec.SourceSHA
+		if err = setProperties(&pr, props); err != nil {
+			return err
+		}
+		meta.PullRequests[i] = pr
+		return nil
+	})
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+
+	removeSourceBranch := props.RemoveSourceBranch
+	if param.RemoveSourceBranch != nil {
+		removeSourceBranch = *param.RemoveSourceBranch
+	}
+	if removeSourceBranch {
+		// the branch is kept if it was updated after the merge
+		_, _ = r.git(ctx, "update-ref", "-d", branchPrefix+pr.Spec.Source.Name, spec.SourceSHA)
+	}
+	return pr, nil
+}
+
+// commitTree creates a commit of the tree with the parents and returns its sha
+func (g *GitStore) commitTree(ctx context.Context, r *repository, author metav1alpha1.GitUserBaseInfo, tree, message string, parents ...string) (string, error) {
+	args := []string{"commit-tree", tree}
+	for _, parent := range parents {
+		args = append(args, "-p", parent)
+	}
+	out, err := runGit(ctx, r.dir, g.userEnv(author), strings.NewReader(message), args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// merge creates a merge commit of the source branch on the target branch
+func (g *GitStore) merge(ctx context.Context, r *repository, pr metav1alpha1.GitPullRequest, spec metav1alpha1.GitPullRequestMergeabilitySpec, message string) (string, error) {
+	tree, _, err := r.mergeTree(ctx, spec.TargetSHA, spec.SourceSHA)
+	if err != nil {
+		return "", err
+	}
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into '%s'\n\n%s (#%d)\n",
+			pr.Spec.Source.Name, pr.Spec.Target.Name, pr.Spec.Title, pr.Spec.Number)
+	}
+	return g.commitTree(ctx, r, g.committer, tree, message, spec.TargetSHA, spec.SourceSHA)
+}
+
+// squash creates a single commit of all changes of the source branch on the target branch,
+// the author of the pull request is the author of the commit.
+func (g *GitStore) squash(ctx context.Context, r *repository, pr metav1alpha1.GitPullRequest, spec metav1alpha1.GitPullRequestMergeabilitySpec, message string) (string, error) {
+	tree, _, err := r.mergeTree(ctx, spec.TargetSHA, spec.SourceSHA)
+	if err != nil {
+		return "", err
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s (#%d)\n", pr.Spec.Title, pr.Spec.Number)
+	}
+	return g.commitTree(ctx, r, pr.Spec.Author, tree, message, spec.TargetSHA)
+}
+
+// rebase replays commits of the source branch on the target branch keeping their authors,
+// the source branch is fast-forwarded if it already contains the target branch.
+// Commits are replayed in a temporary worktree because bare repositories have no working tree.
+func (g *GitStore) rebase(ctx context.Context, r *repository, pr metav1alpha1.GitPullRequest, spec metav1alpha1.GitPullRequestMergeabilitySpec) (string, error) {
+	if r.isAncestor(ctx, spec.TargetSHA, spec.SourceSHA) {
+		return spec.SourceSHA, nil
+	}
+	dir, err := os.MkdirTemp("", "localgit-rebase-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	worktree := filepath.Join(dir, "worktree")
+	if _, err = r.git(ctx, "worktree", "add", "--quiet", "--detach", worktree, spec.TargetSHA); err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = r.git(context.Background(), "worktree", "remove", "--force", worktree)
+	}()
+
+	_, err = runGit(ctx, "", g.committerEnv(), nil,
+		"-C", worktree, "cherry-pick", "--keep-redundant-commits", spec.TargetSHA+".."+spec.SourceSHA)
+	if err != nil {
+		return "", errors.NewConflict(pullRequestResource, pr.Name, fmt.Errorf("commits could not be rebased: %w", err))
+	}
+	out, err := runGit(ctx, "", nil, nil, "-C", worktree, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"fmt"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var branchProtectionResource = metav1alpha1.GroupVersion.WithResource("gitbranchprotections").GroupResource()
+
+func (r *repository) newBranchProtection(branch string, rules metav1alpha1.GitBranchProtectionRules) metav1alpha1.GitBranchProtection {
+	return metav1alpha1.GitBranchProtection{
+		TypeMeta:   typeMeta(metav1alpha1.GitBranchProtectionGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: branch},
+		Spec: metav1alpha1.GitBranchProtectionSpec{
+			GitBranchBaseInfo:        metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: branch},
+			GitBranchProtectionRules: rules,
+		},
+	}
+}
+
+// DeleteGitBranch deletes a branch with its protection rules.
+// The default branch could not be deleted, neither protected branches unless deletions are allowed.
+func (g *GitStore) DeleteGitBranch(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) error {
+	r, err := g.repository(repoOption)
+	if err != nil {
+		return err
+	}
+	if err = r.validateBranchName(ctx, branch); err != nil {
+		return err
+	}
+	if branch == r.defaultBranch(ctx) {
+		return errors.NewBadRequest("default branch could not be deleted")
+	}
+	sha, err := r.resolveIfExists(ctx, branchPrefix+branch)
+	if err != nil {
+		return err
+	}
+	if sha == "" {
+		return errors.NewNotFound(branchResource, branch)
+	}
+
+	return g.updateMeta(r, func(meta *repositoryMeta) error {
+		if rules, ok := meta.BranchProtections[branch]; ok && !rules.AllowDeletions {
+			return errors.NewForbidden(branchResource, branch, fmt.Errorf("branch is protected"))
+		}
+		// the branch is kept if it was updated concurrently
+		if _, err := r.git(ctx, "update-ref", "-d", branchPrefix+branch, sha); err != nil {
+			return errors.NewConflict(branchResource, branch, fmt.Errorf("branch was updated concurrently: %w", err))
+		}
+		delete(meta.BranchProtections, branch)
+		return nil
+	})
+}
+
+// GetGitBranchProtection gets protection rules of a branch, not found is returned if the branch is not protected
+func (g *GitStore) GetGitBranchProtection(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranchProtection, error) {
+	r, err := g.repository(repoOption)
+	if err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	if _, err = r.getBranch(ctx, branch); err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	rules, ok := meta.BranchProtections[branch]
+	if !ok {
+		return metav1alpha1.GitBranchProtection{}, errors.NewNotFound(branchProtectionResource, branch)
+	}
+	return r.newBranchProtection(branch, rules), nil
+}
+
+// UpdateGitBranchProtection protects an existing branch or replaces its protection rules.
+// Rules are enforced when pull requests are merged and branches are deleted.
+func (g *GitStore) UpdateGitBranchProtection(ctx context.Context, payload metav1alpha1.UpdateBranchProtectionPayload) (metav1alpha1.GitBranchProtection, error) {
+	rules := payload.GitBranchProtectionRules
+	if rules.RequiredApprovals < 0 {
+		return metav1alpha1.GitBranchProtection{}, errors.NewBadRequest("required approvals could not be negative")
+	}
+	for _, check := range rules.RequiredStatusChecks {
+		if check == "" {
+			return metav1alpha1.GitBranchProtection{}, errors.NewBadRequest("required status checks could not be empty")
+		}
+	}
+	r, err := g.repository(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	if _, err = r.getBranch(ctx, payload.Branch); err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		if meta.BranchProtections == nil {
+			meta.BranchProtections = map[string]metav1alpha1.GitBranchProtectionRules{}
+		}
+		meta.BranchProtections[payload.Branch] = rules
+		return nil
+	})
+	if err != nil {
+		return metav1alpha1.GitBranchProtection{}, err
+	}
+	return r.newBranchProtection(payload.Branch, rules), nil
+}
+
+// DeleteGitBranchProtection removes protection rules of a branch
+func (g *GitStore) DeleteGitBranchProtection(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) error {
+	r, err := g.repository(repoOption)
+	if err != nil {
+		return err
+	}
+	if err = r.validateBranchName(ctx, branch); err != nil {
+		return err
+	}
+	return g.updateMeta(r, func(meta *repositoryMeta) error {
+		if _, ok := meta.BranchProtections[branch]; !ok {
+			return errors.NewNotFound(branchProtectionResource, branch)
+		}
+		delete(meta.BranchProtections, branch)
+		return nil
+	})
+}
+
+// protectionReasons returns why the protection rules of the target branch prevent the pull request from being merged.
+// Approvals are counted by the latest review of each reviewer, and required statuses are checked on the source commit.
+func protectionReasons(meta *repositoryMeta, pr metav1alpha1.GitPullRequest, sourceSHA string) []string {
+	rules, ok := meta.BranchProtections[pr.Spec.Target.Name]
+	if !ok {
+		return nil
+	}
+	reasons := []string{}
+
+	latest := map[string]metav1alpha1.GitPullRequestReview{}
+	for _, review := range meta.PullRequestReviews[pr.Spec.Number] {
+		latest[review.Spec.Author.Name] = review
+	}
+	approvals := 0
+	for _, review := range latest {
+		if review.Spec.State != metav1alpha1.PullRequestReviewApproved {
+			continue
+		}
+		if rules.DismissStaleApprovals && review.Spec.CommitSHA != sourceSHA {
+			continue
+		}
+		approvals++
+	}
+	if approvals < rules.RequiredApprovals {
+		reasons = append(reasons, fmt.Sprintf("%d of %d required approvals", approvals, rules.RequiredApprovals))
+	}
+
+	states := map[string]string{}
+	for _, status := range meta.CommitStatuses[sourceSHA] {
+		states[status.Spec.Name] = status.Spec.Status
+	}
+	for _, check := range rules.RequiredStatusChecks {
+		if states[check] != "success" {
+			reasons = append(reasons, fmt.Sprintf("required status check %s is not successful", check))
+		}
+	}
+	return reasons
+}
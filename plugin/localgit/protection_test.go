@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var _ = Describe("Test.GitStore.Protection", func() {
+	var (
+		ctx      context.Context
+		gitStore *GitStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gitStore = newTestStore(ctx)
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt", Content: "a\n"})
+	})
+
+	It("deletes branches", func() {
+		Expect(gitStore.DeleteGitBranch(ctx, testRepo, "feature")).To(Succeed())
+		_, err := gitStore.GetGitBranch(ctx, testRepo, "feature")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		err = gitStore.DeleteGitBranch(ctx, testRepo, "feature")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		err = gitStore.DeleteGitBranch(ctx, testRepo, "main")
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		err = gitStore.DeleteGitBranch(ctx, testRepo, "invalid..name")
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("protects branches", func() {
+		_, err := gitStore.GetGitBranchProtection(ctx, testRepo, "feature")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		payload := metav1alpha1.UpdateBranchProtectionPayload{GitRepo: testRepo, Branch: "feature"}
+		payload.RequiredApprovals = -1
+		_, err = gitStore.UpdateGitBranchProtection(ctx, payload)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		payload.RequiredApprovals, payload.DevelopersCanPush = 1, pointer.Bool(false)
+		protection, err := gitStore.UpdateGitBranchProtection(ctx, payload)
+		Expect(err).To(BeNil())
+		Expect(protection.Spec.Name).To(Equal("feature"))
+		Expect(protection.Spec.GitRepo).To(Equal(testRepo))
+
+		got, err := gitStore.GetGitBranchProtection(ctx, testRepo, "feature")
+		Expect(err).To(BeNil())
+		Expect(got).To(Equal(protection))
+		branch, err := gitStore.GetGitBranch(ctx, testRepo, "feature")
+		Expect(err).To(BeNil())
+		Expect(*branch.Spec.Protected).To(BeTrue())
+		Expect(*branch.Spec.DevelopersCanPush).To(BeFalse())
+		branches, err := gitStore.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(*branches.Items[0].Spec.Protected).To(BeTrue())
+		Expect(*branches.Items[1].Spec.Protected).To(BeFalse())
+
+		// protected branches are deleted only if deletions are allowed
+		err = gitStore.DeleteGitBranch(ctx, testRepo, "feature")
+		Expect(errors.IsForbidden(err)).To(BeTrue())
+		payload.AllowDeletions = true
+		_, err = gitStore.UpdateGitBranchProtection(ctx, payload)
+		Expect(err).To(BeNil())
+		Expect(gitStore.DeleteGitBranch(ctx, testRepo, "feature")).To(Succeed())
+		_, err = gitStore.UpdateGitBranchProtection(ctx, payload)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		// protection rules are removed with the branch
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt"})
+		_, err = gitStore.GetGitBranchProtection(ctx, testRepo, "feature")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("removes protection rules", func() {
+		_, err := gitStore.UpdateGitBranchProtection(ctx, metav1alpha1.UpdateBranchProtectionPayload{GitRepo: testRepo, Branch: "main"})
+		Expect(err).To(BeNil())
+		Expect(gitStore.DeleteGitBranchProtection(ctx, testRepo, "main")).To(Succeed())
+		branch, err := gitStore.GetGitBranch(ctx, testRepo, "main")
+		Expect(err).To(BeNil())
+		Expect(*branch.Spec.Protected).To(BeFalse())
+		err = gitStore.DeleteGitBranchProtection(ctx, testRepo, "main")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("enforces protection rules when merging pull requests", func() {
+		_, err := gitStore.UpdateGitBranchProtection(ctx, metav1alpha1.UpdateBranchProtectionPayload{
+			GitRepo: testRepo, Branch: "main",
+			GitBranchProtectionRules: metav1alpha1.GitBranchProtectionRules{
+				RequiredApprovals:     1,
+				DismissStaleApprovals: true,
+				RequiredStatusChecks:  []string{"ci/build"},
+			},
+		})
+		Expect(err).To(BeNil())
+		openPullRequest(ctx, gitStore, "feature")
+		option := metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1}
+
+		mergeability, err := gitStore.GetPullRequestMergeability(ctx, option)
+		Expect(err).To(BeNil())
+		Expect(mergeability.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCannotBeMerged))
+		Expect(mergeability.Spec.Reasons).To(HaveLen(2))
+
+		review := metav1alpha1.CreatePullRequestReviewPayload{GitRepo: testRepo, Index: 1}
+		review.State = metav1alpha1.PullRequestReviewApproved
+		_, err = gitStore.CreatePullRequestReview(ctx, review)
+		Expect(err).To(BeNil())
+		_, err = gitStore.CreateGitCommitStatus(ctx, metav1alpha1.CreateCommitStatusPayload{
+			GitRepo:                 testRepo,
+			GitCommitBasicInfo:      metav1alpha1.GitCommitBasicInfo{SHA: pointer.String("feature")},
+			CreateCommitStatusParam: metav1alpha1.CreateCommitStatusParam{State: "success", Context: pointer.String("ci/build")},
+		})
+		Expect(err).To(BeNil())
+		mergeability, err = gitStore.GetPullRequestMergeability(ctx, option)
+		Expect(err).To(BeNil())
+		Expect(mergeability.Spec.Reasons).To(BeEmpty())
+
+		// new commits dismiss the approval and need new statuses
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "b\n"})
+		_, err = gitStore.MergePullRequest(ctx, metav1alpha1.MergePullRequestPayload{GitRepo: testRepo, Index: 1})
+		Expect(errors.IsConflict(err)).To(BeTrue())
+		mergeability, err = gitStore.GetPullRequestMergeability(ctx, option)
+		Expect(err).To(BeNil())
+		Expect(mergeability.Spec.Reasons).To(ConsistOf(
+			"0 of 1 required approvals", "required status check ci/build is not successful"))
+	})
+
+	It("compares revisions", func() {
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "b.txt"})
+		commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "main.txt"})
+		main, feature := readRef(ctx, gitStore, "main"), readRef(ctx, gitStore, "feature")
+
+		comparison, err := gitStore.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{GitRepo: testRepo, Base: "main", Head: "feature"})
+		Expect(err).To(BeNil())
+		Expect(comparison.Spec.BaseSHA).To(Equal(main))
+		Expect(comparison.Spec.HeadSHA).To(Equal(feature))
+		Expect(comparison.Spec.MergeBaseSHA).To(Equal(readRef(ctx, gitStore, "main~1")))
+		Expect(comparison.Spec.Status).To(Equal(metav1alpha1.GitComparisonDiverged))
+		Expect(comparison.Spec.AheadBy).To(Equal(2))
+		Expect(comparison.Spec.BehindBy).To(Equal(1))
+		Expect(comparison.Spec.Commits).To(HaveLen(2))
+		Expect(*comparison.Spec.Commits[1].Spec.SHA).To(Equal(feature))
+		Expect(comparison.Spec.Files).To(HaveLen(2))
+		Expect(comparison.Spec.Files[0].Path).To(Equal("a.txt"))
+		Expect(comparison.Spec.Files[0].Status).To(Equal(metav1alpha1.FileChangeStatusAdded))
+
+		comparison, err = gitStore.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{GitRepo: testRepo, Base: "feature", Head: "main~1"})
+		Expect(err).To(BeNil())
+		Expect(comparison.Spec.Status).To(Equal(metav1alpha1.GitComparisonBehind))
+		Expect(comparison.Spec.Commits).To(BeEmpty())
+		Expect(comparison.Spec.Files).To(BeEmpty())
+		comparison, err = gitStore.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{GitRepo: testRepo, Base: main, Head: "main"})
+		Expect(err).To(BeNil())
+		Expect(comparison.Spec.Status).To(Equal(metav1alpha1.GitComparisonIdentical))
+
+		_, err = gitStore.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{GitRepo: testRepo, Base: "main", Head: "missing"})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = gitStore.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{GitRepo: testRepo, Base: "main"})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
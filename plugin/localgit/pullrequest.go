@@ -0,0 +1,320 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	pullRequestResource     = metav1alpha1.GroupVersion.WithResource("gitpullrequests").GroupResource()
+	pullRequestNoteResource = metav1alpha1.GroupVersion.WithResource("gitpullrequestnotes").GroupResource()
+)
+
+// pullRequestProperties are the properties of pull requests without a field in the spec
+type pullRequestProperties struct {
+	Description        string `json:"description,omitempty"`
+	RemoveSourceBranch bool   `json:"removeSourceBranch,omitempty"`
+
+	// BaseSHA and HeadSHA are the commits compared when the pull request is merged,
+	// so that its changes are still available after the source branch is changed or removed.
+	BaseSHA string `json:"baseSha,omitempty"`
+	HeadSHA string `json:"headSha,omitempty"`
+}
+
+// properties returns the properties of the pull request
+func properties(pr metav1alpha1.GitPullRequest) pullRequestProperties {
+	props := pullRequestProperties{}
+	if pr.Spec.Properties != nil {
+		// properties are always written by the store
+		_ = json.Unmarshal(pr.Spec.Properties.Raw, &props)
+	}
+	return props
+}
+
+// setProperties replaces the properties of the pull request
+func setProperties(pr *metav1alpha1.GitPullRequest, props pullRequestProperties) error {
+	raw, err := json.Marshal(props)
+	if err != nil {
+		return err
+	}
+	pr.Spec.Properties = &runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// findPullRequest returns the index of the pull request in the metadata
+func findPullRequest(meta *repositoryMeta, number int) (int, error) {
+	for i, pr := range meta.PullRequests {
+		if pr.Spec.Number == int64(number) {
+			return i, nil
+		}
+	}
+	return -1, errors.NewNotFound(pullRequestResource, strconv.Itoa(number))
+}
+
+// withMergeStatus returns the pull request with the merge status of opened pull requests,
+// which is checked by merging the branches without touching any reference.
+func (r *repository) withMergeStatus(ctx context.Context, pr metav1alpha1.GitPullRequest) metav1alpha1.GitPullRequest {
+	if pr.Spec.State != metav1alpha1.PullRequestOpenedState {
+		return pr
+	}
+	pr.Spec.MergeStatus = metav1alpha1.MergeStatusUnknown
+	pr.Spec.HasConflicts = false
+	source, _ := r.resolveIfExists(ctx, branchPrefix+pr.Spec.Source.Name)
+	target, _ := r.resolveIfExists(ctx, branchPrefix+pr.Spec.Target.Name)
+	if source == "" || target == "" {
+		return pr
+	}
+	_, conflicts, err := r.mergeTree(ctx, target, source)
+	switch {
+	case err != nil:
+	case conflicts:
+		pr.Spec.MergeStatus = metav1alpha1.MergeStatusCannotBeMerged
+		pr.Spec.HasConflicts = true
+	default:
+		pr.Spec.MergeStatus = metav1alpha1.MergeStatusCanBeMerged
+	}
+	return pr
+}
+
+// mergeTree merges the commits without touching any reference and returns the merged tree,
+// conflicts is true if the commits could not be merged cleanly.
+func (r *repository) mergeTree(ctx context.Context, ours, theirs string) (tree string, conflicts bool, err error) {
+	out, err := r.git(ctx, "merge-tree", "--write-tree", "--no-messages", ours, theirs)
+	if exitCode(err) == 1 {
+		return "", true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(out), false, nil
+}
+
+// CreatePullRequest opens a pull request between branches of the target repository
+func (g *GitStore) CreatePullRequest(ctx context.Context, payload metav1alpha1.CreatePullRequestPayload) (metav1alpha1.GitPullRequest, error) {
+	r, err := g.repository(payload.Target.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	return g.createPullRequest(ctx, r, payload)
+}
+
+func (g *GitStore) createPullRequest(ctx context.Context, r *repository, payload metav1alpha1.CreatePullRequestPayload) (metav1alpha1.GitPullRequest, error) {
+	if payload.Title == "" {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest("title is required")
+	}
+	// the plugin route sets the repository of the source to the full path of the target repository
+	if source := payload.Source.GitRepo; source.Repository != "" && source != r.GitRepo && source.Repository != r.GitRepo.String() {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest("pull requests across repositories are not supported")
+	}
+	if payload.Source.Name == payload.Target.Name {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest("source and target branches are the same")
+	}
+	for _, branch := range []string{payload.Source.Name, payload.Target.Name} {
+		if _, err := r.getBranch(ctx, branch); err != nil {
+			return metav1alpha1.GitPullRequest{}, err
+		}
+	}
+	now := metav1.NewTime(g.now())
+	pr := metav1alpha1.GitPullRequest{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestsGVK),
+		Spec: metav1alpha1.GitPullRequestSpec{
+			GitRepo:   r.GitRepo,
+			Title:     payload.Title,
+			State:     metav1alpha1.PullRequestOpenedState,
+			CreatedAt: now,
+			UpdateAt:  &now,
+			Source:    metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: payload.Source.Name},
+			Target:    metav1alpha1.GitBranchBaseInfo{GitRepo: r.GitRepo, Name: payload.Target.Name},
+			Author:    g.committer,
+		},
+	}
+	err := setProperties(&pr, pullRequestProperties{
+		Description:        payload.Description,
+		RemoveSourceBranch: payload.RemoveSourceBranch,
+	})
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		for _, existing := range meta.PullRequests {
+			if existing.Spec.State == metav1alpha1.PullRequestOpenedState &&
+				existing.Spec.Source.Name == pr.Spec.Source.Name && existing.Spec.Target.Name == pr.Spec.Target.Name {
+				return errors.NewAlreadyExists(pullRequestResource,
+					fmt.Sprintf("%s->%s#%d", pr.Spec.Source.Name, pr.Spec.Target.Name, existing.Spec.Number))
+			}
+		}
+		pr.Spec.Number = int64(len(meta.PullRequests) + 1)
+		pr.Spec.ID = pr.Spec.Number
+		pr.Name = strconv.FormatInt(pr.Spec.Number, 10)
+		meta.PullRequests = append(meta.PullRequests, pr)
+		return nil
+	})
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	return r.withMergeStatus(ctx, pr), nil
+}
+
+// GetGitPullRequest gets a pull request by its number
+func (g *GitStore) GetGitPullRequest(ctx context.Context, option metav1alpha1.GitPullRequestOption) (metav1alpha1.GitPullRequest, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	i, err := findPullRequest(meta, option.Index)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	return r.withMergeStatus(ctx, meta.PullRequests[i]), nil
+}
+
+// ListGitPullRequest lists pull requests with the newest first.
+// Pull requests are filtered by the state, and by the commit which must be reachable from the source branch.
+func (g *GitStore) ListGitPullRequest(ctx context.Context, option metav1alpha1.GitPullRequestListOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestList, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestList{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequestList{}, err
+	}
+	commit := ""
+	if option.Commit != "" {
+		if commit, err = r.resolve(ctx, option.Commit); err != nil {
+			return metav1alpha1.GitPullRequestList{}, err
+		}
+	}
+
+	prs := make([]metav1alpha1.GitPullRequest, 0, len(meta.PullRequests))
+	for i := len(meta.PullRequests) - 1; i >= 0; i-- {
+		pr := meta.PullRequests[i]
+		if option.State != nil && *option.State != metav1alpha1.PullRequestAllState && *option.State != pr.Spec.State {
+			continue
+		}
+		if commit != "" && !r.isAncestor(ctx, commit, branchPrefix+pr.Spec.Source.Name) {
+			continue
+		}
+		prs = append(prs, r.withMergeStatus(ctx, pr))
+	}
+	items, listMeta := page(prs, listOption)
+	return metav1alpha1.GitPullRequestList{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestsListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// isAncestor returns true if the commit is reachable from the revision
+func (r *repository) isAncestor(ctx context.Context, commit, rev string) bool {
+	_, err := r.git(ctx, "merge-base", "--is-ancestor", commit, rev)
+	return err == nil
+}
+
+// CreatePullRequestComment creates a note of the pull request
+func (g *GitStore) CreatePullRequestComment(ctx context.Context, option metav1alpha1.CreatePullRequestCommentPayload) (metav1alpha1.GitPullRequestNote, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestNote{}, err
+	}
+	if option.Body == "" {
+		return metav1alpha1.GitPullRequestNote{}, errors.NewBadRequest("body is required")
+	}
+
+	note := metav1alpha1.GitPullRequestNote{
+		TypeMeta:   typeMeta(metav1alpha1.GitPullRequestNotesGVK),
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(g.now())},
+		Spec:       metav1alpha1.GitPullRequestNoteSpec{Body: option.Body},
+	}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		if _, err := findPullRequest(meta, option.Index); err != nil {
+			return err
+		}
+		note.Spec.ID = meta.nextID()
+		note.Name = strconv.Itoa(note.Spec.ID)
+		if meta.PullRequestNotes == nil {
+			meta.PullRequestNotes = map[int64][]metav1alpha1.GitPullRequestNote{}
+		}
+		number := int64(option.Index)
+		meta.PullRequestNotes[number] = append(meta.PullRequestNotes[number], note)
+		return nil
+	})
+	return note, err
+}
+
+// UpdatePullRequestComment updates the body of a note of the pull request
+func (g *GitStore) UpdatePullRequestComment(ctx context.Context, option metav1alpha1.UpdatePullRequestCommentPayload) (metav1alpha1.GitPullRequestNote, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestNote{}, err
+	}
+	if option.Body == "" {
+		return metav1alpha1.GitPullRequestNote{}, errors.NewBadRequest("body is required")
+	}
+
+	note := metav1alpha1.GitPullRequestNote{}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		if _, err := findPullRequest(meta, option.Index); err != nil {
+			return err
+		}
+		notes := meta.PullRequestNotes[int64(option.Index)]
+		for i := range notes {
+			if notes[i].Spec.ID == option.CommentID {
+				notes[i].Spec.Body = option.Body
+				note = notes[i]
+				return nil
+			}
+		}
+		return errors.NewNotFound(pullRequestNoteResource, strconv.Itoa(option.CommentID))
+	})
+	return note, err
+}
+
+// ListPullRequestComment lists notes of the pull request in the order they are created
+func (g *GitStore) ListPullRequestComment(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestNoteList, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestNoteList{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequestNoteList{}, err
+	}
+	if _, err = findPullRequest(meta, option.Index); err != nil {
+		return metav1alpha1.GitPullRequestNoteList{}, err
+	}
+	items, listMeta := page(meta.PullRequestNotes[int64(option.Index)], listOption)
+	return metav1alpha1.GitPullRequestNoteList{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestNoteListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
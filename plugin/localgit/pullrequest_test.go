@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var _ = Describe("Test.GitStore.PullRequest", func() {
+	var (
+		ctx      context.Context
+		gitStore *GitStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gitStore = newTestStore(ctx)
+	})
+
+	It("creates and lists commit statuses and comments", func() {
+		commit := commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt"})
+		option := metav1alpha1.GitCommitOption{GitRepo: testRepo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: pointer.String("main")}}
+
+		status, err := gitStore.CreateGitCommitStatus(ctx, metav1alpha1.CreateCommitStatusPayload{
+			GitRepo:            testRepo,
+			GitCommitBasicInfo: option.GitCommitBasicInfo,
+			CreateCommitStatusParam: metav1alpha1.CreateCommitStatusParam{
+				State: "success", Context: pointer.String("ci/build"), TargetURL: pointer.String("http://ci/1"),
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(status.Spec.SHA).To(Equal(*commit.Spec.SHA))
+		Expect(status.Spec.Name).To(Equal("ci/build"))
+		Expect(status.Spec.ID).To(Equal(1))
+		_, err = gitStore.CreateGitCommitStatus(ctx, metav1alpha1.CreateCommitStatusPayload{
+			GitRepo:                 testRepo,
+			GitCommitBasicInfo:      option.GitCommitBasicInfo,
+			CreateCommitStatusParam: metav1alpha1.CreateCommitStatusParam{State: "failed", Name: pointer.String("scan")},
+		})
+		Expect(err).To(BeNil())
+		_, err = gitStore.CreateGitCommitStatus(ctx, metav1alpha1.CreateCommitStatusPayload{
+			GitRepo: testRepo, GitCommitBasicInfo: option.GitCommitBasicInfo,
+		})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		comment, err := gitStore.CreateGitCommitComment(ctx, metav1alpha1.CreateCommitCommentPayload{
+			GitRepo:            testRepo,
+			GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: commit.Spec.SHA},
+			CreateCommitCommentParam: metav1alpha1.CreateCommitCommentParam{
+				Note: pointer.String("looks good"), Path: pointer.String("a.txt"), Line: func() *int { i := 1; return &i }(),
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(comment.Spec.Path).To(Equal("a.txt"))
+		Expect(comment.Spec.Line).To(Equal(1))
+		_, err = gitStore.CreateGitCommitComment(ctx, metav1alpha1.CreateCommitCommentPayload{
+			GitRepo: testRepo, GitCommitBasicInfo: option.GitCommitBasicInfo,
+		})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		statuses, err := gitStore.ListGitCommitStatus(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(statuses.TotalItems).To(Equal(2))
+		Expect(statuses.Items[0].Spec.ID).To(Equal(status.Spec.ID))
+		Expect(statuses.Items[0].Spec.TargetURL).To(Equal("http://ci/1"))
+		comments, err := gitStore.ListGitCommitComment(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(comments.Items).To(HaveLen(1))
+		Expect(comments.Items[0].Name).To(Equal(comment.Name))
+		Expect(comments.Items[0].Spec.Note).To(Equal("looks good"))
+
+		// statuses of other commits are not listed
+		option.SHA = pointer.String("main~1")
+		statuses, err = gitStore.ListGitCommitStatus(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(statuses.Items).To(BeEmpty())
+		option.SHA = pointer.String("missing")
+		_, err = gitStore.ListGitCommitStatus(ctx, option, metav1alpha1.ListOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("creates pull requests with merge status", func() {
+		commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt", Content: "a"})
+		_, err := gitStore.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+			GitRepo: testRepo,
+			GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+				Branch:            "feature",
+				Message:           "change a\n\ndetails",
+				Actions:           []coderepositoryv1alpha1.CreateCommitAction{{Action: "update", FilePath: "a.txt", Content: "feature"}},
+				CreatePullRequest: true,
+			}},
+		})
+		Expect(err).To(BeNil())
+
+		pr, err := gitStore.GetGitPullRequest(ctx, metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1})
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.Title).To(Equal("change a"))
+		Expect(pr.Spec.State).To(Equal(metav1alpha1.PullRequestOpenedState))
+		Expect(pr.Spec.Source.Name).To(Equal("feature"))
+		Expect(pr.Spec.Target.Name).To(Equal("main"))
+		Expect(pr.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCanBeMerged))
+
+		// conflicting changes on the target branch
+		commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "main"})
+		pr, err = gitStore.GetGitPullRequest(ctx, metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1})
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCannotBeMerged))
+		Expect(pr.Spec.HasConflicts).To(BeTrue())
+
+		payload := metav1alpha1.CreatePullRequestPayload{
+			Source: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "feature"},
+			Target: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "main"},
+			Title:  "duplicated",
+		}
+		_, err = gitStore.CreatePullRequest(ctx, payload)
+		Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+		payload.Source.Name = "main"
+		_, err = gitStore.CreatePullRequest(ctx, payload)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		payload.Source.Name = "missing"
+		_, err = gitStore.CreatePullRequest(ctx, payload)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		payload.Source = metav1alpha1.GitBranchBaseInfo{GitRepo: metav1alpha1.GitRepo{Project: "fork", Repository: "demo"}, Name: "feature"}
+		_, err = gitStore.CreatePullRequest(ctx, payload)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		_, err = gitStore.GetGitPullRequest(ctx, metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 2})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("lists pull requests by state and commit", func() {
+		first := commitFiles(ctx, gitStore, "first", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "first.txt"})
+		commitFiles(ctx, gitStore, "second", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "second.txt"})
+		for _, branch := range []string{"first", "second"} {
+			_, err := gitStore.CreatePullRequest(ctx, metav1alpha1.CreatePullRequestPayload{
+				Source:      metav1alpha1.GitBranchBaseInfo{Name: branch},
+				Target:      metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "main"},
+				Title:       branch,
+				Description: "description of " + branch,
+			})
+			Expect(err).To(BeNil())
+		}
+
+		list, err := gitStore.ListGitPullRequest(ctx, metav1alpha1.GitPullRequestListOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.TotalItems).To(Equal(2))
+		Expect(list.Items[0].Spec.Title).To(Equal("second"))
+		Expect(string(list.Items[0].Spec.Properties.Raw)).To(ContainSubstring("description of second"))
+
+		list, err = gitStore.ListGitPullRequest(ctx, metav1alpha1.GitPullRequestListOption{GitRepo: testRepo, Commit: *first.Spec.SHA}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Spec.Title).To(Equal("first"))
+
+		for state, count := range map[metav1alpha1.PullRequestState]int{
+			metav1alpha1.PullRequestOpenedState: 2,
+			metav1alpha1.PullRequestAllState:    2,
+			metav1alpha1.PullRequestClosedState: 0,
+		} {
+			list, err = gitStore.ListGitPullRequest(ctx, metav1alpha1.GitPullRequestListOption{GitRepo: testRepo, State: &state}, metav1alpha1.ListOptions{})
+			Expect(err).To(BeNil())
+			Expect(list.Items).To(HaveLen(count), string(state))
+		}
+	})
+
+	It("creates, updates and lists pull request comments", func() {
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt"})
+		_, err := gitStore.CreatePullRequest(ctx, metav1alpha1.CreatePullRequestPayload{
+			Source: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "feature"},
+			Target: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "main"},
+			Title:  "feature",
+		})
+		Expect(err).To(BeNil())
+
+		note, err := gitStore.CreatePullRequestComment(ctx, metav1alpha1.CreatePullRequestCommentPayload{
+			GitRepo: testRepo, Index: 1, CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: "first"},
+		})
+		Expect(err).To(BeNil())
+		_, err = gitStore.CreatePullRequestComment(ctx, metav1alpha1.CreatePullRequestCommentPayload{
+			GitRepo: testRepo, Index: 1, CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: "second"},
+		})
+		Expect(err).To(BeNil())
+		_, err = gitStore.CreatePullRequestComment(ctx, metav1alpha1.CreatePullRequestCommentPayload{
+			GitRepo: testRepo, Index: 2, CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: "missing"},
+		})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		updated, err := gitStore.UpdatePullRequestComment(ctx, metav1alpha1.UpdatePullRequestCommentPayload{
+			GitRepo: testRepo, Index: 1, CommentID: note.Spec.ID,
+			CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: "edited"},
+		})
+		Expect(err).To(BeNil())
+		Expect(updated.Spec.ID).To(Equal(note.Spec.ID))
+		Expect(updated.Spec.Body).To(Equal("edited"))
+		_, err = gitStore.UpdatePullRequestComment(ctx, metav1alpha1.UpdatePullRequestCommentPayload{
+			GitRepo: testRepo, Index: 1, CommentID: 100,
+			CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: "edited"},
+		})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		notes, err := gitStore.ListPullRequestComment(ctx, metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(notes.TotalItems).To(Equal(2))
+		Expect(notes.Items[0].Spec.Body).To(Equal("edited"))
+		Expect(notes.Items[1].Spec.Body).To(Equal("second"))
+
+		// metadata is persisted next to the repository
+		reopened := NewGitStore("local-git", gitStore.root)
+		notes, err = reopened.ListPullRequestComment(ctx, metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(notes.Items).To(HaveLen(2))
+		Expect(gitStore.root + "/group/sub/demo.json").To(BeAnExistingFile())
+	})
+})
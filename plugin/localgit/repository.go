@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getRepository returns the repository with the time it was created and last updated
+func (g *GitStore) getRepository(ctx context.Context, r *repository) (metav1alpha1.GitRepository, error) {
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+	createdAt := meta.CreatedAt
+	if createdAt.IsZero() {
+		// repositories not created by the plugin have no metadata
+		if info, err := os.Stat(r.dir); err == nil {
+			createdAt = metav1.NewTime(info.ModTime())
+		}
+	}
+	updatedAt := createdAt
+	refs, err := r.forEachRef(ctx, branchPrefix, branchFields...)
+	if err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+	for _, values := range refs {
+		if committed := parseTime(values[2]); updatedAt.Before(&committed) {
+			updatedAt = committed
+		}
+	}
+
+	cloneURL := (&url.URL{Scheme: "file", Path: filepath.ToSlash(r.dir)}).String()
+	return metav1alpha1.GitRepository{
+		TypeMeta:   typeMeta(metav1alpha1.GitRepoGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: r.Repository, CreationTimestamp: createdAt},
+		Spec: metav1alpha1.GitRepositorySpec{
+			Name:          r.Repository,
+			HttpCloneURL:  cloneURL,
+			DefaultBranch: r.defaultBranch(ctx),
+			CreatedAt:     createdAt,
+			UpdatedAt:     updatedAt,
+			Owner:         metav1alpha1.GitUserBaseInfo{Name: r.Project},
+		},
+	}, nil
+}
+
+// ListGitRepository lists repositories of the project, repositories are filtered by the keyword if provided.
+// The sub type is ignored because projects are only directories.
+func (g *GitStore) ListGitRepository(ctx context.Context, id, keyword string, _ metav1alpha1.ProjectSubType, listOption metav1alpha1.ListOptions) (metav1alpha1.GitRepositoryList, error) {
+	list := metav1alpha1.GitRepositoryList{TypeMeta: typeMeta(metav1alpha1.GitRepoListGVK)}
+	if err := validateProject(id); err != nil {
+		return list, err
+	}
+	entries, err := os.ReadDir(filepath.Join(g.root, filepath.FromSlash(id)))
+	if err != nil && !os.IsNotExist(err) {
+		return list, err
+	}
+
+	repositories := make([]metav1alpha1.GitRepository, 0, len(entries))
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), repositorySuffix)
+		if !found || !entry.IsDir() || !strings.Contains(name, keyword) {
+			continue
+		}
+		r, err := g.repositoryPath(metav1alpha1.GitRepo{Project: id, Repository: name})
+		if err != nil {
+			continue
+		}
+		repository, err := g.getRepository(ctx, r)
+		if err != nil {
+			return list, err
+		}
+		repositories = append(repositories, repository)
+	}
+	list.Items, list.ListMeta = page(repositories, listOption)
+	return list, nil
+}
+
+// GetGitRepository gets a repository
+func (g *GitStore) GetGitRepository(ctx context.Context, repoOption metav1alpha1.GitRepo) (metav1alpha1.GitRepository, error) {
+	r, err := g.repository(repoOption)
+	if err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+	return g.getRepository(ctx, r)
+}
+
+// CreateGitRepository creates a bare repository with main as the default branch,
+// a commit with a README.md is created if auto init is requested.
+// The visibility is ignored because the repositories are only accessible locally.
+func (g *GitStore) CreateGitRepository(ctx context.Context, payload metav1alpha1.CreateGitRepositoryPayload) (metav1alpha1.GitRepository, error) {
+	r, err := g.repositoryPath(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+	if _, err = os.Stat(r.dir); err == nil {
+		return metav1alpha1.GitRepository{}, errors.NewAlreadyExists(metav1alpha1.GroupVersion.WithResource("gitrepositories").GroupResource(), payload.String())
+	}
+	if err = os.MkdirAll(filepath.Dir(r.dir), 0o755); err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+	if _, err = runGit(ctx, "", nil, nil, "init", "--quiet", "--bare", "--initial-branch="+defaultBranch, r.dir); err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		meta.CreatedAt = metav1.NewTime(g.now())
+		return nil
+	})
+	if err != nil {
+		return metav1alpha1.GitRepository{}, err
+	}
+
+	if payload.AutoInit {
+		title := payload.DisplayName
+		if title == "" {
+			title = payload.Repository
+		}
+		_, err = g.createCommit(ctx, r, coderepositoryv1alpha1.GitCreateCommitSpec{
+			Branch:  defaultBranch,
+			Message: "Initial commit",
+			Actions: []coderepositoryv1alpha1.CreateCommitAction{{
+				Action:   actionCreate,
+				FilePath: "README.md",
+				Content:  fmt.Sprintf("# %s\n", title),
+			}},
+		})
+		if err != nil {
+			return metav1alpha1.GitRepository{}, err
+		}
+	}
+	return g.getRepository(ctx, r)
+}
+
+// DeleteGitRepository deletes a repository with its metadata
+func (g *GitStore) DeleteGitRepository(_ context.Context, gitRepo metav1alpha1.GitRepo) error {
+	r, err := g.repository(gitRepo)
+	if err != nil {
+		return err
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if err = os.RemoveAll(r.dir); err != nil {
+		return err
+	}
+	if err = os.Remove(r.meta); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// emptyTree is the sha of the tree without any file, changes of
+// unrelated branches are compared from it
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// getPullRequest returns the repository and the pull request
+func (g *GitStore) getPullRequest(option metav1alpha1.GitPullRequestOption) (*repository, metav1alpha1.GitPullRequest, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return nil, metav1alpha1.GitPullRequest{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return nil, metav1alpha1.GitPullRequest{}, err
+	}
+	i, err := findPullRequest(meta, option.Index)
+	if err != nil {
+		return nil, metav1alpha1.GitPullRequest{}, err
+	}
+	return r, meta.PullRequests[i], nil
+}
+
+// changes returns the commits the changes of the pull request are compared between.
+// The changes of merged pull requests are kept, and changes of the others are
+// compared from the merge base of the branches to the source branch.
+func (r *repository) changes(ctx context.Context, pr metav1alpha1.GitPullRequest) (base, head string, err error) {
+	if props := properties(pr); props.HeadSHA != "" {
+		return props.BaseSHA, props.HeadSHA, nil
+	}
+	if head, err = r.resolve(ctx, branchPrefix+pr.Spec.Source.Name); err != nil {
+		return "", "", err
+	}
+	target, err := r.resolve(ctx, branchPrefix+pr.Spec.Target.Name)
+	if err != nil {
+		return "", "", err
+	}
+	return r.mergeBase(ctx, target, head), head, nil
+}
+
+// mergeBase returns the best common ancestor of the commits, the empty tree is returned if there is none
+func (r *repository) mergeBase(ctx context.Context, a, b string) string {
+	out, err := r.git(ctx, "merge-base", a, b)
+	if err != nil {
+		return emptyTree
+	}
+	return strings.TrimSpace(out)
+}
+
+// ListPullRequestFiles lists files changed by the pull request in the order of paths
+func (g *GitStore) ListPullRequestFiles(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestFileList, error) {
+	r, pr, err := g.getPullRequest(option)
+	if err != nil {
+		return metav1alpha1.GitPullRequestFileList{}, err
+	}
+	base, head, err := r.changes(ctx, pr)
+	if err != nil {
+		return metav1alpha1.GitPullRequestFileList{}, err
+	}
+	files, err := r.diffFiles(ctx, base, head)
+	if err != nil {
+		return metav1alpha1.GitPullRequestFileList{}, err
+	}
+	items, listMeta := page(files, listOption)
+	return metav1alpha1.GitPullRequestFileList{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestFileListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// diffFiles returns the files changed between the commits.
+// Statuses and line counts are listed by two commands with the same options,
+// so the files are listed in the same order.
+func (r *repository) diffFiles(ctx context.Context, base, head string) ([]metav1alpha1.GitPullRequestFile, error) {
+	out, err := r.git(ctx, "diff", "--name-status", "-z", "-M", base, head)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]metav1alpha1.GitPullRequestFile, 0)
+	tokens := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i+1 < len(tokens); i += 2 {
+		spec := metav1alpha1.GitPullRequestFileSpec{Path: tokens[i+1]}
+		switch status := tokens[i]; status[0] {
+		case 'A':
+			spec.Status = metav1alpha1.FileChangeStatusAdded
+		case 'D':
+			spec.Status = metav1alpha1.FileChangeStatusDeleted
+		case 'R':
+			// renames are followed by the old and the new paths
+			spec.Status = metav1alpha1.FileChangeStatusRenamed
+			spec.OldPath, spec.Path = tokens[i+1], tokens[i+2]
+			i++
+		default:
+			spec.Status = metav1alpha1.FileChangeStatusModified
+		}
+		files = append(files, metav1alpha1.GitPullRequestFile{
+			TypeMeta:   typeMeta(metav1alpha1.GitPullRequestFileGVK),
+			ObjectMeta: metav1.ObjectMeta{Name: spec.Path},
+			Spec:       spec,
+		})
+	}
+
+	out, err = r.git(ctx, "diff", "--numstat", "-z", "-M", base, head)
+	if err != nil {
+		return nil, err
+	}
+	tokens = strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i, file := 0, 0; i < len(tokens) && file < len(files); i, file = i+1, file+1 {
+		counts := strings.SplitN(tokens[i], "\t", 3)
+		if len(counts) != 3 {
+			return nil, fmt.Errorf("unexpected numstat output %q", tokens[i])
+		}
+		if counts[2] == "" {
+			// renames are followed by the old and the new paths
+			i += 2
+		}
+		spec := &files[file].Spec
+		if counts[0] == "-" {
+			spec.Binary = true
+			continue
+		}
+		spec.Additions, _ = strconv.Atoi(counts[0])
+		spec.Deletions, _ = strconv.Atoi(counts[1])
+	}
+	return files, nil
+}
+
+// GetPullRequestDiff returns the changes of the pull request in unified diff format
+func (g *GitStore) GetPullRequestDiff(ctx context.Context, option metav1alpha1.GitPullRequestDiffOption) (metav1alpha1.GitPullRequestDiff, error) {
+	r, pr, err := g.getPullRequest(option.GitPullRequestOption)
+	if err != nil {
+		return metav1alpha1.GitPullRequestDiff{}, err
+	}
+	base, head, err := r.changes(ctx, pr)
+	if err != nil {
+		return metav1alpha1.GitPullRequestDiff{}, err
+	}
+	args := []string{"diff", "-M", "--full-index", base, head}
+	if option.Path != "" {
+		file, err := cleanFilePath(option.Path)
+		if err != nil {
+			return metav1alpha1.GitPullRequestDiff{}, err
+		}
+		args = append(args, "--", file)
+	}
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return metav1alpha1.GitPullRequestDiff{}, err
+	}
+	return metav1alpha1.GitPullRequestDiff{
+		TypeMeta:   typeMeta(metav1alpha1.GitPullRequestDiffGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: pr.Name},
+		Spec: metav1alpha1.GitPullRequestDiffSpec{
+			BaseSHA: base,
+			HeadSHA: head,
+			Diff:    out,
+		},
+	}, nil
+}
+
+// ListPullRequestReviews lists reviews of the pull request in the order they are submitted
+func (g *GitStore) ListPullRequestReviews(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestReviewList, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequestReviewList{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitPullRequestReviewList{}, err
+	}
+	if _, err = findPullRequest(meta, option.Index); err != nil {
+		return metav1alpha1.GitPullRequestReviewList{}, err
+	}
+	items, listMeta := page(meta.PullRequestReviews[int64(option.Index)], listOption)
+	return metav1alpha1.GitPullRequestReviewList{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestReviewListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// CreatePullRequestReview submits a review of an opened pull request by the committer of the store.
+// The latest commit of the source branch is reviewed if the commit is not provided.
+func (g *GitStore) CreatePullRequestReview(ctx context.Context, payload metav1alpha1.CreatePullRequestReviewPayload) (metav1alpha1.GitPullRequestReview, error) {
+	param := payload.CreatePullRequestReviewParam
+	if !param.State.IsValid() {
+		return metav1alpha1.GitPullRequestReview{}, errors.NewBadRequest(fmt.Sprintf("invalid review state %q", param.State))
+	}
+	if param.State != metav1alpha1.PullRequestReviewApproved && param.Body == "" {
+		return metav1alpha1.GitPullRequestReview{}, errors.NewBadRequest("body is required unless approving")
+	}
+	r, pr, err := g.getPullRequest(metav1alpha1.GitPullRequestOption{GitRepo: payload.GitRepo, Index: payload.Index})
+	if err != nil {
+		return metav1alpha1.GitPullRequestReview{}, err
+	}
+	if pr.Spec.State != metav1alpha1.PullRequestOpenedState {
+		return metav1alpha1.GitPullRequestReview{}, errors.NewBadRequest(fmt.Sprintf("pull request is %s", pr.Spec.State))
+	}
+	rev := param.CommitSHA
+	if rev == "" {
+		rev = branchPrefix + pr.Spec.Source.Name
+	}
+	sha, err := r.resolve(ctx, rev)
+	if err != nil {
+		return metav1alpha1.GitPullRequestReview{}, err
+	}
+
+	review := metav1alpha1.GitPullRequestReview{
+		TypeMeta: typeMeta(metav1alpha1.GitPullRequestReviewGVK),
+		Spec: metav1alpha1.GitPullRequestReviewSpec{
+			State:       param.State,
+			Body:        param.Body,
+			CommitSHA:   sha,
+			Author:      g.committer,
+			SubmittedAt: metav1.NewTime(g.now()),
+		},
+	}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		if _, err := findPullRequest(meta, payload.Index); err != nil {
+			return err
+		}
+		review.Spec.ID = meta.nextID()
+		review.Name = strconv.Itoa(review.Spec.ID)
+		if meta.PullRequestReviews == nil {
+			meta.PullRequestReviews = map[int64][]metav1alpha1.GitPullRequestReview{}
+		}
+		number := int64(payload.Index)
+		meta.PullRequestReviews[number] = append(meta.PullRequestReviews[number], review)
+		return nil
+	})
+	return review, err
+}
+
+// RequestPullRequestReviewers adds reviewers to the pull request, reviewers already requested are skipped
+func (g *GitStore) RequestPullRequestReviewers(ctx context.Context, payload metav1alpha1.RequestPullRequestReviewersPayload) (metav1alpha1.GitPullRequest, error) {
+	if len(payload.Reviewers) == 0 {
+		return metav1alpha1.GitPullRequest{}, errors.NewBadRequest("reviewers are required")
+	}
+	for _, reviewer := range payload.Reviewers {
+		if reviewer.Name == "" {
+			return metav1alpha1.GitPullRequest{}, errors.NewBadRequest("name of reviewers is required")
+		}
+	}
+	r, err := g.repository(payload.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+
+	pr := metav1alpha1.GitPullRequest{}
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		i, err := findPullRequest(meta, payload.Index)
+		if err != nil {
+			return err
+		}
+		spec := &meta.PullRequests[i].Spec
+		for _, reviewer := range payload.Reviewers {
+			requested := false
+			for _, existing := range spec.Reviewers {
+				requested = requested || existing.Name == reviewer.Name
+			}
+			if !requested {
+				spec.Reviewers = append(spec.Reviewers, reviewer)
+			}
+		}
+		now := metav1.NewTime(g.now())
+		spec.UpdateAt = &now
+		pr = meta.PullRequests[i]
+		return nil
+	})
+	if err != nil {
+		return metav1alpha1.GitPullRequest{}, err
+	}
+	return r.withMergeStatus(ctx, pr), nil
+}
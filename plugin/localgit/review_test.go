@@ -0,0 +1,308 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// openPullRequest opens a pull request from the branch to main
+func openPullRequest(ctx context.Context, gitStore *GitStore, branch string) metav1alpha1.GitPullRequest {
+	pr, err := gitStore.CreatePullRequest(ctx, metav1alpha1.CreatePullRequestPayload{
+		Source: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: branch},
+		Target: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "main"},
+		Title:  branch,
+	})
+	Expect(err).To(BeNil())
+	return pr
+}
+
+// readRef returns the commit the revision points to
+func readRef(ctx context.Context, gitStore *GitStore, rev string) string {
+	r, err := gitStore.repository(testRepo)
+	Expect(err).To(BeNil())
+	sha, err := r.resolve(ctx, rev)
+	Expect(err).To(BeNil())
+	return sha
+}
+
+// parents returns the parents of the commit
+func parents(ctx context.Context, gitStore *GitStore, rev string) []string {
+	r, err := gitStore.repository(testRepo)
+	Expect(err).To(BeNil())
+	out, err := r.git(ctx, "rev-list", "--parents", "-n", "1", rev)
+	Expect(err).To(BeNil())
+	return strings.Fields(out)[1:]
+}
+
+var _ = Describe("Test.GitStore.Review", func() {
+	var (
+		ctx      context.Context
+		gitStore *GitStore
+		option   metav1alpha1.GitPullRequestOption
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gitStore = newTestStore(ctx)
+		option = metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1}
+		commitFiles(ctx, gitStore, "main",
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "a.txt", Content: "a\n"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "old.txt", Content: "renamed\n"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "removed.txt", Content: "removed\n"},
+		)
+	})
+
+	It("lists changed files and diffs of pull requests", func() {
+		commitFiles(ctx, gitStore, "feature",
+			coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "a\nb\n"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "move", FilePath: "new.txt", PreviousPath: "old.txt"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "delete", FilePath: "removed.txt"},
+			coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "image.png",
+				Encoding: "base64", Content: base64.StdEncoding.EncodeToString([]byte{0, 1, 2})},
+		)
+		// changes of the target branch are not part of the pull request
+		commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "main.txt"})
+		openPullRequest(ctx, gitStore, "feature")
+
+		files, err := gitStore.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(files.TotalItems).To(Equal(4))
+		specs := map[string]metav1alpha1.GitPullRequestFileSpec{}
+		for _, file := range files.Items {
+			specs[file.Spec.Path] = file.Spec
+		}
+		Expect(specs["a.txt"]).To(Equal(metav1alpha1.GitPullRequestFileSpec{
+			Path: "a.txt", Status: metav1alpha1.FileChangeStatusModified, Additions: 1,
+		}))
+		Expect(specs["new.txt"]).To(Equal(metav1alpha1.GitPullRequestFileSpec{
+			Path: "new.txt", OldPath: "old.txt", Status: metav1alpha1.FileChangeStatusRenamed,
+		}))
+		Expect(specs["removed.txt"]).To(Equal(metav1alpha1.GitPullRequestFileSpec{
+			Path: "removed.txt", Status: metav1alpha1.FileChangeStatusDeleted, Deletions: 1,
+		}))
+		Expect(specs["image.png"]).To(Equal(metav1alpha1.GitPullRequestFileSpec{
+			Path: "image.png", Status: metav1alpha1.FileChangeStatusAdded, Binary: true,
+		}))
+
+		files, err = gitStore.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{Page: 2, ItemsPerPage: 3})
+		Expect(err).To(BeNil())
+		Expect(files.Items).To(HaveLen(1))
+
+		diff, err := gitStore.GetPullRequestDiff(ctx, metav1alpha1.GitPullRequestDiffOption{GitPullRequestOption: option})
+		Expect(err).To(BeNil())
+		Expect(diff.Spec.HeadSHA).To(Equal(readRef(ctx, gitStore, "feature")))
+		Expect(diff.Spec.Diff).To(ContainSubstring("+b\n"))
+		Expect(diff.Spec.Diff).To(ContainSubstring("rename from old.txt"))
+		Expect(diff.Spec.Diff).NotTo(ContainSubstring("main.txt"))
+
+		diff, err = gitStore.GetPullRequestDiff(ctx, metav1alpha1.GitPullRequestDiffOption{GitPullRequestOption: option, Path: "a.txt"})
+		Expect(err).To(BeNil())
+		Expect(diff.Spec.Diff).To(HavePrefix("diff --git a/a.txt b/a.txt"))
+		Expect(diff.Spec.Diff).NotTo(ContainSubstring("removed.txt"))
+
+		option.Index = 2
+		_, err = gitStore.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("submits reviews and requests reviewers", func() {
+		commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "b\n"})
+		openPullRequest(ctx, gitStore, "feature")
+
+		payload := metav1alpha1.CreatePullRequestReviewPayload{GitRepo: testRepo, Index: 1}
+		payload.State = metav1alpha1.PullRequestReviewApproved
+		approved, err := gitStore.CreatePullRequestReview(ctx, payload)
+		Expect(err).To(BeNil())
+		Expect(approved.Spec.ID).NotTo(BeZero())
+		Expect(approved.Spec.CommitSHA).To(Equal(readRef(ctx, gitStore, "feature")))
+		Expect(approved.Spec.Author).To(Equal(gitStore.committer))
+
+		payload.State = metav1alpha1.PullRequestReviewCommented
+		_, err = gitStore.CreatePullRequestReview(ctx, payload)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		payload.State = metav1alpha1.PullRequestReviewDismissed
+		_, err = gitStore.CreatePullRequestReview(ctx, payload)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		payload.State, payload.Body, payload.CommitSHA = metav1alpha1.PullRequestReviewCommented, "nit", "main"
+		commented, err := gitStore.CreatePullRequestReview(ctx, payload)
+		Expect(err).To(BeNil())
+		Expect(commented.Spec.CommitSHA).To(Equal(readRef(ctx, gitStore, "main")))
+
+		reviews, err := gitStore.ListPullRequestReviews(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(reviews.TotalItems).To(Equal(2))
+		Expect(reviews.Items[0].Spec.ID).To(Equal(approved.Spec.ID))
+		Expect(reviews.Items[1].Spec.Body).To(Equal("nit"))
+
+		alice := metav1alpha1.GitUserBaseInfo{Name: "alice", Email: "alice@example.com"}
+		bob := metav1alpha1.GitUserBaseInfo{Name: "bob"}
+		pr, err := gitStore.RequestPullRequestReviewers(ctx, metav1alpha1.RequestPullRequestReviewersPayload{
+			GitRepo: testRepo, Index: 1,
+			RequestPullRequestReviewersParam: metav1alpha1.RequestPullRequestReviewersParam{Reviewers: []metav1alpha1.GitUserBaseInfo{alice}},
+		})
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCanBeMerged))
+		pr, err = gitStore.RequestPullRequestReviewers(ctx, metav1alpha1.RequestPullRequestReviewersPayload{
+			GitRepo: testRepo, Index: 1,
+			RequestPullRequestReviewersParam: metav1alpha1.RequestPullRequestReviewersParam{Reviewers: []metav1alpha1.GitUserBaseInfo{alice, bob}},
+		})
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.Reviewers).To(Equal([]metav1alpha1.GitUserBaseInfo{alice, bob}))
+		_, err = gitStore.RequestPullRequestReviewers(ctx, metav1alpha1.RequestPullRequestReviewersPayload{GitRepo: testRepo, Index: 1})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		_, err = gitStore.RequestPullRequestReviewers(ctx, metav1alpha1.RequestPullRequestReviewersPayload{
+			GitRepo: testRepo, Index: 2,
+			RequestPullRequestReviewersParam: metav1alpha1.RequestPullRequestReviewersParam{Reviewers: []metav1alpha1.GitUserBaseInfo{bob}},
+		})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	Describe("merging pull requests", func() {
+		var merge metav1alpha1.MergePullRequestPayload
+
+		BeforeEach(func() {
+			commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "feature\n"})
+			commitFiles(ctx, gitStore, "feature", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "b.txt", Content: "b\n"})
+			// unrelated changes on the target branch, pull requests are not fast-forwarded
+			commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "create", FilePath: "main.txt"})
+			openPullRequest(ctx, gitStore, "feature")
+			merge = metav1alpha1.MergePullRequestPayload{GitRepo: testRepo, Index: 1}
+		})
+
+		It("checks mergeability", func() {
+			mergeability, err := gitStore.GetPullRequestMergeability(ctx, option)
+			Expect(err).To(BeNil())
+			Expect(mergeability.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCanBeMerged))
+			Expect(mergeability.Spec.SourceSHA).To(Equal(readRef(ctx, gitStore, "feature")))
+			Expect(mergeability.Spec.TargetSHA).To(Equal(readRef(ctx, gitStore, "main")))
+			Expect(mergeability.Spec.Strategies).To(ConsistOf(
+				metav1alpha1.MergeStrategyMerge, metav1alpha1.MergeStrategySquash, metav1alpha1.MergeStrategyRebase))
+			Expect(mergeability.Spec.Reasons).To(BeEmpty())
+
+			review := metav1alpha1.CreatePullRequestReviewPayload{GitRepo: testRepo, Index: 1}
+			review.State, review.Body = metav1alpha1.PullRequestReviewChangesRequested, "fix it"
+			_, err = gitStore.CreatePullRequestReview(ctx, review)
+			Expect(err).To(BeNil())
+			commitFiles(ctx, gitStore, "main", coderepositoryv1alpha1.CreateCommitAction{Action: "update", FilePath: "a.txt", Content: "main\n"})
+
+			mergeability, err = gitStore.GetPullRequestMergeability(ctx, option)
+			Expect(err).To(BeNil())
+			Expect(mergeability.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCannotBeMerged))
+			Expect(mergeability.Spec.HasConflicts).To(BeTrue())
+			Expect(mergeability.Spec.Reasons).To(HaveLen(2))
+			_, err = gitStore.MergePullRequest(ctx, merge)
+			Expect(errors.IsConflict(err)).To(BeTrue())
+
+			// the latest review of each reviewer counts
+			review.State = metav1alpha1.PullRequestReviewApproved
+			_, err = gitStore.CreatePullRequestReview(ctx, review)
+			Expect(err).To(BeNil())
+			mergeability, err = gitStore.GetPullRequestMergeability(ctx, option)
+			Expect(err).To(BeNil())
+			Expect(mergeability.Spec.Reasons).To(HaveLen(1))
+		})
+
+		It("merges with a merge commit", func() {
+			merge.RemoveSourceBranch = pointer.Bool(true)
+			pr, err := gitStore.MergePullRequest(ctx, merge)
+			Expect(err).To(BeNil())
+			Expect(pr.Spec.State).To(Equal(metav1alpha1.PullRequestMergedState))
+			Expect(pr.Spec.MergedBy).To(Equal(gitStore.committer))
+			Expect(pr.Spec.MergeLog.Time).NotTo(BeNil())
+
+			commit, err := gitStore.GetGitCommit(ctx, metav1alpha1.GitCommitOption{
+				GitRepo: testRepo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: pointer.String("main")},
+			})
+			Expect(err).To(BeNil())
+			Expect(*commit.Spec.Message).To(HavePrefix("Merge branch 'feature' into 'main'"))
+			Expect(parents(ctx, gitStore, "main")).To(HaveLen(2))
+			Expect(readFile(ctx, gitStore, "main", "b.txt")).To(Equal("b\n"))
+			_, err = gitStore.GetGitBranch(ctx, testRepo, "feature")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			// changes of merged pull requests are kept
+			files, err := gitStore.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{})
+			Expect(err).To(BeNil())
+			Expect(files.Items).To(HaveLen(2))
+			mergeability, err := gitStore.GetPullRequestMergeability(ctx, option)
+			Expect(err).To(BeNil())
+			Expect(mergeability.Spec.Reasons).To(ConsistOf("pull request is merged"))
+			_, err = gitStore.MergePullRequest(ctx, merge)
+			Expect(errors.IsBadRequest(err)).To(BeTrue())
+			review := metav1alpha1.CreatePullRequestReviewPayload{GitRepo: testRepo, Index: 1}
+			review.State = metav1alpha1.PullRequestReviewApproved
+			_, err = gitStore.CreatePullRequestReview(ctx, review)
+			Expect(errors.IsBadRequest(err)).To(BeTrue())
+		})
+
+		It("squashes changes into a commit", func() {
+			merge.Strategy, merge.CommitMessage = metav1alpha1.MergeStrategySquash, "squashed\n"
+			target := readRef(ctx, gitStore, "main")
+			_, err := gitStore.MergePullRequest(ctx, merge)
+			Expect(err).To(BeNil())
+
+			commit, err := gitStore.GetGitCommit(ctx, metav1alpha1.GitCommitOption{
+				GitRepo: testRepo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: pointer.String("main")},
+			})
+			Expect(err).To(BeNil())
+			Expect(*commit.Spec.Message).To(HavePrefix("squashed"))
+			Expect(parents(ctx, gitStore, "main")).To(Equal([]string{target}))
+			Expect(readFile(ctx, gitStore, "main", "a.txt")).To(Equal("feature\n"))
+			// the source branch is kept by default
+			_, err = gitStore.GetGitBranch(ctx, testRepo, "feature")
+			Expect(err).To(BeNil())
+		})
+
+		It("rebases commits on the target branch", func() {
+			merge.Strategy = metav1alpha1.MergeStrategyRebase
+			target := readRef(ctx, gitStore, "main")
+			_, err := gitStore.MergePullRequest(ctx, merge)
+			Expect(err).To(BeNil())
+
+			commits, err := gitStore.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: testRepo, Ref: "main"}, metav1alpha1.ListOptions{})
+			Expect(err).To(BeNil())
+			Expect(*commits.Items[2].Spec.SHA).To(Equal(target))
+			Expect(*commits.Items[0].Spec.Message).To(HavePrefix("update feature"))
+			Expect(parents(ctx, gitStore, "main")).To(HaveLen(1))
+			Expect(*commits.Items[0].Spec.Author).To(Equal(gitStore.committer))
+			Expect(readFile(ctx, gitStore, "main", "b.txt")).To(Equal("b\n"))
+			Expect(readFile(ctx, gitStore, "main", "main.txt")).To(Equal(""))
+		})
+
+		It("rejects invalid merges", func() {
+			merge.Strategy = "fast-forward"
+			_, err := gitStore.MergePullRequest(ctx, merge)
+			Expect(errors.IsBadRequest(err)).To(BeTrue())
+			merge.Strategy, merge.SHA = metav1alpha1.MergeStrategyMerge, readRef(ctx, gitStore, "main")
+			_, err = gitStore.MergePullRequest(ctx, merge)
+			Expect(errors.IsConflict(err)).To(BeTrue())
+			merge.Index = 2
+			_, err = gitStore.MergePullRequest(ctx, merge)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/go-resty/resty/v2"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client/base"
+	v2 "github.com/katanomi/pkg/plugin/client/v2"
+	"github.com/katanomi/pkg/plugin/route"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var _ = Describe("Test.GitStore.Route", func() {
+	var (
+		ctx          context.Context
+		server       *httptest.Server
+		pluginClient *v2.PluginClient
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ws, err := route.NewService(newTestStore(ctx), base.MetaFilter)
+		Expect(err).To(BeNil())
+		// same router as sharedmain.AppBuilder
+		container := restful.NewContainer()
+		container.Router(restful.RouterJSR311{})
+		container.Add(ws)
+		server = httptest.NewServer(container)
+		DeferCleanup(server.Close)
+
+		address, err := apis.ParseURL(server.URL + "/plugins/v1alpha1/local-git/")
+		Expect(err).To(BeNil())
+		pluginClient = v2.NewPluginClient(&duckv1.Addressable{URL: address}, base.Meta{}, corev1.Secret{},
+			base.ClientOpts(resty.New()))
+	})
+
+	It("serves the git plugin apis", func() {
+		repo, err := pluginClient.GetGitRepository(ctx, testRepo)
+		Expect(err).To(BeNil())
+		Expect(repo.Spec.Name).To(Equal("demo"))
+
+		_, err = pluginClient.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+			GitRepo:  testRepo,
+			FilePath: "docs/guide.md",
+			CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{
+				Branch: "main", Message: "add guide", Content: []byte("# guide\n"),
+			},
+		})
+		Expect(err).To(BeNil())
+		file, err := pluginClient.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{GitRepo: testRepo, Ref: "main", Path: "docs/guide.md"})
+		Expect(err).To(BeNil())
+		content, err := base64.StdEncoding.DecodeString(string(file.Spec.Content))
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("# guide\n"))
+
+		branches, err := pluginClient.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(branches.Items).To(HaveLen(1))
+
+		_, err = pluginClient.GetGitRepository(ctx, metav1alpha1.GitRepo{Project: "group/sub", Repository: "missing"})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("serves the pull request review apis", func() {
+		_, err := pluginClient.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+			GitRepo:  testRepo,
+			FilePath: "a.txt",
+			CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{
+				Branch: "feature", Message: "add a", Content: []byte("a\n"),
+			},
+		})
+		Expect(err).To(BeNil())
+		_, err = pluginClient.CreatePullRequest(ctx, metav1alpha1.CreatePullRequestPayload{
+			Source: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "feature"},
+			Target: metav1alpha1.GitBranchBaseInfo{GitRepo: testRepo, Name: "main"},
+			Title:  "add a",
+		})
+		Expect(err).To(BeNil())
+		option := metav1alpha1.GitPullRequestOption{GitRepo: testRepo, Index: 1}
+
+		files, err := pluginClient.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(files.Items).To(HaveLen(1))
+		Expect(files.Items[0].Spec.Status).To(Equal(metav1alpha1.FileChangeStatusAdded))
+		diff, err := pluginClient.GetPullRequestDiff(ctx, metav1alpha1.GitPullRequestDiffOption{GitPullRequestOption: option, Path: "a.txt"})
+		Expect(err).To(BeNil())
+		Expect(diff.Spec.Diff).To(ContainSubstring("+a"))
+
+		review := metav1alpha1.CreatePullRequestReviewPayload{GitRepo: testRepo, Index: 1}
+		review.State = metav1alpha1.PullRequestReviewApproved
+		_, err = pluginClient.CreatePullRequestReview(ctx, review)
+		Expect(err).To(BeNil())
+		reviews, err := pluginClient.ListPullRequestReviews(ctx, option, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(reviews.Items).To(HaveLen(1))
+		pr, err := pluginClient.RequestPullRequestReviewers(ctx, metav1alpha1.RequestPullRequestReviewersPayload{
+			GitRepo: testRepo, Index: 1,
+			RequestPullRequestReviewersParam: metav1alpha1.RequestPullRequestReviewersParam{
+				Reviewers: []metav1alpha1.GitUserBaseInfo{{Name: "alice"}},
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.Reviewers).To(HaveLen(1))
+
+		mergeability, err := pluginClient.GetPullRequestMergeability(ctx, option)
+		Expect(err).To(BeNil())
+		Expect(mergeability.Spec.MergeStatus).To(Equal(metav1alpha1.MergeStatusCanBeMerged))
+		merge := metav1alpha1.MergePullRequestPayload{GitRepo: testRepo, Index: 1}
+		merge.Strategy, merge.SHA = metav1alpha1.MergeStrategySquash, mergeability.Spec.SourceSHA
+		pr, err = pluginClient.MergePullRequest(ctx, merge)
+		Expect(err).To(BeNil())
+		Expect(pr.Spec.State).To(Equal(metav1alpha1.PullRequestMergedState))
+		_, err = pluginClient.MergePullRequest(ctx, merge)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("serves the branch apis", func() {
+		_, err := pluginClient.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+			GitRepo: testRepo, CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: "release/1.0", Ref: "main"},
+		})
+		Expect(err).To(BeNil())
+
+		protection, err := pluginClient.UpdateGitBranchProtection(ctx, metav1alpha1.UpdateBranchProtectionPayload{
+			GitRepo: testRepo, Branch: "release/1.0",
+			GitBranchProtectionRules: metav1alpha1.GitBranchProtectionRules{RequiredApprovals: 2},
+		})
+		Expect(err).To(BeNil())
+		Expect(protection.Spec.Name).To(Equal("release/1.0"))
+		protection, err = pluginClient.GetGitBranchProtection(ctx, testRepo, "release/1.0")
+		Expect(err).To(BeNil())
+		Expect(protection.Spec.RequiredApprovals).To(Equal(2))
+		err = pluginClient.DeleteGitBranch(ctx, testRepo, "release/1.0")
+		Expect(errors.IsForbidden(err)).To(BeTrue())
+		Expect(pluginClient.DeleteGitBranchProtection(ctx, testRepo, "release/1.0")).To(Succeed())
+
+		comparison, err := pluginClient.CompareGitCommits(ctx, metav1alpha1.GitCommitCompareOption{
+			GitRepo: testRepo, Base: "main", Head: "release/1.0",
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Spec.Status).To(Equal(metav1alpha1.GitComparisonIdentical))
+
+		Expect(pluginClient.DeleteGitBranch(ctx, testRepo, "release/1.0")).To(Succeed())
+		_, err = pluginClient.GetGitBranch(ctx, testRepo, "release/1.0")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"strconv"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// commitOf returns the repository and the full sha of the commit
+func (g *GitStore) commitOf(ctx context.Context, repo metav1alpha1.GitRepo, commit metav1alpha1.GitCommitBasicInfo) (*repository, string, error) {
+	r, err := g.repository(repo)
+	if err != nil {
+		return nil, "", err
+	}
+	if commit.SHA == nil || *commit.SHA == "" {
+		return nil, "", errors.NewBadRequest("commit sha is empty")
+	}
+	sha, err := r.resolve(ctx, *commit.SHA)
+	if err != nil {
+		return nil, "", err
+	}
+	return r, sha, nil
+}
+
+// ListGitCommitStatus lists statuses of the commit in the order they are created
+func (g *GitStore) ListGitCommitStatus(ctx context.Context, option metav1alpha1.GitCommitOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitCommitStatusList, error) {
+	r, sha, err := g.commitOf(ctx, option.GitRepo, option.GitCommitBasicInfo)
+	if err != nil {
+		return metav1alpha1.GitCommitStatusList{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitCommitStatusList{}, err
+	}
+	items, listMeta := page(meta.CommitStatuses[sha], listOption)
+	return metav1alpha1.GitCommitStatusList{
+		TypeMeta: typeMeta(metav1alpha1.GitCommitStatusListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// CreateGitCommitStatus creates a status of the commit, the name falls back to the context
+func (g *GitStore) CreateGitCommitStatus(ctx context.Context, payload metav1alpha1.CreateCommitStatusPayload) (metav1alpha1.GitCommitStatus, error) {
+	r, sha, err := g.commitOf(ctx, payload.GitRepo, payload.GitCommitBasicInfo)
+	if err != nil {
+		return metav1alpha1.GitCommitStatus{}, err
+	}
+	param := payload.CreateCommitStatusParam
+	if param.State == "" {
+		return metav1alpha1.GitCommitStatus{}, errors.NewBadRequest("state is required")
+	}
+
+	status := metav1alpha1.GitCommitStatus{
+		TypeMeta: typeMeta(metav1alpha1.GitCommitStatusGVK),
+		Spec: metav1alpha1.GitCommitStatusSpec{
+			SHA:       sha,
+			Status:    param.State,
+			CreatedAt: metav1.NewTime(g.now()),
+			Author:    g.committer,
+		},
+	}
+	if param.GitCommitStatus != nil {
+		param.GitCommitStatus.Status.DeepCopyInto(&status.Status)
+		status.Spec.Properties = param.GitCommitStatus.Spec.Properties.DeepCopy()
+	}
+	if param.Ref != nil {
+		status.Spec.Ref = *param.Ref
+	}
+	if param.Name != nil {
+		status.Spec.Name = *param.Name
+	}
+	if param.TargetURL != nil {
+		status.Spec.TargetURL = *param.TargetURL
+	}
+	if param.Description != nil {
+		status.Spec.Description = *param.Description
+	}
+	if status.Spec.Name == "" && param.Context != nil {
+		status.Spec.Name = *param.Context
+	}
+
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		status.Spec.ID = meta.nextID()
+		status.Name = strconv.Itoa(status.Spec.ID)
+		if meta.CommitStatuses == nil {
+			meta.CommitStatuses = map[string][]metav1alpha1.GitCommitStatus{}
+		}
+		meta.CommitStatuses[sha] = append(meta.CommitStatuses[sha], status)
+		return nil
+	})
+	return status, err
+}
+
+// ListGitCommitComment lists comments of the commit in the order they are created
+func (g *GitStore) ListGitCommitComment(ctx context.Context, option metav1alpha1.GitCommitOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitCommitCommentList, error) {
+	r, sha, err := g.commitOf(ctx, option.GitRepo, option.GitCommitBasicInfo)
+	if err != nil {
+		return metav1alpha1.GitCommitCommentList{}, err
+	}
+	meta, err := g.getMeta(r)
+	if err != nil {
+		return metav1alpha1.GitCommitCommentList{}, err
+	}
+	items, listMeta := page(meta.CommitComments[sha], listOption)
+	return metav1alpha1.GitCommitCommentList{
+		TypeMeta: typeMeta(metav1alpha1.GitCommitCommentListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
+
+// CreateGitCommitComment creates a comment of the commit, optionally on a line of a file
+func (g *GitStore) CreateGitCommitComment(ctx context.Context, payload metav1alpha1.CreateCommitCommentPayload) (metav1alpha1.GitCommitComment, error) {
+	r, sha, err := g.commitOf(ctx, payload.GitRepo, payload.GitCommitBasicInfo)
+	if err != nil {
+		return metav1alpha1.GitCommitComment{}, err
+	}
+	param := payload.CreateCommitCommentParam
+	if param.Note == nil || *param.Note == "" {
+		return metav1alpha1.GitCommitComment{}, errors.NewBadRequest("note is required")
+	}
+
+	comment := metav1alpha1.GitCommitComment{
+		TypeMeta:   typeMeta(metav1alpha1.GitCommitCommentGVK),
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(g.now())},
+		Spec: metav1alpha1.GitCommitCommentSpec{
+			Note:     *param.Note,
+			LineType: param.LineType,
+			Author:   g.committer,
+		},
+	}
+	if param.Path != nil {
+		comment.Spec.Path = *param.Path
+	}
+	if param.Line != nil {
+		comment.Spec.Line = *param.Line
+	}
+
+	err = g.updateMeta(r, func(meta *repositoryMeta) error {
+		comment.Name = strconv.Itoa(meta.nextID())
+		if meta.CommitComments == nil {
+			meta.CommitComments = map[string][]metav1alpha1.GitCommitComment{}
+		}
+		meta.CommitComments[sha] = append(meta.CommitComments[sha], comment)
+		return nil
+	})
+	return comment, err
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/pointer"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tagFields are the fields of tags listed by forEachRef,
+// the peeled object name is only available for annotated tags.
+var tagFields = []string{"%(refname:lstrip=2)", "%(objecttype)", "%(objectname)", "%(*objectname)", "%(contents)"}
+
+func newTag(values []string) metav1alpha1.GitRepositoryTag {
+	name, objectType, sha := values[0], values[1], values[2]
+	tag := metav1alpha1.GitRepositoryTag{
+		TypeMeta:   typeMeta(metav1alpha1.GitRepositoryTagGVK),
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: metav1alpha1.GitRepositoryTagSpec{
+			GitRepositoryTagInfo: metav1alpha1.GitRepositoryTagInfo{Name: name, SHA: pointer.String(sha)},
+		},
+	}
+	if objectType == "tag" {
+		// annotated tags point to the commit of the peeled object
+		tag.Spec.SHA = pointer.String(values[3])
+		tag.Spec.Message = pointer.String(strings.TrimSuffix(values[4], "\n"))
+	}
+	return tag
+}
+
+// GetGitRepositoryTag gets a lightweight or annotated tag of the repository
+func (g *GitStore) GetGitRepositoryTag(ctx context.Context, option metav1alpha1.GitRepositoryTagOption) (metav1alpha1.GitRepositoryTag, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitRepositoryTag{}, err
+	}
+	if option.Tag != "" {
+		refs, err := r.forEachRef(ctx, tagPrefix+option.Tag, tagFields...)
+		if err != nil {
+			return metav1alpha1.GitRepositoryTag{}, err
+		}
+		for _, values := range refs {
+			// patterns also match references under the tag name
+			if values[0] == option.Tag {
+				return newTag(values), nil
+			}
+		}
+	}
+	return metav1alpha1.GitRepositoryTag{}, errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitrepositorytags").GroupResource(), option.Tag)
+}
+
+// ListGitRepositoryTag lists tags of the repository sorted by name
+func (g *GitStore) ListGitRepositoryTag(ctx context.Context, option metav1alpha1.GitRepositoryTagListOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitRepositoryTagList, error) {
+	r, err := g.repository(option.GitRepo)
+	if err != nil {
+		return metav1alpha1.GitRepositoryTagList{}, err
+	}
+	refs, err := r.forEachRef(ctx, tagPrefix, tagFields...)
+	if err != nil {
+		return metav1alpha1.GitRepositoryTagList{}, err
+	}
+	tags := make([]metav1alpha1.GitRepositoryTag, 0, len(refs))
+	for _, values := range refs {
+		tags = append(tags, newTag(values))
+	}
+	items, listMeta := page(tags, listOption)
+	return metav1alpha1.GitRepositoryTagList{
+		TypeMeta: typeMeta(metav1alpha1.GitRepositoryTagListGVK),
+		ListMeta: listMeta,
+		Items:    items,
+	}, nil
+}
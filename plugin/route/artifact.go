@@ -26,6 +26,8 @@ import (
 	kerrors "github.com/katanomi/pkg/errors"
 	"github.com/katanomi/pkg/plugin/client"
 	"github.com/katanomi/pkg/plugin/path"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"knative.dev/pkg/logging"
 )
 
@@ -430,6 +432,61 @@ func (a *artifactTagDeleter) DeleteArtifactTag(request *restful.Request, respons
 	response.WriteHeader(http.StatusOK)
 }
 
+type artifactPromoter struct {
+	impl client.ArtifactPromoter
+	tags []string
+}
+
+// NewArtifactPromote create a promote artifact route with plugin client
+func NewArtifactPromote(impl client.ArtifactPromoter) Route {
+	return &artifactPromoter{
+		tags: []string{"projects", "repositories", "artifacts"},
+		impl: impl,
+	}
+}
+
+func (a *artifactPromoter) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "repository belong to integraion")
+	repositoryParam := ws.PathParameter("repository", "artifact belong to repository")
+	artifactParam := ws.PathParameter("artifact", "artifact name, maybe is version or tag")
+	ws.Route(
+		ws.POST("/projects/{project:*}/repositories/{repository:*}/artifacts/{artifact}/promote").To(a.PromoteArtifact).
+			// docs
+			Doc("PromoteArtifact").Param(projectParam).Param(repositoryParam).Param(artifactParam).
+			Reads(metav1alpha1.PromoteArtifactPayload{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.Artifact{}),
+	)
+}
+
+// PromoteArtifact http handler for promote artifact
+func (a *artifactPromoter) PromoteArtifact(request *restful.Request, response *restful.Response) {
+	pathParams := metav1alpha1.ArtifactOptions{
+		RepositoryOptions: metav1alpha1.RepositoryOptions{
+			Project: path.Parameter(request, "project"),
+		},
+		Repository: path.Parameter(request, "repository"),
+		Artifact:   path.Parameter(request, "artifact"),
+	}
+
+	payload := metav1alpha1.PromoteArtifactPayload{}
+	if err := request.ReadEntity(&payload); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	if errs := payload.Validate(field.NewPath("payload")); len(errs) > 0 {
+		kerrors.HandleError(request, response, errors.NewBadRequest(errs.ToAggregate().Error()))
+		return
+	}
+	artifact, err := a.impl.PromoteArtifact(request.Request.Context(), pathParams, payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, artifact)
+}
+
 type scanImage struct {
 	impl client.ScanImage
 	tags []string
@@ -230,6 +230,53 @@ func TestDeleteProjectArtifact(t *testing.T) {
 	}
 }
 
+func TestPromoteArtifact(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&MockArtifactImpl{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	tests := []struct {
+		project string
+		payload metav1alpha1.PromoteArtifactPayload
+		code    int
+	}{
+		{
+			project: "proj%2Fsub",
+			payload: metav1alpha1.PromoteArtifactPayload{Target: metav1alpha1.ArtifactPromotionTarget{Project: "release", Repository: "katanomi"}},
+			code:    http.StatusOK,
+		},
+		{
+			project: "proj",
+			payload: metav1alpha1.PromoteArtifactPayload{Target: metav1alpha1.ArtifactPromotionTarget{Project: "release"}},
+			code:    http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		path := fmt.Sprintf("/plugins/v1alpha1/test-artifacts-1/projects/%s/repositories/katanomi/artifacts/v1.0.0/promote", test.project)
+		body, _ := json.Marshal(test.payload)
+		httpRequest, _ := http.NewRequest("POST", path, bytes.NewReader(body))
+		httpRequest.Header.Set("Accept", "application/json")
+		httpRequest.Header.Set("Content-Type", "application/json")
+		httpWriter := httptest.NewRecorder()
+		container.Dispatch(httpWriter, httpRequest)
+		g.Expect(httpWriter.Code).To(Equal(test.code), httpWriter.Body.String())
+		if test.code != http.StatusOK {
+			continue
+		}
+
+		artifact := metav1alpha1.Artifact{}
+		err = json.Unmarshal(httpWriter.Body.Bytes(), &artifact)
+		g.Expect(err).To(BeNil())
+		g.Expect(artifact.Name).To(Equal("release/katanomi:v1.0.0"))
+		g.Expect(artifact.Spec.Version).To(Equal("proj/sub/katanomi:v1.0.0"))
+	}
+}
+
 type MockArtifactImpl struct {
 }
 
@@ -272,3 +319,10 @@ func (t *MockArtifactImpl) DeleteArtifactTag(ctx context.Context, params metav1a
 func (t *MockArtifactImpl) DeleteProjectArtifact(ctx context.Context, params metav1alpha1.ProjectArtifactOptions) error {
 	return nil
 }
+
+func (t *MockArtifactImpl) PromoteArtifact(ctx context.Context, params metav1alpha1.ArtifactOptions, payload metav1alpha1.PromoteArtifactPayload) (*metav1alpha1.Artifact, error) {
+	artifact := &metav1alpha1.Artifact{}
+	artifact.Name = fmt.Sprintf("%s/%s:%s", payload.Target.Project, payload.Target.Repository, params.Artifact)
+	artifact.Spec.Version = fmt.Sprintf("%s/%s:%s", params.Project, params.Repository, params.Artifact)
+	return artifact, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const (
+	// KeyCacheMaxAge route metadata key of the duration clients could cache the response
+	// without revalidation, e.g. ws.GET(path).Metadata(KeyCacheMaxAge, time.Minute)
+	KeyCacheMaxAge = "cache-max-age"
+
+	// ToolMetadataCacheMaxAge duration clients could cache the tool metadata,
+	// which rarely changes during the lifetime of a plugin
+	ToolMetadataCacheMaxAge = time.Minute
+
+	// GitResourceCacheMaxAge duration clients could cache repositories and branches,
+	// kept short because they change with every push
+	GitResourceCacheMaxAge = 10 * time.Second
+)
+
+// CacheMaxAge returns the cache max age declared by the selected route of the request,
+// or zero if the route does not declare it
+func CacheMaxAge(request *restful.Request) time.Duration {
+	route := request.SelectedRoute()
+	if route == nil {
+		return 0
+	}
+	maxAge, _ := route.Metadata()[KeyCacheMaxAge].(time.Duration)
+	return maxAge
+}
+
+// ETag returns a strong entity tag of the json encoded entity
+func ETag(entity interface{}) (string, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// ETagMatch returns true if the If-None-Match header of the request matches the etag
+func ETagMatch(request *restful.Request, etag string) bool {
+	for _, value := range request.Request.Header.Values("If-None-Match") {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WriteEntityWithETag writes the entity with its ETag, or 304 Not Modified if the
+// entity matches the If-None-Match header of the request.
+// Clients could cache the response for the maxAge, and revalidate it every time if maxAge is zero.
+func WriteEntityWithETag(request *restful.Request, response *restful.Response, entity interface{}, maxAge time.Duration) {
+	etag, err := ETag(entity)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusOK, entity)
+		return
+	}
+	response.Header().Set("ETag", etag)
+	if maxAge > 0 {
+		response.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		response.Header().Set("Cache-Control", "private, no-cache")
+	}
+	if ETagMatch(request, etag) {
+		response.WriteHeader(http.StatusNotModified)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, entity)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/client/base"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestETag(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	etag, err := ETag(map[string]string{"version": "v1"})
+	g.Expect(err).To(Succeed())
+	g.Expect(etag).To(MatchRegexp(`^"[0-9a-f]{32}"$`))
+	g.Expect(ETag(map[string]string{"version": "v1"})).To(Equal(etag))
+	g.Expect(ETag(map[string]string{"version": "v2"})).NotTo(Equal(etag))
+
+	_, err = ETag(func() {})
+	g.Expect(err).To(HaveOccurred())
+
+	request := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+	g.Expect(ETagMatch(request, etag)).To(BeFalse())
+	request.Request.Header.Set("If-None-Match", `"other", W/`+etag)
+	g.Expect(ETagMatch(request, etag)).To(BeTrue())
+	request.Request.Header.Set("If-None-Match", "*")
+	g.Expect(ETagMatch(request, etag)).To(BeTrue())
+}
+
+func TestWriteEntityWithETag(t *testing.T) {
+	g := NewGomegaWithT(t)
+	impl := &TestCachedToolMetadata{version: "v1"}
+	ws, err := NewService(impl, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Add(ws)
+	notModified := 0
+	container.Filter(func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		chain.ProcessFilter(req, resp)
+		if resp.StatusCode() == http.StatusNotModified {
+			notModified++
+		}
+	})
+	server := httptest.NewServer(container)
+	defer server.Close()
+
+	url, _ := apis.ParseURL(server.URL + "/plugins/v1alpha1/test-cached-metadata")
+	address := &duckv1.Addressable{URL: url}
+	pluginClient := base.NewPluginClient(base.CacheOpts(base.NewResponseCache(1024, time.Hour)))
+	get := func() string {
+		meta := metav1alpha1.ToolMeta{}
+		response, err := pluginClient.GetResponse(context.Background(), address, "tools/metadata", base.ResultOpts(&meta))
+		g.Expect(err).To(Succeed())
+		g.Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		g.Expect(response.Header().Get("Cache-Control")).To(Equal("private, max-age=60"))
+		return meta.Spec.Version
+	}
+
+	// the tool metadata is fresh for a while and served from the cache
+	g.Expect(get()).To(Equal("v1"))
+	g.Expect(get()).To(Equal("v1"))
+	g.Expect(impl.calls).To(Equal(1))
+	g.Expect(notModified).To(Equal(0))
+}
+
+func TestGitBranchCacheMaxAge(t *testing.T) {
+	g := NewGomegaWithT(t)
+	impl := &TestCachedGitBranchGetter{}
+	ws, err := NewService(impl, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+	server := httptest.NewServer(container)
+	defer server.Close()
+
+	url, _ := apis.ParseURL(server.URL + "/plugins/v1alpha1/test-cached-branch")
+	address := &duckv1.Addressable{URL: url}
+	pluginClient := base.NewPluginClient(base.CacheOpts(base.NewResponseCache(1024, time.Hour)))
+	get := func() string {
+		branch := metav1alpha1.GitBranch{}
+		response, err := pluginClient.GetResponse(context.Background(), address,
+			"projects/demo/coderepositories/repo/branches/main", base.ResultOpts(&branch))
+		g.Expect(err).To(Succeed())
+		g.Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		g.Expect(response.Header().Get("Cache-Control")).To(Equal("private, max-age=10"))
+		return branch.Name
+	}
+
+	// the second request within the max age never reaches the plugin
+	g.Expect(get()).To(Equal("main"))
+	g.Expect(get()).To(Equal("main"))
+	g.Expect(impl.calls).To(Equal(1))
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	g := NewGomegaWithT(t)
+	entity := map[string]string{"version": "v1"}
+	handler := func(request *restful.Request, response *restful.Response) {
+		WriteEntityWithETag(request, response, entity, CacheMaxAge(request))
+	}
+	ws := new(restful.WebService)
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("/cached").To(handler).Metadata(KeyCacheMaxAge, 30*time.Second))
+	ws.Route(ws.GET("/revalidated").To(handler))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	serve := func(path, etag string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		container.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve("/cached", "")
+	g.Expect(recorder.Code).To(Equal(http.StatusOK))
+	g.Expect(recorder.Header().Get("Cache-Control")).To(Equal("private, max-age=30"))
+
+	recorder = serve("/revalidated", "")
+	g.Expect(recorder.Code).To(Equal(http.StatusOK))
+	g.Expect(recorder.Header().Get("Cache-Control")).To(Equal("private, no-cache"))
+	etag := recorder.Header().Get("ETag")
+	g.Expect(etag).NotTo(BeEmpty())
+
+	recorder = serve("/revalidated", etag)
+	g.Expect(recorder.Code).To(Equal(http.StatusNotModified))
+
+	g.Expect(CacheMaxAge(restful.NewRequest(httptest.NewRequest(http.MethodGet, "/", nil)))).To(BeZero())
+}
+
+type TestCachedToolMetadata struct {
+	version string
+	calls   int
+}
+
+func (t *TestCachedToolMetadata) GetToolMetadata(ctx context.Context) (*metav1alpha1.ToolMeta, error) {
+	t.calls++
+	return &metav1alpha1.ToolMeta{Spec: metav1alpha1.ToolMetaSpec{Version: t.version}}, nil
+}
+
+func (t *TestCachedToolMetadata) Path() string {
+	return "test-cached-metadata"
+}
+
+func (t *TestCachedToolMetadata) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+type TestCachedGitBranchGetter struct {
+	calls int
+}
+
+func (t *TestCachedGitBranchGetter) GetGitBranch(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranch, error) {
+	t.calls++
+	gitBranch := metav1alpha1.GitBranch{}
+	gitBranch.Name = branch
+	return gitBranch, nil
+}
+
+func (t *TestCachedGitBranchGetter) Path() string {
+	return "test-cached-branch"
+}
+
+func (t *TestCachedGitBranchGetter) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
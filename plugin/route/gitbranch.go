@@ -51,6 +51,7 @@ func (a *gitBranchLister) Register(ws *restful.WebService) {
 		ws.GET("/projects/{project:*}/coderepositories/{repository}/branches").To(a.ListBranch).
 			Doc("ListBranch").Param(projectParam).Param(repositoryParam).Param(keywordParam).
 			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Metadata(KeyCacheMaxAge, GitResourceCacheMaxAge).
 			Returns(http.StatusOK, "OK", metav1alpha1.GitBranchList{}),
 	)
 }
@@ -68,7 +69,7 @@ func (a *gitBranchLister) ListBranch(request *restful.Request, response *restful
 		kerrors.HandleError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, branchList)
+	WriteEntityWithETag(request, response, branchList, CacheMaxAge(request))
 }
 
 type gitBranchCreator struct {
@@ -136,6 +137,7 @@ func (a *gitBranchGetter) Register(ws *restful.WebService) {
 		ws.GET("/projects/{project:*}/coderepositories/{repository}/branches/{branch}").To(a.GetGitBranch).
 			Doc("ListBranch").Param(projectParam).Param(repositoryParam).Param(branchParam).
 			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Metadata(KeyCacheMaxAge, GitResourceCacheMaxAge).
 			Returns(http.StatusOK, "OK", metav1alpha1.GitBranch{}),
 	)
 }
@@ -150,5 +152,170 @@ func (a *gitBranchGetter) GetGitBranch(request *restful.Request, response *restf
 		kerrors.HandleError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, branchObj)
+	WriteEntityWithETag(request, response, branchObj, CacheMaxAge(request))
+}
+
+type gitBranchDeleter struct {
+	impl client.GitBranchDeleter
+	tags []string
+}
+
+// NewGitBranchDeleter create a git branch delete route with plugin client
+func NewGitBranchDeleter(impl client.GitBranchDeleter) Route {
+	return &gitBranchDeleter{
+		tags: []string{"git", "repositories", "branch"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitBranchDeleter) Register(ws *restful.WebService) {
+	branchParam := ws.PathParameter("branch", "branch name")
+	repositoryParam := ws.PathParameter("repository", "branch belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	ws.Route(
+		ws.DELETE("/projects/{project:*}/coderepositories/{repository}/branches/{branch}").To(a.DeleteGitBranch).
+			Doc("DeleteBranch").Param(projectParam).Param(repositoryParam).Param(branchParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", nil),
+	)
+}
+
+// DeleteGitBranch delete branch
+func (a *gitBranchDeleter) DeleteGitBranch(request *restful.Request, response *restful.Response) {
+	repo := path.Parameter(request, "repository")
+	project := path.Parameter(request, "project")
+	branch := path.Parameter(request, "branch")
+	if err := a.impl.DeleteGitBranch(request.Request.Context(), metav1alpha1.GitRepo{Repository: repo, Project: project}, branch); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+type gitBranchProtectionGetter struct {
+	impl client.GitBranchProtectionGetter
+	tags []string
+}
+
+// NewGitBranchProtectionGetter create a git branch protection getter route with plugin client
+func NewGitBranchProtectionGetter(impl client.GitBranchProtectionGetter) Route {
+	return &gitBranchProtectionGetter{
+		tags: []string{"git", "repositories", "branch", "protection"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitBranchProtectionGetter) Register(ws *restful.WebService) {
+	branchParam := ws.PathParameter("branch", "branch name")
+	repositoryParam := ws.PathParameter("repository", "branch belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/branches/{branch}/protection").To(a.GetGitBranchProtection).
+			Doc("GetBranchProtection").Param(projectParam).Param(repositoryParam).Param(branchParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitBranchProtection{}),
+	)
+}
+
+// GetGitBranchProtection get protection rules of branch
+func (a *gitBranchProtectionGetter) GetGitBranchProtection(request *restful.Request, response *restful.Response) {
+	repo := path.Parameter(request, "repository")
+	project := path.Parameter(request, "project")
+	branch := path.Parameter(request, "branch")
+	protection, err := a.impl.GetGitBranchProtection(request.Request.Context(), metav1alpha1.GitRepo{Repository: repo, Project: project}, branch)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, protection)
+}
+
+type gitBranchProtectionUpdater struct {
+	impl client.GitBranchProtectionUpdater
+	tags []string
+}
+
+// NewGitBranchProtectionUpdater create a git branch protection update route with plugin client
+func NewGitBranchProtectionUpdater(impl client.GitBranchProtectionUpdater) Route {
+	return &gitBranchProtectionUpdater{
+		tags: []string{"git", "repositories", "branch", "protection"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitBranchProtectionUpdater) Register(ws *restful.WebService) {
+	branchParam := ws.PathParameter("branch", "branch name")
+	repositoryParam := ws.PathParameter("repository", "branch belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	ws.Route(
+		ws.PUT("/projects/{project:*}/coderepositories/{repository}/branches/{branch}/protection").To(a.UpdateGitBranchProtection).
+			Doc("UpdateBranchProtection").Param(projectParam).Param(repositoryParam).Param(branchParam).
+			Reads(metav1alpha1.GitBranchProtectionRules{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitBranchProtection{}),
+	)
+}
+
+// UpdateGitBranchProtection set protection rules of branch
+func (a *gitBranchProtectionUpdater) UpdateGitBranchProtection(request *restful.Request, response *restful.Response) {
+	var rules metav1alpha1.GitBranchProtectionRules
+	if err := request.ReadEntity(&rules); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	payload := metav1alpha1.UpdateBranchProtectionPayload{
+		GitRepo: metav1alpha1.GitRepo{
+			Repository: path.Parameter(request, "repository"),
+			Project:    path.Parameter(request, "project"),
+		},
+		Branch:                   path.Parameter(request, "branch"),
+		GitBranchProtectionRules: rules,
+	}
+	protection, err := a.impl.UpdateGitBranchProtection(request.Request.Context(), payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, protection)
+}
+
+type gitBranchProtectionDeleter struct {
+	impl client.GitBranchProtectionDeleter
+	tags []string
+}
+
+// NewGitBranchProtectionDeleter create a git branch protection delete route with plugin client
+func NewGitBranchProtectionDeleter(impl client.GitBranchProtectionDeleter) Route {
+	return &gitBranchProtectionDeleter{
+		tags: []string{"git", "repositories", "branch", "protection"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitBranchProtectionDeleter) Register(ws *restful.WebService) {
+	branchParam := ws.PathParameter("branch", "branch name")
+	repositoryParam := ws.PathParameter("repository", "branch belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	ws.Route(
+		ws.DELETE("/projects/{project:*}/coderepositories/{repository}/branches/{branch}/protection").To(a.DeleteGitBranchProtection).
+			Doc("DeleteBranchProtection").Param(projectParam).Param(repositoryParam).Param(branchParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", nil),
+	)
+}
+
+// DeleteGitBranchProtection remove protection rules of branch
+func (a *gitBranchProtectionDeleter) DeleteGitBranchProtection(request *restful.Request, response *restful.Response) {
+	repo := path.Parameter(request, "repository")
+	project := path.Parameter(request, "project")
+	branch := path.Parameter(request, "branch")
+	if err := a.impl.DeleteGitBranchProtection(request.Request.Context(), metav1alpha1.GitRepo{Repository: repo, Project: project}, branch); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/katanomi/pkg/plugin/client"
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -140,3 +141,89 @@ func (t *TestGitBranchCreator) CreateGitBranch(ctx context.Context, payload meta
 		ObjectMeta: metav1.ObjectMeta{Name: payload.Branch},
 	}, nil
 }
+
+func TestGitBranchProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestGitBranchProtector{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	branchPath := "/plugins/v1alpha1/test-protection/projects/group%2Fsub/coderepositories/repo/branches/"
+	tests := []struct {
+		method   string
+		path     string
+		body     interface{}
+		code     int
+		contains string
+	}{
+		{method: http.MethodGet, path: "release%252F1.0/protection", code: http.StatusOK, contains: `"name":"release/1.0"`},
+		{method: http.MethodPut, path: "main/protection", body: metav1alpha1.GitBranchProtectionRules{RequiredApprovals: 2},
+			code: http.StatusOK, contains: `"requiredApprovals":2`},
+		{method: http.MethodDelete, path: "main/protection", code: http.StatusOK},
+		{method: http.MethodDelete, path: "feature", code: http.StatusOK},
+		{method: http.MethodDelete, path: "missing", code: http.StatusNotFound},
+		{method: http.MethodGet, path: "missing/protection", code: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		var body bytes.Buffer
+		if test.body != nil {
+			g.Expect(json.NewEncoder(&body).Encode(test.body)).To(Succeed())
+		}
+		request, _ := http.NewRequest(test.method, branchPath+test.path, &body)
+		request.Header.Set("Accept", "application/json")
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		container.Dispatch(recorder, request)
+		g.Expect(recorder.Code).To(Equal(test.code), "%s %s: %s", test.method, test.path, recorder.Body.String())
+		if test.contains != "" {
+			var compacted bytes.Buffer
+			g.Expect(json.Compact(&compacted, recorder.Body.Bytes())).To(Succeed())
+			g.Expect(compacted.String()).To(ContainSubstring(test.contains), "%s %s", test.method, test.path)
+		}
+	}
+}
+
+type TestGitBranchProtector struct {
+}
+
+func (t *TestGitBranchProtector) Path() string {
+	return "test-protection"
+}
+
+func (t *TestGitBranchProtector) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestGitBranchProtector) notFound(branch string) error {
+	return errors.NewNotFound(metav1alpha1.GroupVersion.WithResource("gitbranches").GroupResource(), branch)
+}
+
+func (t *TestGitBranchProtector) DeleteGitBranch(ctx context.Context, repo metav1alpha1.GitRepo, branch string) error {
+	if branch == "missing" {
+		return t.notFound(branch)
+	}
+	return nil
+}
+
+func (t *TestGitBranchProtector) GetGitBranchProtection(ctx context.Context, repo metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranchProtection, error) {
+	if branch == "missing" {
+		return metav1alpha1.GitBranchProtection{}, t.notFound(branch)
+	}
+	return metav1alpha1.GitBranchProtection{Spec: metav1alpha1.GitBranchProtectionSpec{
+		GitBranchBaseInfo: metav1alpha1.GitBranchBaseInfo{GitRepo: repo, Name: branch},
+	}}, nil
+}
+
+func (t *TestGitBranchProtector) UpdateGitBranchProtection(ctx context.Context, payload metav1alpha1.UpdateBranchProtectionPayload) (metav1alpha1.GitBranchProtection, error) {
+	return metav1alpha1.GitBranchProtection{Spec: metav1alpha1.GitBranchProtectionSpec{
+		GitBranchBaseInfo:        metav1alpha1.GitBranchBaseInfo{GitRepo: payload.GitRepo, Name: payload.Branch},
+		GitBranchProtectionRules: payload.GitBranchProtectionRules,
+	}}, nil
+}
+
+func (t *TestGitBranchProtector) DeleteGitBranchProtection(ctx context.Context, repo metav1alpha1.GitRepo, branch string) error {
+	return nil
+}
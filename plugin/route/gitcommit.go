@@ -199,3 +199,52 @@ func (a *gitCommitLister) ListCommit(request *restful.Request, response *restful
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, commitObject)
 }
+
+type gitCommitComparer struct {
+	impl client.GitCommitComparer
+	tags []string
+}
+
+// NewGitCommitComparer compare git revisions route with plugin client
+func NewGitCommitComparer(impl client.GitCommitComparer) Route {
+	return &gitCommitComparer{
+		tags: []string{"git", "repositories", "commit"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitCommitComparer) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "commit belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project").DataType("string")
+	baseParam := ws.QueryParameter("base", "revision compared from").Required(true)
+	headParam := ws.QueryParameter("head", "revision compared to").Required(true)
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/compare").To(a.CompareCommits).
+			Doc("CompareCommits").Param(projectParam).Param(repositoryParam).Param(baseParam).Param(headParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitCommitComparison{}),
+	)
+}
+
+// CompareCommits compare two revisions
+func (a *gitCommitComparer) CompareCommits(request *restful.Request, response *restful.Response) {
+	option := metav1alpha1.GitCommitCompareOption{
+		GitRepo: metav1alpha1.GitRepo{
+			Repository: path.Parameter(request, "repository"),
+			Project:    path.Parameter(request, "project"),
+		},
+		Base: request.QueryParameter("base"),
+		Head: request.QueryParameter("head"),
+	}
+	if option.Base == "" || option.Head == "" {
+		kerrors.HandleError(request, response, errors.NewBadRequest("base and head are required"))
+		return
+	}
+	comparison, err := a.impl.CompareGitCommits(request.Request.Context(), option)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, comparison)
+}
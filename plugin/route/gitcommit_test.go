@@ -172,3 +172,57 @@ func TestCreateGitCommit(t *testing.T) {
 		g.Expect(commit.Annotations["repository"]).To(Equal(item.repo))
 	}
 }
+
+func TestCompareGitCommits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestGitCommitComparer{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{query: "base=main&head=feature%2Fa", code: http.StatusOK},
+		{query: "base=main", code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		httpRequest, _ := http.NewRequest("GET", "/plugins/v1alpha1/test-compare/projects/proj%2Fsub/coderepositories/repo/compare?"+test.query, nil)
+		httpRequest.Header.Set("Accept", "application/json")
+		httpWriter := httptest.NewRecorder()
+		container.Dispatch(httpWriter, httpRequest)
+		g.Expect(httpWriter.Code).To(Equal(test.code), test.query)
+		if test.code != http.StatusOK {
+			continue
+		}
+
+		comparison := metav1alpha1.GitCommitComparison{}
+		g.Expect(json.Unmarshal(httpWriter.Body.Bytes(), &comparison)).To(Succeed())
+		g.Expect(comparison.Spec.GitRepo).To(Equal(metav1alpha1.GitRepo{Project: "proj/sub", Repository: "repo"}))
+		g.Expect(comparison.Spec.Base).To(Equal("main"))
+		g.Expect(comparison.Spec.Head).To(Equal("feature/a"))
+	}
+}
+
+type TestGitCommitComparer struct {
+}
+
+func (t *TestGitCommitComparer) Path() string {
+	return "test-compare"
+}
+
+func (t *TestGitCommitComparer) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestGitCommitComparer) CompareGitCommits(ctx context.Context, option metav1alpha1.GitCommitCompareOption) (metav1alpha1.GitCommitComparison, error) {
+	return metav1alpha1.GitCommitComparison{Spec: metav1alpha1.GitCommitComparisonSpec{
+		GitRepo: option.GitRepo,
+		Base:    option.Base,
+		Head:    option.Head,
+		Status:  metav1alpha1.GitComparisonAhead,
+	}}, nil
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	restfulspec "github.com/emicklei/go-restful-openapi/v2"
+	"github.com/emicklei/go-restful/v3"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	kerrors "github.com/katanomi/pkg/errors"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/path"
+)
+
+// pullRequestOption parses the pull request from path parameters
+func pullRequestOption(request *restful.Request) (metav1alpha1.GitPullRequestOption, error) {
+	index, err := strconv.Atoi(path.Parameter(request, "index"))
+	if err != nil {
+		return metav1alpha1.GitPullRequestOption{}, err
+	}
+	return metav1alpha1.GitPullRequestOption{
+		GitRepo: metav1alpha1.GitRepo{
+			Repository: path.Parameter(request, "repository"),
+			Project:    path.Parameter(request, "project"),
+		},
+		Index: index,
+	}, nil
+}
+
+type gitPullRequestFileLister struct {
+	impl client.GitPullRequestFileLister
+	tags []string
+}
+
+// NewGitPullRequestFileLister list files changed by a pr route with plugin client
+func NewGitPullRequestFileLister(impl client.GitPullRequestFileLister) Route {
+	return &gitPullRequestFileLister{
+		tags: []string{"git", "repositories", "pull request", "files"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestFileLister) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/files").To(a.ListPullRequestFiles).
+			Doc("ListPullRequestFiles").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequestFileList{}),
+	)
+}
+
+// ListPullRequestFiles list files changed by pr
+func (a *gitPullRequestFileLister) ListPullRequestFiles(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	files, err := a.impl.ListPullRequestFiles(request.Request.Context(), option, GetListOptionsFromRequest(request))
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, files)
+}
+
+type gitPullRequestDiffGetter struct {
+	impl client.GitPullRequestDiffGetter
+	tags []string
+}
+
+// NewGitPullRequestDiffGetter get the diff of a pr route with plugin client
+func NewGitPullRequestDiffGetter(impl client.GitPullRequestDiffGetter) Route {
+	return &gitPullRequestDiffGetter{
+		tags: []string{"git", "repositories", "pull request", "diff"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestDiffGetter) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/diff").To(a.GetPullRequestDiff).
+			Doc("GetPullRequestDiff").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Param(ws.QueryParameter("path", "limits the diff to the file")).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequestDiff{}),
+	)
+}
+
+// GetPullRequestDiff get the diff of pr
+func (a *gitPullRequestDiffGetter) GetPullRequestDiff(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	diff, err := a.impl.GetPullRequestDiff(request.Request.Context(), metav1alpha1.GitPullRequestDiffOption{
+		GitPullRequestOption: option,
+		Path:                 request.QueryParameter("path"),
+	})
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, diff)
+}
+
+type gitPullRequestReviewLister struct {
+	impl client.GitPullRequestReviewLister
+	tags []string
+}
+
+// NewGitPullRequestReviewLister list reviews of a pr route with plugin client
+func NewGitPullRequestReviewLister(impl client.GitPullRequestReviewLister) Route {
+	return &gitPullRequestReviewLister{
+		tags: []string{"git", "repositories", "pull request", "review"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestReviewLister) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/reviews").To(a.ListPullRequestReviews).
+			Doc("ListPullRequestReviews").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequestReviewList{}),
+	)
+}
+
+// ListPullRequestReviews list reviews of pr
+func (a *gitPullRequestReviewLister) ListPullRequestReviews(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	reviews, err := a.impl.ListPullRequestReviews(request.Request.Context(), option, GetListOptionsFromRequest(request))
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, reviews)
+}
+
+type gitPullRequestReviewCreator struct {
+	impl client.GitPullRequestReviewCreator
+	tags []string
+}
+
+// NewGitPullRequestReviewCreator submit a review of a pr route with plugin client
+func NewGitPullRequestReviewCreator(impl client.GitPullRequestReviewCreator) Route {
+	return &gitPullRequestReviewCreator{
+		tags: []string{"git", "repositories", "pull request", "review"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestReviewCreator) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.POST("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/reviews").To(a.CreatePullRequestReview).
+			Doc("CreatePullRequestReview").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Reads(metav1alpha1.CreatePullRequestReviewParam{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequestReview{}),
+	)
+}
+
+// CreatePullRequestReview submit a review of pr
+func (a *gitPullRequestReviewCreator) CreatePullRequestReview(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	var params metav1alpha1.CreatePullRequestReviewParam
+	if err = request.ReadEntity(&params); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	review, err := a.impl.CreatePullRequestReview(request.Request.Context(), metav1alpha1.CreatePullRequestReviewPayload{
+		GitRepo:                      option.GitRepo,
+		Index:                        option.Index,
+		CreatePullRequestReviewParam: params,
+	})
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, review)
+}
+
+type gitPullRequestReviewerRequester struct {
+	impl client.GitPullRequestReviewerRequester
+	tags []string
+}
+
+// NewGitPullRequestReviewerRequester request reviewers of a pr route with plugin client
+func NewGitPullRequestReviewerRequester(impl client.GitPullRequestReviewerRequester) Route {
+	return &gitPullRequestReviewerRequester{
+		tags: []string{"git", "repositories", "pull request", "review"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestReviewerRequester) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.POST("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/reviewers").To(a.RequestPullRequestReviewers).
+			Doc("RequestPullRequestReviewers").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Reads(metav1alpha1.RequestPullRequestReviewersParam{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequest{}),
+	)
+}
+
+// RequestPullRequestReviewers request reviewers of pr
+func (a *gitPullRequestReviewerRequester) RequestPullRequestReviewers(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	var params metav1alpha1.RequestPullRequestReviewersParam
+	if err = request.ReadEntity(&params); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	pr, err := a.impl.RequestPullRequestReviewers(request.Request.Context(), metav1alpha1.RequestPullRequestReviewersPayload{
+		GitRepo:                          option.GitRepo,
+		Index:                            option.Index,
+		RequestPullRequestReviewersParam: params,
+	})
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, pr)
+}
+
+type gitPullRequestMergeabilityGetter struct {
+	impl client.GitPullRequestMergeabilityGetter
+	tags []string
+}
+
+// NewGitPullRequestMergeabilityGetter get the mergeability of a pr route with plugin client
+func NewGitPullRequestMergeabilityGetter(impl client.GitPullRequestMergeabilityGetter) Route {
+	return &gitPullRequestMergeabilityGetter{
+		tags: []string{"git", "repositories", "pull request", "merge"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestMergeabilityGetter) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.GET("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/mergeability").To(a.GetPullRequestMergeability).
+			Doc("GetPullRequestMergeability").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequestMergeability{}),
+	)
+}
+
+// GetPullRequestMergeability get the mergeability of pr
+func (a *gitPullRequestMergeabilityGetter) GetPullRequestMergeability(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	mergeability, err := a.impl.GetPullRequestMergeability(request.Request.Context(), option)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, mergeability)
+}
+
+type gitPullRequestMerger struct {
+	impl client.GitPullRequestMerger
+	tags []string
+}
+
+// NewGitPullRequestMerger merge a pr route with plugin client
+func NewGitPullRequestMerger(impl client.GitPullRequestMerger) Route {
+	return &gitPullRequestMerger{
+		tags: []string{"git", "repositories", "pull request", "merge"},
+		impl: impl,
+	}
+}
+
+// Register route
+func (a *gitPullRequestMerger) Register(ws *restful.WebService) {
+	repositoryParam := ws.PathParameter("repository", "pulls belong to repository")
+	projectParam := ws.PathParameter("project", "repository belong to project")
+	indexParam := ws.PathParameter("index", "pr index")
+	ws.Route(
+		ws.POST("/projects/{project:*}/coderepositories/{repository}/pulls/{index}/merge").To(a.MergePullRequest).
+			Doc("MergePullRequest").Param(projectParam).Param(repositoryParam).Param(indexParam).
+			Reads(metav1alpha1.MergePullRequestParam{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.GitPullRequest{}),
+	)
+}
+
+// MergePullRequest merge pr
+func (a *gitPullRequestMerger) MergePullRequest(request *restful.Request, response *restful.Response) {
+	option, err := pullRequestOption(request)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	var params metav1alpha1.MergePullRequestParam
+	if err = request.ReadEntity(&params); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	pr, err := a.impl.MergePullRequest(request.Request.Context(), metav1alpha1.MergePullRequestPayload{
+		GitRepo:               option.GitRepo,
+		Index:                 option.Index,
+		MergePullRequestParam: params,
+	})
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, pr)
+}
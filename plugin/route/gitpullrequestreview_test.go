@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestGitPullRequestReview(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestGitPullRequestReviewer{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	prPath := "/plugins/v1alpha1/test-review/projects/group%2Fsub/coderepositories/repo/pulls/"
+	tests := []struct {
+		method   string
+		path     string
+		body     interface{}
+		code     int
+		contains string
+	}{
+		{method: http.MethodGet, path: "1/files?page=1&itemsPerPage=10", code: http.StatusOK, contains: `"path":"group/sub/repo#1"`},
+		{method: http.MethodGet, path: "1/diff?path=README.md", code: http.StatusOK, contains: `"diff":"README.md"`},
+		{method: http.MethodGet, path: "1/reviews", code: http.StatusOK, contains: `"kind":"GitPullRequestReviewList"`},
+		{method: http.MethodPost, path: "1/reviews", body: metav1alpha1.CreatePullRequestReviewParam{State: metav1alpha1.PullRequestReviewApproved},
+			code: http.StatusOK, contains: `"state":"approved"`},
+		{method: http.MethodPost, path: "1/reviewers", body: metav1alpha1.RequestPullRequestReviewersParam{Reviewers: []metav1alpha1.GitUserBaseInfo{{Name: "alice"}}},
+			code: http.StatusOK, contains: `"reviewers":[{"name":"alice"`},
+		{method: http.MethodGet, path: "1/mergeability", code: http.StatusOK, contains: `"mergeStatus":"can_be_merged"`},
+		{method: http.MethodPost, path: "1/merge", body: metav1alpha1.MergePullRequestParam{Strategy: metav1alpha1.MergeStrategySquash},
+			code: http.StatusOK, contains: `"state":"merged"`},
+		{method: http.MethodPost, path: "2/merge", body: metav1alpha1.MergePullRequestParam{}, code: http.StatusConflict},
+		{method: http.MethodGet, path: "abc/files", code: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		var body bytes.Buffer
+		if test.body != nil {
+			g.Expect(json.NewEncoder(&body).Encode(test.body)).To(Succeed())
+		}
+		request, _ := http.NewRequest(test.method, prPath+test.path, &body)
+		request.Header.Set("Accept", "application/json")
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		container.Dispatch(recorder, request)
+		g.Expect(recorder.Code).To(Equal(test.code), "%s %s: %s", test.method, test.path, recorder.Body.String())
+		if test.contains != "" {
+			var compacted bytes.Buffer
+			g.Expect(json.Compact(&compacted, recorder.Body.Bytes())).To(Succeed())
+			g.Expect(compacted.String()).To(ContainSubstring(test.contains), "%s %s", test.method, test.path)
+		}
+	}
+}
+
+type TestGitPullRequestReviewer struct {
+}
+
+func (t *TestGitPullRequestReviewer) Path() string {
+	return "test-review"
+}
+
+func (t *TestGitPullRequestReviewer) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestGitPullRequestReviewer) ListPullRequestFiles(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestFileList, error) {
+	return metav1alpha1.GitPullRequestFileList{
+		Items: []metav1alpha1.GitPullRequestFile{{Spec: metav1alpha1.GitPullRequestFileSpec{
+			Path:   option.Project + "/" + option.Repository + "#1",
+			Status: metav1alpha1.FileChangeStatusAdded,
+		}}},
+	}, nil
+}
+
+func (t *TestGitPullRequestReviewer) GetPullRequestDiff(ctx context.Context, option metav1alpha1.GitPullRequestDiffOption) (metav1alpha1.GitPullRequestDiff, error) {
+	return metav1alpha1.GitPullRequestDiff{Spec: metav1alpha1.GitPullRequestDiffSpec{Diff: option.Path}}, nil
+}
+
+func (t *TestGitPullRequestReviewer) ListPullRequestReviews(ctx context.Context, option metav1alpha1.GitPullRequestOption, listOption metav1alpha1.ListOptions) (metav1alpha1.GitPullRequestReviewList, error) {
+	list := metav1alpha1.GitPullRequestReviewList{Items: []metav1alpha1.GitPullRequestReview{}}
+	list.Kind = metav1alpha1.GitPullRequestReviewListGVK.Kind
+	return list, nil
+}
+
+func (t *TestGitPullRequestReviewer) CreatePullRequestReview(ctx context.Context, payload metav1alpha1.CreatePullRequestReviewPayload) (metav1alpha1.GitPullRequestReview, error) {
+	return metav1alpha1.GitPullRequestReview{Spec: metav1alpha1.GitPullRequestReviewSpec{State: payload.State}}, nil
+}
+
+func (t *TestGitPullRequestReviewer) RequestPullRequestReviewers(ctx context.Context, payload metav1alpha1.RequestPullRequestReviewersPayload) (metav1alpha1.GitPullRequest, error) {
+	return metav1alpha1.GitPullRequest{Spec: metav1alpha1.GitPullRequestSpec{Reviewers: payload.Reviewers}}, nil
+}
+
+func (t *TestGitPullRequestReviewer) GetPullRequestMergeability(ctx context.Context, option metav1alpha1.GitPullRequestOption) (metav1alpha1.GitPullRequestMergeability, error) {
+	return metav1alpha1.GitPullRequestMergeability{Spec: metav1alpha1.GitPullRequestMergeabilitySpec{MergeStatus: metav1alpha1.MergeStatusCanBeMerged}}, nil
+}
+
+func (t *TestGitPullRequestReviewer) MergePullRequest(ctx context.Context, payload metav1alpha1.MergePullRequestPayload) (metav1alpha1.GitPullRequest, error) {
+	if payload.Index != 1 || payload.Strategy != metav1alpha1.MergeStrategySquash {
+		return metav1alpha1.GitPullRequest{}, errors.NewConflict(metav1alpha1.GroupVersion.WithResource("gitpullrequests").GroupResource(), "2", nil)
+	}
+	return metav1alpha1.GitPullRequest{Spec: metav1alpha1.GitPullRequestSpec{State: metav1alpha1.PullRequestMergedState}}, nil
+}
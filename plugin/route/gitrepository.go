@@ -52,6 +52,7 @@ func (a *gitRepositoryLister) Register(ws *restful.WebService) {
 		ws.GET("/projects/{project:*}/coderepositories").To(a.ListGitRepository).
 			Doc("GetGitRepoList").Param(projectParam).Param(keywordParam).Param(subtypeParam).
 			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Metadata(KeyCacheMaxAge, GitResourceCacheMaxAge).
 			Returns(http.StatusOK, "OK", metav1alpha1.GitRepositoryList{}),
 	)
 }
@@ -72,7 +73,7 @@ func (a *gitRepositoryLister) ListGitRepository(request *restful.Request, respon
 		kerrors.HandleError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, repoList)
+	WriteEntityWithETag(request, response, repoList, CacheMaxAge(request))
 }
 
 type gitRepositoryGetter struct {
@@ -96,6 +97,7 @@ func (a *gitRepositoryGetter) Register(ws *restful.WebService) {
 		ws.GET("/projects/{project:*}/coderepositories/{repository}").To(a.GetGitRepository).
 			Doc("GetGitRepo").Param(projectParam).Param(repositoryParam).
 			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Metadata(KeyCacheMaxAge, GitResourceCacheMaxAge).
 			Returns(http.StatusOK, "OK", metav1alpha1.GitRepository{}),
 	)
 }
@@ -113,5 +115,5 @@ func (a *gitRepositoryGetter) GetGitRepository(request *restful.Request, respons
 		kerrors.HandleError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, repoInfo)
+	WriteEntityWithETag(request, response, repoInfo, CacheMaxAge(request))
 }
@@ -26,6 +26,8 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 	kerrors "github.com/katanomi/pkg/errors"
 	"github.com/katanomi/pkg/plugin/client"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type issueList struct {
@@ -143,3 +145,148 @@ func (i *issueAttributeGetter) GetAttributes(request *restful.Request, response
 
 	response.WriteHeaderAndEntity(http.StatusOK, attribute)
 }
+
+type issueCreator struct {
+	impl client.IssueCreator
+	tags []string
+}
+
+// NewIssueCreate create a create issue route with plugin client
+func NewIssueCreate(impl client.IssueCreator) Route {
+	return &issueCreator{
+		tags: []string{"projects", "issues"},
+		impl: impl,
+	}
+}
+
+func (i *issueCreator) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "issue belong to integrate project")
+	ws.Route(
+		ws.POST("/projects/{project:*}/issues").To(i.CreateIssue).
+			Doc("CreateIssue").Param(projectParam).
+			Reads(metav1alpha1.CreateIssuePayload{}).
+			Metadata(restfulspec.KeyOpenAPITags, i.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.Issue{}),
+	)
+}
+
+func (i *issueCreator) CreateIssue(request *restful.Request, response *restful.Response) {
+	pathParams := metav1alpha1.IssueOptions{
+		Identity: path.Parameter(request, "project"),
+	}
+
+	payload := metav1alpha1.CreateIssuePayload{}
+	if err := request.ReadEntity(&payload); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	if errs := payload.Validate(nil, field.NewPath("payload")); len(errs) > 0 {
+		kerrors.HandleError(request, response, errors.NewBadRequest(errs.ToAggregate().Error()))
+		return
+	}
+	issue, err := i.impl.CreateIssue(request.Request.Context(), pathParams, payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, issue)
+}
+
+type issueUpdater struct {
+	impl client.IssueUpdater
+	tags []string
+}
+
+// NewIssueUpdate create an update issue route with plugin client
+func NewIssueUpdate(impl client.IssueUpdater) Route {
+	return &issueUpdater{
+		tags: []string{"projects", "issues"},
+		impl: impl,
+	}
+}
+
+func (i *issueUpdater) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "issue belong to integrate project")
+	issueParam := ws.PathParameter("issue", "issue id")
+	ws.Route(
+		ws.PUT("/projects/{project:*}/issues/{issue}").To(i.UpdateIssue).
+			Doc("UpdateIssue").Param(projectParam).Param(issueParam).
+			Reads(metav1alpha1.UpdateIssuePayload{}).
+			Metadata(restfulspec.KeyOpenAPITags, i.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.Issue{}),
+	)
+}
+
+func (i *issueUpdater) UpdateIssue(request *restful.Request, response *restful.Response) {
+	pathParams := metav1alpha1.IssueOptions{
+		Identity: path.Parameter(request, "project"),
+		IssueId:  path.Parameter(request, "issue"),
+	}
+
+	payload := metav1alpha1.UpdateIssuePayload{}
+	if err := request.ReadEntity(&payload); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	if errs := payload.Validate(nil, field.NewPath("payload")); len(errs) > 0 {
+		kerrors.HandleError(request, response, errors.NewBadRequest(errs.ToAggregate().Error()))
+		return
+	}
+	issue, err := i.impl.UpdateIssue(request.Request.Context(), pathParams, payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, issue)
+}
+
+type issueLinker struct {
+	impl client.IssueLinker
+	tags []string
+}
+
+// NewIssueLink create a link issue route with plugin client
+func NewIssueLink(impl client.IssueLinker) Route {
+	return &issueLinker{
+		tags: []string{"projects", "issues", "links"},
+		impl: impl,
+	}
+}
+
+func (i *issueLinker) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "issue belong to integrate project")
+	issueParam := ws.PathParameter("issue", "issue id")
+	ws.Route(
+		ws.POST("/projects/{project:*}/issues/{issue}/links").To(i.LinkIssue).
+			Doc("LinkIssue").Param(projectParam).Param(issueParam).
+			Reads(metav1alpha1.IssueLinkPayload{}).
+			Metadata(restfulspec.KeyOpenAPITags, i.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.Issue{}),
+	)
+}
+
+func (i *issueLinker) LinkIssue(request *restful.Request, response *restful.Response) {
+	pathParams := metav1alpha1.IssueOptions{
+		Identity: path.Parameter(request, "project"),
+		IssueId:  path.Parameter(request, "issue"),
+	}
+
+	payload := metav1alpha1.IssueLinkPayload{}
+	if err := request.ReadEntity(&payload); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	if errs := payload.Validate(field.NewPath("payload")); len(errs) > 0 {
+		kerrors.HandleError(request, response, errors.NewBadRequest(errs.ToAggregate().Error()))
+		return
+	}
+	issue, err := i.impl.LinkIssue(request.Request.Context(), pathParams, payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, issue)
+}
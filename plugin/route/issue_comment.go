@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"net/http"
+
+	restfulspec "github.com/emicklei/go-restful-openapi/v2"
+	"github.com/emicklei/go-restful/v3"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	kerrors "github.com/katanomi/pkg/errors"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/path"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type issueCommentList struct {
+	impl client.IssueCommentLister
+	tags []string
+}
+
+// NewIssueCommentList create a list issue comment route with plugin client
+func NewIssueCommentList(impl client.IssueCommentLister) Route {
+	return &issueCommentList{
+		tags: []string{"projects", "issues", "comments"},
+		impl: impl,
+	}
+}
+
+func (i *issueCommentList) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "issue belong to integrate project")
+	issueParam := ws.PathParameter("issue", "issue id")
+	ws.Route(
+		ListOptionsDocs(
+			ws.GET("/projects/{project:*}/issues/{issue}/comments").To(i.ListIssueComments).
+				Doc("ListIssueComments").Param(projectParam).Param(issueParam).
+				Metadata(restfulspec.KeyOpenAPITags, i.tags).
+				Returns(http.StatusOK, "OK", metav1alpha1.IssueCommentList{}),
+		),
+	)
+}
+
+func (i *issueCommentList) ListIssueComments(request *restful.Request, response *restful.Response) {
+	option := GetListOptionsFromRequest(request)
+	pathParams := metav1alpha1.IssueOptions{
+		Identity: path.Parameter(request, "project"),
+		IssueId:  path.Parameter(request, "issue"),
+	}
+	comments, err := i.impl.ListIssueComments(request.Request.Context(), pathParams, option)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, comments)
+}
+
+type issueCommentCreator struct {
+	impl client.IssueCommentCreator
+	tags []string
+}
+
+// NewIssueCommentCreate create a create issue comment route with plugin client
+func NewIssueCommentCreate(impl client.IssueCommentCreator) Route {
+	return &issueCommentCreator{
+		tags: []string{"projects", "issues", "comments"},
+		impl: impl,
+	}
+}
+
+func (i *issueCommentCreator) Register(ws *restful.WebService) {
+	projectParam := ws.PathParameter("project", "issue belong to integrate project")
+	issueParam := ws.PathParameter("issue", "issue id")
+	ws.Route(
+		ws.POST("/projects/{project:*}/issues/{issue}/comments").To(i.CreateIssueComment).
+			Doc("CreateIssueComment").Param(projectParam).Param(issueParam).
+			Reads(metav1alpha1.CreateIssueCommentPayload{}).
+			Metadata(restfulspec.KeyOpenAPITags, i.tags).
+			Returns(http.StatusOK, "OK", metav1alpha1.Comment{}),
+	)
+}
+
+func (i *issueCommentCreator) CreateIssueComment(request *restful.Request, response *restful.Response) {
+	pathParams := metav1alpha1.IssueOptions{
+		Identity: path.Parameter(request, "project"),
+		IssueId:  path.Parameter(request, "issue"),
+	}
+
+	payload := metav1alpha1.CreateIssueCommentPayload{}
+	if err := request.ReadEntity(&payload); err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	if errs := payload.Validate(field.NewPath("payload")); len(errs) > 0 {
+		kerrors.HandleError(request, response, errors.NewBadRequest(errs.ToAggregate().Error()))
+		return
+	}
+	comment, err := i.impl.CreateIssueComment(request.Request.Context(), pathParams, payload)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, comment)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"k8s.io/utils/pointer"
+)
+
+func TestIssueWrite(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestIssueWriter{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	issuePath := "/plugins/v1alpha1/test-issue/projects/group%2Fsub/issues"
+	tests := []struct {
+		method   string
+		path     string
+		body     interface{}
+		code     int
+		contains string
+	}{
+		{method: http.MethodPost, path: "", body: metav1alpha1.CreateIssuePayload{Subject: "pipeline failed", Type: "bug"},
+			code: http.StatusOK, contains: `"subject":"pipeline failed"`},
+		{method: http.MethodPost, path: "", body: metav1alpha1.CreateIssuePayload{Subject: "pipeline failed"}, code: http.StatusBadRequest},
+		{method: http.MethodPut, path: "/1", body: metav1alpha1.UpdateIssuePayload{Status: pointer.String("closed")},
+			code: http.StatusOK, contains: `"status":"closed"`},
+		{method: http.MethodPut, path: "/1", body: metav1alpha1.UpdateIssuePayload{}, code: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/1/comments", code: http.StatusOK, contains: `"detail":"group/sub"`},
+		{method: http.MethodPost, path: "/1/comments", body: metav1alpha1.CreateIssueCommentPayload{Detail: "build 3 failed"},
+			code: http.StatusOK, contains: `"detail":"build 3 failed"`},
+		{method: http.MethodPost, path: "/1/comments", body: metav1alpha1.CreateIssueCommentPayload{}, code: http.StatusBadRequest},
+		{method: http.MethodPost, path: "/1/links", body: metav1alpha1.IssueLinkPayload{IssueId: "2", Type: metav1alpha1.IssueLinkBlocks},
+			code: http.StatusOK, contains: `"relateIssues":[{"id":"2"`},
+		{method: http.MethodPost, path: "/1/links", body: metav1alpha1.IssueLinkPayload{IssueId: "2", Type: "parent"}, code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		var body bytes.Buffer
+		if test.body != nil {
+			g.Expect(json.NewEncoder(&body).Encode(test.body)).To(Succeed())
+		}
+		request, _ := http.NewRequest(test.method, issuePath+test.path, &body)
+		request.Header.Set("Accept", "application/json")
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		container.Dispatch(recorder, request)
+		g.Expect(recorder.Code).To(Equal(test.code), "%s %s: %s", test.method, test.path, recorder.Body.String())
+		if test.contains != "" {
+			var compacted bytes.Buffer
+			g.Expect(json.Compact(&compacted, recorder.Body.Bytes())).To(Succeed())
+			g.Expect(compacted.String()).To(ContainSubstring(test.contains), "%s %s", test.method, test.path)
+		}
+	}
+}
+
+type TestIssueWriter struct {
+}
+
+func (t *TestIssueWriter) Path() string {
+	return "test-issue"
+}
+
+func (t *TestIssueWriter) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestIssueWriter) CreateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssuePayload) (*metav1alpha1.Issue, error) {
+	return &metav1alpha1.Issue{Spec: metav1alpha1.IssueSpec{Subject: payload.Subject, Type: payload.Type}}, nil
+}
+
+func (t *TestIssueWriter) UpdateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.UpdateIssuePayload) (*metav1alpha1.Issue, error) {
+	return &metav1alpha1.Issue{Spec: metav1alpha1.IssueSpec{Id: params.IssueId, Status: *payload.Status}}, nil
+}
+
+func (t *TestIssueWriter) ListIssueComments(ctx context.Context, params metav1alpha1.IssueOptions, option metav1alpha1.ListOptions) (*metav1alpha1.IssueCommentList, error) {
+	return &metav1alpha1.IssueCommentList{Items: []metav1alpha1.Comment{{Id: params.IssueId, Detail: params.Identity}}}, nil
+}
+
+func (t *TestIssueWriter) CreateIssueComment(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssueCommentPayload) (*metav1alpha1.Comment, error) {
+	return &metav1alpha1.Comment{Detail: payload.Detail}, nil
+}
+
+func (t *TestIssueWriter) LinkIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.IssueLinkPayload) (*metav1alpha1.Issue, error) {
+	return &metav1alpha1.Issue{Spec: metav1alpha1.IssueSpec{
+		Id:           params.IssueId,
+		RelateIssues: []metav1alpha1.RelateIssue{{Id: payload.IssueId, LinkType: payload.Type}},
+	}}, nil
+}
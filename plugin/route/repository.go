@@ -49,6 +49,7 @@ func (r *repositoryList) Register(ws *restful.WebService) {
 				// docs
 				Doc("ListRepositories").Param(projectParam).
 				Metadata(restfulspec.KeyOpenAPITags, r.tags).
+				Metadata(KeyCacheMaxAge, GitResourceCacheMaxAge).
 				Returns(http.StatusOK, "OK", metav1alpha1.RepositoryList{}),
 		),
 	)
@@ -70,5 +71,5 @@ func (r *repositoryList) ListRepositories(request *restful.Request, response *re
 		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusOK, repositories)
+	WriteEntityWithETag(request, response, repositories, CacheMaxAge(request))
 }
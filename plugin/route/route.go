@@ -120,6 +120,10 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewArtifactTagDelete(v))
 	}
 
+	if v, ok := c.(client.ArtifactPromoter); ok {
+		routes = append(routes, NewArtifactPromote(v))
+	}
+
 	if v, ok := c.(client.ScanImage); ok {
 		routes = append(routes, NewScanImage(v))
 	}
@@ -128,6 +132,13 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewImageConifgGetter(v))
 	}
 
+	if v, ok := c.(client.WebhookReceiver); ok {
+		// webhooks are only received if they could be verified
+		if w := newWebhookReceiver(v); w.verifier != nil {
+			routes = append(routes, w)
+		}
+	}
+
 	if v, ok := c.(client.GitRepoFileGetter); ok {
 		routes = append(routes, NewGitRepoFileGetter(v))
 	}
@@ -148,6 +159,22 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewGitBranchGetter(v))
 	}
 
+	if v, ok := c.(client.GitBranchDeleter); ok {
+		routes = append(routes, NewGitBranchDeleter(v))
+	}
+
+	if v, ok := c.(client.GitBranchProtectionGetter); ok {
+		routes = append(routes, NewGitBranchProtectionGetter(v))
+	}
+
+	if v, ok := c.(client.GitBranchProtectionUpdater); ok {
+		routes = append(routes, NewGitBranchProtectionUpdater(v))
+	}
+
+	if v, ok := c.(client.GitBranchProtectionDeleter); ok {
+		routes = append(routes, NewGitBranchProtectionDeleter(v))
+	}
+
 	if v, ok := c.(client.GitCommitGetter); ok {
 		routes = append(routes, NewGitCommitGetter(v))
 	}
@@ -160,6 +187,10 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewGitCommitLister(v))
 	}
 
+	if v, ok := c.(client.GitCommitComparer); ok {
+		routes = append(routes, NewGitCommitComparer(v))
+	}
+
 	if v, ok := c.(client.GitPullRequestHandler); ok {
 		routes = append(routes, NewGitPullRequestLister(v))
 	}
@@ -176,6 +207,34 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewGitPullRequestCommentLister(v))
 	}
 
+	if v, ok := c.(client.GitPullRequestFileLister); ok {
+		routes = append(routes, NewGitPullRequestFileLister(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestDiffGetter); ok {
+		routes = append(routes, NewGitPullRequestDiffGetter(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestReviewLister); ok {
+		routes = append(routes, NewGitPullRequestReviewLister(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestReviewCreator); ok {
+		routes = append(routes, NewGitPullRequestReviewCreator(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestReviewerRequester); ok {
+		routes = append(routes, NewGitPullRequestReviewerRequester(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestMergeabilityGetter); ok {
+		routes = append(routes, NewGitPullRequestMergeabilityGetter(v))
+	}
+
+	if v, ok := c.(client.GitPullRequestMerger); ok {
+		routes = append(routes, NewGitPullRequestMerger(v))
+	}
+
 	if v, ok := c.(client.GitRepositoryLister); ok {
 		routes = append(routes, NewGitRepositoryLister(v))
 	}
@@ -243,6 +302,26 @@ func match(c client.Interface) []Route {
 		routes = append(routes, NewIssueAttributeGet(v))
 	}
 
+	if v, ok := c.(client.IssueCreator); ok {
+		routes = append(routes, NewIssueCreate(v))
+	}
+
+	if v, ok := c.(client.IssueUpdater); ok {
+		routes = append(routes, NewIssueUpdate(v))
+	}
+
+	if v, ok := c.(client.IssueLinker); ok {
+		routes = append(routes, NewIssueLink(v))
+	}
+
+	if v, ok := c.(client.IssueCommentLister); ok {
+		routes = append(routes, NewIssueCommentList(v))
+	}
+
+	if v, ok := c.(client.IssueCommentCreator); ok {
+		routes = append(routes, NewIssueCommentCreate(v))
+	}
+
 	if v, ok := c.(client.IssueBranchLister); ok {
 		routes = append(routes, NewIssueBranchList(v))
 	}
@@ -341,6 +420,9 @@ func GetMethods(c client.Interface) []string {
 	if _, ok := c.(client.ArtifactTagDeleter); ok {
 		methods = append(methods, "DeleteArtifactTag")
 	}
+	if _, ok := c.(client.ArtifactPromoter); ok {
+		methods = append(methods, "PromoteArtifact")
+	}
 	if _, ok := c.(client.ScanImage); ok {
 		methods = append(methods, "ScanImage")
 	}
@@ -354,7 +436,9 @@ func GetMethods(c client.Interface) []string {
 		methods = append(methods, "IsSameResource")
 	}
 	if _, ok := c.(client.WebhookReceiver); ok {
-		methods = append(methods, "ReceiveWebhook")
+		if _, ok := c.(client.WebhookVerifierGetter); ok {
+			methods = append(methods, "ReceiveWebhook")
+		}
 	}
 	if _, ok := c.(client.GitRepoFileGetter); ok {
 		methods = append(methods, "GetGitRepoFile")
@@ -371,6 +455,18 @@ func GetMethods(c client.Interface) []string {
 	if _, ok := c.(client.GitBranchCreator); ok {
 		methods = append(methods, "CreateGitBranch")
 	}
+	if _, ok := c.(client.GitBranchDeleter); ok {
+		methods = append(methods, "DeleteGitBranch")
+	}
+	if _, ok := c.(client.GitBranchProtectionGetter); ok {
+		methods = append(methods, "GetGitBranchProtection")
+	}
+	if _, ok := c.(client.GitBranchProtectionUpdater); ok {
+		methods = append(methods, "UpdateGitBranchProtection")
+	}
+	if _, ok := c.(client.GitBranchProtectionDeleter); ok {
+		methods = append(methods, "DeleteGitBranchProtection")
+	}
 	if _, ok := c.(client.GitCommitGetter); ok {
 		methods = append(methods, "GetGitCommit")
 	}
@@ -380,6 +476,9 @@ func GetMethods(c client.Interface) []string {
 	if _, ok := c.(client.GitCommitLister); ok {
 		methods = append(methods, "ListGitCommit")
 	}
+	if _, ok := c.(client.GitCommitComparer); ok {
+		methods = append(methods, "CompareGitCommits")
+	}
 	if _, ok := c.(client.GitPullRequestHandler); ok {
 		methods = append(methods, "ListGitPullRequest", "GetGitPullRequest", "CreatePullRequest")
 	}
@@ -392,6 +491,27 @@ func GetMethods(c client.Interface) []string {
 	if _, ok := c.(client.GitPullRequestCommentLister); ok {
 		methods = append(methods, "ListPullRequestComment")
 	}
+	if _, ok := c.(client.GitPullRequestFileLister); ok {
+		methods = append(methods, "ListPullRequestFiles")
+	}
+	if _, ok := c.(client.GitPullRequestDiffGetter); ok {
+		methods = append(methods, "GetPullRequestDiff")
+	}
+	if _, ok := c.(client.GitPullRequestReviewLister); ok {
+		methods = append(methods, "ListPullRequestReviews")
+	}
+	if _, ok := c.(client.GitPullRequestReviewCreator); ok {
+		methods = append(methods, "CreatePullRequestReview")
+	}
+	if _, ok := c.(client.GitPullRequestReviewerRequester); ok {
+		methods = append(methods, "RequestPullRequestReviewers")
+	}
+	if _, ok := c.(client.GitPullRequestMergeabilityGetter); ok {
+		methods = append(methods, "GetPullRequestMergeability")
+	}
+	if _, ok := c.(client.GitPullRequestMerger); ok {
+		methods = append(methods, "MergePullRequest")
+	}
 	if _, ok := c.(client.GitRepositoryLister); ok {
 		methods = append(methods, "ListGitRepository")
 	}
@@ -440,6 +560,21 @@ func GetMethods(c client.Interface) []string {
 	if _, ok := c.(client.IssueAttributeGetter); ok {
 		methods = append(methods, "GetIssueAttribute")
 	}
+	if _, ok := c.(client.IssueCreator); ok {
+		methods = append(methods, "CreateIssue")
+	}
+	if _, ok := c.(client.IssueUpdater); ok {
+		methods = append(methods, "UpdateIssue")
+	}
+	if _, ok := c.(client.IssueLinker); ok {
+		methods = append(methods, "LinkIssue")
+	}
+	if _, ok := c.(client.IssueCommentLister); ok {
+		methods = append(methods, "ListIssueComments")
+	}
+	if _, ok := c.(client.IssueCommentCreator); ok {
+		methods = append(methods, "CreateIssueComment")
+	}
 	if _, ok := c.(client.IssueBranchLister); ok {
 		methods = append(methods, "ListIssueBranches")
 	}
@@ -107,6 +107,7 @@ func TestGetMethods(t *testing.T) {
 				"GetArtifact",
 				"DeleteArtifact",
 				"DeleteArtifactTag",
+				"PromoteArtifact",
 			},
 		},
 	}
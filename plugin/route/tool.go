@@ -112,6 +112,7 @@ func (i *toolMetadata) Register(ws *restful.WebService) {
 		ws.GET("/tools/metadata").To(i.GetToolMetadata).
 			Doc("metadata").
 			Metadata(restfulspec.KeyOpenAPITags, i.tags).
+			Metadata(KeyCacheMaxAge, ToolMetadataCacheMaxAge).
 			Returns(http.StatusOK, "OK", nil),
 	)
 }
@@ -124,5 +125,5 @@ func (i *toolMetadata) GetToolMetadata(request *restful.Request, response *restf
 		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusOK, metadata)
+	WriteEntityWithETag(request, response, metadata, CacheMaxAge(request))
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"bytes"
+	goerrors "errors"
+	"io"
+	"net/http"
+
+	restfulspec "github.com/emicklei/go-restful-openapi/v2"
+	"github.com/emicklei/go-restful/v3"
+	kerrors "github.com/katanomi/pkg/errors"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/webhook"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// WebhookSecretHeader header to store the secret of the webhook.
+	// It must be set by a trusted forwarder which looks up the secret of the webhook
+	// and overwrites any value sent by the caller. Otherwise anyone who can reach the
+	// route is able to choose the secret and sign forged requests with it.
+	WebhookSecretHeader = "X-Plugin-Webhook-Secret"
+
+	// MaxWebhookBodySize is the max size of the webhook request body in bytes
+	MaxWebhookBodySize int64 = 25 << 20
+)
+
+type webhookReceiver struct {
+	impl     client.WebhookReceiver
+	verifier webhook.Verifier
+	tags     []string
+}
+
+// NewWebhookReceive create a route receiving webhooks with plugin client.
+// Requests are verified by the verifier of the plugin client before received,
+// all requests are rejected if the plugin client does not implement WebhookVerifierGetter.
+func NewWebhookReceive(impl client.WebhookReceiver) Route {
+	return newWebhookReceiver(impl)
+}
+
+func newWebhookReceiver(impl client.WebhookReceiver) *webhookReceiver {
+	w := &webhookReceiver{
+		tags: []string{"webhooks"},
+		impl: impl,
+	}
+	if v, ok := impl.(client.WebhookVerifierGetter); ok {
+		w.verifier = v.WebhookVerifier()
+	}
+	return w
+}
+
+func (w *webhookReceiver) Register(ws *restful.WebService) {
+	ws.Route(
+		ws.POST("/webhooks").To(w.ReceiveWebhook).
+			Doc("ReceiveWebhook").
+			Consumes(restful.MIME_JSON, "application/x-www-form-urlencoded").
+			Param(ws.HeaderParameter(WebhookSecretHeader, "secret of the webhook")).
+			Metadata(restfulspec.KeyOpenAPITags, w.tags).
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusUnauthorized, "verification failed", nil).
+			Returns(http.StatusRequestEntityTooLarge, "request body too large", nil).
+			Returns(http.StatusConflict, "replayed request", nil),
+	)
+}
+
+func (w *webhookReceiver) ReceiveWebhook(request *restful.Request, response *restful.Response) {
+	ctx := request.Request.Context()
+	secret := request.HeaderParameter(WebhookSecretHeader)
+	request.Request.Body = http.MaxBytesReader(response.ResponseWriter, request.Request.Body, MaxWebhookBodySize)
+
+	if w.verifier == nil {
+		kerrors.HandleError(request, response, errors.NewUnauthorized("webhooks could not be verified by the plugin"))
+		return
+	}
+	body, err := io.ReadAll(request.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if goerrors.As(err, &maxBytesErr) {
+			err = errors.NewRequestEntityTooLargeError(err.Error())
+		} else {
+			err = errors.NewBadRequest(err.Error())
+		}
+		kerrors.HandleError(request, response, err)
+		return
+	}
+	request.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = w.verifier.Verify(ctx, request.Request.Header, body, secret); err != nil {
+		switch {
+		case webhook.IsReplayed(err):
+			err = errors.NewConflict(kerrors.RESTAPIGroupResource, request.Request.URL.Path, err)
+		case webhook.IsUnauthorized(err):
+			err = errors.NewUnauthorized(err.Error())
+		}
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	event, err := w.impl.ReceiveWebhook(ctx, request, secret)
+	if err != nil {
+		kerrors.HandleError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, event)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cloudevent "github.com/cloudevents/sdk-go/v2"
+	"github.com/emicklei/go-restful/v3"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/webhook"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+func TestWebhookReceive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestWebhookReceiver{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	mac = hmac.New(sha256.New, nil)
+	mac.Write(body)
+	emptyKeySignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name     string
+		secret   string
+		header   map[string]string
+		code     int
+		contains string
+	}{
+		{name: "missing signature", secret: "s3cr3t", header: map[string]string{"X-GitHub-Delivery": "1"},
+			code: http.StatusUnauthorized},
+		{name: "invalid secret", secret: "other", header: map[string]string{"X-Hub-Signature-256": signature, "X-GitHub-Delivery": "1"},
+			code: http.StatusUnauthorized},
+		{name: "valid", secret: "s3cr3t", header: map[string]string{"X-Hub-Signature-256": signature, "X-GitHub-Delivery": "1"},
+			code: http.StatusOK, contains: `"ref": "refs/heads/main"`},
+		{name: "replayed", secret: "s3cr3t", header: map[string]string{"X-Hub-Signature-256": signature, "X-GitHub-Delivery": "1"},
+			code: http.StatusConflict},
+		{name: "missing secret", header: map[string]string{"X-Hub-Signature-256": emptyKeySignature, "X-GitHub-Delivery": "2"},
+			code: http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		request, _ := http.NewRequest(http.MethodPost, "/plugins/v1alpha1/test-webhook/webhooks", bytes.NewReader(body))
+		request.Header.Set("Accept", "application/json")
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(WebhookSecretHeader, test.secret)
+		for key, value := range test.header {
+			request.Header.Set(key, value)
+		}
+		recorder := httptest.NewRecorder()
+		container.Dispatch(recorder, request)
+		g.Expect(recorder.Code).To(Equal(test.code), "%s: %s", test.name, recorder.Body.String())
+		g.Expect(recorder.Body.String()).To(ContainSubstring(test.contains), test.name)
+	}
+}
+
+func TestWebhookReceive_bodyTooLarge(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ws, err := NewService(&TestWebhookReceiver{}, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	body := bytes.Repeat([]byte("a"), int(MaxWebhookBodySize)+1)
+	request, _ := http.NewRequest(http.MethodPost, "/plugins/v1alpha1/test-webhook/webhooks", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSecretHeader, "s3cr3t")
+	recorder := httptest.NewRecorder()
+	container.Dispatch(recorder, request)
+	g.Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge), recorder.Body.String())
+}
+
+func TestWebhookReceive_withoutVerifier(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	impl := &TestUnverifiedWebhookReceiver{}
+	g.Expect(GetMethods(impl)).NotTo(ContainElement("ReceiveWebhook"))
+	ws, err := NewService(impl, client.MetaFilter)
+	g.Expect(err).To(BeNil())
+	for _, r := range ws.Routes() {
+		g.Expect(r.Path).NotTo(HaveSuffix("/webhooks"))
+	}
+
+	// the route rejects all requests if it is registered without a verifier
+	ws = new(restful.WebService).Produces(restful.MIME_JSON)
+	NewWebhookReceive(impl).Register(ws)
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+	request, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader([]byte(`{}`)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSecretHeader, "s3cr3t")
+	recorder := httptest.NewRecorder()
+	container.Dispatch(recorder, request)
+	g.Expect(recorder.Code).To(Equal(http.StatusUnauthorized), recorder.Body.String())
+	g.Expect(impl.received).To(BeFalse())
+}
+
+// TestUnverifiedWebhookReceiver receives webhooks without a verifier
+type TestUnverifiedWebhookReceiver struct {
+	received bool
+}
+
+func (t *TestUnverifiedWebhookReceiver) Path() string {
+	return "test-unverified-webhook"
+}
+
+func (t *TestUnverifiedWebhookReceiver) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestUnverifiedWebhookReceiver) ReceiveWebhook(ctx context.Context, req *restful.Request, secret string) (cloudevent.Event, error) {
+	t.received = true
+	return cloudevent.NewEvent(), nil
+}
+
+type TestWebhookReceiver struct {
+	verifier webhook.Verifier
+}
+
+func (t *TestWebhookReceiver) Path() string {
+	return "test-webhook"
+}
+
+func (t *TestWebhookReceiver) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+func (t *TestWebhookReceiver) WebhookVerifier() webhook.Verifier {
+	if t.verifier == nil {
+		t.verifier, _ = webhook.ProviderVerifier(webhook.ProviderGitHub, webhook.NewMemoryNonceCache())
+	}
+	return t.verifier
+}
+
+func (t *TestWebhookReceiver) ReceiveWebhook(ctx context.Context, req *restful.Request, secret string) (cloudevent.Event, error) {
+	payload := map[string]string{}
+	if err := req.ReadEntity(&payload); err != nil {
+		return cloudevent.Event{}, err
+	}
+	event := cloudevent.NewEvent()
+	event.SetID(req.HeaderParameter("X-GitHub-Delivery"))
+	event.SetSource("https://github.com")
+	event.SetType("push")
+	return event, event.SetData(cloudevent.ApplicationJSON, payload)
+}
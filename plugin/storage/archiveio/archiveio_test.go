@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archiveio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/memory"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func newSourceArchive(g *gomega.WithT) *memory.Archive {
+	ctx := context.Background()
+	archive := memory.NewArchive("source")
+	for i := 0; i < 5; i++ {
+		record := &archivev1alpha1.Record{Spec: archivev1alpha1.RecordSpec{
+			Cluster:           "cluster",
+			Namespace:         "default",
+			Name:              fmt.Sprintf("run-%d", i),
+			UID:               fmt.Sprintf("uid-%d", i),
+			Kind:              "PipelineRun",
+			CreationTimestamp: int64(100 + i),
+			Metadata:          map[string]string{"status": "True"},
+		}}
+		g.Expect(archive.Upsert(ctx, record)).To(gomega.Succeed())
+
+		child := &archivev1alpha1.Record{Spec: archivev1alpha1.RecordSpec{
+			Cluster:    "cluster",
+			Namespace:  "default",
+			Name:       fmt.Sprintf("task-%d", i),
+			UID:        fmt.Sprintf("task-uid-%d", i),
+			Kind:       "TaskRun",
+			TopUID:     record.Spec.UID,
+			TopCluster: "cluster",
+		}}
+		g.Expect(archive.Upsert(ctx, child)).To(gomega.Succeed())
+	}
+	return archive
+}
+
+func countRecords(g *gomega.WithT, archive *memory.Archive, conditions ...archivev1alpha1.Condition) int {
+	list, err := archive.ListRecords(context.Background(), archivev1alpha1.Query{Conditions: conditions}, nil)
+	g.Expect(err).To(gomega.BeNil())
+	return len(list.Items)
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	query := archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{archivev1alpha1.Equal(archivev1alpha1.KindField, "PipelineRun")}}
+
+	for _, format := range []Format{FormatNDJSON, FormatTarball} {
+		t.Run(string(format), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			source := newSourceArchive(g)
+
+			buf := &bytes.Buffer{}
+			manifest, err := Export(ctx, source, query, buf, ExportOptions{Format: format, PageSize: 2, WithRelatedRecords: true})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(manifest.Count).To(gomega.Equal(5))
+			g.Expect(manifest.RelatedCount).To(gomega.Equal(5))
+			g.Expect(manifest.SHA256).NotTo(gomega.BeEmpty())
+
+			target := memory.NewArchive("target")
+			result, err := Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{Format: format})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.Created).To(gomega.Equal(10))
+			g.Expect(countRecords(g, target)).To(gomega.Equal(10))
+			if format == FormatTarball {
+				g.Expect(result.Manifest).NotTo(gomega.BeNil())
+				g.Expect(result.Manifest.Count).To(gomega.Equal(5))
+			}
+
+			// importing again is idempotent
+			result, err = Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{Format: format})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.Skipped).To(gomega.Equal(10))
+			g.Expect(countRecords(g, target)).To(gomega.Equal(10))
+		})
+	}
+}
+
+func TestImportConflictPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	source := newSourceArchive(g)
+	buf := &bytes.Buffer{}
+	_, err := Export(ctx, source, archivev1alpha1.Query{}, buf, ExportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+
+	target := memory.NewArchive("target")
+	existing := &archivev1alpha1.Record{Spec: archivev1alpha1.RecordSpec{Cluster: "cluster", UID: "uid-0", Name: "changed"}}
+	g.Expect(target.Upsert(ctx, existing)).To(gomega.Succeed())
+
+	_, err = Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{ConflictPolicy: ConflictPolicyFail})
+	g.Expect(errors.IsAlreadyExists(err)).To(gomega.BeTrue())
+
+	result, err := Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{ConflictPolicy: ConflictPolicySkip})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Skipped).To(gomega.Equal(1))
+	g.Expect(countRecords(g, target, archivev1alpha1.Name("changed"))).To(gomega.Equal(1))
+
+	result, err = Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{ConflictPolicy: ConflictPolicyOverwrite})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Overwritten).To(gomega.Equal(10))
+	g.Expect(countRecords(g, target, archivev1alpha1.Name("changed"))).To(gomega.Equal(0))
+
+	_, err = Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{ConflictPolicy: "unknown"})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestImportRelatedRecordsOnce(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	source := newSourceArchive(g)
+
+	// task runs are exported both as top level and related records
+	buf := &bytes.Buffer{}
+	manifest, err := Export(ctx, source, archivev1alpha1.Query{}, buf, ExportOptions{WithRelatedRecords: true})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(manifest.Count).To(gomega.Equal(10))
+	g.Expect(manifest.RelatedCount).To(gomega.Equal(5))
+
+	target := memory.NewArchive("target")
+	result, err := Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{ConflictPolicy: ConflictPolicyFail})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.Equal(10))
+
+	result, err = Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Skipped).To(gomega.Equal(10))
+}
+
+func TestImportDeletedRecords(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	source := newSourceArchive(g)
+	g.Expect(source.Delete(ctx, "cluster", "uid-1", nil)).To(gomega.Succeed())
+
+	buf := &bytes.Buffer{}
+	manifest, err := Export(ctx, source, archivev1alpha1.Query{}, buf, ExportOptions{WithDeletedData: true})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(manifest.Count).To(gomega.Equal(10))
+
+	target := memory.NewArchive("target")
+	result, err := Import(ctx, target, bytes.NewReader(buf.Bytes()), ImportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.Equal(10))
+	g.Expect(countRecords(g, target)).To(gomega.Equal(9))
+	g.Expect(countRecords(g, target, archivev1alpha1.UID("uid-1"))).To(gomega.Equal(0))
+
+	list, err := target.ListRecords(ctx, archivev1alpha1.Query{}, &archivev1alpha1.ListOptions{WithDeletedData: true})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(list.Items).To(gomega.HaveLen(10))
+}
+
+func TestImportChecksumMismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	source := newSourceArchive(g)
+
+	records, err := os.CreateTemp(t.TempDir(), "records-*.ndjson")
+	g.Expect(err).To(gomega.BeNil())
+	defer records.Close()
+	manifest, err := Export(ctx, source, archivev1alpha1.Query{}, records, ExportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+
+	// the manifest does not match the tampered records
+	manifest.SHA256 = strings.Repeat("0", 64)
+	buf := &bytes.Buffer{}
+	g.Expect(writeTarball(buf, manifest, records)).To(gomega.Succeed())
+
+	target := memory.NewArchive("target")
+	result, err := Import(ctx, target, buf, ImportOptions{Format: FormatTarball})
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.Equal(0))
+	g.Expect(countRecords(g, target)).To(gomega.Equal(0))
+}
+
+func TestExportImportFile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	source := newSourceArchive(g)
+	fileName := filepath.Join(t.TempDir(), "records.tar.gz")
+
+	manifest, err := ExportToFile(ctx, source, archivev1alpha1.Query{}, fileName, ExportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(manifest.Count).To(gomega.Equal(10))
+
+	target := memory.NewArchive("target")
+	result, err := ImportFromFile(ctx, target, fileName, ImportOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Manifest).NotTo(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.Equal(10))
+}
+
+func TestImportInvalidContent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	target := memory.NewArchive("target")
+
+	_, err := Import(ctx, target, strings.NewReader("{invalid"), ImportOptions{})
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	_, err = Import(ctx, target, strings.NewReader("not gzip"), ImportOptions{Format: FormatTarball})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestDetectFormat(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(DetectFormat("records.tar.gz")).To(gomega.Equal(FormatTarball))
+	g.Expect(DetectFormat("records.tgz")).To(gomega.Equal(FormatTarball))
+	g.Expect(DetectFormat("records.ndjson")).To(gomega.Equal(FormatNDJSON))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archiveio
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	archivecap "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	archiveclient "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultPageSize is the default number of records fetched in each page
+const defaultPageSize = 100
+
+// ExportOptions options for exporting records
+type ExportOptions struct {
+	// Format is the format of the exported content, defaults to ndjson
+	Format Format
+	// PageSize is the number of records fetched in each page
+	PageSize int
+	// WithRelatedRecords exports the related records of each record as well
+	WithRelatedRecords bool
+	// WithDeletedData exports the soft deleted records as well
+	WithDeletedData bool
+}
+
+// Export writes all records matching the query to w and returns the manifest of the export
+func Export(ctx context.Context, storage archivecap.ArchiveCapable, query archivev1alpha1.Query, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	if opts.Format == "" {
+		opts.Format = FormatNDJSON
+	}
+	if err := opts.Format.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Format == FormatNDJSON {
+		return exportNDJSON(ctx, storage, query, w, opts)
+	}
+
+	// the size of records is required by the tar header,
+	// so records are written to a temporary file first
+	tmp, err := os.CreateTemp("", "archive-export-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	manifest, err := exportNDJSON(ctx, storage, query, tmp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeTarball(w, manifest, tmp); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ExportToFile exports records to a file, the format is detected by the file name
+func ExportToFile(ctx context.Context, storage archivecap.ArchiveCapable, query archivev1alpha1.Query, fileName string, opts ExportOptions) (manifest *Manifest, err error) {
+	if opts.Format == "" {
+		opts.Format = DetectFormat(fileName)
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return Export(ctx, storage, query, file, opts)
+}
+
+func exportNDJSON(ctx context.Context, storage archivecap.ArchiveCapable, query archivev1alpha1.Query, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:            ManifestVersion,
+		ExportedAt:         metav1.NewTime(time.Now()),
+		Query:              query,
+		WithRelatedRecords: opts.WithRelatedRecords,
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	listOpts := &archivev1alpha1.ListOptions{
+		Pager:           metav1alpha1.Pager{ItemsPerPage: pageSize},
+		WithDeletedData: opts.WithDeletedData,
+	}
+
+	hash := sha256.New()
+	encoder := json.NewEncoder(io.MultiWriter(w, hash))
+	fn := func(record *archivev1alpha1.Record) error {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+		manifest.Count++
+		manifest.RelatedCount += len(record.RelatedRecords)
+		return nil
+	}
+
+	var err error
+	if opts.WithRelatedRecords {
+		err = archiveclient.StreamRelatedRecords(ctx, storage, query, listOpts, fn)
+	} else {
+		err = archiveclient.StreamRecords(ctx, storage, query, listOpts, fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return manifest, nil
+}
+
+func writeTarball(w io.Writer, manifest *Manifest, records *os.File) error {
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	info, err := records.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err = records.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := manifest.ExportedAt.Time
+	if err = tarWriter.WriteHeader(&tar.Header{Name: ManifestFileName, Mode: 0o644, Size: int64(len(manifestData)), ModTime: modTime}); err != nil {
+		return err
+	}
+	if _, err = tarWriter.Write(manifestData); err != nil {
+		return err
+	}
+	if err = tarWriter.WriteHeader(&tar.Header{Name: RecordsFileName, Mode: 0o644, Size: info.Size(), ModTime: modTime}); err != nil {
+		return err
+	}
+	if _, err = io.Copy(tarWriter, records); err != nil {
+		return err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
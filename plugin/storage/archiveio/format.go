@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archiveio
+
+import (
+	"fmt"
+	"strings"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Format describe the file format of exported records
+type Format string
+
+const (
+	// FormatNDJSON is newline delimited json, one record per line
+	FormatNDJSON Format = "ndjson"
+	// FormatTarball is a gzipped tarball with a manifest and a ndjson file of records
+	FormatTarball Format = "tarball"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format
+	ManifestVersion = "v1alpha1"
+
+	// ManifestFileName is the name of the manifest file in tarballs
+	ManifestFileName = "manifest.json"
+	// RecordsFileName is the name of the records file in tarballs
+	RecordsFileName = "records.ndjson"
+)
+
+// DetectFormat returns the format according to the extension of a file name.
+// Files ending with .tar.gz or .tgz are tarballs and others are ndjson.
+func DetectFormat(fileName string) Format {
+	if strings.HasSuffix(fileName, ".tar.gz") || strings.HasSuffix(fileName, ".tgz") {
+		return FormatTarball
+	}
+	return FormatNDJSON
+}
+
+// Validate returns error if the format is not supported
+func (f Format) Validate() error {
+	switch f {
+	case FormatNDJSON, FormatTarball:
+		return nil
+	}
+	return fmt.Errorf("unsupported format %q", f)
+}
+
+// Manifest describe the content of an exported tarball
+type Manifest struct {
+	// Version is the version of the manifest
+	Version string `json:"version"`
+	// ExportedAt is the time when the export started
+	ExportedAt metav1.Time `json:"exportedAt"`
+	// Query is the query used to select exported records
+	Query archivev1alpha1.Query `json:"query"`
+	// WithRelatedRecords describe whether related records are exported
+	WithRelatedRecords bool `json:"withRelatedRecords,omitempty"`
+	// Count is the number of exported records, related records are not included
+	Count int `json:"count"`
+	// RelatedCount is the number of exported related records
+	RelatedCount int `json:"relatedCount,omitempty"`
+	// SHA256 is the hex encoded sha256 checksum of the records file
+	SHA256 string `json:"sha256"`
+}
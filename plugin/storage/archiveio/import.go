@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archiveio
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	archivecap "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ConflictPolicy describe what to do when an imported record already exists
+type ConflictPolicy string
+
+const (
+	// ConflictPolicySkip keeps the existing record
+	ConflictPolicySkip ConflictPolicy = "skip"
+	// ConflictPolicyOverwrite overwrites the existing record
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+	// ConflictPolicyFail stops the import with an already exists error
+	ConflictPolicyFail ConflictPolicy = "fail"
+)
+
+// ImportOptions options for importing records
+type ImportOptions struct {
+	// Format is the format of the imported content, defaults to ndjson
+	Format Format
+	// ConflictPolicy is the policy for existing records, defaults to skip
+	ConflictPolicy ConflictPolicy
+}
+
+// ImportResult describe the result of an import
+type ImportResult struct {
+	// Manifest is the manifest of the imported tarball, nil for ndjson
+	Manifest *Manifest `json:"manifest,omitempty"`
+	// Created is the number of created records
+	Created int `json:"created"`
+	// Overwritten is the number of overwritten records
+	Overwritten int `json:"overwritten"`
+	// Skipped is the number of skipped records
+	Skipped int `json:"skipped"`
+}
+
+// Import reads exported records from r and replays them through Upsert.
+// Records are identified by cluster and uid, so importing the same content repeatedly is idempotent,
+// and a record exported both as a top level and a related record is imported once.
+// The ids of records are cleared as they are assigned by the target storage,
+// and records with a deletion timestamp are soft deleted after being upserted.
+// The checksum of a tarball is verified before any record is imported.
+func Import(ctx context.Context, storage archivecap.ArchiveCapable, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	if opts.Format == "" {
+		opts.Format = FormatNDJSON
+	}
+	if err := opts.Format.Validate(); err != nil {
+		return nil, err
+	}
+	switch opts.ConflictPolicy {
+	case "":
+		opts.ConflictPolicy = ConflictPolicySkip
+	case ConflictPolicySkip, ConflictPolicyOverwrite, ConflictPolicyFail:
+	default:
+		return nil, fmt.Errorf("unsupported conflict policy %q", opts.ConflictPolicy)
+	}
+
+	importer := &importer{storage: storage, policy: opts.ConflictPolicy, result: &ImportResult{}, imported: map[string]bool{}}
+	if opts.Format == FormatNDJSON {
+		return importer.result, importer.importNDJSON(ctx, r)
+	}
+	return importer.result, importer.importTarball(ctx, r)
+}
+
+// ImportFromFile imports records from a file, the format is detected by the file name
+func ImportFromFile(ctx context.Context, storage archivecap.ArchiveCapable, fileName string, opts ImportOptions) (*ImportResult, error) {
+	if opts.Format == "" {
+		opts.Format = DetectFormat(fileName)
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Import(ctx, storage, file, opts)
+}
+
+type importer struct {
+	storage archivecap.ArchiveCapable
+	policy  ConflictPolicy
+	result  *ImportResult
+	// imported is the keys of records already imported
+	imported map[string]bool
+}
+
+// importNDJSON imports records line by line
+func (i *importer) importNDJSON(ctx context.Context, r io.Reader) error {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		record := &archivev1alpha1.Record{}
+		if err := decoder.Decode(record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := i.importRecord(ctx, record); err != nil {
+			return err
+		}
+		for j := range record.RelatedRecords {
+			if err := i.importRecord(ctx, &record.RelatedRecords[j]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (i *importer) importTarball(ctx context.Context, r io.Reader) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		switch header.Name {
+		case ManifestFileName:
+			manifest := &Manifest{}
+			if err = json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return err
+			}
+			if manifest.Version != ManifestVersion {
+				return fmt.Errorf("unsupported manifest version %q", manifest.Version)
+			}
+			i.result.Manifest = manifest
+		case RecordsFileName:
+			if i.result.Manifest == nil {
+				return fmt.Errorf("%s must precede %s in the tarball", ManifestFileName, RecordsFileName)
+			}
+			return i.importVerifiedRecords(ctx, tarReader)
+		}
+	}
+	return fmt.Errorf("%s is not found in the tarball", RecordsFileName)
+}
+
+// importVerifiedRecords copies the records to a temporary file and
+// imports them only if the checksum matches the manifest
+func (i *importer) importVerifiedRecords(ctx context.Context, r io.Reader) error {
+	tmp, err := os.CreateTemp("", "archive-import-*.ndjson")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, hash), r); err != nil {
+		return err
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != i.result.Manifest.SHA256 {
+		return fmt.Errorf("checksum of %s mismatch, expected %s but got %s", RecordsFileName, i.result.Manifest.SHA256, checksum)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return i.importNDJSON(ctx, tmp)
+}
+
+func (i *importer) importRecord(ctx context.Context, record *archivev1alpha1.Record) error {
+	key := record.Spec.Cluster + "/" + record.Spec.UID
+	if i.imported[key] {
+		return nil
+	}
+	i.imported[key] = true
+
+	exists, err := i.exists(ctx, record)
+	if err != nil {
+		return err
+	}
+	if exists {
+		switch i.policy {
+		case ConflictPolicySkip:
+			i.result.Skipped++
+			return nil
+		case ConflictPolicyFail:
+			return errors.NewAlreadyExists(schema.GroupResource{Group: archivev1alpha1.GroupVersion.Group, Resource: "records"},
+				fmt.Sprintf("%s/%s", record.Spec.Cluster, record.Spec.UID))
+		}
+	}
+
+	upserted := *record
+	upserted.Spec.ID = 0
+	upserted.RelatedRecords = nil
+	if err = i.storage.Upsert(ctx, &upserted); err != nil {
+		return err
+	}
+	if record.DeletionTimestamp != nil {
+		if err = i.storage.Delete(ctx, record.Spec.Cluster, record.Spec.UID, &archivev1alpha1.DeleteOption{}); err != nil {
+			return err
+		}
+	}
+	if exists {
+		i.result.Overwritten++
+	} else {
+		i.result.Created++
+	}
+	return nil
+}
+
+func (i *importer) exists(ctx context.Context, record *archivev1alpha1.Record) (bool, error) {
+	query := archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{
+		archivev1alpha1.Cluster(record.Spec.Cluster),
+		archivev1alpha1.UID(record.Spec.UID),
+	}}
+	list, err := i.storage.ListRecords(ctx, query, &archivev1alpha1.ListOptions{WithDeletedData: true})
+	if err != nil {
+		return false, err
+	}
+	return len(list.Items) > 0, nil
+}
@@ -0,0 +1,819 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivesql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// identifierRegexp restricts names which are not from the column whitelist, such as aliases and indexes
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Statement is a compiled sql statement with its bind args
+type Statement struct {
+	// SQL is the parameterised sql
+	SQL string `json:"sql"`
+	// Args is the bind args of the sql
+	Args []interface{} `json:"args"`
+}
+
+// CompilerOption configures a Compiler
+type CompilerOption func(*Compiler)
+
+// WithColumns sets the whitelist of keys allowed in queries and their column names
+func WithColumns(columns map[string]string) CompilerOption {
+	return func(c *Compiler) {
+		c.columns = columns
+	}
+}
+
+// WithJSONColumns sets the fields stored as json columns, such as metadata and data.
+// Keys like `metadata."status"` will be compiled to json extraction of the column.
+func WithJSONColumns(columns map[string]string) CompilerOption {
+	return func(c *Compiler) {
+		c.jsonColumns = columns
+	}
+}
+
+// WithSoftDeleteColumn sets the nullable column marking soft deleted records.
+// Records with a non-null value are excluded unless WithDeletedData is set.
+func WithSoftDeleteColumn(column string) CompilerOption {
+	return func(c *Compiler) {
+		c.softDeleteColumn = column
+	}
+}
+
+// DefaultColumns returns the default whitelist of archive record fields
+// with snake case column names
+func DefaultColumns() map[string]string {
+	fields := []string{
+		archivev1alpha1.IDField,
+		archivev1alpha1.TopOwnerClusterField,
+		archivev1alpha1.TopClusterField,
+		archivev1alpha1.ParentClusterField,
+		archivev1alpha1.ClusterField,
+		archivev1alpha1.TopOwnerUIDField,
+		archivev1alpha1.TopUIDField,
+		archivev1alpha1.ParentUIDField,
+		archivev1alpha1.UIDField,
+		archivev1alpha1.TopOwnerNamespaceField,
+		archivev1alpha1.TopNamespaceField,
+		archivev1alpha1.ParentNamespaceField,
+		archivev1alpha1.NamespaceField,
+		archivev1alpha1.TopOwnerNameField,
+		archivev1alpha1.TopNameField,
+		archivev1alpha1.ParentNameField,
+		archivev1alpha1.NameField,
+		archivev1alpha1.GroupField,
+		archivev1alpha1.VersionField,
+		archivev1alpha1.KindField,
+		archivev1alpha1.CreationTimestampField,
+		archivev1alpha1.CleanupTimeField,
+	}
+	columns := make(map[string]string, len(fields))
+	for _, field := range fields {
+		columns[field] = toSnakeCase(field)
+	}
+	return columns
+}
+
+// DefaultJSONColumns returns the default json columns of archive records
+func DefaultJSONColumns() map[string]string {
+	return map[string]string{
+		archivev1alpha1.MetadataFiled: archivev1alpha1.MetadataFiled,
+		archivev1alpha1.DataField:     archivev1alpha1.DataField,
+	}
+}
+
+// Compiler compiles archive queries of a table to parameterised sql.
+// Only whitelisted keys are accepted, so user supplied keys can never inject sql.
+type Compiler struct {
+	dialect          Dialect
+	table            string
+	columns          map[string]string
+	jsonColumns      map[string]string
+	softDeleteColumn string
+}
+
+// NewCompiler returns a compiler for table, DefaultColumns and DefaultJSONColumns
+// are used unless other columns are set in options.
+func NewCompiler(dialect Dialect, table string, opts ...CompilerOption) (*Compiler, error) {
+	if err := dialect.Validate(); err != nil {
+		return nil, err
+	}
+	if table == "" {
+		return nil, fmt.Errorf("table name is required")
+	}
+	c := &Compiler{
+		dialect:     dialect,
+		table:       table,
+		columns:     DefaultColumns(),
+		jsonColumns: DefaultJSONColumns(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Dialect returns the dialect of the compiler
+func (c *Compiler) Dialect() Dialect {
+	return c.dialect
+}
+
+// Where compiles conditions to a predicate which can be used after WHERE
+func (c *Compiler) Where(conditions []archivev1alpha1.Condition) (*Statement, error) {
+	b := c.newBuilder()
+	if err := b.writeConditions(conditions, archivev1alpha1.ConditionOperatorAnd); err != nil {
+		return nil, err
+	}
+	return b.statement(), nil
+}
+
+// Select compiles a query to a select statement.
+// Keyset pagination is used when the options have a cursor.
+func (c *Compiler) Select(query archivev1alpha1.Query, opts *archivev1alpha1.ListOptions) (*Statement, error) {
+	conditions := query.Conditions
+	var orders []archivev1alpha1.Order
+	if opts != nil {
+		orders = opts.Orders
+	}
+	if opts != nil && opts.Cursor != nil {
+		cursorConditions, err := archivev1alpha1.CursorConditions(opts.Cursor, opts.Orders)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(cursorConditions, conditions...)
+		orders = archivev1alpha1.CursorOrders(opts.Orders)
+	}
+
+	b := c.newBuilder()
+	b.write("SELECT ")
+	if len(query.Fields) == 0 {
+		b.write("*")
+	}
+	for i, field := range query.Fields {
+		if i > 0 {
+			b.write(", ")
+		}
+		if err := b.writeField(field); err != nil {
+			return nil, err
+		}
+	}
+	b.write(" FROM ")
+	b.write(c.dialect.QuoteIdentifier(c.table))
+	if err := b.writeIndexes(query.Indexs); err != nil {
+		return nil, err
+	}
+	if err := b.writeWhere(conditions, opts); err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		if err := b.writeOrders(orders, nil); err != nil {
+			return nil, err
+		}
+		b.writePagination(opts)
+	}
+	return b.statement(), nil
+}
+
+// Count compiles conditions to a statement counting the matched records
+func (c *Compiler) Count(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) (*Statement, error) {
+	b := c.newBuilder()
+	b.write("SELECT COUNT(*) FROM ")
+	b.write(c.dialect.QuoteIdentifier(c.table))
+	if err := b.writeWhere(conditions, opts); err != nil {
+		return nil, err
+	}
+	return b.statement(), nil
+}
+
+// Delete compiles conditions to a delete statement.
+// It will be an update of the soft delete column unless opts.Direct is true.
+func (c *Compiler) Delete(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.DeleteOption) (*Statement, error) {
+	b := c.newBuilder()
+	table := c.dialect.QuoteIdentifier(c.table)
+	if opts != nil && opts.Direct {
+		b.write("DELETE FROM " + table)
+		if err := b.writeWhere(conditions, &archivev1alpha1.ListOptions{WithDeletedData: true}); err != nil {
+			return nil, err
+		}
+		return b.statement(), nil
+	}
+
+	if c.softDeleteColumn == "" {
+		return nil, fmt.Errorf("soft delete is not supported without a soft delete column")
+	}
+	b.write(fmt.Sprintf("UPDATE %s SET %s = CURRENT_TIMESTAMP", table, c.dialect.QuoteIdentifier(c.softDeleteColumn)))
+	if err := b.writeWhere(conditions, nil); err != nil {
+		return nil, err
+	}
+	return b.statement(), nil
+}
+
+// Aggregate compiles an aggregate query to a group by statement.
+// Orders of the options may refer to the aliases of group and aggregate fields.
+// Cursor is not supported because groups have no stable keys to be positioned at,
+// page based pagination should be used instead.
+func (c *Compiler) Aggregate(query archivev1alpha1.AggregateQuery, opts *archivev1alpha1.ListOptions) (*Statement, error) {
+	if opts != nil && opts.Cursor != nil {
+		return nil, errors.NewBadRequest("cursor is not supported by aggregate queries")
+	}
+	groups := len(query.GroupFields) + len(query.TimeBuckets)
+	if groups == 0 && len(query.AggregateFields) == 0 {
+		return nil, fmt.Errorf("at least one group or aggregate field is required")
+	}
+
+	b := c.newBuilder()
+	aliases := make(map[string]bool, groups+len(query.AggregateFields))
+	b.write("SELECT ")
+	for i, field := range query.GroupFields {
+		if i > 0 {
+			b.write(", ")
+		}
+		if err := b.writeField(field); err != nil {
+			return nil, err
+		}
+		aliases[outputName(field)] = true
+	}
+	for i, bucket := range query.TimeBuckets {
+		if i > 0 || len(query.GroupFields) > 0 {
+			b.write(", ")
+		}
+		if err := b.writeTimeBucket(bucket); err != nil {
+			return nil, err
+		}
+		aliases[outputName(bucket.Field)] = true
+	}
+	for i, field := range query.AggregateFields {
+		if i > 0 || groups > 0 {
+			b.write(", ")
+		}
+		if err := b.writeAggregateField(field); err != nil {
+			return nil, err
+		}
+		aliases[outputName(field.Field)] = true
+	}
+	b.write(" FROM ")
+	b.write(c.dialect.QuoteIdentifier(c.table))
+	if err := b.writeWhere(query.Conditions, opts); err != nil {
+		return nil, err
+	}
+	if groups > 0 {
+		// group by positions, so that json expressions are not compiled twice
+		positions := make([]string, 0, groups)
+		for i := 0; i < groups; i++ {
+			positions = append(positions, strconv.Itoa(i+1))
+		}
+		b.write(" GROUP BY " + strings.Join(positions, ", "))
+	}
+	if opts != nil {
+		if err := b.writeOrders(opts.Orders, aliases); err != nil {
+			return nil, err
+		}
+		b.writePagination(opts)
+	}
+	return b.statement(), nil
+}
+
+// builder accumulates sql and bind args
+type builder struct {
+	*Compiler
+	sql  strings.Builder
+	args []interface{}
+}
+
+func (c *Compiler) newBuilder() *builder {
+	return &builder{Compiler: c}
+}
+
+func (b *builder) statement() *Statement {
+	return &Statement{SQL: b.sql.String(), Args: b.args}
+}
+
+func (b *builder) write(s string) {
+	b.sql.WriteString(s)
+}
+
+// bind adds an arg and returns its placeholder
+func (b *builder) bind(v interface{}) string {
+	b.args = append(b.args, v)
+	return b.dialect.Placeholder(len(b.args))
+}
+
+// writeColumn writes the column expression of a whitelisted key
+func (b *builder) writeColumn(key string) error {
+	if column, ok := b.columns[key]; ok {
+		b.write(b.dialect.QuoteIdentifier(column))
+		return nil
+	}
+
+	field, path, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+	column, ok := b.jsonColumns[field]
+	if !ok || len(path) == 0 {
+		return fmt.Errorf("key %q is not allowed", key)
+	}
+	// the placeholder index is decided by the args already bound
+	expr, arg := b.dialect.jsonText(b.dialect.QuoteIdentifier(column), path, b.dialect.Placeholder(len(b.args)+1))
+	b.args = append(b.args, arg)
+	b.write(expr)
+	return nil
+}
+
+func (b *builder) writeField(field archivev1alpha1.Field) error {
+	alias := outputName(field)
+	if !identifierRegexp.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q of field %q", alias, field.Name)
+	}
+	if err := b.writeColumn(field.Name); err != nil {
+		return err
+	}
+	b.write(" AS " + b.dialect.QuoteIdentifier(alias))
+	return nil
+}
+
+func (b *builder) writeAggregateField(field archivev1alpha1.AggregateField) error {
+	alias := outputName(field.Field)
+	if !identifierRegexp.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q of aggregate field %q", alias, field.Name)
+	}
+
+	var err error
+	switch field.Operator {
+	case archivev1alpha1.AggregateOperatorMax:
+		err = b.writeFunction("MAX", field.Name)
+	case archivev1alpha1.AggregateOperatorMin:
+		err = b.writeFunction("MIN", field.Name)
+	case archivev1alpha1.AggregateOperatorSum:
+		err = b.writeFunction("SUM", field.Name)
+	case archivev1alpha1.AggregateOperatorCount:
+		if field.Name == "" {
+			b.write("COUNT(*)")
+		} else {
+			err = b.writeFunction("COUNT", field.Name)
+		}
+	case archivev1alpha1.AggregateOperatorCountDistinct:
+		b.write("COUNT(DISTINCT ")
+		err = b.writeColumn(field.Name)
+		b.write(")")
+	case archivev1alpha1.AggregateOperatorAvg:
+		b.write("AVG(")
+		err = b.writeNumericColumn(field.Name)
+		b.write(")")
+	case archivev1alpha1.AggregateOperatorPercentile:
+		err = b.writePercentile(field)
+	default:
+		return fmt.Errorf("unsupported aggregate operator %q", field.Operator)
+	}
+	if err != nil {
+		return err
+	}
+	b.write(" AS " + b.dialect.QuoteIdentifier(alias))
+	return nil
+}
+
+// writeFunction writes a sql function with the column of key as the only argument
+func (b *builder) writeFunction(function, key string) error {
+	b.write(function + "(")
+	if err := b.writeColumn(key); err != nil {
+		return err
+	}
+	b.write(")")
+	return nil
+}
+
+// writeNumericColumn writes the column of key casted to a number,
+// json values are extracted as text in postgres and must be casted before calculation
+func (b *builder) writeNumericColumn(key string) error {
+	if b.dialect != DialectPostgres {
+		return b.writeColumn(key)
+	}
+	b.write("CAST(")
+	if err := b.writeColumn(key); err != nil {
+		return err
+	}
+	b.write(" AS DOUBLE PRECISION)")
+	return nil
+}
+
+// writePercentile writes the continuous percentile, which is only supported by postgres
+func (b *builder) writePercentile(field archivev1alpha1.AggregateField) error {
+	if b.dialect != DialectPostgres {
+		return fmt.Errorf("aggregate operator %q is not supported by %s", field.Operator, b.dialect)
+	}
+	if field.Percentile < 0 || field.Percentile > 1 {
+		return fmt.Errorf("percentile of field %q should be between 0 and 1, got %v", field.Name, field.Percentile)
+	}
+	b.write("PERCENTILE_CONT(" + b.bind(field.Percentile) + ") WITHIN GROUP (ORDER BY ")
+	if err := b.writeNumericColumn(field.Name); err != nil {
+		return err
+	}
+	b.write(")")
+	return nil
+}
+
+// writeTimeBucket writes the start of the time bucket formatted with archivev1alpha1.TimeBucketLayout.
+// Time zones other than UTC are not supported by sqlite, and mysql requires the time zone tables to be loaded.
+func (b *builder) writeTimeBucket(bucket archivev1alpha1.TimeBucket) error {
+	alias := outputName(bucket.Field)
+	if !identifierRegexp.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q of time bucket %q", alias, bucket.Name)
+	}
+	if _, err := bucket.Location(); err != nil {
+		return err
+	}
+	timezone := bucket.TimeZone
+	if timezone == "" {
+		timezone = time.UTC.String()
+	}
+
+	var err error
+	switch b.dialect {
+	case DialectPostgres:
+		err = b.writePostgresTimeBucket(bucket, timezone)
+	case DialectMySQL:
+		err = b.writeMySQLTimeBucket(bucket, timezone)
+	default:
+		err = b.writeSQLiteTimeBucket(bucket, timezone)
+	}
+	if err != nil {
+		return err
+	}
+	b.write(" AS " + b.dialect.QuoteIdentifier(alias))
+	return nil
+}
+
+func (b *builder) writePostgresTimeBucket(bucket archivev1alpha1.TimeBucket, timezone string) error {
+	if _, ok := timeBucketFormats[bucket.Unit]; !ok {
+		return fmt.Errorf("unsupported time bucket unit %q", bucket.Unit)
+	}
+	// the unit is one of the whitelisted values so that it is safe to be inlined
+	b.write("to_char(date_trunc('" + string(bucket.Unit) + "', to_timestamp(")
+	if err := b.writeNumericColumn(bucket.Name); err != nil {
+		return err
+	}
+	b.write(") AT TIME ZONE " + b.bind(timezone) + `), 'YYYY-MM-DD"T"HH24:MI:SS')`)
+	return nil
+}
+
+func (b *builder) writeMySQLTimeBucket(bucket archivev1alpha1.TimeBucket, timezone string) error {
+	format, ok := timeBucketFormats[bucket.Unit]
+	if !ok {
+		return fmt.Errorf("unsupported time bucket unit %q", bucket.Unit)
+	}
+	if timezone == time.UTC.String() {
+		// named time zones require the time zone tables to be loaded
+		timezone = "+00:00"
+	}
+	writeLocalTime := func() error {
+		b.write("CONVERT_TZ(TIMESTAMP('1970-01-01 00:00:00') + INTERVAL ")
+		if err := b.writeColumn(bucket.Name); err != nil {
+			return err
+		}
+		b.write(" SECOND, '+00:00', " + b.bind(timezone) + ")")
+		return nil
+	}
+
+	b.write("DATE_FORMAT(")
+	if bucket.Unit == archivev1alpha1.TimeBucketUnitWeek {
+		b.write("DATE_SUB(")
+		if err := writeLocalTime(); err != nil {
+			return err
+		}
+		b.write(", INTERVAL WEEKDAY(")
+		if err := writeLocalTime(); err != nil {
+			return err
+		}
+		b.write(") DAY)")
+	} else if err := writeLocalTime(); err != nil {
+		return err
+	}
+	b.write(", '" + format + "')")
+	return nil
+}
+
+func (b *builder) writeSQLiteTimeBucket(bucket archivev1alpha1.TimeBucket, timezone string) error {
+	format, ok := timeBucketFormats[bucket.Unit]
+	if !ok {
+		return fmt.Errorf("unsupported time bucket unit %q", bucket.Unit)
+	}
+	if timezone != time.UTC.String() {
+		return fmt.Errorf("time zone %q is not supported by %s", timezone, b.dialect)
+	}
+	b.write("strftime('" + format + "', ")
+	if err := b.writeColumn(bucket.Name); err != nil {
+		return err
+	}
+	b.write(", 'unixepoch'")
+	if bucket.Unit == archivev1alpha1.TimeBucketUnitWeek {
+		// move to the next sunday and then back to monday
+		b.write(", 'weekday 0', '-6 days'")
+	}
+	b.write(")")
+	return nil
+}
+
+// timeBucketFormats maps time bucket units to the strftime like formats used by mysql and sqlite
+var timeBucketFormats = map[archivev1alpha1.TimeBucketUnit]string{
+	archivev1alpha1.TimeBucketUnitHour:  "%Y-%m-%dT%H:00:00",
+	archivev1alpha1.TimeBucketUnitDay:   "%Y-%m-%dT00:00:00",
+	archivev1alpha1.TimeBucketUnitWeek:  "%Y-%m-%dT00:00:00",
+	archivev1alpha1.TimeBucketUnitMonth: "%Y-%m-01T00:00:00",
+}
+
+func (b *builder) writeIndexes(indexes []archivev1alpha1.Index) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		if !identifierRegexp.MatchString(index.Name) {
+			return fmt.Errorf("invalid index name %q", index.Name)
+		}
+		names = append(names, b.dialect.QuoteIdentifier(index.Name))
+	}
+
+	switch b.dialect {
+	case DialectMySQL:
+		b.write(" USE INDEX (" + strings.Join(names, ", ") + ")")
+	case DialectSQLite:
+		// sqlite only accepts a single index
+		b.write(" INDEXED BY " + names[0])
+	}
+	// postgres does not support index hints
+	return nil
+}
+
+func (b *builder) writeWhere(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) error {
+	excludeDeleted := b.softDeleteColumn != "" && (opts == nil || !opts.WithDeletedData)
+	if len(conditions) == 0 && !excludeDeleted {
+		return nil
+	}
+	b.write(" WHERE ")
+	if excludeDeleted {
+		b.write(b.dialect.QuoteIdentifier(b.softDeleteColumn) + " IS NULL")
+		if len(conditions) == 0 {
+			return nil
+		}
+		b.write(" AND ")
+	}
+	return b.writeConditions(conditions, archivev1alpha1.ConditionOperatorAnd)
+}
+
+func (b *builder) writeOrders(orders []archivev1alpha1.Order, aliases map[string]bool) error {
+	for i, order := range orders {
+		if i == 0 {
+			b.write(" ORDER BY ")
+		} else {
+			b.write(", ")
+		}
+		if aliases[order.Field] {
+			b.write(b.dialect.QuoteIdentifier(order.Field))
+		} else if err := b.writeColumn(order.Field); err != nil {
+			return err
+		}
+		if order.Asc {
+			b.write(" ASC")
+		} else {
+			b.write(" DESC")
+		}
+		if b.dialect == DialectPostgres {
+			// nulls are ordered first as mysql and sqlite, which is expected by cursors
+			if order.Asc {
+				b.write(" NULLS FIRST")
+			} else {
+				b.write(" NULLS LAST")
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builder) writePagination(opts *archivev1alpha1.ListOptions) {
+	if opts.Cursor != nil {
+		// the position of cursor is already in the conditions of Select
+		b.write(fmt.Sprintf(" LIMIT %d", opts.GetPageLimit()))
+		return
+	}
+	b.write(fmt.Sprintf(" LIMIT %d OFFSET %d", opts.GetPageLimit(), opts.GetOffset()))
+}
+
+// writeConditions writes conditions joined by the logic operator
+func (b *builder) writeConditions(conditions []archivev1alpha1.Condition, operator archivev1alpha1.ConditionOperator) error {
+	if len(conditions) == 0 {
+		if operator == archivev1alpha1.ConditionOperatorOr {
+			b.write("1 = 0")
+		} else {
+			b.write("1 = 1")
+		}
+		return nil
+	}
+
+	separator := " AND "
+	if operator == archivev1alpha1.ConditionOperatorOr {
+		separator = " OR "
+	}
+	if len(conditions) > 1 {
+		b.write("(")
+	}
+	for i, cond := range conditions {
+		if i > 0 {
+			b.write(separator)
+		}
+		if err := b.writeCondition(cond); err != nil {
+			return err
+		}
+	}
+	if len(conditions) > 1 {
+		b.write(")")
+	}
+	return nil
+}
+
+func (b *builder) writeCondition(cond archivev1alpha1.Condition) error {
+	switch cond.Operator {
+	case archivev1alpha1.ConditionOperatorAnd, archivev1alpha1.ConditionOperatorOr:
+		subs, ok := cond.Value.([]archivev1alpha1.Condition)
+		if !ok && cond.Value != nil {
+			return fmt.Errorf("value of operator %q must be a list of conditions, got %T", cond.Operator, cond.Value)
+		}
+		return b.writeConditions(subs, cond.Operator)
+	case archivev1alpha1.ConditionOperatorIn:
+		values, err := listValue(cond)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			b.write("1 = 0")
+			return nil
+		}
+		if err := b.writeColumn(cond.Key); err != nil {
+			return err
+		}
+		placeholders := make([]string, 0, len(values))
+		for _, v := range values {
+			if err := validateValue(cond, v); err != nil {
+				return err
+			}
+			placeholders = append(placeholders, b.bind(v))
+		}
+		b.write(" IN (" + strings.Join(placeholders, ", ") + ")")
+		return nil
+	case archivev1alpha1.ConditionOperatorExist:
+		if err := b.writeColumn(cond.Key); err != nil {
+			return err
+		}
+		b.write(" IS NOT NULL")
+		return nil
+	case archivev1alpha1.ConditionOperatorNotExist:
+		if err := b.writeColumn(cond.Key); err != nil {
+			return err
+		}
+		b.write(" IS NULL")
+		return nil
+	case archivev1alpha1.ConditionOperatorLike:
+		if err := validateValue(cond, cond.Value); err != nil {
+			return err
+		}
+		if err := b.writeColumn(cond.Key); err != nil {
+			return err
+		}
+		format, pattern := b.dialect.like(fmt.Sprint(cond.Value))
+		b.write(" " + fmt.Sprintf(format, b.bind(pattern)))
+		return nil
+	case archivev1alpha1.ConditionOperatorEqualColumn:
+		other, ok := cond.Value.(string)
+		if !ok {
+			return fmt.Errorf("value of operator %q must be a key, got %T", cond.Operator, cond.Value)
+		}
+		if err := b.writeColumn(cond.Key); err != nil {
+			return err
+		}
+		b.write(" = ")
+		return b.writeColumn(other)
+	}
+
+	operator, ok := comparisonOperators[cond.Operator]
+	if !ok {
+		return fmt.Errorf("unsupported condition operator %q", cond.Operator)
+	}
+	if err := validateValue(cond, cond.Value); err != nil {
+		return err
+	}
+	if err := b.writeColumn(cond.Key); err != nil {
+		return err
+	}
+	b.write(" " + operator + " " + b.bind(cond.Value))
+	return nil
+}
+
+// comparisonOperators maps condition operators to sql operators
+var comparisonOperators = map[archivev1alpha1.ConditionOperator]string{
+	archivev1alpha1.ConditionOperatorEqual:    "=",
+	archivev1alpha1.ConditionOperatorNotEqual: "<>",
+	archivev1alpha1.ConditionOperatorGt:       ">",
+	archivev1alpha1.ConditionOperatorGte:      ">=",
+	archivev1alpha1.ConditionOperatorLt:       "<",
+	archivev1alpha1.ConditionOperatorLte:      "<=",
+}
+
+func listValue(cond archivev1alpha1.Condition) ([]interface{}, error) {
+	switch v := cond.Value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return v, nil
+	case []string:
+		return archivev1alpha1.ToInterfaceSlice(v), nil
+	}
+	return nil, fmt.Errorf("value of operator %q must be a list, got %T", cond.Operator, cond.Value)
+}
+
+// validateValue only allows scalar values to be bound
+func validateValue(cond archivev1alpha1.Condition, v interface{}) error {
+	switch v.(type) {
+	case string, bool, json.Number,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return nil
+	}
+	return fmt.Errorf("unsupported value type %T of key %q", v, cond.Key)
+}
+
+// splitKey splits a key like `metadata."a.b".c` to the field and its json path
+func splitKey(key string) (field string, path []string, err error) {
+	var (
+		segments []string
+		current  strings.Builder
+		quoted   bool
+	)
+	for _, r := range key {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return "", nil, fmt.Errorf("unterminated quote in key %q", key)
+	}
+	segments = append(segments, current.String())
+	for _, segment := range segments {
+		if segment == "" {
+			return "", nil, fmt.Errorf("empty segment in key %q", key)
+		}
+	}
+	return segments[0], segments[1:], nil
+}
+
+// outputName returns the alias of a field or its name if no alias is set
+func outputName(field archivev1alpha1.Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+	return field.Name
+}
+
+func toSnakeCase(s string) string {
+	var builder strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				builder.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
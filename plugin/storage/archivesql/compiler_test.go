@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivesql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	ktesting "github.com/katanomi/pkg/testing"
+	"github.com/onsi/gomega"
+)
+
+var dialects = []Dialect{DialectPostgres, DialectMySQL, DialectSQLite}
+
+// compileAll compiles with all dialects, the result is normalized to be compared with golden files
+func compileAll(t *testing.T, compile func(c *Compiler) (*Statement, error)) map[string]interface{} {
+	g := gomega.NewGomegaWithT(t)
+	result := map[string]*Statement{}
+	for _, dialect := range dialects {
+		c, err := NewCompiler(dialect, "records", WithSoftDeleteColumn("deleted_at"))
+		g.Expect(err).To(gomega.BeNil())
+		stmt, err := compile(c)
+		g.Expect(err).To(gomega.BeNil())
+		result[string(dialect)] = stmt
+	}
+	data, _ := json.Marshal(result)
+	got := map[string]interface{}{}
+	_ = json.Unmarshal(data, &got)
+	return got
+}
+
+func checkGolden(t *testing.T, file string, got map[string]interface{}) {
+	g := gomega.NewGomegaWithT(t)
+	want := map[string]interface{}{}
+	ktesting.MustLoadYaml(file, &want)
+	g.Expect(cmp.Diff(want, got)).To(gomega.BeEmpty())
+}
+
+func TestCompiler_Where(t *testing.T) {
+	tests := map[archivev1alpha1.ConditionOperator]archivev1alpha1.Condition{
+		archivev1alpha1.ConditionOperatorAnd: archivev1alpha1.And(
+			archivev1alpha1.Name("run"),
+			archivev1alpha1.Equal(archivev1alpha1.MetadataKey("status"), "True"),
+		),
+		archivev1alpha1.ConditionOperatorOr: archivev1alpha1.Or(
+			archivev1alpha1.Name("run"),
+			archivev1alpha1.And(archivev1alpha1.Namespace("default"), archivev1alpha1.Equal("data.spec.timeout", "1h")),
+		),
+		archivev1alpha1.ConditionOperatorIn:          archivev1alpha1.In(archivev1alpha1.UIDField, "uid-1", "uid-2"),
+		archivev1alpha1.ConditionOperatorExist:       archivev1alpha1.Exist(archivev1alpha1.MetadataKey("status")),
+		archivev1alpha1.ConditionOperatorNotExist:    archivev1alpha1.NotExist(archivev1alpha1.MetadataKey("status")),
+		archivev1alpha1.ConditionOperatorEqual:       archivev1alpha1.Equal(archivev1alpha1.NameField, "run"),
+		archivev1alpha1.ConditionOperatorEqualColumn: archivev1alpha1.EqualColumn(archivev1alpha1.UIDField, archivev1alpha1.TopUIDField),
+		archivev1alpha1.ConditionOperatorNotEqual:    archivev1alpha1.NotEqual(archivev1alpha1.NameField, "run"),
+		archivev1alpha1.ConditionOperatorGt:          archivev1alpha1.Gt(archivev1alpha1.CreationTimestampField, "100"),
+		archivev1alpha1.ConditionOperatorGte:         archivev1alpha1.Gte(archivev1alpha1.CreationTimestampField, "100"),
+		archivev1alpha1.ConditionOperatorLt:          archivev1alpha1.Lt(archivev1alpha1.CreationTimestampField, "100"),
+		archivev1alpha1.ConditionOperatorLte:         archivev1alpha1.Lte(archivev1alpha1.CreationTimestampField, "100"),
+		archivev1alpha1.ConditionOperatorLike:        archivev1alpha1.Like(archivev1alpha1.MetadataKey("reason"), `F_il\%%`),
+	}
+
+	for operator, cond := range tests {
+		t.Run(string(operator), func(t *testing.T) {
+			got := compileAll(t, func(c *Compiler) (*Statement, error) {
+				return c.Where([]archivev1alpha1.Condition{cond})
+			})
+			checkGolden(t, "testdata/where."+string(operator)+".golden.yaml", got)
+		})
+	}
+}
+
+func TestCompiler_Select(t *testing.T) {
+	query := archivev1alpha1.Query{
+		Conditions: append(archivev1alpha1.NamespacedName("default", "run"), archivev1alpha1.CompletedStatus()),
+		Fields: []archivev1alpha1.Field{
+			{Name: archivev1alpha1.UIDField},
+			{Name: archivev1alpha1.MetadataKey("status"), Alias: "status"},
+		},
+		Indexs: []archivev1alpha1.Index{{Name: "idx_namespace_name"}},
+	}
+	opts := &archivev1alpha1.ListOptions{
+		Pager:  metav1alpha1.Pager{ItemsPerPage: 10, Page: 3},
+		Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField}},
+	}
+	got := compileAll(t, func(c *Compiler) (*Statement, error) {
+		return c.Select(query, opts)
+	})
+	checkGolden(t, "testdata/select.golden.yaml", got)
+}
+
+func TestCompiler_SelectWithCursor(t *testing.T) {
+	orders := []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField}}
+	spec := &archivev1alpha1.RecordSpec{ID: 10, CreationTimestamp: 100}
+	opts := &archivev1alpha1.ListOptions{
+		Pager:  metav1alpha1.Pager{ItemsPerPage: 10},
+		Orders: orders,
+		Cursor: archivev1alpha1.NextCursor(&metav1alpha1.TimeCursor{QueryStartAt: 1}, orders, spec),
+	}
+	got := compileAll(t, func(c *Compiler) (*Statement, error) {
+		return c.Select(archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{archivev1alpha1.Namespace("default")}}, opts)
+	})
+	checkGolden(t, "testdata/select.cursor.golden.yaml", got)
+}
+
+func TestCompiler_Delete(t *testing.T) {
+	conditions := []archivev1alpha1.Condition{archivev1alpha1.Lt(archivev1alpha1.CreationTimestampField, "100")}
+	t.Run("soft", func(t *testing.T) {
+		got := compileAll(t, func(c *Compiler) (*Statement, error) {
+			return c.Delete(conditions, nil)
+		})
+		checkGolden(t, "testdata/delete.soft.golden.yaml", got)
+	})
+	t.Run("direct", func(t *testing.T) {
+		got := compileAll(t, func(c *Compiler) (*Statement, error) {
+			return c.Delete(conditions, &archivev1alpha1.DeleteOption{Direct: true})
+		})
+		checkGolden(t, "testdata/delete.direct.golden.yaml", got)
+	})
+}
+
+func TestCompiler_Aggregate(t *testing.T) {
+	query := archivev1alpha1.AggregateQuery{
+		Conditions:  []archivev1alpha1.Condition{archivev1alpha1.Namespace("default")},
+		GroupFields: []archivev1alpha1.Field{{Name: archivev1alpha1.MetadataKey("status"), Alias: "status"}},
+		AggregateFields: []archivev1alpha1.AggregateField{
+			archivev1alpha1.Count("total"),
+			archivev1alpha1.Max(archivev1alpha1.CreationTimestampField, "latest"),
+		},
+	}
+	opts := &archivev1alpha1.ListOptions{
+		Orders:          []archivev1alpha1.Order{{Field: "total"}},
+		WithDeletedData: true,
+	}
+	got := compileAll(t, func(c *Compiler) (*Statement, error) {
+		return c.Aggregate(query, opts)
+	})
+	checkGolden(t, "testdata/aggregate.golden.yaml", got)
+}
+
+func TestCompiler_Invalid(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	c, err := NewCompiler(DialectPostgres, "records")
+	g.Expect(err).To(gomega.BeNil())
+
+	tests := map[string]archivev1alpha1.Query{
+		"key not in whitelist": {
+			Conditions: []archivev1alpha1.Condition{archivev1alpha1.Equal("name; DROP TABLE records", "a")},
+		},
+		"eqcol injection": {
+			Conditions: []archivev1alpha1.Condition{archivev1alpha1.EqualColumn(archivev1alpha1.UIDField, "1 OR 1=1")},
+		},
+		"invalid alias": {
+			Fields: []archivev1alpha1.Field{{Name: archivev1alpha1.NameField, Alias: `a" FROM x --`}},
+		},
+		"missing alias of json field": {
+			Fields: []archivev1alpha1.Field{{Name: archivev1alpha1.MetadataKey("status")}},
+		},
+		"invalid index": {
+			Indexs: []archivev1alpha1.Index{{Name: "idx) --"}},
+		},
+		"unsupported operator": {
+			Conditions: []archivev1alpha1.Condition{{Key: archivev1alpha1.NameField, Operator: "regexp", Value: "a"}},
+		},
+		"non scalar value": {
+			Conditions: []archivev1alpha1.Condition{{Key: archivev1alpha1.NameField, Operator: archivev1alpha1.ConditionOperatorEqual, Value: map[string]interface{}{}}},
+		},
+	}
+	for name, query := range tests {
+		_, err := c.Select(query, nil)
+		g.Expect(err).NotTo(gomega.BeNil(), name)
+	}
+
+	_, err = NewCompiler("oracle", "records")
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	_, err = c.Delete(nil, nil)
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestCompiler_AggregateTimeBucket(t *testing.T) {
+	query := archivev1alpha1.AggregateQuery{
+		Conditions:  []archivev1alpha1.Condition{archivev1alpha1.Namespace("default")},
+		GroupFields: []archivev1alpha1.Field{{Name: archivev1alpha1.MetadataKey("status"), Alias: "status"}},
+		TimeBuckets: []archivev1alpha1.TimeBucket{
+			archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "week", archivev1alpha1.TimeBucketUnitWeek, ""),
+		},
+		AggregateFields: []archivev1alpha1.AggregateField{
+			archivev1alpha1.Avg("data.status.duration", "avg_duration"),
+			archivev1alpha1.CountDistinct(archivev1alpha1.TopOwnerUIDField, "owners"),
+		},
+	}
+	opts := &archivev1alpha1.ListOptions{
+		Orders:          []archivev1alpha1.Order{{Field: "week", Asc: true}},
+		WithDeletedData: true,
+	}
+	got := compileAll(t, func(c *Compiler) (*Statement, error) {
+		return c.Aggregate(query, opts)
+	})
+	checkGolden(t, "testdata/aggregate.timebucket.golden.yaml", got)
+}
+
+func TestCompiler_AggregatePercentile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	c, err := NewCompiler(DialectPostgres, "records")
+	g.Expect(err).To(gomega.BeNil())
+
+	query := archivev1alpha1.AggregateQuery{
+		TimeBuckets: []archivev1alpha1.TimeBucket{
+			archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "day", archivev1alpha1.TimeBucketUnitDay, "Asia/Shanghai"),
+		},
+		AggregateFields: []archivev1alpha1.AggregateField{
+			archivev1alpha1.Percentile("data.status.duration", "p95", 0.95),
+		},
+	}
+	stmt, err := c.Aggregate(query, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(stmt.SQL).To(gomega.Equal(`SELECT to_char(date_trunc('day', to_timestamp(CAST("creation_timestamp" AS DOUBLE PRECISION)) AT TIME ZONE $1), 'YYYY-MM-DD"T"HH24:MI:SS') AS "day", ` +
+		`PERCENTILE_CONT($2) WITHIN GROUP (ORDER BY CAST("data"#>>$3 AS DOUBLE PRECISION)) AS "p95" FROM "records" GROUP BY 1`))
+	g.Expect(stmt.Args).To(gomega.Equal([]interface{}{"Asia/Shanghai", 0.95, `{"status","duration"}`}))
+}
+
+func TestCompiler_AggregateInvalid(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	day := archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "day", archivev1alpha1.TimeBucketUnitDay, "")
+
+	tests := map[string]struct {
+		dialect Dialect
+		query   archivev1alpha1.AggregateQuery
+	}{
+		"percentile is not supported by mysql": {
+			dialect: DialectMySQL,
+			query:   archivev1alpha1.AggregateQuery{AggregateFields: []archivev1alpha1.AggregateField{archivev1alpha1.Percentile(archivev1alpha1.CreationTimestampField, "p50", 0.5)}},
+		},
+		"percentile out of range": {
+			dialect: DialectPostgres,
+			query:   archivev1alpha1.AggregateQuery{AggregateFields: []archivev1alpha1.AggregateField{archivev1alpha1.Percentile(archivev1alpha1.CreationTimestampField, "p95", 95)}},
+		},
+		"time zone is not supported by sqlite": {
+			dialect: DialectSQLite,
+			query: archivev1alpha1.AggregateQuery{TimeBuckets: []archivev1alpha1.TimeBucket{
+				archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "day", archivev1alpha1.TimeBucketUnitDay, "Asia/Shanghai"),
+			}},
+		},
+		"unknown time zone": {
+			dialect: DialectPostgres,
+			query: archivev1alpha1.AggregateQuery{TimeBuckets: []archivev1alpha1.TimeBucket{
+				archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "day", archivev1alpha1.TimeBucketUnitDay, "Mars/Base"),
+			}},
+		},
+		"unsupported unit": {
+			dialect: DialectMySQL,
+			query: archivev1alpha1.AggregateQuery{TimeBuckets: []archivev1alpha1.TimeBucket{
+				archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "year", "year", ""),
+			}},
+		},
+		"time bucket field not allowed": {
+			dialect: DialectSQLite,
+			query: archivev1alpha1.AggregateQuery{TimeBuckets: []archivev1alpha1.TimeBucket{
+				{Field: archivev1alpha1.Field{Name: "now()", Alias: "day"}, Unit: day.Unit},
+			}},
+		},
+	}
+	for name, tt := range tests {
+		c, err := NewCompiler(tt.dialect, "records")
+		g.Expect(err).To(gomega.BeNil())
+		_, err = c.Aggregate(tt.query, nil)
+		g.Expect(err).NotTo(gomega.BeNil(), name)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivesql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect describe the sql dialect of a database
+type Dialect string
+
+const (
+	// DialectPostgres is the dialect for PostgreSQL
+	DialectPostgres Dialect = "postgres"
+	// DialectMySQL is the dialect for MySQL
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLite is the dialect for SQLite
+	DialectSQLite Dialect = "sqlite"
+)
+
+// Validate returns error if the dialect is not supported
+func (d Dialect) Validate() error {
+	switch d {
+	case DialectPostgres, DialectMySQL, DialectSQLite:
+		return nil
+	}
+	return fmt.Errorf("unsupported sql dialect %q", d)
+}
+
+// QuoteIdentifier quotes an identifier such as a table or column name
+func (d Dialect) QuoteIdentifier(name string) string {
+	if d == DialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Placeholder returns the bind placeholder for the n-th argument, n starts from 1
+func (d Dialect) Placeholder(n int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// jsonText returns the expression extracting the text value of path from a json column.
+// The path is passed as a bind argument so that it is never interpolated into the sql.
+func (d Dialect) jsonText(column string, path []string, placeholder string) (string, interface{}) {
+	switch d {
+	case DialectPostgres:
+		return fmt.Sprintf("%s#>>%s", column, placeholder), postgresTextArray(path)
+	case DialectMySQL:
+		return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", column, placeholder), jsonPath(path)
+	default:
+		return fmt.Sprintf("json_extract(%s, %s)", column, placeholder), jsonPath(path)
+	}
+}
+
+// like returns the case sensitive operator format of the like pattern and the pattern to bind,
+// wildcards `%` and `_` are escaped by `\` as the conditions are evaluated in memory.
+// The like of sqlite ignores case of ascii characters, so the pattern is converted to a glob.
+func (d Dialect) like(pattern string) (format string, value string) {
+	switch d {
+	case DialectPostgres:
+		return `LIKE %s ESCAPE '\'`, pattern
+	case DialectMySQL:
+		// backslashes are escaped in mysql string literals
+		return `LIKE %s COLLATE utf8mb4_bin ESCAPE '\\'`, pattern
+	default:
+		return "GLOB %s", globPattern(pattern)
+	}
+}
+
+// globPattern converts a like pattern to a glob pattern of sqlite
+func globPattern(pattern string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			builder.WriteString(globLiteral(r))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			builder.WriteString("*")
+		case r == '_':
+			builder.WriteString("?")
+		default:
+			builder.WriteString(globLiteral(r))
+		}
+	}
+	return builder.String()
+}
+
+// globLiteral matches the character literally in a glob pattern
+func globLiteral(r rune) string {
+	if strings.ContainsRune("*?[", r) {
+		return "[" + string(r) + "]"
+	}
+	return string(r)
+}
+
+// jsonPath returns a json path expression like $."a"."b"
+func jsonPath(path []string) string {
+	var builder strings.Builder
+	builder.WriteString("$")
+	for _, segment := range path {
+		builder.WriteString(`."`)
+		builder.WriteString(escapeQuoted(segment))
+		builder.WriteString(`"`)
+	}
+	return builder.String()
+}
+
+// postgresTextArray returns a text array literal like {"a","b"}
+func postgresTextArray(path []string) string {
+	items := make([]string, 0, len(path))
+	for _, segment := range path {
+		items = append(items, `"`+escapeQuoted(segment)+`"`)
+	}
+	return "{" + strings.Join(items, ",") + "}"
+}
+
+func escapeQuoted(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,337 @@
+//go:build cgo
+
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivesql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// monday is 2024-01-01T00:00:00Z
+const monday int64 = 1704067200
+
+var sqliteRecords = []archivev1alpha1.RecordSpec{
+	{ID: 1, Namespace: "default", Name: "run-1", UID: "uid-1", TopOwnerUID: "owner-1", CreationTimestamp: monday,
+		Metadata: map[string]string{"status": "True", "reason": "Succeeded", "message": "Build 50% Done"},
+		Data:     map[string]interface{}{"status": map[string]interface{}{"duration": 10}, "spec": map[string]interface{}{"timeout": "1h"}}},
+	{ID: 2, Namespace: "default", Name: "run-2", UID: "uid-2", TopOwnerUID: "owner-1", CreationTimestamp: monday + 86400,
+		Metadata: map[string]string{"status": "False", "reason": "Failed"},
+		Data:     map[string]interface{}{"status": map[string]interface{}{"duration": 20}}},
+	{ID: 3, Namespace: "default", Name: "run-3", UID: "uid-3", TopOwnerUID: "owner-2", CreationTimestamp: monday + 86400,
+		Metadata: map[string]string{"status": "True", "reason": "Succeeded", "message": "build 50x done_1 [*]"},
+		Data:     map[string]interface{}{"status": map[string]interface{}{"duration": 30}}},
+	{ID: 4, Namespace: "default", Name: "run-4", UID: "uid-4", TopOwnerUID: "owner-2", CreationTimestamp: monday + 8*86400,
+		Metadata: map[string]string{"status": "True", "reason": "Succeeded"},
+		Data:     map[string]interface{}{"status": map[string]interface{}{"duration": 40}}},
+	{ID: 5, Namespace: "other", Name: "run-5", UID: "uid-5", TopUID: "uid-5", TopOwnerUID: "owner-3", CreationTimestamp: monday + 8*86400,
+		Metadata: map[string]string{"status": "Unknown"}},
+}
+
+// newSQLite returns a database with sqliteRecords in the records table
+func newSQLite(t *testing.T) *sql.DB {
+	g := gomega.NewGomegaWithT(t)
+	db, err := sql.Open("sqlite3", ":memory:")
+	g.Expect(err).To(gomega.BeNil())
+	// every connection of an in-memory database is a different database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	columns := DefaultColumns()
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	definitions := make([]string, 0, len(fields)+3)
+	names := make([]string, 0, len(fields)+2)
+	for _, field := range fields {
+		columnType := "TEXT"
+		switch field {
+		case archivev1alpha1.IDField, archivev1alpha1.CreationTimestampField, archivev1alpha1.CleanupTimeField:
+			columnType = "INTEGER"
+		}
+		column := DialectSQLite.QuoteIdentifier(columns[field])
+		definitions = append(definitions, column+" "+columnType)
+		names = append(names, column)
+	}
+	definitions = append(definitions, "metadata TEXT", "data TEXT", "deleted_at TIMESTAMP")
+	names = append(names, "metadata", "data")
+	_, err = db.Exec("CREATE TABLE records (" + strings.Join(definitions, ", ") + ")")
+	g.Expect(err).To(gomega.BeNil())
+
+	insert := "INSERT INTO records (" + strings.Join(names, ", ") + ") VALUES (?" + strings.Repeat(", ?", len(names)-1) + ")"
+	for i := range sqliteRecords {
+		spec := &sqliteRecords[i]
+		args := make([]interface{}, 0, len(names))
+		for _, field := range fields {
+			v, _ := spec.FieldValue(field)
+			args = append(args, v)
+		}
+		metadata, _ := json.Marshal(spec.Metadata)
+		data, _ := json.Marshal(spec.Data)
+		args = append(args, string(metadata), string(data))
+		_, err = db.Exec(insert, args...)
+		g.Expect(err).To(gomega.BeNil())
+	}
+	return db
+}
+
+func newSQLiteCompiler(t *testing.T) *Compiler {
+	g := gomega.NewGomegaWithT(t)
+	c, err := NewCompiler(DialectSQLite, "records", WithSoftDeleteColumn("deleted_at"))
+	g.Expect(err).To(gomega.BeNil())
+	return c
+}
+
+// queryRows runs the statement and returns the rows as maps of column names to values
+func queryRows(t *testing.T, db *sql.DB, stmt *Statement) []map[string]interface{} {
+	g := gomega.NewGomegaWithT(t)
+	rows, err := db.Query(stmt.SQL, stmt.Args...)
+	g.Expect(err).To(gomega.BeNil(), stmt.SQL)
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	g.Expect(err).To(gomega.BeNil())
+	result := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		g.Expect(rows.Scan(pointers...)).To(gomega.Succeed())
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	g.Expect(rows.Err()).To(gomega.BeNil())
+	return result
+}
+
+// selectUIDs runs a select of the uid field and returns the uids in the order of rows
+func selectUIDs(t *testing.T, db *sql.DB, conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) []string {
+	g := gomega.NewGomegaWithT(t)
+	stmt, err := newSQLiteCompiler(t).Select(archivev1alpha1.Query{
+		Conditions: conditions,
+		Fields:     []archivev1alpha1.Field{{Name: archivev1alpha1.UIDField}},
+	}, opts)
+	g.Expect(err).To(gomega.BeNil())
+	uids := make([]string, 0)
+	for _, row := range queryRows(t, db, stmt) {
+		uids = append(uids, row[archivev1alpha1.UIDField].(string))
+	}
+	return uids
+}
+
+func TestSQLite_Where(t *testing.T) {
+	db := newSQLite(t)
+	tests := map[string]struct {
+		condition archivev1alpha1.Condition
+		want      []string
+	}{
+		"and": {
+			condition: archivev1alpha1.And(archivev1alpha1.Namespace("default"), archivev1alpha1.Equal(archivev1alpha1.MetadataKey("status"), "True")),
+			want:      []string{"uid-1", "uid-3", "uid-4"},
+		},
+		"or": {
+			condition: archivev1alpha1.Or(archivev1alpha1.Name("run-2"), archivev1alpha1.Equal("data.spec.timeout", "1h")),
+			want:      []string{"uid-1", "uid-2"},
+		},
+		"in":       {condition: archivev1alpha1.In(archivev1alpha1.UIDField, "uid-2", "uid-5"), want: []string{"uid-2", "uid-5"}},
+		"exist":    {condition: archivev1alpha1.Exist(archivev1alpha1.MetadataKey("reason")), want: []string{"uid-1", "uid-2", "uid-3", "uid-4"}},
+		"notexist": {condition: archivev1alpha1.NotExist(archivev1alpha1.MetadataKey("reason")), want: []string{"uid-5"}},
+		"eq":       {condition: archivev1alpha1.Equal(archivev1alpha1.NameField, "run-3"), want: []string{"uid-3"}},
+		"eqcol":    {condition: archivev1alpha1.EqualColumn(archivev1alpha1.UIDField, archivev1alpha1.TopUIDField), want: []string{"uid-5"}},
+		"ne":       {condition: archivev1alpha1.NotEqual(archivev1alpha1.NamespaceField, "default"), want: []string{"uid-5"}},
+		"gt": {
+			condition: archivev1alpha1.Gt(archivev1alpha1.CreationTimestampField, "1704153600"),
+			want:      []string{"uid-4", "uid-5"},
+		},
+		"gte": {
+			condition: archivev1alpha1.Gte(archivev1alpha1.CreationTimestampField, "1704153600"),
+			want:      []string{"uid-2", "uid-3", "uid-4", "uid-5"},
+		},
+		"lt":   {condition: archivev1alpha1.Lt(archivev1alpha1.CreationTimestampField, "1704153600"), want: []string{"uid-1"}},
+		"lte":  {condition: archivev1alpha1.Lte(archivev1alpha1.CreationTimestampField, "1704153600"), want: []string{"uid-1", "uid-2", "uid-3"}},
+		"like": {condition: archivev1alpha1.Like(archivev1alpha1.MetadataKey("reason"), "Fail%"), want: []string{"uid-2"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			opts := &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.IDField, Asc: true}}}
+			g.Expect(selectUIDs(t, db, []archivev1alpha1.Condition{tt.condition}, opts)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestSQLite_Like(t *testing.T) {
+	db := newSQLite(t)
+	message := archivev1alpha1.MetadataKey("message")
+	tests := map[string]struct {
+		pattern string
+		want    []string
+	}{
+		"case sensitive":     {pattern: "build%", want: []string{"uid-3"}},
+		"percent":            {pattern: "%Done", want: []string{"uid-1"}},
+		"escaped percent":    {pattern: `%50\% %`, want: []string{"uid-1"}},
+		"underscore":         {pattern: "%50_ %", want: []string{"uid-1", "uid-3"}},
+		"escaped underscore": {pattern: `%done\_1%`, want: []string{"uid-3"}},
+		"glob wildcards":     {pattern: "%[*]", want: []string{"uid-3"}},
+		"no match":           {pattern: "%*", want: []string{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			cond := archivev1alpha1.Like(message, tt.pattern)
+			opts := &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.IDField, Asc: true}}}
+			g.Expect(selectUIDs(t, db, []archivev1alpha1.Condition{cond}, opts)).To(gomega.Equal(tt.want))
+
+			// the same records are matched in memory
+			matched := make([]string, 0)
+			for i := range sqliteRecords {
+				ok, err := archivev1alpha1.MatchCondition(&sqliteRecords[i], cond)
+				g.Expect(err).To(gomega.BeNil())
+				if ok {
+					matched = append(matched, sqliteRecords[i].UID)
+				}
+			}
+			g.Expect(matched).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestSQLite_Select(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	db := newSQLite(t)
+	orders := []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField}, {Field: archivev1alpha1.IDField, Asc: true}}
+
+	opts := &archivev1alpha1.ListOptions{Pager: metav1alpha1.Pager{ItemsPerPage: 2, Page: 2}, Orders: orders}
+	g.Expect(selectUIDs(t, db, nil, opts)).To(gomega.Equal([]string{"uid-2", "uid-3"}))
+
+	stmt, err := newSQLiteCompiler(t).Count([]archivev1alpha1.Condition{archivev1alpha1.Namespace("default")}, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(queryRows(t, db, stmt)[0]["COUNT(*)"]).To(gomega.BeEquivalentTo(4))
+}
+
+func TestSQLite_SelectWithCursor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	db := newSQLite(t)
+	orders := []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField}}
+	specs := map[string]*archivev1alpha1.RecordSpec{}
+	for i := range sqliteRecords {
+		specs[sqliteRecords[i].UID] = &sqliteRecords[i]
+	}
+
+	listAll := func(orders []archivev1alpha1.Order) []string {
+		cursor := &metav1alpha1.TimeCursor{QueryStartAt: 1}
+		got := make([]string, 0)
+		for page := 0; page < len(sqliteRecords); page++ {
+			opts := &archivev1alpha1.ListOptions{Pager: metav1alpha1.Pager{ItemsPerPage: 2}, Orders: orders, Cursor: cursor}
+			uids := selectUIDs(t, db, nil, opts)
+			if len(uids) == 0 {
+				break
+			}
+			got = append(got, uids...)
+			cursor = archivev1alpha1.NextCursor(cursor, orders, specs[uids[len(uids)-1]])
+		}
+		return got
+	}
+
+	// records with the same creation timestamp are split to different pages
+	g.Expect(listAll(orders)).To(gomega.Equal([]string{"uid-4", "uid-5", "uid-2", "uid-3", "uid-1"}))
+
+	// null values are ordered first
+	reason := archivev1alpha1.MetadataKey("reason")
+	g.Expect(listAll([]archivev1alpha1.Order{{Field: reason, Asc: true}})).To(gomega.Equal([]string{"uid-5", "uid-2", "uid-1", "uid-3", "uid-4"}))
+	g.Expect(listAll([]archivev1alpha1.Order{{Field: reason}})).To(gomega.Equal([]string{"uid-1", "uid-3", "uid-4", "uid-2", "uid-5"}))
+}
+
+func TestSQLite_Delete(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	db := newSQLite(t)
+	c := newSQLiteCompiler(t)
+	conditions := []archivev1alpha1.Condition{archivev1alpha1.Lt(archivev1alpha1.CreationTimestampField, "1704153600")}
+	all := &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.IDField, Asc: true}}}
+	withDeleted := &archivev1alpha1.ListOptions{Orders: all.Orders, WithDeletedData: true}
+
+	stmt, err := c.Delete(conditions, nil)
+	g.Expect(err).To(gomega.BeNil())
+	_, err = db.Exec(stmt.SQL, stmt.Args...)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(selectUIDs(t, db, nil, all)).To(gomega.Equal([]string{"uid-2", "uid-3", "uid-4", "uid-5"}))
+	g.Expect(selectUIDs(t, db, nil, withDeleted)).To(gomega.HaveLen(5))
+
+	stmt, err = c.Delete(conditions, &archivev1alpha1.DeleteOption{Direct: true})
+	g.Expect(err).To(gomega.BeNil())
+	_, err = db.Exec(stmt.SQL, stmt.Args...)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(selectUIDs(t, db, nil, withDeleted)).To(gomega.Equal([]string{"uid-2", "uid-3", "uid-4", "uid-5"}))
+}
+
+func TestSQLite_Aggregate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	db := newSQLite(t)
+	c := newSQLiteCompiler(t)
+
+	stmt, err := c.Aggregate(archivev1alpha1.AggregateQuery{
+		Conditions:  []archivev1alpha1.Condition{archivev1alpha1.Namespace("default")},
+		GroupFields: []archivev1alpha1.Field{{Name: archivev1alpha1.MetadataKey("status"), Alias: "status"}},
+		AggregateFields: []archivev1alpha1.AggregateField{
+			archivev1alpha1.Count("total"),
+			archivev1alpha1.Max(archivev1alpha1.CreationTimestampField, "latest"),
+		},
+	}, &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: "total"}}})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(queryRows(t, db, stmt)).To(gomega.Equal([]map[string]interface{}{
+		{"status": "True", "total": int64(3), "latest": monday + 8*86400},
+		{"status": "False", "total": int64(1), "latest": monday + 86400},
+	}))
+
+	stmt, err = c.Aggregate(archivev1alpha1.AggregateQuery{
+		Conditions: []archivev1alpha1.Condition{archivev1alpha1.Namespace("default")},
+		TimeBuckets: []archivev1alpha1.TimeBucket{
+			archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "week", archivev1alpha1.TimeBucketUnitWeek, ""),
+		},
+		AggregateFields: []archivev1alpha1.AggregateField{
+			archivev1alpha1.Avg("data.status.duration", "avg_duration"),
+			archivev1alpha1.CountDistinct(archivev1alpha1.TopOwnerUIDField, "owners"),
+		},
+	}, &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: "week", Asc: true}}})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(queryRows(t, db, stmt)).To(gomega.Equal([]map[string]interface{}{
+		{"week": "2024-01-01T00:00:00", "avg_duration": float64(20), "owners": int64(2)},
+		{"week": "2024-01-08T00:00:00", "avg_duration": float64(40), "owners": int64(1)},
+	}))
+
+	_, err = c.Aggregate(archivev1alpha1.AggregateQuery{
+		AggregateFields: []archivev1alpha1.AggregateField{archivev1alpha1.Count("total")},
+	}, &archivev1alpha1.ListOptions{Cursor: &metav1alpha1.TimeCursor{}})
+	g.Expect(errors.IsBadRequest(err)).To(gomega.BeTrue())
+}
@@ -23,3 +23,14 @@ import (
 
 // ArchiveV1alpha1GV is group version used to register these objects
 var ArchiveV1alpha1GV = schema.GroupVersion{Group: archive.CapabilityName, Version: "v1alpha1"}
+
+const (
+	// FeatureAggregate indicates that records could be aggregated
+	FeatureAggregate = "aggregate"
+	// FeatureTimeBuckets indicates that aggregations could be grouped by time buckets
+	FeatureTimeBuckets = "timeBuckets"
+	// FeatureSoftDelete indicates that records are soft deleted unless deleted directly
+	FeatureSoftDelete = "softDelete"
+	// FeatureCursor indicates that records could be listed with cursor based pagination
+	FeatureCursor = "cursor"
+)
@@ -18,10 +18,13 @@ package capabilities
 
 import (
 	"reflect"
+	"sort"
 
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
 	archivev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
 	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // reflectedCapElmMap for reflection in init func
@@ -57,3 +60,50 @@ func GetImplementedCapabilities(obj interface{}) []string {
 
 	return capabilities
 }
+
+// FeatureProvider is optionally implemented by storage plugins to declare
+// the optional features supported for their capabilities.
+type FeatureProvider interface {
+	// Features returns the supported features of each capability group version
+	Features() map[schema.GroupVersion][]string
+}
+
+// Discover returns the capabilities implemented by an object and their features, sorted by name and version.
+// Features are declared by FeatureProvider, and the ones served by the framework are added automatically.
+func Discover(obj interface{}) []storagev1alpha1.StorageCapability {
+	if obj == nil {
+		return nil
+	}
+	var declared map[schema.GroupVersion][]string
+	if provider, ok := obj.(FeatureProvider); ok {
+		declared = provider.Features()
+	}
+
+	typeOfObj := reflect.TypeOf(obj)
+	capabilities := make([]storagev1alpha1.StorageCapability, 0, len(RegisteredCapabilities))
+	for gv, intf := range RegisteredCapabilities {
+		if !typeOfObj.Implements(reflect.TypeOf(intf).Elem()) {
+			continue
+		}
+		features := sets.NewString(declared[gv]...)
+		if gv == filestorev1alpha1.FileStoreV1alpha1GV {
+			features.Insert(filestorev1alpha1.FeatureRangedRead)
+			if _, ok := obj.(filestorev1alpha1.FileUploadCapable); ok {
+				features.Insert(filestorev1alpha1.FeatureResumableUpload)
+			}
+		}
+		capabilities = append(capabilities, storagev1alpha1.StorageCapability{
+			Name:     gv.Group,
+			Version:  gv.Version,
+			Features: features.List(),
+		})
+	}
+
+	sort.Slice(capabilities, func(i, j int) bool {
+		if capabilities[i].Name != capabilities[j].Name {
+			return capabilities[i].Name < capabilities[j].Name
+		}
+		return capabilities[i].Version < capabilities[j].Version
+	})
+	return capabilities
+}
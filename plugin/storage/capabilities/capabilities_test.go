@@ -26,6 +26,7 @@ import (
 	archivecapv1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
 	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type fakeFileStoreImp struct{}
@@ -132,3 +133,31 @@ func TestGetImplementedCapabilities(t *testing.T) {
 		})
 	}
 }
+
+type fakeFeatureImp struct {
+	fakeArchiveImp
+}
+
+func (f *fakeFeatureImp) Features() map[schema.GroupVersion][]string {
+	return map[schema.GroupVersion][]string{
+		archivecapv1alpha1.ArchiveV1alpha1GV:  {archivecapv1alpha1.FeatureSoftDelete, archivecapv1alpha1.FeatureAggregate},
+		filestorev1alpha1.FileStoreV1alpha1GV: {"ignored"},
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Discover(nil)).To(BeNil())
+	g.Expect(Discover(fakeArchiveImp{})).To(BeEmpty())
+
+	g.Expect(Discover(&fakeMultipleImp{})).To(Equal([]v1alpha1.StorageCapability{
+		{Name: "archive", Version: "v1alpha1", Features: []string{}},
+		{Name: "file-store", Version: "v1alpha1", Features: []string{filestorev1alpha1.FeatureRangedRead}},
+	}))
+
+	// features of capabilities not implemented are ignored
+	g.Expect(Discover(&fakeFeatureImp{})).To(Equal([]v1alpha1.StorageCapability{
+		{Name: "archive", Version: "v1alpha1", Features: []string{archivecapv1alpha1.FeatureAggregate, archivecapv1alpha1.FeatureSoftDelete}},
+	}))
+}
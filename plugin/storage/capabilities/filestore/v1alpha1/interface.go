@@ -56,3 +56,26 @@ type FileObject struct {
 	v1alpha1.FileMeta
 	FileReadCloser io.ReadCloser
 }
+
+// FileUploadCapable defines methods of resumable chunked uploads.
+// It is optional for file-store plugins, the upload routes are only served
+// when the plugin implements it.
+type FileUploadCapable interface {
+	// CreateFileUpload starts an upload session of a file object, the name of the meta is the object name
+	CreateFileUpload(ctx context.Context, meta v1alpha1.FileMeta) (*v1alpha1.FileUpload, error)
+	// GetFileUpload returns the upload session with the uploaded chunks
+	GetFileUpload(ctx context.Context, uploadID string) (*v1alpha1.FileUpload, error)
+	// PutFileUploadChunk stores a chunk of the upload, the chunk replaces the uploaded one with the same index.
+	// The checksum of the chunk must be verified before it is stored.
+	PutFileUploadChunk(ctx context.Context, uploadID string, chunk *FileChunk) (*v1alpha1.FileUploadChunk, error)
+	// CompleteFileUpload assembles the chunks into the file object and removes the upload session
+	CompleteFileUpload(ctx context.Context, uploadID string, opts v1alpha1.FileUploadCompleteOptions) (*v1alpha1.FileMeta, error)
+	// AbortFileUpload removes the upload session and the uploaded chunks
+	AbortFileUpload(ctx context.Context, uploadID string) error
+}
+
+// FileChunk wraps FileUploadChunk with chunk reader for implementing chunked upload
+type FileChunk struct {
+	v1alpha1.FileUploadChunk
+	ChunkReadCloser io.ReadCloser
+}
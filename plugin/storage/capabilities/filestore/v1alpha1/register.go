@@ -23,3 +23,12 @@ import (
 
 // FileStoreV1alpha1GV is group version used to register versioned resource
 var FileStoreV1alpha1GV = schema.GroupVersion{Group: filestore.CapabilityName, Version: "v1alpha1"}
+
+const (
+	// FeatureRangedRead indicates that part of a file object could be read with the Range header.
+	// It is served for all file-store plugins.
+	FeatureRangedRead = "rangedRead"
+	// FeatureResumableUpload indicates that file objects could be uploaded in chunks,
+	// it is served when the plugin implements FileUploadCapable.
+	FeatureResumableUpload = "resumableUpload"
+)
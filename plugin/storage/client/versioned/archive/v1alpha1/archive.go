@@ -35,6 +35,9 @@ type RecordGetter interface {
 //go:generate mockgen -source=archive.go -destination=../../../../../../testing/mock/github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1/interface.go -package=v1alpha1 RecordInterface
 type RecordInterface interface {
 	archivev1alpha1.ArchiveCapable
+
+	// StreamRecords iterates over all records matching the query with cursor based pagination
+	StreamRecords(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error
 }
 
 func newRecord(clt *ArchiveClient, pluginName string) *archive {
@@ -121,3 +124,8 @@ func (a archive) Aggregate(ctx context.Context, query v1alpha1.AggregateQuery, o
 	}
 	return ret, nil
 }
+
+// StreamRecords iterates over all records matching the query with cursor based pagination
+func (a archive) StreamRecords(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error {
+	return StreamRecords(ctx, a, query, opts, fn)
+}
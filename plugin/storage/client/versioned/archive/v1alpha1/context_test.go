@@ -50,6 +50,10 @@ func (t testStorage) Aggregate(ctx context.Context, aggs v1alpha1.AggregateQuery
 	return nil, nil
 }
 
+func (t testStorage) StreamRecords(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error {
+	return nil
+}
+
 func TestWithStorage(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	ctx := context.Background()
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	archivev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+)
+
+// RecordFunc is called for each record when streaming records,
+// returning an error stops the streaming and the error will be returned.
+type RecordFunc func(record *v1alpha1.Record) error
+
+// StreamRecords iterates over all records matching the query page by page using cursor based pagination,
+// so that no record is skipped or duplicated when records are inserted concurrently.
+// The page size is decided by the ItemsPerPage of opts, and the Page is ignored.
+func StreamRecords(ctx context.Context, lister archivev1alpha1.ArchiveCapable, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error {
+	return streamRecords(ctx, lister.ListRecords, query, opts, fn)
+}
+
+// StreamRelatedRecords is the same as StreamRecords but the related records of each record are returned as well
+func StreamRelatedRecords(ctx context.Context, lister archivev1alpha1.ArchiveCapable, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error {
+	return streamRecords(ctx, lister.ListRelatedRecords, query, opts, fn)
+}
+
+type listFunc func(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions) (*v1alpha1.RecordList, error)
+
+func streamRecords(ctx context.Context, list listFunc, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn RecordFunc) error {
+	listOpts := v1alpha1.ListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+	if listOpts.Cursor == nil {
+		listOpts.Cursor = &metav1alpha1.TimeCursor{}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		records, err := list(ctx, query, &listOpts)
+		if err != nil {
+			return err
+		}
+		for i := range records.Items {
+			if err = fn(&records.Items[i]); err != nil {
+				return err
+			}
+		}
+		if records.Continue == "" || len(records.Items) == 0 {
+			return nil
+		}
+		if listOpts.Cursor, err = metav1alpha1.ParseTimeCursor(records.Continue); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/memory"
+	"github.com/onsi/gomega"
+)
+
+// insertingStorage inserts a newer record before each list to simulate concurrent writers
+type insertingStorage struct {
+	*memory.Archive
+	inserted int
+}
+
+func (s *insertingStorage) ListRecords(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions) (*v1alpha1.RecordList, error) {
+	s.inserted++
+	record := &v1alpha1.Record{Spec: v1alpha1.RecordSpec{UID: fmt.Sprintf("uid-new-%d", s.inserted), CreationTimestamp: int64(1000 + s.inserted)}}
+	if err := s.Upsert(ctx, record); err != nil {
+		return nil, err
+	}
+	return s.Archive.ListRecords(ctx, query, opts)
+}
+
+func TestStreamRecords(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	storage := &insertingStorage{Archive: memory.NewArchive("memory")}
+	for i := 0; i < 25; i++ {
+		record := &v1alpha1.Record{Spec: v1alpha1.RecordSpec{UID: fmt.Sprintf("uid-%d", i), CreationTimestamp: int64(100 + i)}}
+		g.Expect(storage.Upsert(ctx, record)).To(gomega.Succeed())
+	}
+
+	opts := &v1alpha1.ListOptions{
+		Pager:  metav1alpha1.Pager{ItemsPerPage: 10},
+		Orders: []v1alpha1.Order{{Field: v1alpha1.CreationTimestampField}},
+	}
+	uids := []string{}
+	err := StreamRecords(ctx, storage, v1alpha1.Query{Conditions: []v1alpha1.Condition{v1alpha1.Like(v1alpha1.UIDField, "uid-%")}}, opts,
+		func(record *v1alpha1.Record) error {
+			uids = append(uids, record.Spec.UID)
+			return nil
+		})
+	g.Expect(err).To(gomega.BeNil())
+	// records inserted after the first page are before the cursor and never returned,
+	// and the others are neither skipped nor duplicated
+	expected := []string{"uid-new-1"}
+	for i := 24; i >= 0; i-- {
+		expected = append(expected, fmt.Sprintf("uid-%d", i))
+	}
+	g.Expect(uids).To(gomega.Equal(expected))
+	g.Expect(opts.Cursor).To(gomega.BeNil())
+
+	// stops when the func returns error
+	count := 0
+	stopErr := errors.New("stop")
+	err = StreamRecords(ctx, storage, v1alpha1.Query{}, opts, func(record *v1alpha1.Record) error {
+		count++
+		if count == 3 {
+			return stopErr
+		}
+		return nil
+	})
+	g.Expect(err).To(gomega.Equal(stopErr))
+	g.Expect(count).To(gomega.Equal(3))
+}
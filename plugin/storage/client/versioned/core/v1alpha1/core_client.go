@@ -24,6 +24,7 @@ import (
 type CoreV1alpha1Interface interface {
 	RESTClient() client.Interface
 	AuthGetter
+	DiscoveryGetter
 }
 
 // New creates a new CoreV1alpha1Client for the given RESTClient.
@@ -44,6 +45,10 @@ func (c *CoreV1alpha1Client) Auth() AuthInterface {
 	return newAuth(c)
 }
 
+func (c *CoreV1alpha1Client) Discovery() DiscoveryInterface {
+	return newDiscovery(c)
+}
+
 func NewForClient(pClient *client.StoragePluginClient) *CoreV1alpha1Client {
 	return &CoreV1alpha1Client{restClient: pClient.ForGroupVersion(&v1alpha1.CoreV1alpha1GV)}
 }
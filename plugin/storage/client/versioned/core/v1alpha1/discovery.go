@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	client2 "github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/storage/client"
+)
+
+// DiscoveryGetter returns Discovery getter object
+type DiscoveryGetter interface {
+	Discovery() DiscoveryInterface
+}
+
+// DiscoveryInterface for discovering capabilities served by the storage plugin
+type DiscoveryInterface interface {
+	Get(ctx context.Context) (*v1alpha1.StorageDiscovery, error)
+}
+
+type discovery struct {
+	client client.Interface
+}
+
+func (d *discovery) Get(ctx context.Context) (*v1alpha1.StorageDiscovery, error) {
+	result := &v1alpha1.StorageDiscovery{}
+	err := d.client.Get(ctx, "capabilities", client2.ResultOpts(result))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newDiscovery returns a discovery
+func newDiscovery(c *CoreV1alpha1Client) *discovery {
+	return &discovery{
+		client: c.RESTClient(),
+	}
+}
@@ -35,6 +35,9 @@ type FileObjectInterface interface {
 	PUT(ctx context.Context, fileObj filestorev1alpha1.FileObject,
 		options ...client.OptionFunc) (*v1alpha1.FileMeta, error)
 	GET(ctx context.Context, fileObjectName string) (*filestorev1alpha1.FileObject, error)
+	// GETRange gets part of the file object starting from offset,
+	// the rest of the file object is returned when length is not positive.
+	GETRange(ctx context.Context, fileObjectName string, offset, length int64) (*filestorev1alpha1.FileObject, error)
 	DELETE(ctx context.Context, fileObjectName string) error
 }
 
@@ -66,11 +69,27 @@ func (f *fileObjects) PUT(ctx context.Context, fileObj filestorev1alpha1.FileObj
 }
 
 func (f *fileObjects) GET(ctx context.Context, key string) (*filestorev1alpha1.FileObject, error) {
+	return f.get(ctx, key)
+}
+
+func (f *fileObjects) GETRange(ctx context.Context, key string, offset, length int64) (*filestorev1alpha1.FileObject, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid range offset %d", offset)
+	}
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+	return f.get(ctx, key, client.HeaderOpts("Range", byteRange))
+}
+
+func (f *fileObjects) get(ctx context.Context, key string, options ...client.OptionFunc) (*filestorev1alpha1.FileObject, error) {
 	path := fmt.Sprintf("storageplugins/%s/fileobjects/%s", f.pluginName, key)
 	fileObject := filestorev1alpha1.FileObject{}
-	resp, err := f.client.GetResponse(ctx, path, func(request *resty.Request) {
+	options = append(options, func(request *resty.Request) {
 		request.SetDoNotParseResponse(true)
 	})
+	resp, err := f.client.GetResponse(ctx, path, options...)
 	if err != nil {
 		return nil, err
 	}
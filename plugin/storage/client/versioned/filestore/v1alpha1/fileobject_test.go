@@ -24,6 +24,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client"
 	v1alpha13 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
 	v1alpha12 "github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
 	"github.com/katanomi/pkg/testing"
@@ -68,6 +69,39 @@ var _ = Describe("Test.Fileobject", func() {
 		})
 	})
 
+	Context("Test.FileObject.GETRange", func() {
+		It("requests the range", func() {
+			var rangeHeader string
+			mockStoragePluginClient.EXPECT().
+				GetResponse(ctx, "storageplugins/foo/fileobjects/dir1/file1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, options ...client.OptionFunc) (*resty.Response, error) {
+					request := resty.New().R()
+					for _, option := range options {
+						option(request)
+					}
+					rangeHeader = request.Header.Get("Range")
+					return &resty.Response{
+						RawResponse: &http.Response{
+							StatusCode: http.StatusPartialContent,
+							Header:     httpHeaders,
+						},
+					}, nil
+				}).Times(2)
+
+			_, err := fileObject.GETRange(ctx, "dir1/file1", 10, 5)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rangeHeader).To(Equal("bytes=10-14"))
+
+			obj, err := fileObject.GETRange(ctx, "dir1/file1", 10, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rangeHeader).To(Equal("bytes=10-"))
+			Expect(obj.Name).To(Equal(fakeFileMeta.Name))
+
+			_, err = fileObject.GETRange(ctx, "dir1/file1", -1, 0)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
 	Context("Test.FileObject.PUT", func() {
 		It("returns fileobject with meta", func() {
 			mockStoragePluginClient.EXPECT().
@@ -28,6 +28,7 @@ type FileStoreV1alpha1Interface interface {
 	RESTClient() client.Interface
 	FileObjectGetter
 	FileMetaGetter
+	FileUploadGetter
 }
 
 // FileObjectGetter returns FileObject getter object
@@ -40,6 +41,11 @@ type FileMetaGetter interface {
 	FileMeta(pluginName string) FileMetaInterface
 }
 
+// FileUploadGetter returns FileUpload getter object
+type FileUploadGetter interface {
+	FileUpload(pluginName string) FileUploadInterface
+}
+
 // FileStoreV1alpha1Client is client for core v1alpha1
 type FileStoreV1alpha1Client struct {
 	restClient client.Interface
@@ -63,6 +69,10 @@ func (c *FileStoreV1alpha1Client) FileMeta(pluginName string) FileMetaInterface
 	return newFileMetas(c, pluginName)
 }
 
+func (c *FileStoreV1alpha1Client) FileUpload(pluginName string) FileUploadInterface {
+	return newFileUploads(c, pluginName)
+}
+
 func NewForClient(pClient client.Interface) FileStoreV1alpha1Interface {
 	return &FileStoreV1alpha1Client{restClient: pClient.ForGroupVersion(&filestorev1alpha1.FileStoreV1alpha1GV)}
 }
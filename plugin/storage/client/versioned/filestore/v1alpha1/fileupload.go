@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	pclient "github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/storage/client"
+)
+
+//go:generate mockgen -source=fileupload.go -destination=../../../../../../testing/mock/github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1/fileupload.go -package=v1alpha1 FileUploadInterface
+
+// DefaultChunkSize is the default size of chunks for resumable uploads
+const DefaultChunkSize int64 = 8 << 20
+
+// FileUploadInterface for resumable file upload restful resource methods
+type FileUploadInterface interface {
+	Create(ctx context.Context, meta v1alpha1.FileMeta) (*v1alpha1.FileUpload, error)
+	Get(ctx context.Context, uploadID string) (*v1alpha1.FileUpload, error)
+	PutChunk(ctx context.Context, uploadID string, index int, data []byte) (*v1alpha1.FileUploadChunk, error)
+	Complete(ctx context.Context, uploadID string, opts v1alpha1.FileUploadCompleteOptions) (*v1alpha1.FileMeta, error)
+	Abort(ctx context.Context, uploadID string) error
+}
+
+type fileUploads struct {
+	client     client.Interface
+	pluginName string
+}
+
+// newFileUploads returns a FileUploads
+func newFileUploads(c *FileStoreV1alpha1Client, pluginName string) *fileUploads {
+	return &fileUploads{
+		client:     c.RESTClient(),
+		pluginName: pluginName,
+	}
+}
+
+func (f *fileUploads) Create(ctx context.Context, meta v1alpha1.FileMeta) (*v1alpha1.FileUpload, error) {
+	path := fmt.Sprintf("storageplugins/%s/fileuploads", f.pluginName)
+	upload := v1alpha1.FileUpload{}
+	err := f.client.Post(ctx, path, pclient.BodyOpts(meta), pclient.ResultOpts(&upload))
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (f *fileUploads) Get(ctx context.Context, uploadID string) (*v1alpha1.FileUpload, error) {
+	path := fmt.Sprintf("storageplugins/%s/fileuploads/%s", f.pluginName, uploadID)
+	upload := v1alpha1.FileUpload{}
+	err := f.client.Get(ctx, path, pclient.ResultOpts(&upload))
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (f *fileUploads) PutChunk(ctx context.Context, uploadID string, index int, data []byte) (*v1alpha1.FileUploadChunk, error) {
+	path := fmt.Sprintf("storageplugins/%s/fileuploads/%s/chunks/%d", f.pluginName, uploadID, index)
+	chunk := v1alpha1.FileUploadChunk{}
+	err := f.client.Put(ctx, path, pclient.ResultOpts(&chunk),
+		pclient.HeaderOpts(v1alpha1.HeaderChunkSHA256, v1alpha1.ChunkChecksum(data)),
+		pclient.HeaderOpts("Content-Type", "application/octet-stream"),
+		// this must be set or 406 status code will be returned
+		pclient.HeaderOpts("Accept", "application/json"),
+		pclient.BodyOpts(bytes.NewReader(data)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &chunk, nil
+}
+
+func (f *fileUploads) Complete(ctx context.Context, uploadID string, opts v1alpha1.FileUploadCompleteOptions) (*v1alpha1.FileMeta, error) {
+	path := fmt.Sprintf("storageplugins/%s/fileuploads/%s/complete", f.pluginName, uploadID)
+	meta := v1alpha1.FileMeta{}
+	err := f.client.Post(ctx, path, pclient.BodyOpts(opts), pclient.ResultOpts(&meta))
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (f *fileUploads) Abort(ctx context.Context, uploadID string) error {
+	path := fmt.Sprintf("storageplugins/%s/fileuploads/%s", f.pluginName, uploadID)
+	return f.client.Delete(ctx, path)
+}
+
+// UploadOptions options for uploading a file in chunks
+type UploadOptions struct {
+	// ChunkSize is the size of each chunk, DefaultChunkSize is used if not positive
+	ChunkSize int64
+	// UploadID resumes an existing upload when set,
+	// chunks already uploaded with the same checksum are skipped
+	UploadID string
+	// SHA256 is the optional checksum of the whole file verified on completion
+	SHA256 string
+}
+
+// UploadFile uploads size bytes of reader in chunks and completes the upload.
+// When the upload is interrupted it could be resumed by calling UploadFile again
+// with the UploadID of the returned upload, which is returned even on error once created.
+func UploadFile(ctx context.Context, uploads FileUploadInterface, meta v1alpha1.FileMeta,
+	reader io.ReaderAt, size int64, opts UploadOptions) (*v1alpha1.FileUpload, *v1alpha1.FileMeta, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	var (
+		upload *v1alpha1.FileUpload
+		err    error
+	)
+	if opts.UploadID != "" {
+		upload, err = uploads.Get(ctx, opts.UploadID)
+	} else {
+		upload, err = uploads.Create(ctx, meta)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf := make([]byte, chunkSize)
+	for index := 0; int64(index)*chunkSize < size; index++ {
+		offset := int64(index) * chunkSize
+		length := chunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+		data := buf[:length]
+		if _, err = reader.ReadAt(data, offset); err != nil && err != io.EOF {
+			return upload, nil, err
+		}
+
+		uploaded := upload.GetChunk(index)
+		if uploaded != nil && uploaded.Size == length && uploaded.SHA256 == v1alpha1.ChunkChecksum(data) {
+			continue
+		}
+		if _, err = uploads.PutChunk(ctx, upload.Name, index, data); err != nil {
+			return upload, nil, err
+		}
+	}
+
+	fileMeta, err := uploads.Complete(ctx, upload.Name, v1alpha1.FileUploadCompleteOptions{SHA256: opts.SHA256})
+	if err != nil {
+		return upload, nil, err
+	}
+	return upload, fileMeta, nil
+}
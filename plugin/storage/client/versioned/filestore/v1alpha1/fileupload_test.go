@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	v1alpha12 "github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/testing/mock/github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test.FileUpload", func() {
+	var fileUpload v1alpha12.FileUploadInterface
+
+	BeforeEach(func() {
+		fileUpload = mockFileStoreClient.FileUpload("foo")
+	})
+
+	It("creates uploads", func() {
+		mockStoragePluginClient.EXPECT().
+			Post(ctx, "storageplugins/foo/fileuploads", gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		upload, err := fileUpload.Create(ctx, v1alpha1.FileMeta{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upload).ShouldNot(BeNil())
+	})
+
+	It("gets uploads", func() {
+		mockStoragePluginClient.EXPECT().
+			Get(ctx, "storageplugins/foo/fileuploads/abc", gomock.Any()).
+			Return(nil)
+
+		upload, err := fileUpload.Get(ctx, "abc")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upload).ShouldNot(BeNil())
+	})
+
+	It("puts chunks", func() {
+		mockStoragePluginClient.EXPECT().
+			Put(ctx, "storageplugins/foo/fileuploads/abc/chunks/3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		chunk, err := fileUpload.PutChunk(ctx, "abc", 3, []byte("hello"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(chunk).ShouldNot(BeNil())
+	})
+
+	It("completes uploads", func() {
+		mockStoragePluginClient.EXPECT().
+			Post(ctx, "storageplugins/foo/fileuploads/abc/complete", gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		meta, err := fileUpload.Complete(ctx, "abc", v1alpha1.FileUploadCompleteOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(meta).ShouldNot(BeNil())
+	})
+
+	It("aborts uploads", func() {
+		mockStoragePluginClient.EXPECT().
+			Delete(ctx, "storageplugins/foo/fileuploads/abc").
+			Return(nil)
+
+		Expect(fileUpload.Abort(ctx, "abc")).To(Succeed())
+	})
+})
+
+var _ = Describe("Test.UploadFile", func() {
+	var (
+		ctx     context.Context
+		uploads *filestorev1alpha1.MockFileUploadInterface
+		meta    v1alpha1.FileMeta
+		upload  *v1alpha1.FileUpload
+		content string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		uploads = filestorev1alpha1.NewMockFileUploadInterface(gomock.NewController(GinkgoT()))
+		meta = v1alpha1.FileMeta{}
+		meta.Name = "dir/file.txt"
+		upload = &v1alpha1.FileUpload{}
+		upload.Name = "abc"
+		content = "0123456789"
+	})
+
+	It("uploads all chunks and completes", func() {
+		uploads.EXPECT().Create(ctx, meta).Return(upload, nil)
+		for index, chunk := range []string{"0123", "4567", "89"} {
+			uploads.EXPECT().PutChunk(ctx, "abc", index, []byte(chunk)).Return(&v1alpha1.FileUploadChunk{}, nil)
+		}
+		uploads.EXPECT().Complete(ctx, "abc", v1alpha1.FileUploadCompleteOptions{SHA256: "sum"}).Return(&meta, nil)
+
+		got, fileMeta, err := v1alpha12.UploadFile(ctx, uploads, meta, strings.NewReader(content), int64(len(content)),
+			v1alpha12.UploadOptions{ChunkSize: 4, SHA256: "sum"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(got.Name).To(Equal("abc"))
+		Expect(fileMeta.Name).To(Equal("dir/file.txt"))
+	})
+
+	It("resumes uploads by skipping uploaded chunks", func() {
+		upload.Status.Chunks = []v1alpha1.FileUploadChunk{
+			{Index: 0, Size: 4, SHA256: v1alpha1.ChunkChecksum([]byte("0123"))},
+			// corrupted chunks are uploaded again
+			{Index: 1, Size: 4, SHA256: v1alpha1.ChunkChecksum([]byte("xxxx"))},
+		}
+		uploads.EXPECT().Get(ctx, "abc").Return(upload, nil)
+		uploads.EXPECT().PutChunk(ctx, "abc", 1, []byte("4567")).Return(&v1alpha1.FileUploadChunk{}, nil)
+		uploads.EXPECT().PutChunk(ctx, "abc", 2, []byte("89")).Return(&v1alpha1.FileUploadChunk{}, nil)
+		uploads.EXPECT().Complete(ctx, "abc", v1alpha1.FileUploadCompleteOptions{}).Return(&meta, nil)
+
+		_, _, err := v1alpha12.UploadFile(ctx, uploads, meta, strings.NewReader(content), int64(len(content)),
+			v1alpha12.UploadOptions{ChunkSize: 4, UploadID: "abc"})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("returns the upload to resume on failure", func() {
+		uploads.EXPECT().Create(ctx, meta).Return(upload, nil)
+		uploads.EXPECT().PutChunk(ctx, "abc", 0, gomock.Any()).Return(nil, errors.New("connection reset"))
+
+		got, fileMeta, err := v1alpha12.UploadFile(ctx, uploads, meta, strings.NewReader(content), int64(len(content)),
+			v1alpha12.UploadOptions{ChunkSize: 4})
+		Expect(err).Should(HaveOccurred())
+		Expect(got.Name).To(Equal("abc"))
+		Expect(fileMeta).To(BeNil())
+	})
+})
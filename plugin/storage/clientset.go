@@ -17,13 +17,17 @@ limitations under the License.
 package storage
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-resty/resty/v2"
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
 	client2 "github.com/katanomi/pkg/plugin/storage/client"
 	archivev1alpha1 "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
 	corev1alpha1 "github.com/katanomi/pkg/plugin/storage/client/versioned/core/v1alpha1"
 	"github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	v1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -33,6 +37,11 @@ type Interface interface {
 	CoreV1alpha1() corev1alpha1.CoreV1alpha1Interface
 	FileStoreV1alpha1() v1alpha1.FileStoreV1alpha1Interface
 	ArchiveV1alpha1() archivev1alpha1.ArchiveInterface
+
+	// Discover returns the capabilities served by the storage plugin
+	Discover(ctx context.Context) (*storagev1alpha1.StorageDiscovery, error)
+	// Supports returns an UnsupportedCapabilityError if the capability or any feature is not served
+	Supports(ctx context.Context, gv schema.GroupVersion, features ...string) error
 }
 
 // Clientset contains the  core and capabilities plugin clients.
@@ -40,6 +49,10 @@ type Clientset struct {
 	coreV1alpha1      corev1alpha1.CoreV1alpha1Interface
 	fileStoreV1alpha1 v1alpha1.FileStoreV1alpha1Interface
 	archiveV1alpha1   archivev1alpha1.ArchiveInterface
+
+	// discoveryLock protects the cached discovery
+	discoveryLock sync.Mutex
+	discovery     *storagev1alpha1.StorageDiscovery
 }
 
 // CoreV1alpha1 return core v1alpha1 interface
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// UnsupportedCapabilityError is returned when a storage plugin does not serve
+// a capability or some optional features of it
+type UnsupportedCapabilityError struct {
+	// GroupVersion of the capability
+	GroupVersion schema.GroupVersion
+	// MissingFeatures are the features not supported, empty if the capability is not served at all
+	MissingFeatures []string
+}
+
+// Error implements error
+func (e *UnsupportedCapabilityError) Error() string {
+	if len(e.MissingFeatures) == 0 {
+		return fmt.Sprintf("capability %s is not supported by the storage plugin", e.GroupVersion)
+	}
+	return fmt.Sprintf("features %s of capability %s are not supported by the storage plugin",
+		strings.Join(e.MissingFeatures, ","), e.GroupVersion)
+}
+
+// IsUnsupportedCapability returns true if the error is or wraps an UnsupportedCapabilityError
+func IsUnsupportedCapability(err error) bool {
+	var target *UnsupportedCapabilityError
+	return errors.As(err, &target)
+}
+
+// Discover returns the capabilities served by the storage plugin.
+// The result is cached after the first successful request.
+func (c *Clientset) Discover(ctx context.Context) (*storagev1alpha1.StorageDiscovery, error) {
+	c.discoveryLock.Lock()
+	defer c.discoveryLock.Unlock()
+
+	if c.discovery == nil {
+		discovery, err := c.coreV1alpha1.Discovery().Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.discovery = discovery
+	}
+	return c.discovery.DeepCopy(), nil
+}
+
+// Supports returns nil if the storage plugin serves the capability with all the features,
+// otherwise an UnsupportedCapabilityError is returned so callers could fail fast
+// instead of calling the capability.
+func (c *Clientset) Supports(ctx context.Context, gv schema.GroupVersion, features ...string) error {
+	discovery, err := c.Discover(ctx)
+	if err != nil {
+		return err
+	}
+	capability := discovery.GetCapability(gv.Group, gv.Version)
+	if capability == nil {
+		return &UnsupportedCapabilityError{GroupVersion: gv}
+	}
+	if missing := capability.MissingFeatures(features...); len(missing) > 0 {
+		return &UnsupportedCapabilityError{GroupVersion: gv, MissingFeatures: missing}
+	}
+	return nil
+}
+
+// InvalidateDiscovery drops the cached discovery, the next Discover requests the storage plugin again
+func (c *Clientset) InvalidateDiscovery() {
+	c.discoveryLock.Lock()
+	defer c.discoveryLock.Unlock()
+	c.discovery = nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/go-resty/resty/v2"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/storage"
+	archivev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/localfs"
+	"github.com/katanomi/pkg/plugin/storage/memory"
+	"github.com/katanomi/pkg/plugin/storage/route"
+	. "github.com/onsi/gomega"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func newDiscoveryServer(t *testing.T, requests *int32) *storage.Clientset {
+	g := NewGomegaWithT(t)
+	plugin := localfs.NewFileStore("local", t.TempDir())
+	g.Expect(plugin.Setup(context.Background(), nil)).To(Succeed())
+	return newPluginServer(t, plugin, requests)
+}
+
+func newPluginServer(t *testing.T, plugin client.Interface, requests *int32) *storage.Clientset {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	svcs, err := route.NewServicesWithContext(ctx, plugin)
+	g.Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	for _, svc := range svcs {
+		container.Add(svc)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		container.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	url, err := apis.ParseURL(server.URL + route.GetPluginAPIPath(plugin))
+	g.Expect(err).To(BeNil())
+	clientset, err := storage.NewForClient(&duckv1.Addressable{URL: url}, resty.New())
+	g.Expect(err).To(BeNil())
+	return clientset
+}
+
+func TestClientset_Discover(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	var requests int32
+	clientset := newDiscoveryServer(t, &requests)
+
+	discovery, err := clientset.Discover(ctx)
+	g.Expect(err).To(BeNil())
+	capability := discovery.GetCapability("file-store", "v1alpha1")
+	g.Expect(capability).NotTo(BeNil())
+	g.Expect(capability.Features).To(ConsistOf(filestorev1alpha1.FeatureRangedRead, filestorev1alpha1.FeatureResumableUpload))
+
+	g.Expect(clientset.Supports(ctx, filestorev1alpha1.FileStoreV1alpha1GV)).To(Succeed())
+	g.Expect(clientset.Supports(ctx, filestorev1alpha1.FileStoreV1alpha1GV, filestorev1alpha1.FeatureResumableUpload)).To(Succeed())
+	// the discovery is cached
+	g.Expect(requests).To(Equal(int32(1)))
+
+	clientset.InvalidateDiscovery()
+	_, err = clientset.Discover(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(requests).To(Equal(int32(2)))
+}
+
+func TestClientset_DiscoverArchive(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	var requests int32
+	clientset := newPluginServer(t, memory.NewArchive("memory"), &requests)
+
+	discovery, err := clientset.Discover(ctx)
+	g.Expect(err).To(BeNil())
+	capability := discovery.GetCapability("archive", "v1alpha1")
+	g.Expect(capability).NotTo(BeNil())
+	g.Expect(capability.Features).To(ConsistOf(
+		archivev1alpha1.FeatureAggregate,
+		archivev1alpha1.FeatureTimeBuckets,
+		archivev1alpha1.FeatureSoftDelete,
+		archivev1alpha1.FeatureCursor,
+	))
+	g.Expect(clientset.Supports(ctx, archivev1alpha1.ArchiveV1alpha1GV, archivev1alpha1.FeatureCursor)).To(Succeed())
+}
+
+func TestClientset_Supports(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	var requests int32
+	clientset := newDiscoveryServer(t, &requests)
+
+	err := clientset.Supports(ctx, archivev1alpha1.ArchiveV1alpha1GV)
+	g.Expect(storage.IsUnsupportedCapability(err)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal("capability archive/v1alpha1 is not supported by the storage plugin"))
+
+	err = clientset.Supports(ctx, filestorev1alpha1.FileStoreV1alpha1GV, filestorev1alpha1.FeatureRangedRead, "versioning")
+	g.Expect(storage.IsUnsupportedCapability(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+	g.Expect(err).To(Equal(&storage.UnsupportedCapabilityError{
+		GroupVersion:    filestorev1alpha1.FileStoreV1alpha1GV,
+		MissingFeatures: []string{"versioning"},
+	}))
+	g.Expect(storage.IsUnsupportedCapability(fmt.Errorf("other"))).To(BeFalse())
+}
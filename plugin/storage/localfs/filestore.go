@@ -0,0 +1,461 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	_ filestorev1alpha1.FileStoreCapable  = &FileStore{}
+	_ filestorev1alpha1.FileUploadCapable = &FileStore{}
+)
+
+const (
+	// digestAlgorithm is the algorithm used to address the content of objects
+	digestAlgorithm = "sha256"
+	// metaSuffix is the suffix of file meta sidecars
+	metaSuffix = ".meta.json"
+	// refsSuffix is the suffix of reference count files of blobs
+	refsSuffix = ".refs"
+
+	blobsDir   = "blobs"
+	metasDir   = "metas"
+	tmpDir     = "tmp"
+	uploadsDir = "uploads"
+)
+
+// FileStore is a file store capability implementation on the local file system.
+// Contents are stored once under blobs/sha256 addressed by their digest and
+// reference counted, the file meta of each object is stored as a json sidecar under metas.
+// All files are written to a temporary file first and renamed into place,
+// and updates of metas and references are serialized, so concurrent writers
+// in the same process never observe partial objects.
+// Resumable uploads are kept under uploads until they are completed.
+// It implements client.Interface and can be registered as a storage plugin.
+type FileStore struct {
+	path string
+	root string
+
+	// lock serializes updates of metas and reference counts
+	lock sync.Mutex
+	// uploadLock serializes updates of upload sessions,
+	// it is always acquired before lock when both are required
+	uploadLock sync.Mutex
+	// now returns the current time, used for last modified annotations
+	now func() time.Time
+}
+
+// NewFileStore returns a file store storage plugin served under path storing files in root
+func NewFileStore(path, root string) *FileStore {
+	return &FileStore{
+		path: path,
+		root: root,
+		now:  time.Now,
+	}
+}
+
+// Path returns the path of the storage plugin
+func (f *FileStore) Path() string {
+	return f.path
+}
+
+// Setup creates the directories of the file store
+func (f *FileStore) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	for _, dir := range []string{blobsDir, metasDir, tmpDir, uploadsDir} {
+		if err := os.MkdirAll(filepath.Join(f.root, dir), 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutFileObject stores the content of the object and its file meta,
+// the content is shared with other objects having the same digest.
+func (f *FileStore) PutFileObject(_ context.Context, obj *filestorev1alpha1.FileObject) (*storagev1alpha1.FileMeta, error) {
+	if obj == nil || obj.FileReadCloser == nil {
+		return nil, errors.NewBadRequest("file object content is required")
+	}
+	defer obj.FileReadCloser.Close()
+	if err := validateObjectName(obj.Name); err != nil {
+		return nil, err
+	}
+
+	digest, size, tmpFile, err := f.writeTemp(obj.FileReadCloser)
+	if err != nil {
+		return nil, err
+	}
+	// the temporary file is renamed if the blob does not exist yet
+	defer os.Remove(tmpFile)
+
+	return f.commit(obj.FileMeta, digest, size, tmpFile)
+}
+
+// commit stores the temporary file as the blob of digest if it does not exist
+// and writes the file meta referring to it, the blob previously referred by the object is released.
+func (f *FileStore) commit(fileMeta storagev1alpha1.FileMeta, digest string, size int64, tmpFile string) (*storagev1alpha1.FileMeta, error) {
+	meta := fileMeta.DeepCopy()
+	meta.Spec.Key = f.path + ":" + meta.Name
+	meta.Spec.ContentLength = size
+	if meta.Spec.ContentType == "" {
+		meta.Spec.ContentType = restful.MIME_OCTET
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[storagev1alpha1.ContentDigestAnnotation] = digest
+	meta.Annotations[storagev1alpha1.LastModifiedAnnotation] = f.now().UTC().Format(time.RFC3339)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, err := os.Stat(f.blobPath(digest)); os.IsNotExist(err) {
+		if err = os.MkdirAll(filepath.Dir(f.blobPath(digest)), 0o755); err != nil {
+			return nil, err
+		}
+		if err = os.Rename(tmpFile, f.blobPath(digest)); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	old, err := f.readMeta(meta.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	oldDigest := ""
+	if old != nil {
+		oldDigest = old.Annotations[storagev1alpha1.ContentDigestAnnotation]
+	}
+
+	// references are increased before the meta is written and decreased after,
+	// so that a blob is never removed while a meta still refers to it
+	if oldDigest != digest {
+		if err = f.addRef(digest, 1); err != nil {
+			return nil, err
+		}
+	}
+	if err = f.writeMeta(meta); err != nil {
+		return nil, err
+	}
+	if oldDigest != "" && oldDigest != digest {
+		if err = f.addRef(oldDigest, -1); err != nil {
+			return nil, err
+		}
+	}
+	return meta, nil
+}
+
+// GetFileObject returns the file meta and content of the object, the caller should close the reader
+func (f *FileStore) GetFileObject(_ context.Context, objectName string) (*filestorev1alpha1.FileObject, error) {
+	if err := validateObjectName(objectName); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	meta, err := f.readMeta(objectName)
+	if err != nil {
+		return nil, err
+	}
+	// the opened file stays readable even if the blob is removed later
+	file, err := os.Open(f.blobPath(meta.Annotations[storagev1alpha1.ContentDigestAnnotation]))
+	if err != nil {
+		return nil, err
+	}
+	return &filestorev1alpha1.FileObject{FileMeta: *meta, FileReadCloser: file}, nil
+}
+
+// DeleteFileObject deletes the object, the content is removed when it is not referred by any object
+func (f *FileStore) DeleteFileObject(_ context.Context, objectName string) error {
+	if err := validateObjectName(objectName); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	meta, err := f.readMeta(objectName)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(f.metaPath(objectName)); err != nil {
+		return err
+	}
+	f.removeEmptyDirs(filepath.Dir(f.metaPath(objectName)), filepath.Join(f.root, metasDir))
+	return f.addRef(meta.Annotations[storagev1alpha1.ContentDigestAnnotation], -1)
+}
+
+// GetFileMeta returns the file meta of the object
+func (f *FileStore) GetFileMeta(_ context.Context, objectName string) (*storagev1alpha1.FileMeta, error) {
+	if err := validateObjectName(objectName); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.readMeta(objectName)
+}
+
+// ListFileMetas lists file metas of objects whose names start with the prefix in lexical order.
+// When Recursive is false only objects directly under the prefix are returned,
+// and the sub directories are returned as file metas whose names end with a slash.
+func (f *FileStore) ListFileMetas(_ context.Context, opt storagev1alpha1.FileMetaListOptions) ([]storagev1alpha1.FileMeta, error) {
+	if err := validatePrefix(opt.Prefix); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	metasRoot := filepath.Join(f.root, metasDir)
+	// only the directory containing the prefix needs to be walked
+	base := metasRoot
+	if i := strings.LastIndex(opt.Prefix, "/"); i >= 0 {
+		base = filepath.Join(metasRoot, filepath.FromSlash(opt.Prefix[:i]))
+	}
+	if rel, err := filepath.Rel(metasRoot, base); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid prefix %q", opt.Prefix))
+	}
+
+	items := map[string]storagev1alpha1.FileMeta{}
+	err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, metaSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(metasRoot, p)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), metaSuffix)
+		if !strings.HasPrefix(name, opt.Prefix) {
+			return nil
+		}
+		if !opt.Recursive {
+			if i := strings.Index(name[len(opt.Prefix):], "/"); i >= 0 {
+				dir := name[:len(opt.Prefix)+i+1]
+				dirMeta := storagev1alpha1.FileMeta{}
+				dirMeta.Name = dir
+				items[dir] = dirMeta
+				return nil
+			}
+		}
+		meta, err := f.readMeta(name)
+		if err != nil {
+			return err
+		}
+		items[name] = *meta
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(items))
+	for name := range items {
+		if opt.StartAfter == "" || name > opt.StartAfter {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if opt.Limit > 0 && len(names) > opt.Limit {
+		names = names[:opt.Limit]
+	}
+	result := make([]storagev1alpha1.FileMeta, 0, len(names))
+	for _, name := range names {
+		result = append(result, items[name])
+	}
+	return result, nil
+}
+
+// writeTemp writes the content to a temporary file and returns its digest and size
+func (f *FileStore) writeTemp(r io.Reader) (digest string, size int64, name string, err error) {
+	file, err := os.CreateTemp(filepath.Join(f.root, tmpDir), "blob-*")
+	if err != nil {
+		return "", 0, "", err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(file.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	if size, err = io.Copy(io.MultiWriter(file, hash), r); err != nil {
+		return "", 0, "", err
+	}
+	if err = file.Sync(); err != nil {
+		return "", 0, "", err
+	}
+	return digestAlgorithm + ":" + hex.EncodeToString(hash.Sum(nil)), size, file.Name(), nil
+}
+
+// writeFile writes data to a temporary file and renames it to name atomically
+func (f *FileStore) writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Join(f.root, tmpDir), "file-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), name)
+}
+
+func (f *FileStore) readMeta(objectName string) (*storagev1alpha1.FileMeta, error) {
+	data, err := os.ReadFile(f.metaPath(objectName))
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFound(storagev1alpha1.GroupVersion.WithResource("fileobjects").GroupResource(), objectName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	meta := &storagev1alpha1.FileMeta{}
+	if err = json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("invalid file meta of %q: %w", objectName, err)
+	}
+	return meta, nil
+}
+
+func (f *FileStore) writeMeta(meta *storagev1alpha1.FileMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return f.writeFile(f.metaPath(meta.Name), data)
+}
+
+// refs returns the reference count of a blob
+func (f *FileStore) refs(digest string) (int, error) {
+	data, err := os.ReadFile(f.blobPath(digest) + refsSuffix)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// addRef changes the reference count of a blob, the blob is removed when nothing refers to it
+func (f *FileStore) addRef(digest string, delta int) error {
+	count, err := f.refs(digest)
+	if err != nil {
+		return err
+	}
+	count += delta
+	blob := f.blobPath(digest)
+	if count > 0 {
+		return f.writeFile(blob+refsSuffix, []byte(strconv.Itoa(count)))
+	}
+	for _, name := range []string{blob + refsSuffix, blob} {
+		if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	f.removeEmptyDirs(filepath.Dir(blob), filepath.Join(f.root, blobsDir, digestAlgorithm))
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents until stop if they are empty
+func (f *FileStore) removeEmptyDirs(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		// os.Remove fails if the directory is not empty
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// blobPath returns the path of the blob like blobs/sha256/ab/abcdef...
+func (f *FileStore) blobPath(digest string) string {
+	hexDigest := strings.TrimPrefix(digest, digestAlgorithm+":")
+	prefix := hexDigest
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(f.root, blobsDir, digestAlgorithm, prefix, hexDigest)
+}
+
+func (f *FileStore) metaPath(objectName string) string {
+	return filepath.Join(f.root, metasDir, filepath.FromSlash(objectName)+metaSuffix)
+}
+
+// validateObjectName makes sure the object name is a clean relative path,
+// so that objects could never be written outside of the root
+func validateObjectName(name string) error {
+	if name == "" || strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") ||
+		path.Clean(name) != name || name == ".." || strings.HasPrefix(name, "../") || strings.Contains(name, "\\") {
+		return errors.NewBadRequest(fmt.Sprintf("invalid file object name %q", name))
+	}
+	return nil
+}
+
+// validatePrefix makes sure the prefix is relative and has no parent segments,
+// so that listing could never walk outside of the root
+func validatePrefix(prefix string) error {
+	if strings.HasPrefix(prefix, "/") || strings.Contains(prefix, "\\") {
+		return errors.NewBadRequest(fmt.Sprintf("invalid prefix %q", prefix))
+	}
+	for _, segment := range strings.Split(prefix, "/") {
+		if segment == ".." {
+			return errors.NewBadRequest(fmt.Sprintf("invalid prefix %q", prefix))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/route"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func newFileObject(name, content string) *filestorev1alpha1.FileObject {
+	obj := &filestorev1alpha1.FileObject{FileReadCloser: io.NopCloser(strings.NewReader(content))}
+	obj.Name = name
+	obj.Labels = map[string]string{"app": "test"}
+	obj.Annotations = map[string]string{storagev1alpha1.FileTypeAnnotation: "report"}
+	obj.Spec.ContentType = "text/plain"
+	return obj
+}
+
+func readObject(fileStore *FileStore, name string) string {
+	obj, err := fileStore.GetFileObject(context.Background(), name)
+	Expect(err).To(BeNil())
+	defer obj.FileReadCloser.Close()
+	data, err := io.ReadAll(obj.FileReadCloser)
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+// blobs returns the reference counts of all blobs
+func blobs(root string) map[string]string {
+	result := map[string]string{}
+	err := filepath.Walk(filepath.Join(root, blobsDir), func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(p, refsSuffix) {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		result[filepath.Base(strings.TrimSuffix(p, refsSuffix))] = string(data)
+		return err
+	})
+	Expect(err).To(BeNil())
+	return result
+}
+
+func metaNames(metas []storagev1alpha1.FileMeta) []string {
+	names := make([]string, 0, len(metas))
+	for _, meta := range metas {
+		names = append(names, meta.Name)
+	}
+	return names
+}
+
+var _ = Describe("Test.FileStore", func() {
+	var (
+		ctx       context.Context
+		root      string
+		fileStore *FileStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = GinkgoT().TempDir()
+		fileStore = NewFileStore("local", root)
+		Expect(fileStore.Setup(ctx, nil)).To(Succeed())
+	})
+
+	It("can be served as a storage plugin", func() {
+		svcs, err := route.NewServicesWithContext(ctx, fileStore)
+		Expect(err).To(BeNil())
+		Expect(svcs).To(HaveLen(2))
+		Expect(svcs[0].RootPath()).To(Equal("/storage/local/file-store/v1alpha1"))
+		Expect(svcs[1].RootPath()).To(Equal("/storage/local/core/v1alpha1"))
+	})
+
+	It("puts and gets file objects", func() {
+		meta, err := fileStore.PutFileObject(ctx, newFileObject("reports/junit.xml", "hello"))
+		Expect(err).To(BeNil())
+		Expect(meta.Name).To(Equal("reports/junit.xml"))
+		Expect(meta.Key()).To(Equal("local:reports/junit.xml"))
+		Expect(meta.Spec.ContentLength).To(Equal(int64(5)))
+		Expect(meta.Spec.ContentType).To(Equal("text/plain"))
+		Expect(meta.Labels).To(Equal(map[string]string{"app": "test"}))
+		Expect(meta.Annotations).To(HaveKeyWithValue(storagev1alpha1.FileTypeAnnotation, "report"))
+		Expect(meta.Annotations).To(HaveKeyWithValue(storagev1alpha1.ContentDigestAnnotation,
+			"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"))
+		Expect(meta.LastModified().IsZero()).To(BeFalse())
+
+		got, err := fileStore.GetFileMeta(ctx, "reports/junit.xml")
+		Expect(err).To(BeNil())
+		Expect(got).To(Equal(meta))
+		Expect(readObject(fileStore, "reports/junit.xml")).To(Equal("hello"))
+
+		_, err = fileStore.GetFileMeta(ctx, "reports/missing.xml")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = fileStore.GetFileObject(ctx, "reports/missing.xml")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(errors.IsNotFound(fileStore.DeleteFileObject(ctx, "reports/missing.xml"))).To(BeTrue())
+	})
+
+	It("deduplicates contents with reference counting", func() {
+		_, err := fileStore.PutFileObject(ctx, newFileObject("a.txt", "same"))
+		Expect(err).To(BeNil())
+		_, err = fileStore.PutFileObject(ctx, newFileObject("b/b.txt", "same"))
+		Expect(err).To(BeNil())
+		// putting the same content again does not add references
+		_, err = fileStore.PutFileObject(ctx, newFileObject("a.txt", "same"))
+		Expect(err).To(BeNil())
+		Expect(blobs(root)).To(HaveLen(1))
+		Expect(blobs(root)).To(ContainElement("2"))
+
+		// overwriting releases the old content
+		_, err = fileStore.PutFileObject(ctx, newFileObject("a.txt", "changed"))
+		Expect(err).To(BeNil())
+		Expect(blobs(root)).To(ConsistOf("1", "1"))
+		Expect(readObject(fileStore, "a.txt")).To(Equal("changed"))
+
+		Expect(fileStore.DeleteFileObject(ctx, "b/b.txt")).To(Succeed())
+		Expect(blobs(root)).To(ConsistOf("1"))
+		Expect(fileStore.DeleteFileObject(ctx, "a.txt")).To(Succeed())
+		Expect(blobs(root)).To(BeEmpty())
+
+		entries, err := os.ReadDir(filepath.Join(root, blobsDir))
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(1))
+		entries, err = os.ReadDir(filepath.Join(root, metasDir))
+		Expect(err).To(BeNil())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("lists file metas by prefix", func() {
+		for _, name := range []string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "dir2/e.txt"} {
+			_, err := fileStore.PutFileObject(ctx, newFileObject(name, name))
+			Expect(err).To(BeNil())
+		}
+
+		metas, err := fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Recursive: true})
+		Expect(err).To(BeNil())
+		Expect(metaNames(metas)).To(Equal([]string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "dir2/e.txt"}))
+		Expect(metas[1].Spec.ContentLength).To(Equal(int64(len("dir/b.txt"))))
+
+		metas, err = fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Prefix: "dir/", Recursive: true})
+		Expect(err).To(BeNil())
+		Expect(metaNames(metas)).To(Equal([]string{"dir/b.txt", "dir/c.txt", "dir/sub/d.txt"}))
+
+		metas, err = fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Prefix: "dir"})
+		Expect(err).To(BeNil())
+		Expect(metaNames(metas)).To(Equal([]string{"dir/", "dir2/"}))
+
+		metas, err = fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Prefix: "dir/"})
+		Expect(err).To(BeNil())
+		Expect(metaNames(metas)).To(Equal([]string{"dir/b.txt", "dir/c.txt", "dir/sub/"}))
+
+		metas, err = fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Recursive: true, StartAfter: "dir/b.txt", Limit: 2})
+		Expect(err).To(BeNil())
+		Expect(metaNames(metas)).To(Equal([]string{"dir/c.txt", "dir/sub/d.txt"}))
+
+		metas, err = fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Prefix: "missing/", Recursive: true})
+		Expect(err).To(BeNil())
+		Expect(metas).To(BeEmpty())
+	})
+
+	It("rejects invalid object names", func() {
+		for _, name := range []string{"", "/abs", "../escape", "a/../../b", "dir/", "a//b", `a\b`} {
+			_, err := fileStore.PutFileObject(ctx, newFileObject(name, "x"))
+			Expect(errors.IsBadRequest(err)).To(BeTrue(), name)
+		}
+		_, err := fileStore.PutFileObject(ctx, &filestorev1alpha1.FileObject{})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("rejects prefixes outside of the root", func() {
+		for _, prefix := range []string{"../../etc/", "/etc/", "dir/../../", "..", `dir\..\`} {
+			_, err := fileStore.ListFileMetas(ctx, storagev1alpha1.FileMetaListOptions{Prefix: prefix, Recursive: true})
+			Expect(errors.IsBadRequest(err)).To(BeTrue(), prefix)
+		}
+	})
+
+	It("is consistent under concurrent writers", func() {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				// writers of the same object race with writers sharing contents
+				_, err := fileStore.PutFileObject(ctx, newFileObject("shared.txt", fmt.Sprintf("content-%d", i%3)))
+				Expect(err).To(BeNil())
+				_, err = fileStore.PutFileObject(ctx, newFileObject(fmt.Sprintf("objects/%d.txt", i), fmt.Sprintf("content-%d", i%3)))
+				Expect(err).To(BeNil())
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(readObject(fileStore, "shared.txt")).To(HavePrefix("content-"))
+		counts := blobs(root)
+		Expect(counts).To(HaveLen(3))
+		total := 0
+		for _, count := range counts {
+			n := 0
+			_, err := fmt.Sscan(count, &n)
+			Expect(err).To(BeNil())
+			total += n
+		}
+		Expect(total).To(Equal(21))
+
+		entries, err := os.ReadDir(filepath.Join(root, tmpDir))
+		Expect(err).To(BeNil())
+		Expect(entries).To(BeEmpty())
+	})
+})
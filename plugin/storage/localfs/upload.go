@@ -0,0 +1,237 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localfs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// uploadFile is the file storing the upload session in its directory
+	uploadFile = "upload.json"
+	// chunksDir is the directory storing uploaded chunks in the upload directory
+	chunksDir = "chunks"
+)
+
+// uploadIDPattern matches the ids generated by newUploadID
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// CreateFileUpload starts an upload session of the object named by the meta
+func (f *FileStore) CreateFileUpload(_ context.Context, meta storagev1alpha1.FileMeta) (*storagev1alpha1.FileUpload, error) {
+	if err := validateObjectName(meta.Name); err != nil {
+		return nil, err
+	}
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &storagev1alpha1.FileUpload{}
+	upload.SetGroupVersionKind(storagev1alpha1.FileUploadGVK)
+	upload.Name = id
+	upload.CreationTimestamp = metav1.NewTime(f.now())
+	meta.DeepCopyInto(&upload.Spec.FileMeta)
+
+	f.uploadLock.Lock()
+	defer f.uploadLock.Unlock()
+
+	if err = os.MkdirAll(filepath.Join(f.uploadPath(id), chunksDir), 0o755); err != nil {
+		return nil, err
+	}
+	if err = f.writeUpload(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetFileUpload returns the upload session with the uploaded chunks
+func (f *FileStore) GetFileUpload(_ context.Context, uploadID string) (*storagev1alpha1.FileUpload, error) {
+	f.uploadLock.Lock()
+	defer f.uploadLock.Unlock()
+
+	return f.readUpload(uploadID)
+}
+
+// PutFileUploadChunk stores a chunk after verifying its checksum,
+// a chunk uploaded again with the same index replaces the previous one.
+func (f *FileStore) PutFileUploadChunk(ctx context.Context, uploadID string, chunk *filestorev1alpha1.FileChunk) (*storagev1alpha1.FileUploadChunk, error) {
+	if chunk == nil || chunk.ChunkReadCloser == nil {
+		return nil, errors.NewBadRequest("chunk content is required")
+	}
+	defer chunk.ChunkReadCloser.Close()
+	if chunk.Index < 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid chunk index %d", chunk.Index))
+	}
+	// fails fast before the content is read
+	if _, err := f.GetFileUpload(ctx, uploadID); err != nil {
+		return nil, err
+	}
+
+	digest, size, tmpFile, err := f.writeTemp(chunk.ChunkReadCloser)
+	if err != nil {
+		return nil, err
+	}
+	// the temporary file is renamed if the chunk is valid
+	defer os.Remove(tmpFile)
+
+	checksum := strings.TrimPrefix(digest, digestAlgorithm+":")
+	if !strings.EqualFold(checksum, chunk.SHA256) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("checksum of chunk %d mismatch, expected %s, got %s", chunk.Index, chunk.SHA256, checksum))
+	}
+
+	f.uploadLock.Lock()
+	defer f.uploadLock.Unlock()
+
+	// the upload may be completed or aborted while the chunk is written
+	upload, err := f.readUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmpFile, f.chunkPath(uploadID, chunk.Index)); err != nil {
+		return nil, err
+	}
+
+	uploaded := storagev1alpha1.FileUploadChunk{Index: chunk.Index, Size: size, SHA256: checksum}
+	chunks := []storagev1alpha1.FileUploadChunk{uploaded}
+	for _, item := range upload.Status.Chunks {
+		if item.Index != chunk.Index {
+			chunks = append(chunks, item)
+		}
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+	upload.Status.Chunks = chunks
+	upload.Status.Size = 0
+	for _, item := range chunks {
+		upload.Status.Size += item.Size
+	}
+	if err = f.writeUpload(upload); err != nil {
+		return nil, err
+	}
+	return &uploaded, nil
+}
+
+// CompleteFileUpload assembles the chunks into the file object and removes the upload session.
+// Chunks must be uploaded contiguously from index 0.
+func (f *FileStore) CompleteFileUpload(_ context.Context, uploadID string, opts storagev1alpha1.FileUploadCompleteOptions) (*storagev1alpha1.FileMeta, error) {
+	f.uploadLock.Lock()
+	defer f.uploadLock.Unlock()
+
+	upload, err := f.readUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	readers := make([]io.Reader, 0, len(upload.Status.Chunks))
+	for i, chunk := range upload.Status.Chunks {
+		if chunk.Index != i {
+			return nil, errors.NewBadRequest(fmt.Sprintf("chunk %d of upload %s is missing", i, uploadID))
+		}
+		file, err := os.Open(f.chunkPath(uploadID, i))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+
+	digest, size, tmpFile, err := f.writeTemp(io.MultiReader(readers...))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile)
+
+	checksum := strings.TrimPrefix(digest, digestAlgorithm+":")
+	if opts.SHA256 != "" && !strings.EqualFold(checksum, opts.SHA256) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("checksum of upload %s mismatch, expected %s, got %s", uploadID, opts.SHA256, checksum))
+	}
+
+	meta, err := f.commit(upload.Spec.FileMeta, digest, size, tmpFile)
+	if err != nil {
+		return nil, err
+	}
+	return meta, os.RemoveAll(f.uploadPath(uploadID))
+}
+
+// AbortFileUpload removes the upload session and the uploaded chunks
+func (f *FileStore) AbortFileUpload(_ context.Context, uploadID string) error {
+	f.uploadLock.Lock()
+	defer f.uploadLock.Unlock()
+
+	if _, err := f.readUpload(uploadID); err != nil {
+		return err
+	}
+	return os.RemoveAll(f.uploadPath(uploadID))
+}
+
+func (f *FileStore) readUpload(uploadID string) (*storagev1alpha1.FileUpload, error) {
+	if !uploadIDPattern.MatchString(uploadID) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid upload id %q", uploadID))
+	}
+	data, err := os.ReadFile(filepath.Join(f.uploadPath(uploadID), uploadFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFound(storagev1alpha1.GroupVersion.WithResource("fileuploads").GroupResource(), uploadID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	upload := &storagev1alpha1.FileUpload{}
+	if err = json.Unmarshal(data, upload); err != nil {
+		return nil, fmt.Errorf("invalid upload %q: %w", uploadID, err)
+	}
+	return upload, nil
+}
+
+func (f *FileStore) writeUpload(upload *storagev1alpha1.FileUpload) error {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	return f.writeFile(filepath.Join(f.uploadPath(upload.Name), uploadFile), data)
+}
+
+func (f *FileStore) uploadPath(uploadID string) string {
+	return filepath.Join(f.root, uploadsDir, uploadID)
+}
+
+func (f *FileStore) chunkPath(uploadID string, index int) string {
+	return filepath.Join(f.uploadPath(uploadID), chunksDir, strconv.Itoa(index))
+}
+
+// newUploadID returns a random hex encoded upload id
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
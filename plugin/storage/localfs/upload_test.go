@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func newFileChunk(index int, content, checksum string) *filestorev1alpha1.FileChunk {
+	if checksum == "" {
+		checksum = storagev1alpha1.ChunkChecksum([]byte(content))
+	}
+	return &filestorev1alpha1.FileChunk{
+		FileUploadChunk: storagev1alpha1.FileUploadChunk{Index: index, SHA256: checksum},
+		ChunkReadCloser: io.NopCloser(strings.NewReader(content)),
+	}
+}
+
+var _ = Describe("Test.FileStore.Upload", func() {
+	var (
+		ctx       context.Context
+		root      string
+		fileStore *FileStore
+		upload    *storagev1alpha1.FileUpload
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = GinkgoT().TempDir()
+		fileStore = NewFileStore("local", root)
+		Expect(fileStore.Setup(ctx, nil)).To(Succeed())
+
+		var err error
+		upload, err = fileStore.CreateFileUpload(ctx, newFileObject("reports/big.txt", "").FileMeta)
+		Expect(err).To(BeNil())
+		Expect(upload.Name).To(MatchRegexp("^[0-9a-f]{32}$"))
+		Expect(upload.Kind).To(Equal("FileUpload"))
+		Expect(upload.Spec.FileMeta.Name).To(Equal("reports/big.txt"))
+	})
+
+	It("assembles uploaded chunks in order", func() {
+		// chunks could be uploaded out of order
+		_, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(1, "world", ""))
+		Expect(err).To(BeNil())
+		chunk, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "hello ", ""))
+		Expect(err).To(BeNil())
+		Expect(*chunk).To(Equal(storagev1alpha1.FileUploadChunk{
+			Index: 0, Size: 6, SHA256: storagev1alpha1.ChunkChecksum([]byte("hello ")),
+		}))
+
+		got, err := fileStore.GetFileUpload(ctx, upload.Name)
+		Expect(err).To(BeNil())
+		Expect(got.Status.Size).To(Equal(int64(11)))
+		Expect(got.Status.Chunks).To(HaveLen(2))
+		Expect(got.GetChunk(1).Size).To(Equal(int64(5)))
+
+		meta, err := fileStore.CompleteFileUpload(ctx, upload.Name, storagev1alpha1.FileUploadCompleteOptions{
+			SHA256: storagev1alpha1.ChunkChecksum([]byte("hello world")),
+		})
+		Expect(err).To(BeNil())
+		Expect(meta.Name).To(Equal("reports/big.txt"))
+		Expect(meta.Spec.ContentLength).To(Equal(int64(11)))
+		Expect(meta.Spec.ContentType).To(Equal("text/plain"))
+		Expect(readObject(fileStore, "reports/big.txt")).To(Equal("hello world"))
+
+		// the upload session is removed once completed
+		_, err = fileStore.GetFileUpload(ctx, upload.Name)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = os.Stat(fileStore.uploadPath(upload.Name))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("replaces a chunk uploaded again", func() {
+		_, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "broken", ""))
+		Expect(err).To(BeNil())
+		_, err = fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "fixed", ""))
+		Expect(err).To(BeNil())
+
+		got, err := fileStore.GetFileUpload(ctx, upload.Name)
+		Expect(err).To(BeNil())
+		Expect(got.Status.Chunks).To(HaveLen(1))
+		Expect(got.Status.Size).To(Equal(int64(5)))
+
+		_, err = fileStore.CompleteFileUpload(ctx, upload.Name, storagev1alpha1.FileUploadCompleteOptions{})
+		Expect(err).To(BeNil())
+		Expect(readObject(fileStore, "reports/big.txt")).To(Equal("fixed"))
+	})
+
+	It("rejects chunks with mismatched checksum", func() {
+		_, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "hello", "deadbeef"))
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		got, err := fileStore.GetFileUpload(ctx, upload.Name)
+		Expect(err).To(BeNil())
+		Expect(got.Status.Chunks).To(BeEmpty())
+
+		_, err = fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(-1, "hello", ""))
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("fails to complete with missing chunks or mismatched checksum", func() {
+		_, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(1, "world", ""))
+		Expect(err).To(BeNil())
+		_, err = fileStore.CompleteFileUpload(ctx, upload.Name, storagev1alpha1.FileUploadCompleteOptions{})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		_, err = fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "hello ", ""))
+		Expect(err).To(BeNil())
+		_, err = fileStore.CompleteFileUpload(ctx, upload.Name, storagev1alpha1.FileUploadCompleteOptions{SHA256: "deadbeef"})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		// the upload could be completed after failures
+		_, err = fileStore.CompleteFileUpload(ctx, upload.Name, storagev1alpha1.FileUploadCompleteOptions{})
+		Expect(err).To(BeNil())
+		Expect(blobs(root)).To(HaveLen(1))
+	})
+
+	It("aborts uploads", func() {
+		_, err := fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(0, "hello", ""))
+		Expect(err).To(BeNil())
+		Expect(fileStore.AbortFileUpload(ctx, upload.Name)).To(Succeed())
+
+		_, err = fileStore.PutFileUploadChunk(ctx, upload.Name, newFileChunk(1, "world", ""))
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(errors.IsNotFound(fileStore.AbortFileUpload(ctx, upload.Name))).To(BeTrue())
+		_, err = fileStore.GetFileObject(ctx, "reports/big.txt")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("rejects invalid upload ids and object names", func() {
+		_, err := fileStore.GetFileUpload(ctx, "../metas")
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+
+		_, err = fileStore.CreateFileUpload(ctx, newFileObject("../escape", "").FileMeta)
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	It("returns seekable content for ranged downloads", func() {
+		_, err := fileStore.PutFileObject(ctx, newFileObject("a.txt", "0123456789"))
+		Expect(err).To(BeNil())
+		obj, err := fileStore.GetFileObject(ctx, "a.txt")
+		Expect(err).To(BeNil())
+		defer obj.FileReadCloser.Close()
+
+		seeker, ok := obj.FileReadCloser.(io.ReadSeeker)
+		Expect(ok).To(BeTrue())
+		_, err = seeker.Seek(7, io.SeekStart)
+		Expect(err).To(BeNil())
+		data, err := io.ReadAll(seeker)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal("789"))
+	})
+})
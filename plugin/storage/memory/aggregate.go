@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+)
+
+// aggregateGroup holds the records of a group
+type aggregateGroup struct {
+	values map[string]interface{}
+	specs  []*archivev1alpha1.RecordSpec
+}
+
+// aggregate groups specs by the group fields and time buckets and calculates the aggregate fields for each group.
+// Groups are returned in the order they first appear in specs.
+func aggregate(specs []*archivev1alpha1.RecordSpec, query archivev1alpha1.AggregateQuery) (archivev1alpha1.AggregateResult, error) {
+	for _, field := range query.AggregateFields {
+		if err := validateAggregateField(field); err != nil {
+			return nil, err
+		}
+	}
+	locations := make([]*time.Location, 0, len(query.TimeBuckets))
+	for _, bucket := range query.TimeBuckets {
+		loc, err := bucket.Location()
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+
+	groups := make([]*aggregateGroup, 0)
+	groupIndex := map[string]*aggregateGroup{}
+	for _, spec := range specs {
+		values := make(map[string]interface{}, len(query.GroupFields)+len(query.TimeBuckets))
+		keys := make([]string, 0, len(query.GroupFields)+len(query.TimeBuckets))
+		for _, field := range query.GroupFields {
+			v, _ := spec.FieldValue(field.Name)
+			values[outputName(field)] = v
+			keys = append(keys, archivev1alpha1.StringValue(v))
+		}
+		for i, bucket := range query.TimeBuckets {
+			v, err := bucketValue(spec, bucket, locations[i])
+			if err != nil {
+				return nil, err
+			}
+			values[outputName(bucket.Field)] = v
+			keys = append(keys, archivev1alpha1.StringValue(v))
+		}
+		key := strings.Join(keys, "\x00")
+		group, ok := groupIndex[key]
+		if !ok {
+			group = &aggregateGroup{values: values}
+			groupIndex[key] = group
+			groups = append(groups, group)
+		}
+		group.specs = append(group.specs, spec)
+	}
+
+	result := make(archivev1alpha1.AggregateResult, 0, len(groups))
+	for _, group := range groups {
+		item := group.values
+		for _, field := range query.AggregateFields {
+			item[outputName(field.Field)] = aggregateField(group.specs, field)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func validateAggregateField(field archivev1alpha1.AggregateField) error {
+	switch field.Operator {
+	case archivev1alpha1.AggregateOperatorMax, archivev1alpha1.AggregateOperatorMin, archivev1alpha1.AggregateOperatorSum,
+		archivev1alpha1.AggregateOperatorCount, archivev1alpha1.AggregateOperatorAvg, archivev1alpha1.AggregateOperatorCountDistinct:
+		return nil
+	case archivev1alpha1.AggregateOperatorPercentile:
+		if field.Percentile < 0 || field.Percentile > 1 {
+			return fmt.Errorf("percentile of field %q should be between 0 and 1, got %v", field.Name, field.Percentile)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported aggregate operator %q", field.Operator)
+}
+
+// bucketValue returns the time bucket value of spec, nil is returned if the field is not a timestamp
+func bucketValue(spec *archivev1alpha1.RecordSpec, bucket archivev1alpha1.TimeBucket, loc *time.Location) (interface{}, error) {
+	v, ok := spec.FieldValue(bucket.Name)
+	if !ok {
+		return nil, nil
+	}
+	seconds, isNumber := archivev1alpha1.NumericValue(v)
+	if !isNumber {
+		return nil, nil
+	}
+	start, err := bucket.Truncate(time.Unix(int64(seconds), 0), loc)
+	if err != nil {
+		return nil, err
+	}
+	return start.Format(archivev1alpha1.TimeBucketLayout), nil
+}
+
+// aggregateField calculates the value of an aggregate field for specs
+func aggregateField(specs []*archivev1alpha1.RecordSpec, field archivev1alpha1.AggregateField) interface{} {
+	if field.Operator == archivev1alpha1.AggregateOperatorCount && field.Name == "" {
+		return len(specs)
+	}
+
+	var (
+		result   interface{}
+		count    int
+		sum      float64
+		numbers  []float64
+		distinct = map[string]bool{}
+	)
+	for _, spec := range specs {
+		v, ok := spec.FieldValue(field.Name)
+		if !ok {
+			continue
+		}
+		count++
+		switch field.Operator {
+		case archivev1alpha1.AggregateOperatorMax:
+			if result == nil || archivev1alpha1.CompareValues(v, result) > 0 {
+				result = v
+			}
+		case archivev1alpha1.AggregateOperatorMin:
+			if result == nil || archivev1alpha1.CompareValues(v, result) < 0 {
+				result = v
+			}
+		case archivev1alpha1.AggregateOperatorSum, archivev1alpha1.AggregateOperatorAvg, archivev1alpha1.AggregateOperatorPercentile:
+			if f, isNumber := archivev1alpha1.NumericValue(v); isNumber {
+				sum += f
+				numbers = append(numbers, f)
+			}
+		case archivev1alpha1.AggregateOperatorCountDistinct:
+			distinct[archivev1alpha1.StringValue(v)] = true
+		}
+	}
+
+	switch field.Operator {
+	case archivev1alpha1.AggregateOperatorCount:
+		return count
+	case archivev1alpha1.AggregateOperatorSum:
+		return sum
+	case archivev1alpha1.AggregateOperatorAvg:
+		if len(numbers) == 0 {
+			return nil
+		}
+		return sum / float64(len(numbers))
+	case archivev1alpha1.AggregateOperatorCountDistinct:
+		return len(distinct)
+	case archivev1alpha1.AggregateOperatorPercentile:
+		return percentile(numbers, field.Percentile)
+	}
+	return result
+}
+
+// percentile calculates the continuous percentile with linear interpolation like PERCENTILE_CONT in sql
+func percentile(numbers []float64, fraction float64) interface{} {
+	if len(numbers) == 0 {
+		return nil
+	}
+	sort.Float64s(numbers)
+	position := fraction * float64(len(numbers)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return numbers[lower] + (numbers[upper]-numbers[lower])*(position-float64(lower))
+}
+
+func sortAggregateResult(result archivev1alpha1.AggregateResult, orders []archivev1alpha1.Order) {
+	if len(orders) == 0 {
+		return
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		for _, order := range orders {
+			if cmp := archivev1alpha1.CompareValues(result[i][order.Field], result[j][order.Field]); cmp != 0 {
+				return (cmp < 0) == order.Asc
+			}
+		}
+		return false
+	})
+}
+
+// outputName returns the alias of a field or its name if no alias is set
+func outputName(field archivev1alpha1.Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+	return field.Name
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/capabilities"
+	archivecap "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ archivecap.ArchiveCapable = &Archive{}
+var _ capabilities.FeatureProvider = &Archive{}
+
+// Archive is an in-memory implementation of the archive capability.
+// Records are identified by cluster and uid, soft deleted records are
+// kept with a deletion timestamp until they are deleted directly.
+// It implements client.Interface and can be registered as a storage plugin.
+type Archive struct {
+	path string
+
+	lock    sync.RWMutex
+	nextID  uint
+	records map[string]*archiveEntry
+}
+
+type archiveEntry struct {
+	record  archivev1alpha1.Record
+	deleted bool
+}
+
+// NewArchive returns an empty in-memory archive storage plugin served under path
+func NewArchive(path string) *Archive {
+	return &Archive{
+		path:    path,
+		records: map[string]*archiveEntry{},
+	}
+}
+
+// Path returns the path of the storage plugin
+func (a *Archive) Path() string {
+	return a.path
+}
+
+// Features implements capabilities.FeatureProvider
+func (a *Archive) Features() map[schema.GroupVersion][]string {
+	return map[schema.GroupVersion][]string{
+		archivecap.ArchiveV1alpha1GV: {
+			archivecap.FeatureAggregate,
+			archivecap.FeatureTimeBuckets,
+			archivecap.FeatureSoftDelete,
+			archivecap.FeatureCursor,
+		},
+	}
+}
+
+// Setup implements client.Interface, nothing to do for the in-memory storage
+func (a *Archive) Setup(_ context.Context, _ *zap.SugaredLogger) error {
+	return nil
+}
+
+// Upsert create or update a record
+func (a *Archive) Upsert(_ context.Context, record *archivev1alpha1.Record) error {
+	if record == nil || record.Spec.UID == "" {
+		return errors.NewBadRequest("record uid is required")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	stored := copyRecord(record)
+	stored.RelatedRecords = nil
+	stored.DeletionTimestamp = nil
+	key := recordKey(record.Spec.Cluster, record.Spec.UID)
+	if entry, ok := a.records[key]; ok {
+		stored.Spec.ID = entry.record.Spec.ID
+	} else {
+		a.nextID++
+		stored.Spec.ID = a.nextID
+	}
+	a.records[key] = &archiveEntry{record: stored}
+	return nil
+}
+
+// Delete delete a record
+func (a *Archive) Delete(_ context.Context, cluster string, uid string, opts *archivev1alpha1.DeleteOption) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := recordKey(cluster, uid)
+	if entry, ok := a.records[key]; ok {
+		a.deleteEntry(key, entry, opts)
+	}
+	return nil
+}
+
+// DeleteBatch delete records by conditions
+func (a *Archive) DeleteBatch(_ context.Context, conditions []archivev1alpha1.Condition, opts *archivev1alpha1.DeleteOption) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for key, entry := range a.records {
+		matched, err := archivev1alpha1.MatchConditions(&entry.record.Spec, conditions)
+		if err != nil {
+			return errors.NewBadRequest(err.Error())
+		}
+		if matched {
+			a.deleteEntry(key, entry, opts)
+		}
+	}
+	return nil
+}
+
+func (a *Archive) deleteEntry(key string, entry *archiveEntry, opts *archivev1alpha1.DeleteOption) {
+	if opts != nil && opts.Direct {
+		delete(a.records, key)
+		return
+	}
+	if !entry.deleted {
+		now := metav1.Now()
+		entry.record.DeletionTimestamp = &now
+	}
+	entry.deleted = true
+}
+
+// ListRecords list records by conditions
+func (a *Archive) ListRecords(_ context.Context, query archivev1alpha1.Query, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.RecordList, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.search(query.Conditions, opts)
+}
+
+// ListRelatedRecords list records by conditions together with their related records.
+// A record is related to another one when its top or parent resource is the other one.
+func (a *Archive) ListRelatedRecords(_ context.Context, query archivev1alpha1.Query, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.RecordList, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	list, err := a.search(query.Conditions, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	withDeleted := opts != nil && opts.WithDeletedData
+	records := list.Items
+	for i := range records {
+		owner := &records[i].Spec
+		for _, entry := range a.sortedEntries() {
+			if entry.deleted && !withDeleted {
+				continue
+			}
+			if isRelated(&entry.record.Spec, owner) {
+				records[i].RelatedRecords = append(records[i].RelatedRecords, copyRecord(&entry.record))
+			}
+		}
+	}
+	return list, nil
+}
+
+// Aggregate aggregate records by conditions
+func (a *Archive) Aggregate(_ context.Context, aggs archivev1alpha1.AggregateQuery, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.AggregateResult, error) {
+	if opts != nil && opts.Cursor != nil {
+		return nil, errors.NewBadRequest("cursor is not supported by aggregate queries")
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	entries, err := a.filter(aggs.Conditions, opts)
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]*archivev1alpha1.RecordSpec, 0, len(entries))
+	for _, entry := range entries {
+		specs = append(specs, &entry.record.Spec)
+	}
+
+	result, err := aggregate(specs, aggs)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		sortAggregateResult(result, opts.Orders)
+		result = paginate(result, opts)
+	}
+	return &result, nil
+}
+
+// search returns copies of records matching the conditions with orders and pagination applied
+func (a *Archive) search(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.RecordList, error) {
+	if opts != nil && opts.Cursor != nil {
+		return a.searchByCursor(conditions, opts)
+	}
+
+	entries, err := a.filter(conditions, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		sortEntries(entries, opts.Orders)
+		entries = paginate(entries, opts)
+	}
+	return &archivev1alpha1.RecordList{Items: copyRecords(entries)}, nil
+}
+
+// searchByCursor returns records after the position of the cursor in options
+func (a *Archive) searchByCursor(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.RecordList, error) {
+	cursorConditions, err := archivev1alpha1.CursorConditions(opts.Cursor, opts.Orders)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	entries, err := a.filter(append(cursorConditions, conditions...), opts)
+	if err != nil {
+		return nil, err
+	}
+	sortEntries(entries, archivev1alpha1.CursorOrders(opts.Orders))
+
+	list := &archivev1alpha1.RecordList{}
+	limit := opts.GetPageLimit()
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	if len(entries) == limit {
+		last := &entries[len(entries)-1].record.Spec
+		list.Continue = archivev1alpha1.NextCursor(opts.Cursor, opts.Orders, last).Encode()
+	}
+	list.Items = copyRecords(entries)
+	return list, nil
+}
+
+// filter returns entries matching the conditions ordered by id
+func (a *Archive) filter(conditions []archivev1alpha1.Condition, opts *archivev1alpha1.ListOptions) ([]*archiveEntry, error) {
+	withDeleted := opts != nil && opts.WithDeletedData
+	entries := make([]*archiveEntry, 0)
+	for _, entry := range a.sortedEntries() {
+		if entry.deleted && !withDeleted {
+			continue
+		}
+		matched, err := archivev1alpha1.MatchConditions(&entry.record.Spec, conditions)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		if matched {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (a *Archive) sortedEntries() []*archiveEntry {
+	entries := make([]*archiveEntry, 0, len(a.records))
+	for _, entry := range a.records {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].record.Spec.ID < entries[j].record.Spec.ID
+	})
+	return entries
+}
+
+func sortEntries(entries []*archiveEntry, orders []archivev1alpha1.Order) {
+	if len(orders) == 0 {
+		return
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		for _, order := range orders {
+			vi, _ := entries[i].record.Spec.FieldValue(order.Field)
+			vj, _ := entries[j].record.Spec.FieldValue(order.Field)
+			if result := archivev1alpha1.CompareValues(vi, vj); result != 0 {
+				return (result < 0) == order.Asc
+			}
+		}
+		return false
+	})
+}
+
+// paginate returns the items of the page described in the list options
+func paginate[T any](items []T, opts *archivev1alpha1.ListOptions) []T {
+	offset := opts.GetOffset()
+	if offset >= len(items) {
+		return items[:0]
+	}
+	end := offset + opts.GetPageLimit()
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// isRelated returns true if the top or parent resource of spec is owner
+func isRelated(spec, owner *archivev1alpha1.RecordSpec) bool {
+	if spec.UID == owner.UID && spec.Cluster == owner.Cluster {
+		return false
+	}
+	return (spec.TopUID == owner.UID && spec.TopCluster == owner.Cluster) ||
+		(spec.ParentUID == owner.UID && spec.ParentCluster == owner.Cluster)
+}
+
+func recordKey(cluster, uid string) string {
+	return fmt.Sprintf("%s/%s", cluster, uid)
+}
+
+func copyRecords(entries []*archiveEntry) []archivev1alpha1.Record {
+	records := make([]archivev1alpha1.Record, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, copyRecord(&entry.record))
+	}
+	return records
+}
+
+func copyRecord(record *archivev1alpha1.Record) archivev1alpha1.Record {
+	return archivev1alpha1.Record{
+		TypeMeta:   record.TypeMeta,
+		ObjectMeta: *record.ObjectMeta.DeepCopy(),
+		Spec:       *record.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/route"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func newRecord(uid, name string, creationTimestamp int64, metadata map[string]string) *archivev1alpha1.Record {
+	return &archivev1alpha1.Record{
+		Spec: archivev1alpha1.RecordSpec{
+			Cluster:           "cluster",
+			Namespace:         "default",
+			Name:              name,
+			UID:               uid,
+			Group:             "tekton.dev",
+			Version:           "v1",
+			Kind:              "PipelineRun",
+			CreationTimestamp: creationTimestamp,
+			Metadata:          metadata,
+			Data: map[string]interface{}{
+				"spec": map[string]interface{}{"timeout": "1h"},
+			},
+		},
+	}
+}
+
+func recordNames(list *archivev1alpha1.RecordList) []string {
+	names := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		names = append(names, item.Spec.Name)
+	}
+	return names
+}
+
+var _ = Describe("Test.Archive", func() {
+	var (
+		ctx     context.Context
+		archive *Archive
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		archive = NewArchive("memory")
+		Expect(archive.Upsert(ctx, newRecord("uid-1", "run-1", 100, map[string]string{"status": "True", "duration": "10"}))).To(Succeed())
+		Expect(archive.Upsert(ctx, newRecord("uid-2", "run-2", 200, map[string]string{"status": "False", "duration": "30"}))).To(Succeed())
+		Expect(archive.Upsert(ctx, newRecord("uid-3", "build-3", 300, map[string]string{"status": "True", "duration": "20"}))).To(Succeed())
+	})
+
+	It("can be served as a storage plugin", func() {
+		svcs, err := route.NewServicesWithContext(ctx, archive)
+		Expect(err).To(BeNil())
+		Expect(svcs).To(HaveLen(2))
+		Expect(svcs[0].RootPath()).To(Equal("/storage/memory/archive/v1alpha1"))
+		Expect(svcs[1].RootPath()).To(Equal("/storage/memory/core/v1alpha1"))
+	})
+
+	Context("Upsert", func() {
+		It("returns error when uid is empty", func() {
+			Expect(archive.Upsert(ctx, newRecord("", "run", 1, nil))).NotTo(Succeed())
+		})
+
+		It("updates existing record and keeps its id", func() {
+			list, err := archive.ListRecords(ctx, archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{archivev1alpha1.UID("uid-2")}}, nil)
+			Expect(err).To(BeNil())
+			Expect(list.Items).To(HaveLen(1))
+			id := list.Items[0].Spec.ID
+
+			Expect(archive.Upsert(ctx, newRecord("uid-2", "run-2-updated", 200, nil))).To(Succeed())
+			list, err = archive.ListRecords(ctx, archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{archivev1alpha1.UID("uid-2")}}, nil)
+			Expect(err).To(BeNil())
+			Expect(recordNames(list)).To(Equal([]string{"run-2-updated"}))
+			Expect(list.Items[0].Spec.ID).To(Equal(id))
+		})
+	})
+
+	DescribeTable("ListRecords with conditions",
+		func(conditions []archivev1alpha1.Condition, expected []string) {
+			list, err := archive.ListRecords(ctx, archivev1alpha1.Query{Conditions: conditions}, nil)
+			Expect(err).To(BeNil())
+			Expect(recordNames(list)).To(Equal(expected))
+		},
+		Entry("no condition", nil, []string{"run-1", "run-2", "build-3"}),
+		Entry("eq", []archivev1alpha1.Condition{archivev1alpha1.Name("run-1")}, []string{"run-1"}),
+		Entry("ne", []archivev1alpha1.Condition{archivev1alpha1.NotEqual(archivev1alpha1.NameField, "run-1")}, []string{"run-2", "build-3"}),
+		Entry("gt", []archivev1alpha1.Condition{archivev1alpha1.Gt(archivev1alpha1.CreationTimestampField, "100")}, []string{"run-2", "build-3"}),
+		Entry("gte", []archivev1alpha1.Condition{archivev1alpha1.Gte(archivev1alpha1.CreationTimestampField, "200")}, []string{"run-2", "build-3"}),
+		Entry("lt", []archivev1alpha1.Condition{archivev1alpha1.Lt(archivev1alpha1.CreationTimestampField, "200")}, []string{"run-1"}),
+		Entry("lte", []archivev1alpha1.Condition{archivev1alpha1.Lte(archivev1alpha1.CreationTimestampField, "200")}, []string{"run-1", "run-2"}),
+		Entry("in", []archivev1alpha1.Condition{archivev1alpha1.In(archivev1alpha1.UIDField, "uid-1", "uid-3")}, []string{"run-1", "build-3"}),
+		Entry("like", []archivev1alpha1.Condition{archivev1alpha1.Like(archivev1alpha1.NameField, "run-%")}, []string{"run-1", "run-2"}),
+		Entry("exist", []archivev1alpha1.Condition{archivev1alpha1.Exist(archivev1alpha1.MetadataKey("status"))}, []string{"run-1", "run-2", "build-3"}),
+		Entry("exist with missing key", []archivev1alpha1.Condition{archivev1alpha1.Exist(archivev1alpha1.MetadataKey("reason"))}, []string{}),
+		Entry("eqcol", []archivev1alpha1.Condition{archivev1alpha1.EqualColumn(archivev1alpha1.NamespaceField, archivev1alpha1.NamespaceField)}, []string{"run-1", "run-2", "build-3"}),
+		Entry("metadata", []archivev1alpha1.Condition{archivev1alpha1.Equal(archivev1alpha1.MetadataKey("status"), "True")}, []string{"run-1", "build-3"}),
+		Entry("data", []archivev1alpha1.Condition{archivev1alpha1.Equal("data.spec.timeout", "1h")}, []string{"run-1", "run-2", "build-3"}),
+		Entry("or", []archivev1alpha1.Condition{archivev1alpha1.Or(archivev1alpha1.Name("run-1"), archivev1alpha1.Name("build-3"))}, []string{"run-1", "build-3"}),
+		Entry("and", []archivev1alpha1.Condition{archivev1alpha1.And(archivev1alpha1.Like(archivev1alpha1.NameField, "run%"), archivev1alpha1.CompletedStatus())}, []string{"run-1", "run-2"}),
+		Entry("jsonpath", []archivev1alpha1.Condition{archivev1alpha1.JSONPathMatch(archivev1alpha1.MetadataFiled, "$.duration", archivev1alpha1.ConditionOperatorGte, 20)}, []string{"run-2", "build-3"}),
+		Entry("fulltext", []archivev1alpha1.Condition{archivev1alpha1.FullText(archivev1alpha1.MetadataFiled, "true 20")}, []string{"build-3"}),
+	)
+
+	It("returns error for unknown operator", func() {
+		_, err := archive.ListRecords(ctx, archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{{Key: "name", Operator: "unknown"}}}, nil)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("lists records with orders and pagination", func() {
+		opts := &archivev1alpha1.ListOptions{
+			Pager:  metav1alpha1.Pager{ItemsPerPage: 2, Page: 1},
+			Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField, Asc: false}},
+		}
+		list, err := archive.ListRecords(ctx, archivev1alpha1.Query{}, opts)
+		Expect(err).To(BeNil())
+		Expect(recordNames(list)).To(Equal([]string{"build-3", "run-2"}))
+
+		opts.Page = 2
+		list, err = archive.ListRecords(ctx, archivev1alpha1.Query{}, opts)
+		Expect(err).To(BeNil())
+		Expect(recordNames(list)).To(Equal([]string{"run-1"}))
+
+		opts.Page = 3
+		list, err = archive.ListRecords(ctx, archivev1alpha1.Query{}, opts)
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(BeEmpty())
+	})
+
+	It("lists records with cursor", func() {
+		opts := &archivev1alpha1.ListOptions{
+			Pager:  metav1alpha1.Pager{ItemsPerPage: 2},
+			Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.MetadataKey("status"), Asc: true}},
+			Cursor: &metav1alpha1.TimeCursor{},
+		}
+		list, err := archive.ListRecords(ctx, archivev1alpha1.Query{}, opts)
+		Expect(err).To(BeNil())
+		Expect(recordNames(list)).To(Equal([]string{"run-2", "run-1"}))
+		Expect(list.Continue).NotTo(BeEmpty())
+
+		// a record inserted before the cursor will not be returned
+		Expect(archive.Upsert(ctx, newRecord("uid-0", "run-0", 50, map[string]string{"status": "False"}))).To(Succeed())
+
+		opts.Cursor, err = metav1alpha1.ParseTimeCursor(list.Continue)
+		Expect(err).To(BeNil())
+		list, err = archive.ListRecords(ctx, archivev1alpha1.Query{}, opts)
+		Expect(err).To(BeNil())
+		Expect(recordNames(list)).To(Equal([]string{"build-3"}))
+		Expect(list.Continue).To(BeEmpty())
+	})
+
+	Context("Delete", func() {
+		It("soft deletes a record", func() {
+			Expect(archive.Delete(ctx, "cluster", "uid-1", nil)).To(Succeed())
+			list, err := archive.ListRecords(ctx, archivev1alpha1.Query{}, nil)
+			Expect(err).To(BeNil())
+			Expect(recordNames(list)).To(Equal([]string{"run-2", "build-3"}))
+
+			list, err = archive.ListRecords(ctx, archivev1alpha1.Query{}, &archivev1alpha1.ListOptions{WithDeletedData: true})
+			Expect(err).To(BeNil())
+			Expect(recordNames(list)).To(Equal([]string{"run-1", "run-2", "build-3"}))
+		})
+
+		It("deletes records directly by conditions", func() {
+			conditions := []archivev1alpha1.Condition{archivev1alpha1.Like(archivev1alpha1.NameField, "run%")}
+			Expect(archive.DeleteBatch(ctx, conditions, &archivev1alpha1.DeleteOption{Direct: true})).To(Succeed())
+			list, err := archive.ListRecords(ctx, archivev1alpha1.Query{}, &archivev1alpha1.ListOptions{WithDeletedData: true})
+			Expect(err).To(BeNil())
+			Expect(recordNames(list)).To(Equal([]string{"build-3"}))
+		})
+	})
+
+	It("lists related records", func() {
+		child := newRecord("uid-4", "task-4", 400, nil)
+		child.Spec.TopCluster = "cluster"
+		child.Spec.TopUID = "uid-1"
+		child.Spec.ParentCluster = "cluster"
+		child.Spec.ParentUID = "uid-1"
+		Expect(archive.Upsert(ctx, child)).To(Succeed())
+
+		list, err := archive.ListRelatedRecords(ctx, archivev1alpha1.Query{Conditions: []archivev1alpha1.Condition{archivev1alpha1.Name("run-1")}}, nil)
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].RelatedRecords).To(HaveLen(1))
+		Expect(list.Items[0].RelatedRecords[0].Spec.Name).To(Equal("task-4"))
+	})
+
+	It("aggregates records", func() {
+		query := archivev1alpha1.AggregateQuery{
+			GroupFields: []archivev1alpha1.Field{{Name: archivev1alpha1.MetadataKey("status"), Alias: "status"}},
+			AggregateFields: []archivev1alpha1.AggregateField{
+				archivev1alpha1.Count("count"),
+				archivev1alpha1.Max(archivev1alpha1.CreationTimestampField, "latest"),
+				archivev1alpha1.Min(archivev1alpha1.MetadataKey("duration"), "fastest"),
+				archivev1alpha1.Sum(archivev1alpha1.MetadataKey("duration"), "total"),
+			},
+		}
+		opts := &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: "count", Asc: false}}}
+		result, err := archive.Aggregate(ctx, query, opts)
+		Expect(err).To(BeNil())
+
+		type item struct {
+			Status  string `json:"status"`
+			Count   int    `json:"count"`
+			Latest  int64  `json:"latest"`
+			Fastest string `json:"fastest"`
+			Total   int    `json:"total"`
+		}
+		items := []item{}
+		Expect(result.Unmarshal(&items)).To(Succeed())
+		Expect(items).To(Equal([]item{
+			{Status: "True", Count: 2, Latest: 300, Fastest: "10", Total: 30},
+			{Status: "False", Count: 1, Latest: 200, Fastest: "30", Total: 30},
+		}))
+	})
+
+	It("aggregates records by time buckets", func() {
+		monday := time.Date(2023, 5, 1, 23, 30, 0, 0, time.UTC).Unix()
+		tuesday := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC).Unix()
+		Expect(archive.Upsert(ctx, newRecord("uid-4", "run-4", monday, map[string]string{"status": "True", "duration": "40"}))).To(Succeed())
+		Expect(archive.Upsert(ctx, newRecord("uid-5", "run-5", tuesday, map[string]string{"status": "True", "duration": "50"}))).To(Succeed())
+
+		query := archivev1alpha1.AggregateQuery{
+			TimeBuckets: []archivev1alpha1.TimeBucket{
+				archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "day", archivev1alpha1.TimeBucketUnitDay, "Asia/Shanghai"),
+				archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "week", archivev1alpha1.TimeBucketUnitWeek, ""),
+			},
+			AggregateFields: []archivev1alpha1.AggregateField{
+				archivev1alpha1.Count("count"),
+				archivev1alpha1.Avg(archivev1alpha1.MetadataKey("duration"), "avg"),
+				archivev1alpha1.Percentile(archivev1alpha1.MetadataKey("duration"), "p95", 0.95),
+				archivev1alpha1.CountDistinct(archivev1alpha1.MetadataKey("status"), "statuses"),
+			},
+		}
+		opts := &archivev1alpha1.ListOptions{Orders: []archivev1alpha1.Order{{Field: "day", Asc: true}}}
+		result, err := archive.Aggregate(ctx, query, opts)
+		Expect(err).To(BeNil())
+
+		type item struct {
+			Day      string  `json:"day"`
+			Week     string  `json:"week"`
+			Count    int     `json:"count"`
+			Avg      float64 `json:"avg"`
+			P95      float64 `json:"p95"`
+			Statuses int     `json:"statuses"`
+		}
+		items := []item{}
+		Expect(result.Unmarshal(&items)).To(Succeed())
+		Expect(items).To(HaveLen(2))
+		Expect(items[0].Day).To(Equal("1970-01-01T00:00:00"))
+		Expect(items[0].Week).To(Equal("1969-12-29T00:00:00"))
+		Expect(items[0].Count).To(Equal(3))
+		Expect(items[0].Avg).To(BeNumerically("~", 20))
+		Expect(items[0].P95).To(BeNumerically("~", 29))
+		Expect(items[0].Statuses).To(Equal(2))
+		Expect(items[1].Day).To(Equal("2023-05-02T00:00:00"))
+		Expect(items[1].Week).To(Equal("2023-05-01T00:00:00"))
+		Expect(items[1].Count).To(Equal(2))
+		Expect(items[1].Avg).To(BeNumerically("~", 45))
+		Expect(items[1].P95).To(BeNumerically("~", 49.5))
+		Expect(items[1].Statuses).To(Equal(1))
+
+		day, err := archivev1alpha1.ParseTimeBucket(items[1].Day, time.FixedZone("CST", 8*3600))
+		Expect(err).To(BeNil())
+		Expect(day.Unix()).To(BeNumerically("<=", monday))
+	})
+
+	It("returns error for invalid aggregate query", func() {
+		_, err := archive.Aggregate(ctx, archivev1alpha1.AggregateQuery{
+			AggregateFields: []archivev1alpha1.AggregateField{archivev1alpha1.Percentile(archivev1alpha1.CreationTimestampField, "p95", 95)},
+		}, nil)
+		Expect(err).NotTo(BeNil())
+
+		_, err = archive.Aggregate(ctx, archivev1alpha1.AggregateQuery{
+			TimeBuckets: []archivev1alpha1.TimeBucket{archivev1alpha1.Bucket(archivev1alpha1.CreationTimestampField, "year", "year", "")},
+		}, nil)
+		Expect(err).NotTo(BeNil())
+
+		_, err = archive.Aggregate(ctx, archivev1alpha1.AggregateQuery{
+			AggregateFields: []archivev1alpha1.AggregateField{archivev1alpha1.Count("total")},
+		}, &archivev1alpha1.ListOptions{Cursor: &metav1alpha1.TimeCursor{}})
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	archivecap "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	archiveclient "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
+	"github.com/katanomi/pkg/workers"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// JobName is the name of the retention job, which is also the config key of its cron spec
+const JobName = "archive-retention"
+
+var _ workers.JobRunnable = &Job{}
+
+// Job applies retention policies periodically, it should be added to the runners of workers.CronWorker
+type Job struct {
+	// Policies are the retention policies to be applied
+	Policies []archivev1alpha1.RetentionPolicy
+	// Options are the options for applying policies
+	Options Options
+	// Storage is the archive storage, the storage in context will be used if not set
+	Storage archivecap.ArchiveCapable
+}
+
+// NewJob returns a retention job for the policies
+func NewJob(storage archivecap.ArchiveCapable, opts Options, policies ...archivev1alpha1.RetentionPolicy) *Job {
+	return &Job{
+		Policies: policies,
+		Options:  opts,
+		Storage:  storage,
+	}
+}
+
+// Setup prepares the archive storage of the job
+func (j *Job) Setup(ctx context.Context, _ client.Client, _ *resty.Client) error {
+	if j.Storage != nil {
+		return nil
+	}
+	if storage := archiveclient.GetStorage(ctx); storage != nil {
+		j.Storage = storage
+		return nil
+	}
+	return fmt.Errorf("archive storage is not found for job %s", JobName)
+}
+
+// JobName returns the name of the job
+func (j *Job) JobName() string {
+	return JobName
+}
+
+// RunFunc returns the func applying all policies
+func (j *Job) RunFunc(ctx context.Context) func() {
+	return func() {
+		_, _ = j.Run(ctx)
+	}
+}
+
+// Run applies all policies and returns their reports.
+// A failed policy does not prevent the others from being applied.
+func (j *Job) Run(ctx context.Context) ([]*Report, error) {
+	logger := logging.FromContext(ctx).With("job", JobName)
+	reports := make([]*Report, 0, len(j.Policies))
+	var lastErr error
+	for _, policy := range j.Policies {
+		report, err := Apply(ctx, j.Storage, policy, j.Options)
+		if err != nil {
+			logger.Errorw("failed to apply retention policy", "policy", policy.Name, "err", err)
+			lastErr = err
+		}
+		if report == nil {
+			continue
+		}
+		reports = append(reports, report)
+		logger.Infow("applied retention policy", "policy", report.Policy, "dryRun", report.DryRun,
+			"scanned", report.Scanned, "deleted", report.Deleted, "deletedByReason", report.DeletedByReason)
+		if report.DryRun {
+			for _, record := range report.Samples {
+				logger.Infow("record would be deleted", "policy", report.Policy, "cluster", record.Cluster,
+					"namespace", record.Namespace, "name", record.Name, "uid", record.UID, "reason", record.Reason)
+			}
+		}
+	}
+	return reports, lastErr
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	archivecap "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
+	archiveclient "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	defaultPageSize   = 500
+	defaultBatchSize  = 100
+	defaultMaxSamples = 100
+)
+
+// statusKey is the metadata key of record status, see archivev1alpha1.TopConditionToMetadata
+var statusKey = archivev1alpha1.MetadataKey("status")
+
+// Reason describe why a record is deleted
+type Reason string
+
+const (
+	// ReasonMaxAge the record is older than the max age
+	ReasonMaxAge Reason = "MaxAge"
+	// ReasonMaxCount the record exceeds the max count of its group
+	ReasonMaxCount Reason = "MaxCount"
+)
+
+// DeletedRecord describe a record deleted by a retention policy
+type DeletedRecord struct {
+	Cluster           string `json:"cluster,omitempty"`
+	Namespace         string `json:"namespace"`
+	Name              string `json:"name"`
+	UID               string `json:"uid"`
+	CreationTimestamp int64  `json:"creationTimestamp,omitempty"`
+	Reason            Reason `json:"reason"`
+}
+
+// Report describe the result of applying a retention policy
+type Report struct {
+	// Policy is the name of the applied policy
+	Policy string `json:"policy"`
+	// DryRun describe the records are only reported but not deleted
+	DryRun bool `json:"dryRun,omitempty"`
+	// Direct describe the records are deleted directly instead of soft delete
+	Direct bool `json:"direct,omitempty"`
+	// Scanned is the number of records evaluated
+	Scanned int `json:"scanned"`
+	// Deleted is the number of records deleted, or would be deleted in dry run
+	Deleted int `json:"deleted"`
+	// DeletedByReason is the number of deleted records of each reason
+	DeletedByReason map[Reason]int `json:"deletedByReason,omitempty"`
+	// Samples are the first deleted records, at most Options.MaxSamples records are kept
+	Samples []DeletedRecord `json:"samples,omitempty"`
+}
+
+// add counts the deleted record, which is kept as a sample if there are less than maxSamples
+func (r *Report) add(record DeletedRecord, maxSamples int) {
+	r.Deleted++
+	if r.DeletedByReason == nil {
+		r.DeletedByReason = map[Reason]int{}
+	}
+	r.DeletedByReason[record.Reason]++
+	if len(r.Samples) < maxSamples {
+		r.Samples = append(r.Samples, record)
+	}
+}
+
+// Options options for applying retention policies
+type Options struct {
+	// DryRun only reports records which would be deleted
+	DryRun bool
+	// Now returns the current time, defaults to time.Now
+	Now func() time.Time
+	// PageSize is the number of records fetched in each page
+	PageSize int
+	// BatchSize is the max number of records deleted in each DeleteBatch call
+	BatchSize int
+	// MaxSamples is the max number of deleted records kept in the report,
+	// defaults to 100 and no record is kept if it is negative
+	MaxSamples int
+}
+
+// Apply evaluates the policy against completed records in storage and deletes
+// the selected ones through DeleteBatch, unless it is a dry run.
+// Records are deleted in batches while they are streamed, so that the expired
+// records are never loaded at once.
+func Apply(ctx context.Context, storage archivecap.ArchiveCapable, policy archivev1alpha1.RetentionPolicy, opts Options) (*Report, error) {
+	if errs := policy.Validate(field.NewPath("policy")); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.MaxSamples == 0 {
+		opts.MaxSamples = defaultMaxSamples
+	}
+
+	report := &Report{Policy: policy.Name, DryRun: opts.DryRun, Direct: policy.Direct}
+	e := newEvaluator(policy, opts.Now())
+	d := newBatchDeleter(storage, policy.Direct, opts.BatchSize)
+	query := archivev1alpha1.Query{
+		Conditions: append(append([]archivev1alpha1.Condition{}, policy.Conditions...), archivev1alpha1.CompletedStatus()),
+	}
+	// newest first, so that the latest records of each group are counted first.
+	// Records are streamed by cursor, so deleting the returned records does not shift the later pages.
+	listOpts := &archivev1alpha1.ListOptions{
+		Orders: []archivev1alpha1.Order{{Field: archivev1alpha1.CreationTimestampField, Asc: false}},
+	}
+	listOpts.ItemsPerPage = opts.PageSize
+	err := archiveclient.StreamRecords(ctx, storage, query, listOpts, func(record *archivev1alpha1.Record) error {
+		report.Scanned++
+		reason := e.evaluate(&record.Spec)
+		if reason == "" {
+			return nil
+		}
+		deleted := DeletedRecord{
+			Cluster:           record.Spec.Cluster,
+			Namespace:         record.Spec.Namespace,
+			Name:              record.Spec.Name,
+			UID:               record.Spec.UID,
+			CreationTimestamp: record.Spec.CreationTimestamp,
+			Reason:            reason,
+		}
+		report.add(deleted, opts.MaxSamples)
+		if opts.DryRun {
+			return nil
+		}
+		return d.add(ctx, deleted)
+	})
+	if err == nil && !opts.DryRun {
+		err = d.flush(ctx)
+	}
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// batchDeleter deletes records in batches of each cluster
+type batchDeleter struct {
+	storage    archivecap.ArchiveCapable
+	deleteOpts *archivev1alpha1.DeleteOption
+	batchSize  int
+	// pending is the uids to be deleted of each cluster
+	pending map[string][]string
+}
+
+func newBatchDeleter(storage archivecap.ArchiveCapable, direct bool, batchSize int) *batchDeleter {
+	return &batchDeleter{
+		storage:    storage,
+		deleteOpts: &archivev1alpha1.DeleteOption{Direct: direct},
+		batchSize:  batchSize,
+		pending:    map[string][]string{},
+	}
+}
+
+// add adds the record to the batch of its cluster, the batch is deleted once it is full
+func (d *batchDeleter) add(ctx context.Context, record DeletedRecord) error {
+	uids := append(d.pending[record.Cluster], record.UID)
+	if len(uids) < d.batchSize {
+		d.pending[record.Cluster] = uids
+		return nil
+	}
+	delete(d.pending, record.Cluster)
+	return d.deleteBatch(ctx, record.Cluster, uids)
+}
+
+// flush deletes the pending records of all clusters
+func (d *batchDeleter) flush(ctx context.Context) error {
+	clusters := make([]string, 0, len(d.pending))
+	for cluster := range d.pending {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	for _, cluster := range clusters {
+		uids := d.pending[cluster]
+		delete(d.pending, cluster)
+		if err := d.deleteBatch(ctx, cluster, uids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *batchDeleter) deleteBatch(ctx context.Context, cluster string, uids []string) error {
+	conditions := []archivev1alpha1.Condition{
+		archivev1alpha1.Cluster(cluster),
+		archivev1alpha1.In(archivev1alpha1.UIDField, uids...),
+	}
+	return d.storage.DeleteBatch(ctx, conditions, d.deleteOpts)
+}
+
+// evaluator decides whether records should be deleted,
+// records must be evaluated from the newest to the oldest
+type evaluator struct {
+	policy archivev1alpha1.RetentionPolicy
+	// expireBefore is the creation timestamp before which records are expired
+	expireBefore int64
+
+	counts       map[string]int
+	statusCounts map[string]int
+}
+
+func newEvaluator(policy archivev1alpha1.RetentionPolicy, now time.Time) *evaluator {
+	e := &evaluator{
+		policy:       policy,
+		counts:       map[string]int{},
+		statusCounts: map[string]int{},
+	}
+	if policy.MaxAge != nil {
+		e.expireBefore = now.Add(-policy.MaxAge.Duration).Unix()
+	}
+	return e
+}
+
+// evaluate returns the reason if the record should be deleted, otherwise returns empty
+func (e *evaluator) evaluate(spec *archivev1alpha1.RecordSpec) (reason Reason) {
+	if limit := e.policy.MaxCount; limit != nil && !limit.IsNotSet() {
+		key := groupKey(spec, limit.GetGroupBy())
+		e.counts[key]++
+		if e.counts[key] > *limit.Count {
+			reason = ReasonMaxCount
+		}
+	}
+	if reason == "" && e.policy.MaxAge != nil && spec.CreationTimestamp < e.expireBefore {
+		reason = ReasonMaxAge
+	}
+
+	if keep := e.policy.KeepLastPerStatus; keep != nil && !keep.IsNotSet() {
+		status, _ := spec.FieldValue(statusKey)
+		key := fmt.Sprintf("%s/%v", groupKey(spec, keep.GetGroupBy()), status)
+		e.statusCounts[key]++
+		if e.statusCounts[key] <= *keep.Count {
+			return ""
+		}
+	}
+	return reason
+}
+
+// groupKey returns the key of the group which the record belongs to
+func groupKey(spec *archivev1alpha1.RecordSpec, groupBy archivev1alpha1.RetentionGroupBy) string {
+	if groupBy == archivev1alpha1.RetentionGroupByNamespace {
+		return fmt.Sprintf("%s/%s", spec.Cluster, spec.Namespace)
+	}
+	if spec.TopOwnerUID == "" {
+		// records without top owner are grouped by themselves
+		return fmt.Sprintf("%s/%s", spec.Cluster, spec.UID)
+	}
+	return fmt.Sprintf("%s/%s", spec.TopOwnerCluster, spec.TopOwnerUID)
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	archivev1alpha1 "github.com/katanomi/pkg/apis/archive/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	archiveclient "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage/memory"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var now = time.Unix(10000, 0)
+
+// contextStorage makes the memory archive usable as the storage in context
+type contextStorage struct {
+	*memory.Archive
+}
+
+func (s contextStorage) StreamRecords(ctx context.Context, query archivev1alpha1.Query, opts *archivev1alpha1.ListOptions, fn archiveclient.RecordFunc) error {
+	return archiveclient.StreamRecords(ctx, s, query, opts, fn)
+}
+
+// newArchive returns an archive with records of two owners, each owner has 5 records
+// created at 1000, 2000 ... 5000 with status True and False alternately, plus a running record.
+func newArchive(g *gomega.WithT) *memory.Archive {
+	ctx := context.Background()
+	archive := memory.NewArchive("memory")
+	for _, owner := range []string{"a", "b"} {
+		for i := 1; i <= 5; i++ {
+			status := "True"
+			if i%2 == 0 {
+				status = "False"
+			}
+			record := &archivev1alpha1.Record{Spec: archivev1alpha1.RecordSpec{
+				Cluster:           "cluster",
+				Namespace:         "ns-" + owner,
+				Name:              fmt.Sprintf("%s-%d", owner, i),
+				UID:               fmt.Sprintf("%s-%d", owner, i),
+				TopOwnerCluster:   "cluster",
+				TopOwnerUID:       owner,
+				CreationTimestamp: int64(i * 1000),
+				Metadata:          map[string]string{"status": status},
+			}}
+			g.Expect(archive.Upsert(ctx, record)).To(gomega.Succeed())
+		}
+		running := &archivev1alpha1.Record{Spec: archivev1alpha1.RecordSpec{
+			Cluster:         "cluster",
+			Namespace:       "ns-" + owner,
+			Name:            owner + "-running",
+			UID:             owner + "-running",
+			TopOwnerCluster: "cluster",
+			TopOwnerUID:     owner,
+			Metadata:        map[string]string{"status": "Unknown"},
+		}}
+		g.Expect(archive.Upsert(ctx, running)).To(gomega.Succeed())
+	}
+	return archive
+}
+
+func deletedNames(report *Report) []string {
+	names := make([]string, 0, len(report.Samples))
+	for _, record := range report.Samples {
+		names = append(names, record.Name)
+	}
+	return names
+}
+
+func remainingNames(g *gomega.WithT, archive *memory.Archive, withDeleted bool) []string {
+	list, err := archive.ListRecords(context.Background(), archivev1alpha1.Query{}, &archivev1alpha1.ListOptions{
+		Pager:           metav1alpha1.Pager{ItemsPerPage: 100},
+		WithDeletedData: withDeleted,
+	})
+	g.Expect(err).To(gomega.BeNil())
+	names := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		names = append(names, item.Spec.Name)
+	}
+	return names
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		policy   archivev1alpha1.RetentionPolicy
+		expected []string
+	}{
+		"max age": {
+			policy: archivev1alpha1.RetentionPolicy{
+				Name:   "max-age",
+				MaxAge: &metav1.Duration{Duration: 7500 * time.Second},
+			},
+			expected: []string{"a-2", "a-1", "b-2", "b-1"},
+		},
+		"max count per top owner": {
+			policy: archivev1alpha1.RetentionPolicy{
+				Name:     "max-count",
+				MaxCount: &archivev1alpha1.RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(3)}},
+			},
+			expected: []string{"a-2", "a-1", "b-2", "b-1"},
+		},
+		"max count per namespace with conditions": {
+			policy: archivev1alpha1.RetentionPolicy{
+				Name:       "max-count-namespace",
+				Conditions: []archivev1alpha1.Condition{archivev1alpha1.Namespace("ns-a")},
+				MaxCount: &archivev1alpha1.RetentionCountLimit{
+					HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(4)},
+					GroupBy:       archivev1alpha1.RetentionGroupByNamespace,
+				},
+			},
+			expected: []string{"a-1"},
+		},
+		"keep last per status": {
+			policy: archivev1alpha1.RetentionPolicy{
+				Name:              "keep-last",
+				MaxCount:          &archivev1alpha1.RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(1)}},
+				KeepLastPerStatus: &archivev1alpha1.RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(1)}},
+			},
+			// a-5 is the latest True and a-4 is the latest False
+			expected: []string{"a-3", "a-2", "a-1", "b-3", "b-2", "b-1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			archive := newArchive(g)
+
+			report, err := Apply(ctx, archive, tt.policy, Options{DryRun: true, Now: func() time.Time { return now }, PageSize: 2})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(report.DryRun).To(gomega.BeTrue())
+			g.Expect(deletedNames(report)).To(gomega.ConsistOf(tt.expected))
+			g.Expect(remainingNames(g, archive, false)).To(gomega.HaveLen(12))
+
+			report, err = Apply(ctx, archive, tt.policy, Options{Now: func() time.Time { return now }, BatchSize: 1})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(deletedNames(report)).To(gomega.ConsistOf(tt.expected))
+			g.Expect(remainingNames(g, archive, false)).To(gomega.HaveLen(12 - len(tt.expected)))
+			// soft deleted
+			g.Expect(remainingNames(g, archive, true)).To(gomega.HaveLen(12))
+		})
+	}
+}
+
+func TestApplyDirect(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	archive := newArchive(g)
+	policy := archivev1alpha1.RetentionPolicy{
+		Name:   "direct",
+		MaxAge: &metav1.Duration{Duration: time.Second},
+		Direct: true,
+	}
+	report, err := Apply(context.Background(), archive, policy, Options{Now: func() time.Time { return now }})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(report.Deleted).To(gomega.Equal(10))
+	// running records are never deleted
+	g.Expect(remainingNames(g, archive, true)).To(gomega.ConsistOf("a-running", "b-running"))
+
+	_, err = Apply(context.Background(), archive, archivev1alpha1.RetentionPolicy{}, Options{})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+// pagedStorage records the number of pages listed before each DeleteBatch call
+type pagedStorage struct {
+	*memory.Archive
+	pages         int
+	pagesOfDelete []int
+}
+
+func (s *pagedStorage) ListRecords(ctx context.Context, query archivev1alpha1.Query, opts *archivev1alpha1.ListOptions) (*archivev1alpha1.RecordList, error) {
+	s.pages++
+	return s.Archive.ListRecords(ctx, query, opts)
+}
+
+func (s *pagedStorage) DeleteBatch(ctx context.Context, conditions []archivev1alpha1.Condition, opts *archivev1alpha1.DeleteOption) error {
+	s.pagesOfDelete = append(s.pagesOfDelete, s.pages)
+	return s.Archive.DeleteBatch(ctx, conditions, opts)
+}
+
+func TestApplyStreamDeletion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	storage := &pagedStorage{Archive: newArchive(g)}
+	policy := archivev1alpha1.RetentionPolicy{
+		Name:   "max-age",
+		MaxAge: &metav1.Duration{Duration: time.Second},
+		Direct: true,
+	}
+	report, err := Apply(context.Background(), storage, policy, Options{Now: func() time.Time { return now }, PageSize: 2, BatchSize: 3})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(report.Deleted).To(gomega.Equal(10))
+	g.Expect(remainingNames(g, storage.Archive, true)).To(gomega.ConsistOf("a-running", "b-running"))
+
+	// full batches are deleted while the records are still being listed
+	g.Expect(storage.pagesOfDelete).To(gomega.Equal([]int{2, 3, 5, 6}))
+	g.Expect(storage.pages).To(gomega.Equal(6))
+}
+
+func TestApplyMaxSamples(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	archive := newArchive(g)
+	policy := archivev1alpha1.RetentionPolicy{
+		Name:   "max-age",
+		MaxAge: &metav1.Duration{Duration: time.Second},
+	}
+	report, err := Apply(context.Background(), archive, policy, Options{DryRun: true, Now: func() time.Time { return now }, MaxSamples: 3})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(report.Deleted).To(gomega.Equal(10))
+	g.Expect(report.DeletedByReason).To(gomega.Equal(map[Reason]int{ReasonMaxAge: 10}))
+	g.Expect(report.Samples).To(gomega.HaveLen(3))
+
+	report, err = Apply(context.Background(), archive, policy, Options{Now: func() time.Time { return now }, MaxSamples: -1})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(report.Deleted).To(gomega.Equal(10))
+	g.Expect(report.Samples).To(gomega.BeEmpty())
+	g.Expect(remainingNames(g, archive, false)).To(gomega.ConsistOf("a-running", "b-running"))
+}
+
+func TestJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	archive := newArchive(g)
+	policy := archivev1alpha1.RetentionPolicy{
+		Name:     "max-count",
+		MaxCount: &archivev1alpha1.RetentionCountLimit{HistoryLimits: metav1alpha1.HistoryLimits{Count: pointer.Int(4)}},
+	}
+	job := NewJob(nil, Options{}, policy)
+	g.Expect(job.JobName()).To(gomega.Equal(JobName))
+	g.Expect(job.Setup(context.Background(), nil, nil)).NotTo(gomega.Succeed())
+
+	ctx := archiveclient.WithStorage(context.Background(), contextStorage{archive})
+	g.Expect(job.Setup(ctx, nil, nil)).To(gomega.Succeed())
+	job.RunFunc(ctx)()
+	g.Expect(remainingNames(g, archive, false)).To(gomega.HaveLen(10))
+}
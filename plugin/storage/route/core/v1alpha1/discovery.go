@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	restfulspec "github.com/emicklei/go-restful-openapi/v2"
+	"github.com/emicklei/go-restful/v3"
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	"github.com/katanomi/pkg/plugin/storage"
+	corev1alpha1 "github.com/katanomi/pkg/plugin/storage/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type discovery struct {
+	discovery v1alpha1.StorageDiscovery
+	tags      []string
+}
+
+func (d *discovery) GroupVersion() schema.GroupVersion {
+	return corev1alpha1.CoreV1alpha1GV
+}
+
+// NewDiscovery new route for discovering capabilities served by the storage plugin
+func NewDiscovery(capabilities []v1alpha1.StorageCapability) storage.VersionedRouter {
+	d := &discovery{tags: []string{"discovery"}}
+	d.discovery.SetGroupVersionKind(v1alpha1.StorageDiscoveryGVK)
+	d.discovery.Capabilities = capabilities
+	return d
+}
+
+func (d *discovery) Register(ctx context.Context, ws *restful.WebService) error {
+	ws.Route(
+		ws.GET("/capabilities").To(d.Discover).
+			Doc("Storage plugin lists served capabilities, versions and optional features").
+			Metadata(restfulspec.KeyOpenAPITags, d.tags).
+			Returns(http.StatusOK, "OK", v1alpha1.StorageDiscovery{}),
+	)
+	return nil
+}
+
+// Discover is handler of discovery route
+func (d *discovery) Discover(req *restful.Request, resp *restful.Response) {
+	resp.WriteHeaderAndEntity(http.StatusOK, d.discovery)
+}
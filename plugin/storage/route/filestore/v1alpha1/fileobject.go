@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	kclient "github.com/katanomi/pkg/client"
 
@@ -129,7 +131,8 @@ func (a *fileObject) PutFileObject(req *restful.Request, resp *restful.Response)
 	resp.WriteHeaderAndEntity(http.StatusCreated, newMeta)
 }
 
-// GetFileObject is handler of put file object
+// GetFileObject is handler of get file object.
+// A single byte range could be requested with the Range header to download partial content.
 func (a *fileObject) GetFileObject(req *restful.Request, resp *restful.Response) {
 	pluginName := path.Parameter(req, "storagePlugin")
 	objectName := path.Parameter(req, "objectName")
@@ -141,13 +144,88 @@ func (a *fileObject) GetFileObject(req *restful.Request, resp *restful.Response)
 		kerrors.HandleError(req, resp, err)
 		return
 	}
+	defer fileObject.FileReadCloser.Close()
 
-	_, err = io.Copy(resp.ResponseWriter, fileObject.FileReadCloser)
-	if err != nil {
-		kerrors.HandleError(req, resp, err)
+	header := resp.Header()
+	header.Set(v1alpha1.HeaderFileMeta, fileObject.FileMeta.Encode())
+	header.Set("Accept-Ranges", "bytes")
+	if fileObject.Spec.ContentType != "" {
+		header.Set("Content-Type", fileObject.Spec.ContentType)
+	}
+
+	if seeker, ok := fileObject.FileReadCloser.(io.ReadSeeker); ok {
+		// handles range, conditional and HEAD requests
+		http.ServeContent(resp.ResponseWriter, req.Request, fileObject.Name, fileObject.LastModified(), seeker)
 		return
 	}
 
+	if err = serveRange(resp.ResponseWriter, req.Request, fileObject.FileReadCloser, fileObject.Spec.ContentLength); err != nil {
+		logging.FromContext(ctx).Errorw("GetFileObject err", "err", err, "objectName", objectName)
+	}
+}
+
+// serveRange serves the content of a reader which is not seekable,
+// a single byte range is served by skipping the leading content.
+// The whole content is served if there is no valid range or the size is unknown.
+func serveRange(w http.ResponseWriter, r *http.Request, reader io.Reader, size int64) error {
+	start, end, ok := parseRange(r.Header.Get("Range"), size)
+	if !ok {
+		_, err := io.Copy(w, reader)
+		return err
+	}
+	if start >= size {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return nil
+	}
+
+	if _, err := io.CopyN(io.Discard, reader, start); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	w.Header().Set("Content-Length", strconv.FormatInt(end-start+1, 10))
+	w.WriteHeader(http.StatusPartialContent)
+	_, err := io.CopyN(w, reader, end-start+1)
+	return err
+}
+
+// parseRange parses a single byte range like bytes=0-99, bytes=100- or bytes=-100.
+// The returned end is inclusive and limited by the size.
+func parseRange(value string, size int64) (start, end int64, ok bool) {
+	spec, found := strings.CutPrefix(value, "bytes=")
+	if !found || size <= 0 || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	var err error
+	if first == "" {
+		// suffix range of the last bytes
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, size - 1, true
+	}
+	if start, err = strconv.ParseInt(first, 10, 64); err != nil || start < 0 {
+		return 0, 0, false
+	}
+	end = size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+	return start, end, true
 }
 
 // DeleteFileObject is handler of delete file object
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	filestoreroute "github.com/katanomi/pkg/plugin/storage/route/filestore/v1alpha1"
+	"github.com/katanomi/pkg/testing/mock/github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileObject.GET", func() {
+	var (
+		ctx        context.Context
+		capability *v1alpha1.MockFileObjectInterface
+		container  *restful.Container
+		fileObject filestorev1alpha1.FileObject
+		rangeValue string
+		recorder   *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		mockCtl := gomock.NewController(GinkgoT())
+		ctx = allowAllContext(mockCtl)
+		capability = v1alpha1.NewMockFileObjectInterface(mockCtl)
+		container = newContainer(ctx, filestoreroute.NewFileObject(capability))
+
+		fileObject = filestorev1alpha1.FileObject{}
+		fileObject.Name = "dir/file.txt"
+		fileObject.Spec.ContentType = "text/plain"
+		fileObject.Spec.ContentLength = 10
+		rangeValue = ""
+	})
+
+	JustBeforeEach(func() {
+		capability.EXPECT().GetFileObject(gomock.Any(), "dir/file.txt").Return(&fileObject, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/storageplugins/local/fileobjects/dir/file.txt", nil)
+		if rangeValue != "" {
+			req.Header.Set("Range", rangeValue)
+		}
+		recorder = dispatch(ctx, container, req)
+	})
+
+	Context("content is not seekable", func() {
+		BeforeEach(func() {
+			// io.NopCloser hides the io.Seeker of the reader
+			fileObject.FileReadCloser = io.NopCloser(strings.NewReader("0123456789"))
+		})
+
+		It("returns whole content with file meta", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal("0123456789"))
+			Expect(recorder.Header().Get("Accept-Ranges")).To(Equal("bytes"))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain"))
+			meta, err := storagev1alpha1.DecodeAsFileMeta(recorder.Header().Get(storagev1alpha1.HeaderFileMeta))
+			Expect(err).To(BeNil())
+			Expect(meta.Name).To(Equal("dir/file.txt"))
+		})
+
+		When("a range is requested", func() {
+			BeforeEach(func() { rangeValue = "bytes=2-5" })
+
+			It("returns partial content", func() {
+				Expect(recorder.Code).To(Equal(http.StatusPartialContent))
+				Expect(recorder.Body.String()).To(Equal("2345"))
+				Expect(recorder.Header().Get("Content-Range")).To(Equal("bytes 2-5/10"))
+			})
+		})
+
+		When("a suffix range is requested", func() {
+			BeforeEach(func() { rangeValue = "bytes=-3" })
+
+			It("returns the last bytes", func() {
+				Expect(recorder.Code).To(Equal(http.StatusPartialContent))
+				Expect(recorder.Body.String()).To(Equal("789"))
+				Expect(recorder.Header().Get("Content-Range")).To(Equal("bytes 7-9/10"))
+			})
+		})
+
+		When("the range is beyond the content", func() {
+			BeforeEach(func() { rangeValue = "bytes=20-" })
+
+			It("returns range not satisfiable", func() {
+				Expect(recorder.Code).To(Equal(http.StatusRequestedRangeNotSatisfiable))
+				Expect(recorder.Header().Get("Content-Range")).To(Equal("bytes */10"))
+			})
+		})
+
+		When("multiple ranges are requested", func() {
+			BeforeEach(func() { rangeValue = "bytes=0-1,4-5" })
+
+			It("returns whole content", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(recorder.Body.String()).To(Equal("0123456789"))
+			})
+		})
+	})
+
+	Context("content is seekable", func() {
+		BeforeEach(func() {
+			fileObject.FileReadCloser = readSeekCloser{strings.NewReader("0123456789")}
+			rangeValue = "bytes=5-"
+		})
+
+		It("returns partial content", func() {
+			Expect(recorder.Code).To(Equal(http.StatusPartialContent))
+			Expect(recorder.Body.String()).To(Equal("56789"))
+			Expect(recorder.Header().Get("Content-Range")).To(Equal("bytes 5-9/10"))
+		})
+	})
+})
+
+type readSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekCloser) Close() error { return nil }
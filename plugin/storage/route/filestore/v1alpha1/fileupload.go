@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	restfulspec "github.com/emicklei/go-restful-openapi/v2"
+	"github.com/emicklei/go-restful/v3"
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	kclient "github.com/katanomi/pkg/client"
+	kerrors "github.com/katanomi/pkg/errors"
+	"github.com/katanomi/pkg/plugin/path"
+	"github.com/katanomi/pkg/plugin/storage"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/logging"
+)
+
+type fileUpload struct {
+	impl filestorev1alpha1.FileUploadCapable
+	tags []string
+	*zap.SugaredLogger
+}
+
+// NewFileUpload new route for resumable chunked uploads
+func NewFileUpload(impl filestorev1alpha1.FileUploadCapable) storage.VersionedRouter {
+	return &fileUpload{
+		impl: impl,
+		tags: []string{"file-store"},
+	}
+}
+
+func (a *fileUpload) GroupVersion() schema.GroupVersion {
+	return filestorev1alpha1.FileStoreV1alpha1GV
+}
+
+func (a *fileUpload) Register(ctx context.Context, ws *restful.WebService) error {
+	storagePluginParam := ws.PathParameter("storagePlugin", "storage plugin to be used")
+	uploadIDParam := ws.PathParameter("uploadID", "id of the upload session")
+	indexParam := ws.PathParameter("index", "index of the chunk, starts from 0")
+
+	a.SugaredLogger = logging.FromContext(ctx).With("resource", "fileupload")
+
+	ws.Route(
+		ws.POST("storageplugins/{storagePlugin}/fileuploads").To(a.CreateFileUpload).
+			Filter(kclient.SubjectReviewFilterForResource(ctx, v1alpha1.FileUploadResourceAttributes("create"), "", "")).
+			Doc("Storage plugin start a resumable upload of a file object").
+			Param(storagePluginParam).
+			Reads(v1alpha1.FileMeta{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusCreated, "Created", v1alpha1.FileUpload{}),
+	)
+
+	ws.Route(
+		ws.GET("storageplugins/{storagePlugin}/fileuploads/{uploadID}").To(a.GetFileUpload).
+			Filter(kclient.SubjectReviewFilterForResource(ctx, v1alpha1.FileUploadResourceAttributes("get"), "", "")).
+			Doc("Storage plugin get an upload with uploaded chunks").
+			Param(storagePluginParam).Param(uploadIDParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", v1alpha1.FileUpload{}),
+	)
+
+	ws.Route(
+		ws.PUT("storageplugins/{storagePlugin}/fileuploads/{uploadID}/chunks/{index}").To(a.PutFileUploadChunk).
+			Filter(kclient.SubjectReviewFilterForResource(ctx, v1alpha1.FileUploadResourceAttributes("update"), "", "")).
+			AllowedMethodsWithoutContentType([]string{http.MethodPut}).
+			Consumes(restful.MIME_OCTET).
+			Doc("Storage plugin upload a chunk, the sha256 checksum of the chunk is required in header").
+			Param(storagePluginParam).Param(uploadIDParam).Param(indexParam).
+			Param(ws.HeaderParameter(v1alpha1.HeaderChunkSHA256, "hex encoded sha256 checksum of the chunk")).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", v1alpha1.FileUploadChunk{}),
+	)
+
+	ws.Route(
+		ws.POST("storageplugins/{storagePlugin}/fileuploads/{uploadID}/complete").To(a.CompleteFileUpload).
+			Filter(kclient.SubjectReviewFilterForResource(ctx, v1alpha1.FileUploadResourceAttributes("update"), "", "")).
+			Doc("Storage plugin assemble the uploaded chunks into the file object").
+			Param(storagePluginParam).Param(uploadIDParam).
+			Reads(v1alpha1.FileUploadCompleteOptions{}).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusCreated, "Created", v1alpha1.FileMeta{}),
+	)
+
+	ws.Route(
+		ws.DELETE("storageplugins/{storagePlugin}/fileuploads/{uploadID}").To(a.AbortFileUpload).
+			Filter(kclient.SubjectReviewFilterForResource(ctx, v1alpha1.FileUploadResourceAttributes("delete"), "", "")).
+			Doc("Storage plugin abort an upload and remove the uploaded chunks").
+			Param(storagePluginParam).Param(uploadIDParam).
+			Metadata(restfulspec.KeyOpenAPITags, a.tags).
+			Returns(http.StatusOK, "OK", nil),
+	)
+
+	return nil
+}
+
+// CreateFileUpload is handler of creating file upload
+func (a *fileUpload) CreateFileUpload(req *restful.Request, resp *restful.Response) {
+	pluginName := path.Parameter(req, "storagePlugin")
+
+	meta := v1alpha1.FileMeta{}
+	if err := req.ReadEntity(&meta); err != nil {
+		kerrors.HandleError(req, resp, errors.NewBadRequest(err.Error()))
+		return
+	}
+
+	ctx := req.Request.Context()
+	upload, err := a.impl.CreateFileUpload(storage.CtxWithPluginName(ctx, pluginName), meta)
+	if err != nil {
+		a.Errorw("CreateFileUpload err", "err", err, "objectName", meta.Name)
+		kerrors.HandleError(req, resp, err)
+		return
+	}
+	resp.WriteHeaderAndEntity(http.StatusCreated, upload)
+}
+
+// GetFileUpload is handler of getting file upload
+func (a *fileUpload) GetFileUpload(req *restful.Request, resp *restful.Response) {
+	pluginName := path.Parameter(req, "storagePlugin")
+	uploadID := path.Parameter(req, "uploadID")
+
+	ctx := req.Request.Context()
+	upload, err := a.impl.GetFileUpload(storage.CtxWithPluginName(ctx, pluginName), uploadID)
+	if err != nil {
+		kerrors.HandleError(req, resp, err)
+		return
+	}
+	resp.WriteHeaderAndEntity(http.StatusOK, upload)
+}
+
+// PutFileUploadChunk is handler of uploading a chunk
+func (a *fileUpload) PutFileUploadChunk(req *restful.Request, resp *restful.Response) {
+	pluginName := path.Parameter(req, "storagePlugin")
+	uploadID := path.Parameter(req, "uploadID")
+
+	index, err := strconv.Atoi(path.Parameter(req, "index"))
+	if err != nil || index < 0 {
+		kerrors.HandleError(req, resp, errors.NewBadRequest(fmt.Sprintf("invalid chunk index %q", path.Parameter(req, "index"))))
+		return
+	}
+	checksum := req.HeaderParameter(v1alpha1.HeaderChunkSHA256)
+	if checksum == "" {
+		kerrors.HandleError(req, resp, errors.NewBadRequest(fmt.Sprintf("header %s is required", v1alpha1.HeaderChunkSHA256)))
+		return
+	}
+
+	chunk := &filestorev1alpha1.FileChunk{
+		FileUploadChunk: v1alpha1.FileUploadChunk{Index: index, SHA256: checksum},
+		ChunkReadCloser: req.Request.Body,
+	}
+	ctx := req.Request.Context()
+	uploaded, err := a.impl.PutFileUploadChunk(storage.CtxWithPluginName(ctx, pluginName), uploadID, chunk)
+	if err != nil {
+		a.Errorw("PutFileUploadChunk err", "err", err, "uploadID", uploadID, "index", index)
+		kerrors.HandleError(req, resp, err)
+		return
+	}
+	resp.WriteHeaderAndEntity(http.StatusOK, uploaded)
+}
+
+// CompleteFileUpload is handler of completing file upload
+func (a *fileUpload) CompleteFileUpload(req *restful.Request, resp *restful.Response) {
+	pluginName := path.Parameter(req, "storagePlugin")
+	uploadID := path.Parameter(req, "uploadID")
+
+	opts := v1alpha1.FileUploadCompleteOptions{}
+	if req.Request.ContentLength != 0 {
+		if err := req.ReadEntity(&opts); err != nil {
+			kerrors.HandleError(req, resp, errors.NewBadRequest(err.Error()))
+			return
+		}
+	}
+
+	ctx := req.Request.Context()
+	meta, err := a.impl.CompleteFileUpload(storage.CtxWithPluginName(ctx, pluginName), uploadID, opts)
+	if err != nil {
+		a.Errorw("CompleteFileUpload err", "err", err, "uploadID", uploadID)
+		kerrors.HandleError(req, resp, err)
+		return
+	}
+	resp.WriteHeaderAndEntity(http.StatusCreated, meta)
+}
+
+// AbortFileUpload is handler of aborting file upload
+func (a *fileUpload) AbortFileUpload(req *restful.Request, resp *restful.Response) {
+	pluginName := path.Parameter(req, "storagePlugin")
+	uploadID := path.Parameter(req, "uploadID")
+
+	ctx := req.Request.Context()
+	if err := a.impl.AbortFileUpload(storage.CtxWithPluginName(ctx, pluginName), uploadID); err != nil {
+		kerrors.HandleError(req, resp, err)
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	storagev1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	pkgclient "github.com/katanomi/pkg/client"
+	"github.com/katanomi/pkg/plugin/storage"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	filestoreroute "github.com/katanomi/pkg/plugin/storage/route/filestore/v1alpha1"
+	"github.com/katanomi/pkg/testing/mock/github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
+	mockClient "github.com/katanomi/pkg/testing/mock/sigs.k8s.io/controller-runtime/pkg/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apiserveruser "k8s.io/apiserver/pkg/authentication/user"
+	apiserverrequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// allowAllContext returns a context whose subject access reviews are always allowed
+func allowAllContext(mockCtl *gomock.Controller) context.Context {
+	mClient := mockClient.NewMockClient(mockCtl)
+	mClient.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&v1.SelfSubjectAccessReview{})).
+		DoAndReturn(func(_ context.Context, review *v1.SelfSubjectAccessReview, _ ...interface{}) error {
+			review.Status.Allowed = true
+			return nil
+		}).AnyTimes()
+	ctx := pkgclient.WithClient(context.Background(), mClient)
+	return apiserverrequest.WithUser(ctx, &apiserveruser.DefaultInfo{Name: "system:serviceaccount:devops:foo"})
+}
+
+func newContainer(ctx context.Context, router storage.VersionedRouter) *restful.Container {
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	// same as the web services created by route.NewServicesWithContext
+	ws := &restful.WebService{}
+	ws.Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+	Expect(router.Register(ctx, ws)).Should(Succeed())
+	container.Add(ws)
+	return container
+}
+
+func dispatch(ctx context.Context, container *restful.Container, req *http.Request) *httptest.ResponseRecorder {
+	req = req.WithContext(ctx)
+	// clients must accept json or 406 status code will be returned
+	req.Header.Set("Accept", restful.MIME_JSON)
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+	return recorder
+}
+
+var _ = Describe("FileUpload", func() {
+	var (
+		ctx        context.Context
+		capability *v1alpha1.MockFileUploadCapable
+		container  *restful.Container
+		upload     storagev1alpha1.FileUpload
+	)
+
+	BeforeEach(func() {
+		mockCtl := gomock.NewController(GinkgoT())
+		ctx = allowAllContext(mockCtl)
+		capability = v1alpha1.NewMockFileUploadCapable(mockCtl)
+		container = newContainer(ctx, filestoreroute.NewFileUpload(capability))
+
+		upload = storagev1alpha1.FileUpload{}
+		upload.Name = "0123456789abcdef0123456789abcdef"
+		upload.Spec.FileMeta.Name = "dir/file.txt"
+	})
+
+	It("creates uploads", func() {
+		capability.EXPECT().CreateFileUpload(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, meta storagev1alpha1.FileMeta) (*storagev1alpha1.FileUpload, error) {
+				Expect(meta.Name).To(Equal("dir/file.txt"))
+				return &upload, nil
+			})
+
+		body, _ := json.Marshal(upload.Spec.FileMeta)
+		req := httptest.NewRequest(http.MethodPost, "/storageplugins/local/fileuploads", bytes.NewReader(body))
+		req.Header.Set("Content-Type", restful.MIME_JSON)
+		recorder := dispatch(ctx, container, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusCreated))
+		got := storagev1alpha1.FileUpload{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &got)).To(Succeed())
+		Expect(got.Name).To(Equal(upload.Name))
+	})
+
+	It("gets uploads", func() {
+		capability.EXPECT().GetFileUpload(gomock.Any(), upload.Name).Return(&upload, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/storageplugins/local/fileuploads/"+upload.Name, nil)
+		recorder := dispatch(ctx, container, req)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		capability.EXPECT().GetFileUpload(gomock.Any(), "missing").
+			Return(nil, errors.NewNotFound(storagev1alpha1.GroupVersion.WithResource("fileuploads").GroupResource(), "missing"))
+		req = httptest.NewRequest(http.MethodGet, "/storageplugins/local/fileuploads/missing", nil)
+		recorder = dispatch(ctx, container, req)
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("puts chunks with checksum", func() {
+		checksum := storagev1alpha1.ChunkChecksum([]byte("hello"))
+		capability.EXPECT().PutFileUploadChunk(gomock.Any(), upload.Name, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, chunk *filestorev1alpha1.FileChunk) (*storagev1alpha1.FileUploadChunk, error) {
+				Expect(chunk.Index).To(Equal(2))
+				Expect(chunk.SHA256).To(Equal(checksum))
+				data, err := io.ReadAll(chunk.ChunkReadCloser)
+				Expect(err).To(BeNil())
+				return &storagev1alpha1.FileUploadChunk{Index: chunk.Index, Size: int64(len(data)), SHA256: chunk.SHA256}, nil
+			})
+
+		req := httptest.NewRequest(http.MethodPut, "/storageplugins/local/fileuploads/"+upload.Name+"/chunks/2", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", restful.MIME_OCTET)
+		req.Header.Set(storagev1alpha1.HeaderChunkSHA256, checksum)
+		recorder := dispatch(ctx, container, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		got := storagev1alpha1.FileUploadChunk{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &got)).To(Succeed())
+		Expect(got).To(Equal(storagev1alpha1.FileUploadChunk{Index: 2, Size: 5, SHA256: checksum}))
+	})
+
+	It("rejects chunks without checksum or with invalid index", func() {
+		req := httptest.NewRequest(http.MethodPut, "/storageplugins/local/fileuploads/"+upload.Name+"/chunks/0", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", restful.MIME_OCTET)
+		Expect(dispatch(ctx, container, req).Code).To(Equal(http.StatusBadRequest))
+
+		req = httptest.NewRequest(http.MethodPut, "/storageplugins/local/fileuploads/"+upload.Name+"/chunks/first", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", restful.MIME_OCTET)
+		req.Header.Set(storagev1alpha1.HeaderChunkSHA256, storagev1alpha1.ChunkChecksum([]byte("hello")))
+		Expect(dispatch(ctx, container, req).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("completes uploads", func() {
+		meta := upload.Spec.FileMeta
+		capability.EXPECT().CompleteFileUpload(gomock.Any(), upload.Name, storagev1alpha1.FileUploadCompleteOptions{SHA256: "abc"}).
+			Return(&meta, nil)
+		capability.EXPECT().CompleteFileUpload(gomock.Any(), upload.Name, storagev1alpha1.FileUploadCompleteOptions{}).
+			Return(&meta, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/storageplugins/local/fileuploads/"+upload.Name+"/complete",
+			strings.NewReader(`{"sha256":"abc"}`))
+		req.Header.Set("Content-Type", restful.MIME_JSON)
+		Expect(dispatch(ctx, container, req).Code).To(Equal(http.StatusCreated))
+
+		// options are optional
+		req = httptest.NewRequest(http.MethodPost, "/storageplugins/local/fileuploads/"+upload.Name+"/complete", nil)
+		req.Header.Set("Content-Type", restful.MIME_JSON)
+		Expect(dispatch(ctx, container, req).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("aborts uploads", func() {
+		capability.EXPECT().AbortFileUpload(gomock.Any(), upload.Name).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/storageplugins/local/fileuploads/"+upload.Name, nil)
+		Expect(dispatch(ctx, container, req).Code).To(Equal(http.StatusOK))
+	})
+})
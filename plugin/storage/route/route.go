@@ -24,6 +24,7 @@ import (
 	"github.com/emicklei/go-restful/v3"
 	"github.com/katanomi/pkg/plugin/client"
 	"github.com/katanomi/pkg/plugin/storage"
+	"github.com/katanomi/pkg/plugin/storage/capabilities"
 	archivev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/archive/v1alpha1"
 	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
 	"github.com/katanomi/pkg/plugin/storage/core/v1alpha1"
@@ -43,6 +44,8 @@ func NewServicesWithContext(ctx context.Context, c client.Interface, filters ...
 	if len(routes) == 0 {
 		return nil, fmt.Errorf("no route for provider %s", c.Path())
 	}
+	// discovery is always served so that clients could check capabilities before calling them
+	routes = append(routes, corev1alpha1.NewDiscovery(capabilities.Discover(c)))
 
 	pluginAPIPath := GetPluginAPIPath(c)
 	// Nesting web services haven't been implemented so far, we return multiple webservices here.
@@ -87,6 +90,10 @@ func match(c client.Interface) []storage.VersionedRouter {
 		routes = append(routes, filestoreroute.NewFileMeta(filestore))
 	}
 
+	if upload, ok := c.(filestorev1alpha1.FileUploadCapable); ok {
+		routes = append(routes, filestoreroute.NewFileUpload(upload))
+	}
+
 	if archive, ok := c.(archivev1alpha1.ArchiveCapable); ok {
 		routes = append(routes, archiveroute.NewArchive(archive))
 	}
@@ -18,8 +18,13 @@ package route
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 
 	"github.com/emicklei/go-restful/v3"
+	"github.com/katanomi/pkg/apis/storage/v1alpha1"
+	filestorev1alpha1 "github.com/katanomi/pkg/plugin/storage/capabilities/filestore/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -31,8 +36,30 @@ var _ = Describe("Test.Storage.Route.Service", func() {
 	Context("NewService with file store plugin", func() {
 		It("returns services with file store routes", func() {
 			svcs, _ = NewServicesWithContext(context.Background(), &fakeFileStorePlugin{})
-			Expect(svcs).To(HaveLen(1))
+			Expect(svcs).To(HaveLen(2))
 			Expect(svcs[0].RootPath()).To(Equal("/storage/fake-filestore/file-store/v1alpha1"))
+			// discovery is served under core
+			Expect(svcs[1].RootPath()).To(Equal("/storage/fake-filestore/core/v1alpha1"))
+		})
+
+		It("serves discovery of capabilities", func() {
+			svcs, _ = NewServicesWithContext(context.Background(), &fakeFileStorePlugin{})
+			container := restful.NewContainer()
+			for _, svc := range svcs {
+				container.Add(svc)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/storage/fake-filestore/core/v1alpha1/capabilities", nil)
+			recorder := httptest.NewRecorder()
+			container.ServeHTTP(recorder, req)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			discovery := v1alpha1.StorageDiscovery{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &discovery)).To(Succeed())
+			Expect(discovery.Kind).To(Equal("StorageDiscovery"))
+			Expect(discovery.Capabilities).To(Equal([]v1alpha1.StorageCapability{
+				{Name: "file-store", Version: "v1alpha1", Features: []string{filestorev1alpha1.FeatureRangedRead}},
+			}))
 		})
 	})
 
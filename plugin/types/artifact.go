@@ -23,7 +23,7 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/artifact.go github.com/katanomi/pkg/plugin/types ArtifactLister,ArtifactGetter,ArtifactDeleter,ProjectArtifactLister,ProjectArtifactGetter,ProjectArtifactDeleter,ProjectArtifactUploader,ProjectArtifactFileGetter,ArtifactTagDeleter,ArtifactTriggerRegister
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/artifact.go github.com/katanomi/pkg/plugin/types ArtifactLister,ArtifactGetter,ArtifactDeleter,ProjectArtifactLister,ProjectArtifactGetter,ProjectArtifactDeleter,ProjectArtifactUploader,ProjectArtifactFileGetter,ArtifactTagDeleter,ArtifactTriggerRegister,ArtifactPromoter
 
 // ArtifactLister list artifact
 type ArtifactLister interface {
@@ -79,6 +79,13 @@ type ArtifactTagDeleter interface {
 	DeleteArtifactTag(ctx context.Context, params metav1alpha1.ArtifactTagOptions) error
 }
 
+// ArtifactPromoter promote an artifact with its tags and properties
+// to another project or registry inside the tool
+type ArtifactPromoter interface {
+	Interface
+	PromoteArtifact(ctx context.Context, params metav1alpha1.ArtifactOptions, payload metav1alpha1.PromoteArtifactPayload) (*metav1alpha1.Artifact, error)
+}
+
 // ArtifactTriggerRegister used to register ArtifactTrigger
 type ArtifactTriggerRegister interface {
 	GetIntegrationClassName() string
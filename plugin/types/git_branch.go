@@ -22,7 +22,7 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/git_branch.go github.com/katanomi/pkg/plugin/types GitBranchLister,GitBranchGetter,GitBranchCreator
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/git_branch.go github.com/katanomi/pkg/plugin/types GitBranchLister,GitBranchGetter,GitBranchCreator,GitBranchDeleter,GitBranchProtectionGetter,GitBranchProtectionUpdater,GitBranchProtectionDeleter
 
 // GitBranchLister List git branch
 type GitBranchLister interface {
@@ -41,3 +41,27 @@ type GitBranchCreator interface {
 	Interface
 	CreateGitBranch(ctx context.Context, payload metav1alpha1.CreateBranchPayload) (metav1alpha1.GitBranch, error)
 }
+
+// GitBranchDeleter delete git branch, default and protected branches could be refused by plugins
+type GitBranchDeleter interface {
+	Interface
+	DeleteGitBranch(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) error
+}
+
+// GitBranchProtectionGetter get protection rules of git branch
+type GitBranchProtectionGetter interface {
+	Interface
+	GetGitBranchProtection(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) (metav1alpha1.GitBranchProtection, error)
+}
+
+// GitBranchProtectionUpdater protect git branch or replace its protection rules
+type GitBranchProtectionUpdater interface {
+	Interface
+	UpdateGitBranchProtection(ctx context.Context, payload metav1alpha1.UpdateBranchProtectionPayload) (metav1alpha1.GitBranchProtection, error)
+}
+
+// GitBranchProtectionDeleter remove protection rules of git branch
+type GitBranchProtectionDeleter interface {
+	Interface
+	DeleteGitBranchProtection(ctx context.Context, repoOption metav1alpha1.GitRepo, branch string) error
+}
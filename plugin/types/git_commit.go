@@ -23,7 +23,7 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/git_commit.go github.com/katanomi/pkg/plugin/types GitCommitGetter,GitCommitCreator,GitCommitLister
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/git_commit.go github.com/katanomi/pkg/plugin/types GitCommitGetter,GitCommitCreator,GitCommitLister,GitCommitComparer
 
 // GitCommitGetter get git commit
 type GitCommitGetter interface {
@@ -46,3 +46,9 @@ type GitCommitLister interface {
 		listOption metav1alpha1.ListOptions,
 	) (metav1alpha1.GitCommitList, error)
 }
+
+// GitCommitComparer compare two revisions of git repository
+type GitCommitComparer interface {
+	Interface
+	CompareGitCommits(ctx context.Context, option metav1alpha1.GitCommitCompareOption) (metav1alpha1.GitCommitComparison, error)
+}
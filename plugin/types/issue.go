@@ -22,7 +22,7 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/issue.go github.com/katanomi/pkg/plugin/types IssueLister,IssueGetter,IssueBranchLister,IssueBranchCreator,IssueBranchDeleter,IssueAttributeGetter
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/issue.go github.com/katanomi/pkg/plugin/types IssueLister,IssueGetter,IssueBranchLister,IssueBranchCreator,IssueBranchDeleter,IssueAttributeGetter,IssueCreator,IssueUpdater,IssueCommentLister,IssueCommentCreator,IssueLinker
 
 // IssueLister issue lister
 type IssueLister interface {
@@ -54,3 +54,33 @@ type IssueAttributeGetter interface {
 	Interface
 	GetIssueAttribute(ctx context.Context, params metav1alpha1.IssueOptions, option metav1alpha1.ListOptions) (*metav1alpha1.Attribute, error)
 }
+
+// IssueCreator create an issue in the project
+type IssueCreator interface {
+	Interface
+	CreateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssuePayload) (*metav1alpha1.Issue, error)
+}
+
+// IssueUpdater update fields of an issue, updating the status transitions the issue
+type IssueUpdater interface {
+	Interface
+	UpdateIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.UpdateIssuePayload) (*metav1alpha1.Issue, error)
+}
+
+// IssueCommentLister list comments of an issue
+type IssueCommentLister interface {
+	Interface
+	ListIssueComments(ctx context.Context, params metav1alpha1.IssueOptions, option metav1alpha1.ListOptions) (*metav1alpha1.IssueCommentList, error)
+}
+
+// IssueCommentCreator comment an issue
+type IssueCommentCreator interface {
+	Interface
+	CreateIssueComment(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.CreateIssueCommentPayload) (*metav1alpha1.Comment, error)
+}
+
+// IssueLinker link an issue to another issue
+type IssueLinker interface {
+	Interface
+	LinkIssue(ctx context.Context, params metav1alpha1.IssueOptions, payload metav1alpha1.IssueLinkPayload) (*metav1alpha1.Issue, error)
+}
@@ -22,7 +22,7 @@ import (
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/pull_request.go github.com/katanomi/pkg/plugin/types GitPullRequestCommentCreator,GitPullRequestCommentUpdater,GitPullRequestCommentLister,GitPullRequestHandler,GitPullRequestLister,GitPullRequestGetter,GitPullRequestCreator
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/pull_request.go github.com/katanomi/pkg/plugin/types GitPullRequestCommentCreator,GitPullRequestCommentUpdater,GitPullRequestCommentLister,GitPullRequestHandler,GitPullRequestLister,GitPullRequestGetter,GitPullRequestCreator,GitPullRequestFileLister,GitPullRequestDiffGetter,GitPullRequestReviewLister,GitPullRequestReviewCreator,GitPullRequestReviewerRequester,GitPullRequestMergeabilityGetter,GitPullRequestMerger
 
 // GitPullRequestCommentCreator create pull request comment functions
 type GitPullRequestCommentCreator interface {
@@ -75,3 +75,53 @@ type GitPullRequestCreator interface {
 	Interface
 	CreatePullRequest(ctx context.Context, payload metav1alpha1.CreatePullRequestPayload) (metav1alpha1.GitPullRequest, error)
 }
+
+// GitPullRequestFileLister list files changed by a pull request
+type GitPullRequestFileLister interface {
+	Interface
+	ListPullRequestFiles(
+		ctx context.Context,
+		option metav1alpha1.GitPullRequestOption,
+		listOption metav1alpha1.ListOptions,
+	) (metav1alpha1.GitPullRequestFileList, error)
+}
+
+// GitPullRequestDiffGetter get the diff of a pull request
+type GitPullRequestDiffGetter interface {
+	Interface
+	GetPullRequestDiff(ctx context.Context, option metav1alpha1.GitPullRequestDiffOption) (metav1alpha1.GitPullRequestDiff, error)
+}
+
+// GitPullRequestReviewLister list reviews of a pull request
+type GitPullRequestReviewLister interface {
+	Interface
+	ListPullRequestReviews(
+		ctx context.Context,
+		option metav1alpha1.GitPullRequestOption,
+		listOption metav1alpha1.ListOptions,
+	) (metav1alpha1.GitPullRequestReviewList, error)
+}
+
+// GitPullRequestReviewCreator submit a review or an approval of a pull request
+type GitPullRequestReviewCreator interface {
+	Interface
+	CreatePullRequestReview(ctx context.Context, payload metav1alpha1.CreatePullRequestReviewPayload) (metav1alpha1.GitPullRequestReview, error)
+}
+
+// GitPullRequestReviewerRequester request users to review a pull request
+type GitPullRequestReviewerRequester interface {
+	Interface
+	RequestPullRequestReviewers(ctx context.Context, payload metav1alpha1.RequestPullRequestReviewersPayload) (metav1alpha1.GitPullRequest, error)
+}
+
+// GitPullRequestMergeabilityGetter check whether a pull request could be merged
+type GitPullRequestMergeabilityGetter interface {
+	Interface
+	GetPullRequestMergeability(ctx context.Context, option metav1alpha1.GitPullRequestOption) (metav1alpha1.GitPullRequestMergeability, error)
+}
+
+// GitPullRequestMerger merge a pull request with a merge strategy
+type GitPullRequestMerger interface {
+	Interface
+	MergePullRequest(ctx context.Context, payload metav1alpha1.MergePullRequestPayload) (metav1alpha1.GitPullRequest, error)
+}
@@ -22,11 +22,12 @@ import (
 	cloudevent "github.com/cloudevents/sdk-go/v2"
 	"github.com/emicklei/go-restful/v3"
 	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/webhook"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 )
 
-//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/webhook.go github.com/katanomi/pkg/plugin/types WebhookRegister,WebhookCreator,WebhookUpdater,WebhookDeleter,WebhookLister,WebhookResourceDiffer,WebhookReceiver
+//go:generate mockgen -package=types -destination=../../testing/mock/github.com/katanomi/pkg/plugin/types/webhook.go github.com/katanomi/pkg/plugin/types WebhookRegister,WebhookCreator,WebhookUpdater,WebhookDeleter,WebhookLister,WebhookResourceDiffer,WebhookReceiver,WebhookVerifierGetter
 
 // WebhookRegister used to register and manage webhooks
 type WebhookRegister interface {
@@ -71,6 +72,14 @@ type WebhookReceiver interface {
 	ReceiveWebhook(ctx context.Context, req *restful.Request, secret string) (cloudevent.Event, error)
 }
 
+// WebhookVerifierGetter provides a verifier for the webhook requests of a WebhookReceiver.
+// The webhook route is only registered for receivers returning a non-nil verifier,
+// requests are verified before calling ReceiveWebhook,
+// those failing the verification are rejected with 401 and replays with 409.
+type WebhookVerifierGetter interface {
+	WebhookVerifier() webhook.Verifier
+}
+
 // GitTriggerRegister used to register GitTrigger
 // TODO: need refactor: maybe integration plugin should decided how to generate cloudevents filters
 // up to now, it is not a better solution that relying on plugins to give some events type to GitTriggerReconcile.
@@ -79,6 +88,10 @@ type WebhookReceiver interface {
 // BranchCloudEventFilter() CloudEventFilters
 // TagCloudEventFilter() CloudEventFilters
 // WebHook() WebHook
+//
+// WebhookReceiver implementations could emit the provider-neutral event types such as
+// metav1alpha1.GitPushEventType with the helpers in the plugin/webhook package,
+// and return the normalized types here, so triggers filter on the same event shape for all providers.
 type GitTriggerRegister interface {
 	GetIntegrationClassName() string
 
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VerificationReason reason why a webhook request fails the verification
+type VerificationReason string
+
+const (
+	// ReasonMissingSignature the token or the signature is missing in the request
+	ReasonMissingSignature VerificationReason = "MissingSignature"
+	// ReasonInvalidSignature the token or the signature does not match the secret
+	ReasonInvalidSignature VerificationReason = "InvalidSignature"
+	// ReasonStale the timestamp of the request is out of the tolerance
+	ReasonStale VerificationReason = "Stale"
+	// ReasonReplayed the delivery id of the request is received before
+	ReasonReplayed VerificationReason = "Replayed"
+)
+
+// VerificationError error returned when a webhook request fails the verification
+type VerificationError struct {
+	// Reason why the request fails the verification
+	Reason VerificationReason
+	// Message detailed message of the failure
+	Message string
+}
+
+// Error implements the error interface
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("webhook verification failed: %s: %s", e.Reason, e.Message)
+}
+
+func newVerificationError(reason VerificationReason, format string, args ...interface{}) error {
+	return &VerificationError{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// ReasonForError returns the reason of a verification error, empty if it is not a verification error
+func ReasonForError(err error) VerificationReason {
+	verr := &VerificationError{}
+	if errors.As(err, &verr) {
+		return verr.Reason
+	}
+	return ""
+}
+
+// IsUnauthorized returns true if the request is not authentic,
+// which includes missing or invalid signatures and stale timestamps
+func IsUnauthorized(err error) bool {
+	switch ReasonForError(err) {
+	case ReasonMissingSignature, ReasonInvalidSignature, ReasonStale:
+		return true
+	}
+	return false
+}
+
+// IsReplayed returns true if the request is a replay of a received request
+func IsReplayed(err error) bool {
+	return ReasonForError(err) == ReasonReplayed
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestReasonForError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	err := newVerificationError(ReasonStale, "timestamp %d", 1)
+	g.Expect(err.Error()).To(Equal("webhook verification failed: Stale: timestamp 1"))
+	g.Expect(ReasonForError(err)).To(Equal(ReasonStale))
+	g.Expect(IsUnauthorized(err)).To(BeTrue())
+	g.Expect(IsReplayed(err)).To(BeFalse())
+
+	err = newVerificationError(ReasonReplayed, "delivery")
+	g.Expect(IsUnauthorized(err)).To(BeFalse())
+	g.Expect(IsReplayed(err)).To(BeTrue())
+
+	g.Expect(ReasonForError(context.Canceled)).To(BeEmpty())
+	g.Expect(IsUnauthorized(nil)).To(BeFalse())
+}
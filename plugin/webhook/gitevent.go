@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+)
+
+// NewGitEvent returns a normalized git cloud event carrying the data.
+// The id should be the delivery id of the webhook if the git provider offers one,
+// so that redelivered webhooks could be deduplicated, a random id is used if empty.
+// The source is usually the web address of the repository.
+func NewGitEvent(source, id string, data metav1alpha1.GitEventData) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if data == nil || isNilPointer(data) {
+		return event, fmt.Errorf("git event data is nil")
+	}
+	eventType := data.EventType()
+	if !eventType.IsValid() {
+		return event, fmt.Errorf("%q is not a normalized git event type", eventType)
+	}
+	if _, ok := data.(*metav1alpha1.GitPullRequestEventData); ok && !eventType.IsPullRequest() {
+		return event, fmt.Errorf("%q is not a pull request event type", eventType)
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	event.SetID(id)
+	event.SetSource(source)
+	event.SetType(eventType.String())
+	event.SetSubject(data.EventSubject())
+	event.SetTime(time.Now())
+	for key, value := range data.EventExtensions() {
+		if value != "" {
+			event.SetExtension(key, value)
+		}
+	}
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return event, err
+	}
+	return event, event.Validate()
+}
+
+// isNilPointer returns true if the data is a typed nil pointer, e.g. (*GitPushEventData)(nil)
+func isNilPointer(data metav1alpha1.GitEventData) bool {
+	value := reflect.ValueOf(data)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// ParseGitEvent returns the data of a normalized git cloud event
+func ParseGitEvent(event cloudevents.Event) (metav1alpha1.GitEventData, error) {
+	var data metav1alpha1.GitEventData
+	eventType := metav1alpha1.GitEventType(event.Type())
+	switch {
+	case eventType == metav1alpha1.GitPushEventType:
+		data = &metav1alpha1.GitPushEventData{}
+	case eventType == metav1alpha1.GitTagEventType:
+		data = &metav1alpha1.GitTagEventData{}
+	case eventType.IsPullRequest():
+		data = &metav1alpha1.GitPullRequestEventData{}
+	case eventType == metav1alpha1.GitCommentEventType:
+		data = &metav1alpha1.GitCommentEventData{}
+	case eventType == metav1alpha1.GitReviewEventType:
+		data = &metav1alpha1.GitReviewEventData{}
+	default:
+		return nil, fmt.Errorf("%q is not a normalized git event type", eventType)
+	}
+
+	if err := event.DataAs(data); err != nil {
+		return nil, err
+	}
+	if pr, ok := data.(*metav1alpha1.GitPullRequestEventData); ok && pr.Type == "" {
+		pr.Type = eventType
+	}
+	return data, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/gomega"
+)
+
+var eventMeta = metav1alpha1.GitEventMeta{
+	Provider: ProviderGitLab,
+	Repository: metav1alpha1.GitEventRepository{
+		GitRepo: metav1alpha1.GitRepo{Project: "group", Repository: "demo"},
+		WebURL:  "https://gitlab.example.com/group/demo",
+	},
+	Sender: metav1alpha1.GitUserBaseInfo{Name: "alice", Email: "alice@example.com"},
+}
+
+func TestNewGitEvent(t *testing.T) {
+	tests := map[string]struct {
+		data       metav1alpha1.GitEventData
+		eventType  metav1alpha1.GitEventType
+		subject    string
+		extensions map[string]string
+	}{
+		"push": {
+			data: &metav1alpha1.GitPushEventData{
+				GitEventMeta: eventMeta,
+				Action:       metav1alpha1.CloudEventExtBranchActionPush,
+				Ref:          "refs/heads/main",
+				Branch:       "main",
+				Before:       "1111",
+				After:        "2222",
+				Commits: []metav1alpha1.GitEventCommit{
+					{SHA: "2222", Message: "fix build", Author: metav1alpha1.GitUserBaseInfo{Email: "bob@example.com"}},
+				},
+			},
+			eventType: metav1alpha1.GitPushEventType,
+			subject:   "main",
+			extensions: map[string]string{
+				"provider":          "gitlab",
+				"repository":        "group/demo",
+				"sender":            "alice",
+				"action":            "push",
+				"branch":            "main",
+				"reference":         "refs/heads/main",
+				"commit":            "2222",
+				"commitmessage":     "fix build",
+				"revisionsubmitter": "bob@example.com",
+			},
+		},
+		"deleted tag": {
+			data: &metav1alpha1.GitTagEventData{
+				GitEventMeta: eventMeta,
+				Action:       metav1alpha1.CloudEventExtTagActionDelete,
+				Ref:          "refs/tags/v1.0.0",
+				Tag:          "v1.0.0",
+			},
+			eventType: metav1alpha1.GitTagEventType,
+			subject:   "v1.0.0",
+			extensions: map[string]string{
+				"provider":   "gitlab",
+				"repository": "group/demo",
+				"sender":     "alice",
+				"action":     "delete",
+				"tag":        "v1.0.0",
+				"reference":  "refs/tags/v1.0.0",
+			},
+		},
+		"merged pull request": {
+			data: &metav1alpha1.GitPullRequestEventData{
+				GitEventMeta: eventMeta,
+				Type:         metav1alpha1.GitPullRequestMergedEventType,
+				Action:       metav1alpha1.CloudEventExtPullRequestActionMerged,
+				PullRequest: metav1alpha1.GitEventPullRequest{
+					Number: 3, Title: "add feature", Source: "feature", Target: "main", HeadSHA: "3333",
+					Author: metav1alpha1.GitUserBaseInfo{Name: "bob", Email: "bob@example.com"},
+				},
+			},
+			eventType: metav1alpha1.GitPullRequestMergedEventType,
+			subject:   "3",
+			extensions: map[string]string{
+				"provider":          "gitlab",
+				"repository":        "group/demo",
+				"sender":            "alice",
+				"action":            "merged",
+				"number":            "3",
+				"pullrequesttitle":  "add feature",
+				"sourcebranch":      "feature",
+				"targetbranch":      "main",
+				"branch":            "feature",
+				"commit":            "3333",
+				"revisionsubmitter": "bob@example.com",
+			},
+		},
+		"commit comment": {
+			data: &metav1alpha1.GitCommentEventData{
+				GitEventMeta: eventMeta,
+				Action:       "created",
+				TargetType:   metav1alpha1.GitCommentTargetCommit,
+				Body:         "/retest",
+				CommitSHA:    "4444",
+			},
+			eventType: metav1alpha1.GitCommentEventType,
+			subject:   "4444",
+			extensions: map[string]string{
+				"provider":   "gitlab",
+				"repository": "group/demo",
+				"sender":     "alice",
+				"action":     "created",
+				"commit":     "4444",
+			},
+		},
+		"review": {
+			data: &metav1alpha1.GitReviewEventData{
+				GitEventMeta: eventMeta,
+				State:        metav1alpha1.PullRequestReviewApproved,
+				PullRequest:  metav1alpha1.GitEventPullRequest{Number: 3, Title: "add feature", Source: "feature", Target: "main"},
+			},
+			eventType: metav1alpha1.GitReviewEventType,
+			subject:   "3",
+			extensions: map[string]string{
+				"provider":         "gitlab",
+				"repository":       "group/demo",
+				"sender":           "alice",
+				"action":           "approved",
+				"number":           "3",
+				"pullrequesttitle": "add feature",
+				"sourcebranch":     "feature",
+				"targetbranch":     "main",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			event, err := NewGitEvent(eventMeta.Repository.WebURL, "delivery-1", tt.data)
+			g.Expect(err).To(Succeed())
+			g.Expect(event.ID()).To(Equal("delivery-1"))
+			g.Expect(event.Type()).To(Equal(tt.eventType.String()))
+			g.Expect(event.Subject()).To(Equal(tt.subject))
+			g.Expect(event.Extensions()).To(HaveLen(len(tt.extensions)))
+			for key, value := range tt.extensions {
+				g.Expect(event.Extensions()).To(HaveKeyWithValue(key, value))
+			}
+
+			data, err := ParseGitEvent(event)
+			g.Expect(err).To(Succeed())
+			g.Expect(data).To(Equal(tt.data))
+		})
+	}
+}
+
+func TestNewGitEvent_invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := NewGitEvent("https://example.com", "", nil)
+	g.Expect(err).To(HaveOccurred())
+
+	// typed nil data
+	_, err = NewGitEvent("https://example.com", "", (*metav1alpha1.GitPushEventData)(nil))
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewGitEvent("https://example.com", "", (*metav1alpha1.GitPullRequestEventData)(nil))
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = NewGitEvent("https://example.com", "", &metav1alpha1.GitPullRequestEventData{Type: metav1alpha1.GitPushEventType})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = NewGitEvent("", "", &metav1alpha1.GitPushEventData{Branch: "main"})
+	g.Expect(err).To(HaveOccurred())
+
+	event, err := NewGitEvent("https://example.com", "", &metav1alpha1.GitPushEventData{Branch: "main"})
+	g.Expect(err).To(Succeed())
+	g.Expect(event.ID()).NotTo(BeEmpty())
+}
+
+func TestParseGitEvent_invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	event := cloudevents.NewEvent()
+	event.SetType("dev.katanomi.cloudevents.gitlab.Push Hook")
+	_, err := ParseGitEvent(event)
+	g.Expect(err).To(HaveOccurred())
+
+	event.SetType(metav1alpha1.GitPushEventType.String())
+	g.Expect(event.SetData(cloudevents.ApplicationJSON, []byte("{"))).To(Succeed())
+	_, err = ParseGitEvent(event)
+	g.Expect(err).To(HaveOccurred())
+}
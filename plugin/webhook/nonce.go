@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxNonces the default max number of nonces recorded by MemoryNonceCache
+const DefaultMaxNonces = 100000
+
+// NonceCache records the delivery ids of received webhooks to reject replays.
+// Implementations shared by multiple replicas, e.g. backed by redis, should add the nonce atomically.
+type NonceCache interface {
+	// Add records the nonce for the ttl, returns false if the nonce is recorded and not expired
+	Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// MemoryNonceCache nonce cache in memory, only suitable for a single replica
+type MemoryNonceCache struct {
+	lock    sync.Mutex
+	expires map[string]time.Time
+	queue   nonceQueue
+	maxSize int
+	now     func() time.Time
+}
+
+var _ NonceCache = &MemoryNonceCache{}
+
+// NewMemoryNonceCache returns a nonce cache in memory recording at most DefaultMaxNonces nonces
+func NewMemoryNonceCache() *MemoryNonceCache {
+	return NewMemoryNonceCacheWithSize(DefaultMaxNonces)
+}
+
+// NewMemoryNonceCacheWithSize returns a nonce cache in memory recording at most maxSize nonces.
+// DefaultMaxNonces is used if maxSize is not positive.
+func NewMemoryNonceCacheWithSize(maxSize int) *MemoryNonceCache {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxNonces
+	}
+	return &MemoryNonceCache{
+		expires: map[string]time.Time{},
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+}
+
+// Add records the nonce for the ttl, returns false if the nonce is recorded and not expired.
+// Expired nonces are removed when adding, and the nonces expiring first are evicted
+// when the cache is full, so replays of evicted nonces are not rejected any more.
+func (c *MemoryNonceCache) Add(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for len(c.queue) > 0 && !now.Before(c.queue[0].expire) {
+		c.pop()
+	}
+	if _, exist := c.expires[nonce]; exist {
+		return false, nil
+	}
+	for len(c.queue) >= c.maxSize {
+		c.pop()
+	}
+	expire := now.Add(ttl)
+	c.expires[nonce] = expire
+	heap.Push(&c.queue, nonceItem{nonce: nonce, expire: expire})
+	return true, nil
+}
+
+// Len returns the number of nonces recorded
+func (c *MemoryNonceCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.expires)
+}
+
+// pop removes the nonce expiring first
+func (c *MemoryNonceCache) pop() {
+	item := heap.Pop(&c.queue).(nonceItem)
+	delete(c.expires, item.nonce)
+}
+
+type nonceItem struct {
+	nonce  string
+	expire time.Time
+}
+
+// nonceQueue nonces ordered by expiration time
+type nonceQueue []nonceItem
+
+func (q nonceQueue) Len() int           { return len(q) }
+func (q nonceQueue) Less(i, j int) bool { return q[i].expire.Before(q[j].expire) }
+func (q nonceQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nonceQueue) Push(x any) { *q = append(*q, x.(nonceItem)) }
+
+func (q *nonceQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMemoryNonceCache(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	cache := NewMemoryNonceCache()
+	cache.now = func() time.Time { return now }
+
+	added, err := cache.Add(ctx, "a", time.Minute)
+	g.Expect(err).To(Succeed())
+	g.Expect(added).To(BeTrue())
+
+	added, _ = cache.Add(ctx, "a", time.Minute)
+	g.Expect(added).To(BeFalse())
+
+	added, _ = cache.Add(ctx, "b", time.Hour)
+	g.Expect(added).To(BeTrue())
+	g.Expect(cache.Len()).To(Equal(2))
+
+	now = now.Add(2 * time.Minute)
+	added, _ = cache.Add(ctx, "a", time.Minute)
+	g.Expect(added).To(BeTrue())
+	g.Expect(cache.Len()).To(Equal(2))
+
+	now = now.Add(2 * time.Hour)
+	added, _ = cache.Add(ctx, "c", time.Minute)
+	g.Expect(added).To(BeTrue())
+	g.Expect(cache.Len()).To(Equal(1))
+}
+
+func TestMemoryNonceCache_maxSize(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	cache := NewMemoryNonceCacheWithSize(2)
+	cache.now = func() time.Time { return now }
+
+	added, err := cache.Add(ctx, "a", time.Hour)
+	g.Expect(err).To(Succeed())
+	g.Expect(added).To(BeTrue())
+	added, _ = cache.Add(ctx, "b", 2*time.Hour)
+	g.Expect(added).To(BeTrue())
+
+	// "a" expiring first is evicted when the cache is full
+	added, _ = cache.Add(ctx, "c", 3*time.Hour)
+	g.Expect(added).To(BeTrue())
+	g.Expect(cache.Len()).To(Equal(2))
+	added, _ = cache.Add(ctx, "b", time.Hour)
+	g.Expect(added).To(BeFalse())
+	added, _ = cache.Add(ctx, "c", time.Hour)
+	g.Expect(added).To(BeFalse())
+
+	// expired "b" is pruned instead of evicting "c"
+	now = now.Add(2*time.Hour + time.Minute)
+	added, _ = cache.Add(ctx, "d", time.Hour)
+	g.Expect(added).To(BeTrue())
+	g.Expect(cache.Len()).To(Equal(2))
+	added, _ = cache.Add(ctx, "c", time.Hour)
+	g.Expect(added).To(BeFalse())
+}
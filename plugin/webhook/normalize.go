@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"strings"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+)
+
+const (
+	// ProviderGitHub git provider of GitHub
+	ProviderGitHub = "github"
+	// ProviderGitLab git provider of GitLab
+	ProviderGitLab = "gitlab"
+	// ProviderGitea git provider of Gitea, Gogs and Forgejo send the same payloads
+	ProviderGitea = "gitea"
+	// ProviderBitbucket git provider of Bitbucket Cloud
+	ProviderBitbucket = "bitbucket"
+
+	refHeadsPrefix = "refs/heads/"
+	refTagsPrefix  = "refs/tags/"
+)
+
+// ParseRef returns the branch or the tag of a full reference,
+// a reference without known prefix is treated as a branch
+func ParseRef(ref string) (branch, tag string) {
+	switch {
+	case strings.HasPrefix(ref, refTagsPrefix):
+		return "", strings.TrimPrefix(ref, refTagsPrefix)
+	case strings.HasPrefix(ref, refHeadsPrefix):
+		return strings.TrimPrefix(ref, refHeadsPrefix), ""
+	}
+	return ref, ""
+}
+
+// IsZeroSHA returns true if the sha is empty or consists of zeros,
+// which providers use as the before sha of a created reference
+// or the after sha of a deleted reference
+func IsZeroSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+// RefAction returns the action of a push to a reference according to the before and after sha
+func RefAction(before, after string) string {
+	switch {
+	case IsZeroSHA(before):
+		return metav1alpha1.CloudEventExtBranchActionCreate
+	case IsZeroSHA(after):
+		return metav1alpha1.CloudEventExtBranchActionDelete
+	}
+	return metav1alpha1.CloudEventExtBranchActionPush
+}
+
+type pullRequestAction struct {
+	eventType metav1alpha1.GitEventType
+	action    string
+}
+
+var (
+	prOpened      = pullRequestAction{metav1alpha1.GitPullRequestOpenedEventType, metav1alpha1.CloudEventExtPullRequestActionOpened}
+	prReopened    = pullRequestAction{metav1alpha1.GitPullRequestOpenedEventType, metav1alpha1.CloudEventExtPullRequestActionReOpened}
+	prSynchronize = pullRequestAction{metav1alpha1.GitPullRequestUpdatedEventType, metav1alpha1.CloudEventExtPullRequestActionSynchronize}
+	prEdited      = pullRequestAction{metav1alpha1.GitPullRequestUpdatedEventType, metav1alpha1.CloudEventExtPullRequestActionEdited}
+	prMerged      = pullRequestAction{metav1alpha1.GitPullRequestMergedEventType, metav1alpha1.CloudEventExtPullRequestActionMerged}
+	prClosed      = pullRequestAction{metav1alpha1.GitPullRequestClosedEventType, metav1alpha1.CloudEventExtPullRequestActionClosed}
+
+	// pullRequestActions actions of the pull request webhooks of each provider
+	pullRequestActions = map[string]map[string]pullRequestAction{
+		// action of the pull_request event
+		ProviderGitHub: {
+			"opened":           prOpened,
+			"reopened":         prReopened,
+			"synchronize":      prSynchronize,
+			"edited":           prEdited,
+			"ready_for_review": prEdited,
+			"closed":           prClosed,
+		},
+		// object_attributes.action of the merge request hook,
+		// receivers should pass synchronize for an update with object_attributes.oldrev
+		ProviderGitLab: {
+			"open":        prOpened,
+			"reopen":      prReopened,
+			"update":      prEdited,
+			"synchronize": prSynchronize,
+			"merge":       prMerged,
+			"close":       prClosed,
+		},
+		// action of the pull_request event
+		ProviderGitea: {
+			"opened":       prOpened,
+			"reopened":     prReopened,
+			"synchronized": prSynchronize,
+			"edited":       prEdited,
+			"closed":       prClosed,
+		},
+		// X-Event-Key header of the pull request events
+		ProviderBitbucket: {
+			"pullrequest:created":   prOpened,
+			"pullrequest:updated":   prSynchronize,
+			"pullrequest:fulfilled": prMerged,
+			"pullrequest:rejected":  prClosed,
+		},
+	}
+)
+
+// PullRequestEventType returns the normalized event type and action of a pull request webhook.
+// The merged flag is required by providers sending closed for both merged and closed pull requests.
+// Returns false if the action does not change the state of the pull request, e.g. labeled.
+func PullRequestEventType(provider, action string, merged bool) (metav1alpha1.GitEventType, string, bool) {
+	item, ok := pullRequestActions[provider][action]
+	if !ok {
+		return "", "", false
+	}
+	if item == prClosed && merged {
+		item = prMerged
+	}
+	return item.eventType, item.action, true
+}
+
+// reviewStates states of the pull request review webhooks of each provider
+var reviewStates = map[string]map[string]metav1alpha1.PullRequestReviewState{
+	// review.state of the pull_request_review event
+	ProviderGitHub: {
+		"approved":          metav1alpha1.PullRequestReviewApproved,
+		"changes_requested": metav1alpha1.PullRequestReviewChangesRequested,
+		"commented":         metav1alpha1.PullRequestReviewCommented,
+		"dismissed":         metav1alpha1.PullRequestReviewDismissed,
+	},
+	// object_attributes.action of the merge request hook
+	ProviderGitLab: {
+		"approved":   metav1alpha1.PullRequestReviewApproved,
+		"approval":   metav1alpha1.PullRequestReviewApproved,
+		"unapproved": metav1alpha1.PullRequestReviewDismissed,
+		"unapproval": metav1alpha1.PullRequestReviewDismissed,
+	},
+	// X-Gitea-Event-Type header of the pull request review events
+	ProviderGitea: {
+		"pull_request_review_approved": metav1alpha1.PullRequestReviewApproved,
+		"pull_request_review_rejected": metav1alpha1.PullRequestReviewChangesRequested,
+		"pull_request_review_comment":  metav1alpha1.PullRequestReviewCommented,
+	},
+	// X-Event-Key header of the pull request events
+	ProviderBitbucket: {
+		"pullrequest:approved":                metav1alpha1.PullRequestReviewApproved,
+		"pullrequest:unapproved":              metav1alpha1.PullRequestReviewDismissed,
+		"pullrequest:changes_request_created": metav1alpha1.PullRequestReviewChangesRequested,
+		"pullrequest:changes_request_removed": metav1alpha1.PullRequestReviewDismissed,
+	},
+}
+
+// ReviewState returns the normalized review state of a pull request review webhook,
+// returns false if the state is unknown
+func ReviewState(provider, state string) (metav1alpha1.PullRequestReviewState, bool) {
+	value, ok := reviewStates[provider][strings.ToLower(state)]
+	return value, ok
+}
+
+// CommentAction returns the normalized action of a comment webhook, one of created, edited or deleted.
+// Providers without action in the comment webhooks are treated as created.
+func CommentAction(action string) string {
+	action = strings.ToLower(action)
+	switch {
+	case strings.HasSuffix(action, "deleted"):
+		return "deleted"
+	case strings.HasSuffix(action, "edited"), strings.HasSuffix(action, "updated"):
+		return "edited"
+	}
+	return "created"
+}
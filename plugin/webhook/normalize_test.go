@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseRef(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	branch, tag := ParseRef("refs/heads/feature/login")
+	g.Expect(branch).To(Equal("feature/login"))
+	g.Expect(tag).To(BeEmpty())
+
+	branch, tag = ParseRef("refs/tags/v1.0.0")
+	g.Expect(branch).To(BeEmpty())
+	g.Expect(tag).To(Equal("v1.0.0"))
+
+	branch, tag = ParseRef("main")
+	g.Expect(branch).To(Equal("main"))
+	g.Expect(tag).To(BeEmpty())
+}
+
+func TestRefAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	zero := "0000000000000000000000000000000000000000"
+	g.Expect(RefAction(zero, "abc")).To(Equal(metav1alpha1.CloudEventExtBranchActionCreate))
+	g.Expect(RefAction("", "abc")).To(Equal(metav1alpha1.CloudEventExtBranchActionCreate))
+	g.Expect(RefAction("abc", zero)).To(Equal(metav1alpha1.CloudEventExtBranchActionDelete))
+	g.Expect(RefAction("abc", "def")).To(Equal(metav1alpha1.CloudEventExtBranchActionPush))
+}
+
+func TestPullRequestEventType(t *testing.T) {
+	tests := []struct {
+		provider  string
+		action    string
+		merged    bool
+		eventType metav1alpha1.GitEventType
+		normal    string
+		ok        bool
+	}{
+		{ProviderGitHub, "opened", false, metav1alpha1.GitPullRequestOpenedEventType, "opened", true},
+		{ProviderGitHub, "synchronize", false, metav1alpha1.GitPullRequestUpdatedEventType, "synchronize", true},
+		{ProviderGitHub, "closed", true, metav1alpha1.GitPullRequestMergedEventType, "merged", true},
+		{ProviderGitHub, "closed", false, metav1alpha1.GitPullRequestClosedEventType, "closed", true},
+		{ProviderGitHub, "labeled", false, "", "", false},
+		{ProviderGitLab, "reopen", false, metav1alpha1.GitPullRequestOpenedEventType, "reopened", true},
+		{ProviderGitLab, "update", false, metav1alpha1.GitPullRequestUpdatedEventType, "edited", true},
+		{ProviderGitLab, "merge", false, metav1alpha1.GitPullRequestMergedEventType, "merged", true},
+		{ProviderGitea, "synchronized", false, metav1alpha1.GitPullRequestUpdatedEventType, "synchronize", true},
+		{ProviderGitea, "closed", true, metav1alpha1.GitPullRequestMergedEventType, "merged", true},
+		{ProviderBitbucket, "pullrequest:created", false, metav1alpha1.GitPullRequestOpenedEventType, "opened", true},
+		{ProviderBitbucket, "pullrequest:rejected", false, metav1alpha1.GitPullRequestClosedEventType, "closed", true},
+		{"gogs", "opened", false, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+" "+tt.action, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			eventType, action, ok := PullRequestEventType(tt.provider, tt.action, tt.merged)
+			g.Expect(ok).To(Equal(tt.ok))
+			g.Expect(eventType).To(Equal(tt.eventType))
+			g.Expect(action).To(Equal(tt.normal))
+		})
+	}
+}
+
+func TestReviewState(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	state, ok := ReviewState(ProviderGitHub, "APPROVED")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(state).To(Equal(metav1alpha1.PullRequestReviewApproved))
+
+	state, ok = ReviewState(ProviderGitea, "pull_request_review_rejected")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(state).To(Equal(metav1alpha1.PullRequestReviewChangesRequested))
+
+	state, ok = ReviewState(ProviderBitbucket, "pullrequest:unapproved")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(state).To(Equal(metav1alpha1.PullRequestReviewDismissed))
+
+	_, ok = ReviewState(ProviderGitLab, "update")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestCommentAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(CommentAction("created")).To(Equal("created"))
+	g.Expect(CommentAction("")).To(Equal("created"))
+	g.Expect(CommentAction("pullrequest:comment_updated")).To(Equal("edited"))
+	g.Expect(CommentAction("edited")).To(Equal("edited"))
+	g.Expect(CommentAction("pullrequest:comment_deleted")).To(Equal("deleted"))
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // NOSONAR // ignore: sha1 is still used by some providers to sign webhooks
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTolerance default tolerance of the timestamp of the signed webhooks
+	DefaultTolerance = 5 * time.Minute
+	// DefaultReplayTTL default duration to remember the delivery ids
+	DefaultReplayTTL = 24 * time.Hour
+)
+
+// Verifier verifies the authenticity of a webhook request with the secret.
+// The body is the raw request body the signature is computed on.
+type Verifier interface {
+	Verify(ctx context.Context, header http.Header, body []byte, secret string) error
+}
+
+// VerifierFunc function implementing Verifier
+type VerifierFunc func(ctx context.Context, header http.Header, body []byte, secret string) error
+
+// Verify calls the function
+func (f VerifierFunc) Verify(ctx context.Context, header http.Header, body []byte, secret string) error {
+	return f(ctx, header, body, secret)
+}
+
+// Verifiers verifies with all the verifiers in order, returns the first error.
+// ReplayVerifier should be the last one, so that forged requests do not record delivery ids.
+type Verifiers []Verifier
+
+// Verify verifies with all the verifiers in order
+func (v Verifiers) Verify(ctx context.Context, header http.Header, body []byte, secret string) error {
+	for _, verifier := range v {
+		if err := verifier.Verify(ctx, header, body, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TokenVerifier verifies the shared token in the header equals the secret
+type TokenVerifier struct {
+	// Header of the token, e.g. X-Gitlab-Token
+	Header string
+}
+
+// Verify verifies the token in constant time, an empty secret is rejected
+func (v *TokenVerifier) Verify(_ context.Context, header http.Header, _ []byte, secret string) error {
+	if secret == "" {
+		return newVerificationError(ReasonMissingSignature, "secret of the webhook is empty")
+	}
+	token := header.Get(v.Header)
+	if token == "" {
+		return newVerificationError(ReasonMissingSignature, "header %s is empty", v.Header)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return newVerificationError(ReasonInvalidSignature, "token in header %s does not match", v.Header)
+	}
+	return nil
+}
+
+// SignatureVerifier verifies the hex encoded HMAC signature of the body
+type SignatureVerifier struct {
+	// Header of the signature, e.g. X-Hub-Signature-256
+	Header string
+
+	// Prefix of the signature, e.g. sha256=
+	Prefix string
+
+	// Hash used by the HMAC
+	Hash func() hash.Hash
+
+	// TimestampHeader header of the unix timestamp in seconds. If set, the signature
+	// is computed on "<timestamp>.<body>" and the timestamp should be within the tolerance.
+	TimestampHeader string
+
+	// Tolerance of the timestamp, DefaultTolerance is used if zero
+	Tolerance time.Duration
+
+	now func() time.Time
+}
+
+// NewHMACSHA256Verifier returns a verifier of the HMAC-SHA256 signature in the header
+func NewHMACSHA256Verifier(header, prefix string) *SignatureVerifier {
+	return &SignatureVerifier{Header: header, Prefix: prefix, Hash: sha256.New}
+}
+
+// NewHMACSHA1Verifier returns a verifier of the HMAC-SHA1 signature in the header
+func NewHMACSHA1Verifier(header, prefix string) *SignatureVerifier {
+	return &SignatureVerifier{Header: header, Prefix: prefix, Hash: sha1.New}
+}
+
+// WithTimestamp verifies the signature with the timestamp in the header within the tolerance
+func (v *SignatureVerifier) WithTimestamp(header string, tolerance time.Duration) *SignatureVerifier {
+	v.TimestampHeader = header
+	v.Tolerance = tolerance
+	return v
+}
+
+// Verify verifies the signature in constant time, an empty secret is rejected
+func (v *SignatureVerifier) Verify(_ context.Context, header http.Header, body []byte, secret string) error {
+	if secret == "" {
+		// anyone could sign with an empty key
+		return newVerificationError(ReasonMissingSignature, "secret of the webhook is empty")
+	}
+	signature := header.Get(v.Header)
+	if signature == "" {
+		return newVerificationError(ReasonMissingSignature, "header %s is empty", v.Header)
+	}
+	if !strings.HasPrefix(signature, v.Prefix) {
+		return newVerificationError(ReasonInvalidSignature, "signature in header %s should start with %q", v.Header, v.Prefix)
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, v.Prefix))
+	if err != nil {
+		return newVerificationError(ReasonInvalidSignature, "signature in header %s is not hex encoded", v.Header)
+	}
+
+	mac := hmac.New(v.Hash, []byte(secret))
+	if v.TimestampHeader != "" {
+		timestamp := header.Get(v.TimestampHeader)
+		if err = v.verifyTimestamp(timestamp); err != nil {
+			return err
+		}
+		mac.Write([]byte(timestamp + "."))
+	}
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return newVerificationError(ReasonInvalidSignature, "signature in header %s does not match", v.Header)
+	}
+	return nil
+}
+
+func (v *SignatureVerifier) verifyTimestamp(timestamp string) error {
+	if timestamp == "" {
+		return newVerificationError(ReasonMissingSignature, "header %s is empty", v.TimestampHeader)
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return newVerificationError(ReasonInvalidSignature, "timestamp in header %s is not unix seconds", v.TimestampHeader)
+	}
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	tolerance := v.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+	if math.Abs(float64(now().Unix()-seconds)) > tolerance.Seconds() {
+		return newVerificationError(ReasonStale, "timestamp %s is out of the tolerance %s", timestamp, tolerance)
+	}
+	return nil
+}
+
+// ReplayVerifier rejects requests whose delivery id is received before
+type ReplayVerifier struct {
+	// Header of the delivery id, e.g. X-GitHub-Delivery
+	Header string
+
+	// Cache records the received delivery ids
+	Cache NonceCache
+
+	// TTL duration to remember the delivery ids, DefaultReplayTTL is used if zero
+	TTL time.Duration
+
+	// Required rejects requests without delivery id, otherwise they are accepted
+	Required bool
+}
+
+// Verify records the delivery id and rejects the request if the id is received before
+func (v *ReplayVerifier) Verify(ctx context.Context, header http.Header, _ []byte, _ string) error {
+	id := header.Get(v.Header)
+	if id == "" {
+		if v.Required {
+			return newVerificationError(ReasonMissingSignature, "header %s is empty", v.Header)
+		}
+		return nil
+	}
+	ttl := v.TTL
+	if ttl == 0 {
+		ttl = DefaultReplayTTL
+	}
+	added, err := v.Cache.Add(ctx, v.Header+"/"+id, ttl)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return newVerificationError(ReasonReplayed, "delivery %s is received before", id)
+	}
+	return nil
+}
+
+// ProviderVerifier returns the verifier of the webhooks of the provider, which verifies
+// the signature or the token and rejects replays if the cache is not nil.
+// Returns false if the provider is unknown.
+func ProviderVerifier(provider string, cache NonceCache) (Verifier, bool) {
+	var verifiers Verifiers
+	var deliveryHeader string
+	switch provider {
+	case ProviderGitHub:
+		verifiers = Verifiers{NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256=")}
+		deliveryHeader = "X-GitHub-Delivery"
+	case ProviderGitLab:
+		verifiers = Verifiers{&TokenVerifier{Header: "X-Gitlab-Token"}}
+		deliveryHeader = "X-Gitlab-Event-UUID"
+	case ProviderGitea:
+		verifiers = Verifiers{NewHMACSHA256Verifier("X-Gitea-Signature", "")}
+		deliveryHeader = "X-Gitea-Delivery"
+	case ProviderBitbucket:
+		verifiers = Verifiers{NewHMACSHA256Verifier("X-Hub-Signature", "sha256=")}
+		deliveryHeader = "X-Request-UUID"
+	default:
+		return nil, false
+	}
+	if cache != nil {
+		verifiers = append(verifiers, &ReplayVerifier{Header: deliveryHeader, Cache: cache})
+	}
+	return verifiers, true
+}
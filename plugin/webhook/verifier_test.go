@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // NOSONAR // ignore: sha1 is still used by some providers to sign webhooks
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const secret = "s3cr3t"
+
+var body = []byte(`{"ref":"refs/heads/main"}`)
+
+func sign(h func() hash.Hash, payload []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func header(kv ...string) http.Header {
+	h := http.Header{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		h.Set(kv[i], kv[i+1])
+	}
+	return h
+}
+
+func TestTokenVerifier(t *testing.T) {
+	verifier := &TokenVerifier{Header: "X-Gitlab-Token"}
+	tests := map[string]struct {
+		header http.Header
+		reason VerificationReason
+	}{
+		"valid":   {header: header("X-Gitlab-Token", secret)},
+		"missing": {header: header(), reason: ReasonMissingSignature},
+		"invalid": {header: header("X-Gitlab-Token", "other"), reason: ReasonInvalidSignature},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			err := verifier.Verify(context.Background(), tt.header, body, secret)
+			g.Expect(ReasonForError(err)).To(Equal(tt.reason))
+		})
+	}
+}
+
+func TestVerifier_emptySecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	err := (&TokenVerifier{Header: "X-Gitlab-Token"}).Verify(ctx, header("X-Gitlab-Token", ""), body, "")
+	g.Expect(ReasonForError(err)).To(Equal(ReasonMissingSignature))
+
+	// the signature signed with an empty key is rejected
+	mac := hmac.New(sha256.New, nil)
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	err = NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256=").Verify(ctx, header("X-Hub-Signature-256", signature), body, "")
+	g.Expect(ReasonForError(err)).To(Equal(ReasonMissingSignature))
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	tests := map[string]struct {
+		verifier *SignatureVerifier
+		header   http.Header
+		reason   VerificationReason
+	}{
+		"valid sha256": {
+			verifier: NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256="),
+			header:   header("X-Hub-Signature-256", "sha256="+sign(sha256.New, body)),
+		},
+		"valid sha1": {
+			verifier: NewHMACSHA1Verifier("X-Hub-Signature", "sha1="),
+			header:   header("X-Hub-Signature", "sha1="+sign(sha1.New, body)),
+		},
+		"missing": {
+			verifier: NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256="),
+			header:   header(),
+			reason:   ReasonMissingSignature,
+		},
+		"wrong prefix": {
+			verifier: NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256="),
+			header:   header("X-Hub-Signature-256", "sha1="+sign(sha256.New, body)),
+			reason:   ReasonInvalidSignature,
+		},
+		"not hex": {
+			verifier: NewHMACSHA256Verifier("X-Gitea-Signature", ""),
+			header:   header("X-Gitea-Signature", "not-hex"),
+			reason:   ReasonInvalidSignature,
+		},
+		"wrong algorithm": {
+			verifier: NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256="),
+			header:   header("X-Hub-Signature-256", "sha256="+sign(sha1.New, body)),
+			reason:   ReasonInvalidSignature,
+		},
+		"tampered body": {
+			verifier: NewHMACSHA256Verifier("X-Hub-Signature-256", "sha256="),
+			header:   header("X-Hub-Signature-256", "sha256="+sign(sha256.New, []byte("{}"))),
+			reason:   ReasonInvalidSignature,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			err := tt.verifier.Verify(context.Background(), tt.header, body, secret)
+			g.Expect(ReasonForError(err)).To(Equal(tt.reason))
+		})
+	}
+}
+
+func TestSignatureVerifier_timestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signWith := func(ts time.Time) http.Header {
+		timestamp := strconv.FormatInt(ts.Unix(), 10)
+		return header(
+			"X-Signature", sign(sha256.New, append([]byte(timestamp+"."), body...)),
+			"X-Timestamp", timestamp,
+		)
+	}
+	tests := map[string]struct {
+		header http.Header
+		reason VerificationReason
+	}{
+		"valid":              {header: signWith(now.Add(-time.Minute))},
+		"clock skew":         {header: signWith(now.Add(time.Minute))},
+		"stale":              {header: signWith(now.Add(-10 * time.Minute)), reason: ReasonStale},
+		"future":             {header: signWith(now.Add(10 * time.Minute)), reason: ReasonStale},
+		"missing":            {header: header("X-Signature", sign(sha256.New, body)), reason: ReasonMissingSignature},
+		"invalid":            {header: header("X-Signature", sign(sha256.New, body), "X-Timestamp", "yesterday"), reason: ReasonInvalidSignature},
+		"unsigned timestamp": {header: header("X-Signature", sign(sha256.New, body), "X-Timestamp", strconv.FormatInt(now.Unix(), 10)), reason: ReasonInvalidSignature},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			verifier := NewHMACSHA256Verifier("X-Signature", "").WithTimestamp("X-Timestamp", 0)
+			verifier.now = func() time.Time { return now }
+			err := verifier.Verify(context.Background(), tt.header, body, secret)
+			g.Expect(ReasonForError(err)).To(Equal(tt.reason))
+		})
+	}
+}
+
+func TestReplayVerifier(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	verifier := &ReplayVerifier{Header: "X-GitHub-Delivery", Cache: NewMemoryNonceCache()}
+
+	g.Expect(verifier.Verify(ctx, header("X-GitHub-Delivery", "1"), body, secret)).To(Succeed())
+	g.Expect(verifier.Verify(ctx, header("X-GitHub-Delivery", "2"), body, secret)).To(Succeed())
+	err := verifier.Verify(ctx, header("X-GitHub-Delivery", "1"), body, secret)
+	g.Expect(IsReplayed(err)).To(BeTrue())
+
+	g.Expect(verifier.Verify(ctx, header(), body, secret)).To(Succeed())
+	verifier.Required = true
+	err = verifier.Verify(ctx, header(), body, secret)
+	g.Expect(IsUnauthorized(err)).To(BeTrue())
+
+	verifier.Cache = failingCache{}
+	err = verifier.Verify(ctx, header("X-GitHub-Delivery", "3"), body, secret)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ReasonForError(err)).To(BeEmpty())
+}
+
+type failingCache struct{}
+
+func (failingCache) Add(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestProviderVerifier(t *testing.T) {
+	tests := map[string]struct {
+		header   http.Header
+		delivery string
+	}{
+		ProviderGitHub: {
+			header:   header("X-Hub-Signature-256", "sha256="+sign(sha256.New, body)),
+			delivery: "X-GitHub-Delivery",
+		},
+		ProviderGitLab: {
+			header:   header("X-Gitlab-Token", secret),
+			delivery: "X-Gitlab-Event-UUID",
+		},
+		ProviderGitea: {
+			header:   header("X-Gitea-Signature", sign(sha256.New, body)),
+			delivery: "X-Gitea-Delivery",
+		},
+		ProviderBitbucket: {
+			header:   header("X-Hub-Signature", "sha256="+sign(sha256.New, body)),
+			delivery: "X-Request-UUID",
+		},
+	}
+	for provider, tt := range tests {
+		t.Run(provider, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ctx := context.Background()
+			verifier, ok := ProviderVerifier(provider, NewMemoryNonceCache())
+			g.Expect(ok).To(BeTrue())
+
+			g.Expect(verifier.Verify(ctx, header(), body, secret)).NotTo(Succeed())
+
+			tt.header.Set(tt.delivery, "delivery-1")
+			g.Expect(verifier.Verify(ctx, tt.header, body, "other")).NotTo(Succeed())
+			g.Expect(verifier.Verify(ctx, tt.header, body, secret)).To(Succeed())
+			g.Expect(IsReplayed(verifier.Verify(ctx, tt.header, body, secret))).To(BeTrue())
+		})
+	}
+
+	_, ok := ProviderVerifier("svn", nil)
+	NewGomegaWithT(t).Expect(ok).To(BeFalse())
+}
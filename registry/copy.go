@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	artifactv1 "github.com/katanomi/pkg/apis/artifacts/v1alpha1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"knative.dev/pkg/logging"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// CopyImageOptions options for copying an image
+type CopyImageOptions struct {
+	// Tags the copied image is tagged with, the tag of the target
+	// or the source uri is used if empty
+	Tags []string
+
+	// Referrers copies the artifacts referring to the image as well,
+	// such as signatures, attestations and sboms
+	Referrers bool
+
+	// SourceCredential credential of the source registry,
+	// the credential in the context is used if nil
+	SourceCredential *auth.Credential
+
+	// TargetCredential credential of the target registry,
+	// the credential in the context is used if nil
+	TargetCredential *auth.Credential
+}
+
+// CopyImage copies an image with all its blobs from the source to the target repository,
+// which could belong to different registries. It is used to promote an image when the tool
+// lacks native promotion. Returns the descriptor of the copied manifest.
+func CopyImage(ctx context.Context, source, target artifactv1.URI, options CopyImageOptions) (ocispec.Descriptor, error) {
+	log := logging.FromContext(ctx).Named("CopyImage").With("source", source.String(), "target", target.String())
+	if source.Host == "" || source.Path == "" || source.Version() == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("source host, path and tag or digest must be set")
+	}
+	if target.Host == "" || target.Path == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("target host and path must be set")
+	}
+
+	tags := options.Tags
+	if len(tags) == 0 {
+		switch {
+		case target.Tag != "":
+			tags = []string{target.Tag}
+		case source.Tag != "":
+			tags = []string{source.Tag}
+		}
+	}
+
+	src, err := newRemoteRepository(ctx, source, options.SourceCredential)
+	if err != nil {
+		log.Errorw("failed to new source repository", "err", err)
+		return ocispec.Descriptor{}, err
+	}
+	dst, err := newRemoteRepository(ctx, target, options.TargetCredential)
+	if err != nil {
+		log.Errorw("failed to new target repository", "err", err)
+		return ocispec.Descriptor{}, err
+	}
+
+	// pushes by digest when there is no tag, the remaining tags are added after copied
+	dstRef := ""
+	if len(tags) > 0 {
+		dstRef = tags[0]
+	}
+	var desc ocispec.Descriptor
+	if options.Referrers {
+		desc, err = oras.ExtendedCopy(ctx, src, source.Version(), dst, dstRef, oras.DefaultExtendedCopyOptions)
+	} else {
+		desc, err = oras.Copy(ctx, src, source.Version(), dst, dstRef, oras.DefaultCopyOptions)
+	}
+	if err != nil {
+		log.Errorw("failed to copy image", "err", err)
+		return ocispec.Descriptor{}, err
+	}
+	log.Debugw("copied image", "descriptor", desc)
+
+	if len(tags) > 1 {
+		if _, err = oras.TagN(ctx, dst, desc.Digest.String(), tags[1:], oras.DefaultTagNOptions); err != nil {
+			log.Errorw("failed to tag image", "err", err)
+			return ocispec.Descriptor{}, err
+		}
+	}
+	return desc, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	artifactv1 "github.com/katanomi/pkg/apis/artifacts/v1alpha1"
+	. "github.com/onsi/gomega"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+func TestCopyImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	source := httptest.NewTLSServer(registry.New())
+	defer source.Close()
+	target := httptest.NewTLSServer(registry.New())
+	defer target.Close()
+	sourceURL, _ := url.Parse(source.URL)
+	targetURL, _ := url.Parse(target.URL)
+
+	image := artifactv1.URI{Host: sourceURL.Host, Path: "dev/app", Tag: "v1.0.0"}
+	g.Expect(PushEmptyImage(ctx, image)).To(Succeed())
+
+	tests := map[string]struct {
+		source  artifactv1.URI
+		target  artifactv1.URI
+		options CopyImageOptions
+		tags    []string
+		wantErr bool
+	}{
+		"source without version": {
+			source:  artifactv1.URI{Host: sourceURL.Host, Path: "dev/app"},
+			target:  artifactv1.URI{Host: targetURL.Host, Path: "release/app"},
+			wantErr: true,
+		},
+		"target without path": {
+			source:  image,
+			target:  artifactv1.URI{Host: targetURL.Host},
+			wantErr: true,
+		},
+		"source not found": {
+			source:  artifactv1.URI{Host: sourceURL.Host, Path: "dev/app", Tag: "v2.0.0"},
+			target:  artifactv1.URI{Host: targetURL.Host, Path: "release/app"},
+			wantErr: true,
+		},
+		"keeps the source tag across registries": {
+			source: image,
+			target: artifactv1.URI{Host: targetURL.Host, Path: "release/app"},
+			tags:   []string{"v1.0.0"},
+		},
+		"uses the target tag in the same registry": {
+			source: image,
+			target: artifactv1.URI{Host: sourceURL.Host, Path: "release/app", Tag: "stable"},
+			tags:   []string{"stable"},
+		},
+		"tags with all the tags": {
+			source:  image,
+			target:  artifactv1.URI{Host: targetURL.Host, Path: "prod/app"},
+			options: CopyImageOptions{Tags: []string{"1.0", "latest"}, Referrers: true},
+			tags:    []string{"1.0", "latest"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			desc, err := CopyImage(ctx, tt.source, tt.target, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(Succeed())
+
+			repo, err := remote.NewRepository(tt.target.Repository())
+			g.Expect(err).To(Succeed())
+			repo.Client = target.Client()
+			for _, tag := range tt.tags {
+				got, err := repo.Resolve(ctx, tag)
+				g.Expect(err).To(Succeed(), tag)
+				g.Expect(got.Digest).To(Equal(desc.Digest), tag)
+			}
+		})
+	}
+}
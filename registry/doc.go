@@ -15,4 +15,5 @@ limitations under the License.
 */
 
 // Package registry contains functions to detect the registry scheme
+// and to push or copy images in registries
 package registry
@@ -62,29 +62,48 @@ func PushEmptyImage(ctx context.Context, uri artifactv1.URI) error {
 		return err
 	}
 
-	var httpCli *http.Client
-	restyClient := restclient.RESTClient(ctx)
-	if restyClient != nil {
-		httpCli = restyClient.GetClient()
-	} else {
-		httpCli = client.NewHTTPClient()
+	repo, err := newRemoteRepository(ctx, uri, nil)
+	if err != nil {
+		log.Errorw("failed to new repository", "err", err)
+		return err
 	}
 
-	repo, err := remote.NewRepository(uri.Repository())
+	// Copy from the memory store to the remote repository
+	_, err = oras.Copy(ctx, memStore, uri.Tag, repo, uri.Tag, oras.DefaultCopyOptions)
 	if err != nil {
-		log.Errorw("failed to new repository", "err", err)
 		return err
 	}
+	return nil
+}
+
+// httpClientFromContext returns the http client of the rest client in the context
+func httpClientFromContext(ctx context.Context) *http.Client {
+	if restyClient := restclient.RESTClient(ctx); restyClient != nil {
+		return restyClient.GetClient()
+	}
+	return client.NewHTTPClient()
+}
+
+// newRemoteRepository returns the repository of the uri, uses the credential
+// in the context if the credential is nil
+func newRemoteRepository(ctx context.Context, uri artifactv1.URI, credential *auth.Credential) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(uri.Repository())
+	if err != nil {
+		return nil, err
+	}
 
+	httpCli := httpClientFromContext(ctx)
 	schemeDeter := NewDefaultRegistrySchemeDetection(resty.NewWithClient(httpCli), true, true)
 	scheme, _ := schemeDeter.DetectScheme(ctx, uri.Host)
 	if scheme == HTTP {
 		repo.PlainHTTP = true
 	}
 
-	credential, err := extraAuthFromContext(ctx)
-	if err != nil {
-		log.Warnw("failed to extra auth, will try to push without credentials", "err", err)
+	cred := auth.EmptyCredential
+	if credential != nil {
+		cred = *credential
+	} else if cred, err = extraAuthFromContext(ctx); err != nil {
+		logging.FromContext(ctx).Warnw("failed to extra auth, will try without credentials", "err", err)
 	}
 
 	// need to make sure ignore authentication is set.
@@ -94,15 +113,9 @@ func PushEmptyImage(ctx context.Context, uri artifactv1.URI) error {
 	repo.Client = &auth.Client{
 		Client:     httpCli,
 		Cache:      auth.DefaultCache,
-		Credential: auth.StaticCredential(uri.Host, credential),
-	}
-
-	// Copy from the memory store to the remote repository
-	_, err = oras.Copy(ctx, memStore, uri.Tag, repo, uri.Tag, oras.DefaultCopyOptions)
-	if err != nil {
-		return err
+		Credential: auth.StaticCredential(uri.Host, cred),
 	}
-	return nil
+	return repo, nil
 }
 
 func extraAuthFromContext(ctx context.Context) (auth.Credential, error) {
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeCoberturaXml is the type of cobertura-xml
+	TypeCoberturaXml ReportType = "cobertura-xml"
+)
+
+// conditionCoverageRegexp matches the covered and total conditions of condition-coverage, e.g. "50% (1/2)"
+var conditionCoverageRegexp = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// CoberturaParser cobertura xml report parser
+type CoberturaParser struct {
+	CoverageSummary `json:",inline"`
+
+	// Files coverage of each source file
+	Files []FileCoverage `json:"files,omitempty"`
+}
+
+// coberturaCoverage the root element of cobertura xml report
+type coberturaCoverage struct {
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name    string           `xml:"name,attr"`
+	Classes []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name     string          `xml:"name,attr"`
+	Filename string          `xml:"filename,attr"`
+	Lines    []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int64  `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"`
+}
+
+// coverageLine the coverage of a line, a line may be reported by more than one class
+type coverageLine struct {
+	hit         bool
+	branchFound int
+	branchHit   int
+}
+
+// Parse parse cobertura xml report.
+func (p *CoberturaParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	coverage := coberturaCoverage{}
+	if err = xml.Unmarshal(content, &coverage); err != nil {
+		return nil, fmt.Errorf("invalid cobertura xml: %s", err.Error())
+	}
+
+	*p = CoberturaParser{}
+	lines := map[string]map[int]*coverageLine{}
+	for _, pkg := range coverage.Packages {
+		for _, class := range pkg.Classes {
+			if lines[class.Filename] == nil {
+				lines[class.Filename] = map[int]*coverageLine{}
+			}
+			for _, item := range class.Lines {
+				line, ok := lines[class.Filename][item.Number]
+				if !ok {
+					line = &coverageLine{}
+					lines[class.Filename][item.Number] = line
+				}
+				line.hit = line.hit || item.Hits > 0
+				if !item.Branch {
+					continue
+				}
+				branchHit, branchFound, err := parseConditionCoverage(item.ConditionCoverage)
+				if err != nil {
+					return nil, fmt.Errorf("invalid condition coverage of line %d in %s: %s", item.Number, class.Filename, err.Error())
+				}
+				line.branchFound = max(line.branchFound, branchFound)
+				line.branchHit = max(line.branchHit, branchHit)
+			}
+		}
+	}
+
+	files := fileCoverages{}
+	for name, fileLines := range lines {
+		file := files.get(name)
+		for _, line := range fileLines {
+			file.LineFound++
+			if line.hit {
+				file.LineHit++
+			}
+			file.BranchFound += line.branchFound
+			file.BranchHit += line.branchHit
+		}
+		p.add(file.CoverageSummary)
+	}
+	p.Files = files.list()
+
+	// reports without line details only contain the summary
+	if len(p.Files) == 0 || p.LineFound == 0 {
+		p.CoverageSummary = CoverageSummary{
+			LineFound:   coverage.LinesValid,
+			LineHit:     coverage.LinesCovered,
+			BranchFound: coverage.BranchesValid,
+			BranchHit:   coverage.BranchesCovered,
+		}
+	}
+	return p, nil
+}
+
+// ConvertToTestCoverage convert to TestCoverage
+func (p *CoberturaParser) ConvertToTestCoverage() v1alpha1.TestCoverage {
+	return p.CoverageSummary.ConvertToTestCoverage()
+}
+
+// parseConditionCoverage returns the covered and total conditions of condition-coverage
+func parseConditionCoverage(conditionCoverage string) (hit, found int, err error) {
+	matches := conditionCoverageRegexp.FindStringSubmatch(conditionCoverage)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("unknown format %q", conditionCoverage)
+	}
+	if hit, err = strconv.Atoi(matches[1]); err != nil {
+		return
+	}
+	found, err = strconv.Atoi(matches[2])
+	return
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestCoberturaParser_Coverage(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		wantTestCoverage v1alpha1.TestCoverage
+		wantFiles        []FileCoverage
+		wantErr          error
+	}{
+		"parse cobertura success": {
+			path: "./testdata/coberturaparser-success.xml",
+			wantTestCoverage: v1alpha1.TestCoverage{
+				Lines:    "71.43",
+				Branches: "75.00",
+			},
+			wantFiles: []FileCoverage{
+				{Name: "app/calc.py", CoverageSummary: CoverageSummary{LineFound: 4, LineHit: 2, BranchFound: 2, BranchHit: 1}},
+				{Name: "app/util.py", CoverageSummary: CoverageSummary{LineFound: 3, LineHit: 3, BranchFound: 2, BranchHit: 2}},
+			},
+		},
+		"report without lines": {
+			path: "./testdata/coberturaparser-summary.xml",
+			wantTestCoverage: v1alpha1.TestCoverage{
+				Lines:    "80.00",
+				Branches: "0.00",
+			},
+			wantFiles: []FileCoverage{},
+		},
+		"cobertura file not found": {
+			path:    "./testdata/coberturaparser-not-found.xml",
+			wantErr: fmt.Errorf("open ./testdata/coberturaparser-not-found.xml: no such file or directory"),
+		},
+		"cobertura invalid xml": {
+			path:    "./testdata/lcovparser-success.info",
+			wantErr: fmt.Errorf("invalid cobertura xml: EOF"),
+		},
+		"cobertura invalid condition coverage": {
+			path:    "./testdata/coberturaparser-failed.xml",
+			wantErr: fmt.Errorf(`invalid condition coverage of line 3 in app/calc.py: unknown format "n/a"`),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &CoberturaParser{}
+			testCoverage, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("CoberturaParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if testCoverage == nil {
+				return
+			}
+
+			converter, _ := testCoverage.(ConvertToTestCoverage)
+			outResult := converter.ConvertToTestCoverage()
+			if !reflect.DeepEqual(outResult, tt.wantTestCoverage) {
+				t.Errorf("CoberturaParser.ConvertToTestCoverage() = %v, want %v", outResult, tt.wantTestCoverage)
+			}
+			if !reflect.DeepEqual(p.Files, tt.wantFiles) {
+				t.Errorf("CoberturaParser.Files = %v, want %v", p.Files, tt.wantFiles)
+			}
+		})
+	}
+}
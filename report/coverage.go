@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+// CoverageSummary stores the number of lines and branches found and hit
+type CoverageSummary struct {
+	// LineFound found lines
+	LineFound int `json:"lineFound"`
+	// LineHit coverage hit lines
+	LineHit int `json:"lineHit"`
+	// BranchFound found branches
+	BranchFound int `json:"branchFound"`
+	// BranchHit coverage hit branches
+	BranchHit int `json:"branchHit"`
+}
+
+// ConvertToTestCoverage convert to TestCoverage
+func (c CoverageSummary) ConvertToTestCoverage() v1alpha1.TestCoverage {
+	return v1alpha1.TestCoverage{
+		Lines:    coverageRate(c.LineHit, c.LineFound),
+		Branches: coverageRate(c.BranchHit, c.BranchFound),
+	}
+}
+
+// add adds the numbers of another summary
+func (c *CoverageSummary) add(other CoverageSummary) {
+	c.LineFound += other.LineFound
+	c.LineHit += other.LineHit
+	c.BranchFound += other.BranchFound
+	c.BranchHit += other.BranchHit
+}
+
+// FileCoverage stores the coverage of a source file
+type FileCoverage struct {
+	// Name path of the source file
+	Name string `json:"name"`
+
+	CoverageSummary `json:",inline"`
+}
+
+// fileCoverages accumulates the coverage by file
+type fileCoverages map[string]*FileCoverage
+
+// get returns the coverage of the file, it is created if not exist
+func (f fileCoverages) get(name string) *FileCoverage {
+	file, ok := f[name]
+	if !ok {
+		file = &FileCoverage{Name: name}
+		f[name] = file
+	}
+	return file
+}
+
+// list returns the coverages sorted by file name
+func (f fileCoverages) list() []FileCoverage {
+	files := make([]FileCoverage, 0, len(f))
+	for _, file := range f {
+		files = append(files, *file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files
+}
+
+// coverageRate returns the percentage of hit in found with two decimals
+func coverageRate(hit, found int) string {
+	var rate float64
+	if found != 0 {
+		rate = float64(hit) / float64(found) * 100
+	}
+	return fmt.Sprintf("%.2f", rate)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeGoCoverProfile is the type of go-coverprofile, generated by go test -coverprofile
+	TypeGoCoverProfile ReportType = "go-coverprofile"
+)
+
+// goCoverModePrefix the first line of the coverprofile
+const goCoverModePrefix = "mode:"
+
+// GoCoverProfileParser go coverprofile parser.
+// The profile has no branch coverage, lines are counted as in lcov reports
+// converted from the profile: the lines of a block are hit if the block is executed.
+type GoCoverProfileParser struct {
+	CoverageSummary `json:",inline"`
+
+	// Files coverage of each source file
+	Files []FileCoverage `json:"files,omitempty"`
+}
+
+// Parse parse go coverprofile.
+func (p *GoCoverProfileParser) Parse(path string) (result interface{}, err error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fi.Close()
+
+	// lines of each file, the value is true if the line is hit
+	lines := map[string]map[int]bool{}
+	scanner := bufio.NewScanner(fi)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if number == 1 {
+			if !strings.HasPrefix(line, goCoverModePrefix) {
+				return nil, fmt.Errorf("invalid go coverprofile: missing %q line", goCoverModePrefix)
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if err = parseGoCoverBlock(line, lines); err != nil {
+			return nil, fmt.Errorf("invalid go coverprofile text:%s. error: %s", line, err.Error())
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	*p = GoCoverProfileParser{}
+	files := fileCoverages{}
+	for name, fileLines := range lines {
+		file := files.get(name)
+		for _, hit := range fileLines {
+			file.LineFound++
+			if hit {
+				file.LineHit++
+			}
+		}
+		p.add(file.CoverageSummary)
+	}
+	p.Files = files.list()
+	return p, nil
+}
+
+// ConvertToTestCoverage convert to TestCoverage, branches are not set because the profile has no branch coverage.
+func (p *GoCoverProfileParser) ConvertToTestCoverage() v1alpha1.TestCoverage {
+	return v1alpha1.TestCoverage{Lines: coverageRate(p.LineHit, p.LineFound)}
+}
+
+// parseGoCoverBlock parses a block line as "name.go:line.column,line.column numberOfStatements count"
+func parseGoCoverBlock(line string, lines map[string]map[int]bool) error {
+	index := strings.LastIndex(line, ":")
+	if index < 0 {
+		return fmt.Errorf("missing file name")
+	}
+	file := line[:index]
+	fields := strings.Fields(line[index+1:])
+	if len(fields) != 3 {
+		return fmt.Errorf("expected 3 fields but got %d", len(fields))
+	}
+	start, end, found := strings.Cut(fields[0], ",")
+	if !found {
+		return fmt.Errorf("invalid block position %q", fields[0])
+	}
+	startLine, err := goCoverLine(start)
+	if err != nil {
+		return err
+	}
+	endLine, err := goCoverLine(end)
+	if err != nil {
+		return err
+	}
+	statements, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return err
+	}
+	count, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return err
+	}
+	if statements == 0 {
+		return nil
+	}
+
+	if lines[file] == nil {
+		lines[file] = map[int]bool{}
+	}
+	for number := startLine; number <= endLine; number++ {
+		lines[file][number] = lines[file][number] || count > 0
+	}
+	return nil
+}
+
+// goCoverLine returns the line of a position as "line.column"
+func goCoverLine(position string) (int, error) {
+	line, _, _ := strings.Cut(position, ".")
+	return strconv.Atoi(line)
+}
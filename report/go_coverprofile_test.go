@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestGoCoverProfileParser_Coverage(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		wantTestCoverage v1alpha1.TestCoverage
+		wantFiles        []FileCoverage
+		wantErr          error
+	}{
+		"parse go coverprofile success": {
+			path:             "./testdata/gocoverprofile-success.out",
+			wantTestCoverage: v1alpha1.TestCoverage{Lines: "81.82"},
+			wantFiles: []FileCoverage{
+				{Name: "example.com/demo/calc.go", CoverageSummary: CoverageSummary{LineFound: 8, LineHit: 6}},
+				{Name: "example.com/demo/util.go", CoverageSummary: CoverageSummary{LineFound: 3, LineHit: 3}},
+			},
+		},
+		"go coverprofile file not found": {
+			path:    "./testdata/gocoverprofile-not-found.out",
+			wantErr: fmt.Errorf("open ./testdata/gocoverprofile-not-found.out: no such file or directory"),
+		},
+		"go coverprofile without mode": {
+			path:    "./testdata/gocoverprofile-nomode.out",
+			wantErr: fmt.Errorf(`invalid go coverprofile: missing "mode:" line`),
+		},
+		"go coverprofile parse block failed": {
+			path:    "./testdata/gocoverprofile-failed.out",
+			wantErr: fmt.Errorf(`invalid go coverprofile text:example.com/demo/calc.go:3.24,5.2 x 1. error: strconv.Atoi: parsing "x": invalid syntax`),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &GoCoverProfileParser{}
+			testCoverage, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("GoCoverProfileParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if testCoverage == nil {
+				return
+			}
+
+			converter, _ := testCoverage.(ConvertToTestCoverage)
+			outResult := converter.ConvertToTestCoverage()
+			if !reflect.DeepEqual(outResult, tt.wantTestCoverage) {
+				t.Errorf("GoCoverProfileParser.ConvertToTestCoverage() = %v, want %v", outResult, tt.wantTestCoverage)
+			}
+			if !reflect.DeepEqual(p.Files, tt.wantFiles) {
+				t.Errorf("GoCoverProfileParser.Files = %v, want %v", p.Files, tt.wantFiles)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeGoTestJson is the type of go-test-json, generated by go test -json
+	TypeGoTestJson ReportType = "go-test-json"
+)
+
+// actions of go test -json events
+// detail: https://pkg.go.dev/cmd/test2json
+const (
+	goTestActionPass   = "pass"
+	goTestActionFail   = "fail"
+	goTestActionSkip   = "skip"
+	goTestActionOutput = "output"
+)
+
+// GoTestJsonParser go test json parser
+type GoTestJsonParser struct {
+	TestSummary `json:",inline"`
+	TestCases   `json:",inline"`
+}
+
+// GoTestEvent an event of go test -json
+type GoTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package,omitempty"`
+	Test    string  `json:"Test,omitempty"`
+	Elapsed float64 `json:"Elapsed,omitempty"`
+	Output  string  `json:"Output,omitempty"`
+}
+
+// Parse parse go test json report.
+// Each test and subtest is counted as a test case, a package failed without
+// any failed test (e.g. build failure) is counted as an errored test case.
+// Outputs of a failed or skipped test are kept as its message and stack.
+func (p *GoTestJsonParser) Parse(path string) (result interface{}, err error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fi.Close()
+
+	*p = GoTestJsonParser{}
+	failedTests := map[string]int{}
+	outputs := map[string][]string{}
+	var failedPackages []GoTestEvent
+	scanner := bufio.NewScanner(fi)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		// build output is printed as plain text
+		if !bytes.HasPrefix(line, []byte("{")) {
+			continue
+		}
+		event := GoTestEvent{}
+		if err = json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("invalid go test json text:%s. error: %s", string(line), err.Error())
+		}
+
+		key := event.Package + "/" + event.Test
+		if event.Action == goTestActionOutput {
+			outputs[key] = append(outputs[key], event.Output)
+			continue
+		}
+		if event.Test == "" {
+			// package level events
+			if event.Action == goTestActionFail {
+				failedPackages = append(failedPackages, event)
+			}
+			continue
+		}
+
+		testCase := v1alpha1.TestCaseResult{
+			Suite:    event.Package,
+			Name:     event.Test,
+			Duration: durationOfSeconds(event.Elapsed),
+		}
+		switch event.Action {
+		case goTestActionPass:
+			testCase.Status = v1alpha1.TestCaseStatusPassed
+		case goTestActionFail:
+			testCase.Status = v1alpha1.TestCaseStatusFailed
+			failedTests[event.Package]++
+		case goTestActionSkip:
+			testCase.Status = v1alpha1.TestCaseStatusSkipped
+		default:
+			// no action
+			continue
+		}
+		if testCase.Status != v1alpha1.TestCaseStatusPassed {
+			testCase.Message, testCase.Stack = goTestOutputMessage(outputs[key])
+		}
+		// outputs of a test run with -count are reported again for each attempt
+		delete(outputs, key)
+		p.addStatus(testCase.Status)
+		p.addCase(testCase)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, event := range failedPackages {
+		if failedTests[event.Package] == 0 {
+			testCase := v1alpha1.TestCaseResult{
+				Suite:    event.Package,
+				Name:     event.Package,
+				Duration: durationOfSeconds(event.Elapsed),
+				Status:   v1alpha1.TestCaseStatusError,
+			}
+			testCase.Message, testCase.Stack = goTestOutputMessage(outputs[event.Package+"/"])
+			p.addStatus(testCase.Status)
+			p.addCase(testCase)
+		}
+	}
+	return p, nil
+}
+
+// goTestOutputMessage returns the first line of the test output as message and
+// the whole output as stack, the framing lines printed by go test are omitted
+func goTestOutputMessage(outputs []string) (message, stack string) {
+	lines := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		line := strings.TrimRight(output, "\n")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- ") {
+			continue
+		}
+		if message == "" {
+			message = trimmed
+		}
+		lines = append(lines, line)
+	}
+	return message, strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestGoTestJsonParser_Result(t *testing.T) {
+	tests := map[string]struct {
+		path                    string
+		wantTestResult          v1alpha1.TestResult
+		wantAutomatedTestResult v1alpha1.AutomatedTestResult
+		wantErr                 error
+	}{
+		"go test json success": {
+			path: "./testdata/gotestjsonparser-success.json",
+			wantTestResult: v1alpha1.TestResult{
+				Passed:          1,
+				Failed:          3,
+				Skipped:         1,
+				PassedTestsRate: "25.00",
+			},
+			wantAutomatedTestResult: v1alpha1.AutomatedTestResult{
+				Total:           5,
+				Passed:          1,
+				Failed:          2,
+				Error:           1,
+				Skipped:         1,
+				PassedTestsRate: 0.25,
+			},
+		},
+		"go test json file not found": {
+			path:    "./testdata/gotestjsonparser-not-found.json",
+			wantErr: fmt.Errorf("open ./testdata/gotestjsonparser-not-found.json: no such file or directory"),
+		},
+		"go test json parse failed": {
+			path:    "./testdata/gotestjsonparser-failed.json",
+			wantErr: fmt.Errorf(`invalid go test json text:{"Action":"pass",. error: unexpected end of JSON input`),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &GoTestJsonParser{}
+			testResult, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("GoTestJsonParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if testResult == nil {
+				return
+			}
+
+			outResult := testResult.(ConvertToTestResult).ConvertToTestResult()
+			if !reflect.DeepEqual(outResult, tt.wantTestResult) {
+				t.Errorf("GoTestJsonParser.ConvertToTestResult() = %v, want %v", outResult, tt.wantTestResult)
+			}
+			outAutomatedResult := testResult.(ConvertToAutomatedTestResult).ConvertToAutomatedTestResult()
+			if !reflect.DeepEqual(outAutomatedResult, tt.wantAutomatedTestResult) {
+				t.Errorf("GoTestJsonParser.ConvertToAutomatedTestResult() = %v, want %v", outAutomatedResult, tt.wantAutomatedTestResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+const (
+	// TypeGrypeJson is the type of grype-json, generated by grype -o json
+	TypeGrypeJson ReportType = "grype-json"
+)
+
+// source types of grype reports
+const (
+	grypeSourceTypeImage = "image"
+)
+
+// GrypeJsonParser grype json report parser
+// detail: https://github.com/anchore/grype#supported-output-formats
+type GrypeJsonParser struct {
+	GrypeReport `json:",inline"`
+}
+
+// GrypeReport the report of grype, only the properties used for counting are parsed
+type GrypeReport struct {
+	Matches []GrypeMatch `json:"matches"`
+	Source  *GrypeSource `json:"source"`
+}
+
+// GrypeMatch a vulnerability matched with a package
+type GrypeMatch struct {
+	Vulnerability          GrypeVulnerability   `json:"vulnerability"`
+	RelatedVulnerabilities []GrypeVulnerability `json:"relatedVulnerabilities,omitempty"`
+	Artifact               GrypeArtifact        `json:"artifact"`
+}
+
+// GrypeVulnerability a vulnerability
+type GrypeVulnerability struct {
+	ID        string      `json:"id"`
+	Namespace string      `json:"namespace,omitempty"`
+	Severity  string      `json:"severity"`
+	CVSS      []GrypeCVSS `json:"cvss,omitempty"`
+}
+
+// GrypeCVSS the CVSS of a vulnerability
+type GrypeCVSS struct {
+	Source  string `json:"source,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Version string `json:"version"`
+	Vector  string `json:"vector,omitempty"`
+	Metrics struct {
+		BaseScore float64 `json:"baseScore"`
+	} `json:"metrics"`
+}
+
+// GrypeArtifact the package a vulnerability is matched with
+type GrypeArtifact struct {
+	Name      string          `json:"name"`
+	Version   string          `json:"version"`
+	Type      string          `json:"type,omitempty"`
+	Locations []GrypeLocation `json:"locations,omitempty"`
+}
+
+// GrypeLocation a location of a package
+type GrypeLocation struct {
+	Path    string `json:"path"`
+	LayerID string `json:"layerID,omitempty"`
+}
+
+// GrypeSource the scanned source
+type GrypeSource struct {
+	Type string `json:"type"`
+	// Target is an object of the image for images, a path for directories and files
+	Target json.RawMessage `json:"target"`
+}
+
+// Parse parse grype json report.
+func (p *GrypeJsonParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	*p = GrypeJsonParser{}
+	if err = json.Unmarshal(content, &p.GrypeReport); err != nil {
+		return nil, fmt.Errorf("invalid grype json: %s", err.Error())
+	}
+	if p.Source == nil {
+		return nil, fmt.Errorf("invalid grype json: source is missing")
+	}
+	return p, nil
+}
+
+// ConvertToVulnScanResult convert to VulnScanResult.
+// The scanned source is the only target, the same vulnerability of the same package
+// version is counted once even if it is matched in several layers or locations.
+// The score is the highest CVSS base score of the vulnerability with the latest CVSS version,
+// the scores of related vulnerabilities are used if the vulnerability has none.
+func (p *GrypeJsonParser) ConvertToVulnScanResult() securityv1alpha1.VulnScanResult {
+	targets := &vulnTargets{}
+	target := targets.get(p.Source.uri(), p.Source.targetType())
+	for _, match := range p.Matches {
+		source, score := match.Vulnerability.score()
+		for _, related := range match.RelatedVulnerabilities {
+			if score > 0 {
+				break
+			}
+			source, score = related.score()
+		}
+		id := match.Vulnerability.ID + "@" + match.Artifact.Name + "@" + match.Artifact.Version
+		target.add(id, ParseVulnSeverity(match.Vulnerability.Severity), score, source, 1)
+	}
+	return securityv1alpha1.VulnScanResult{Targets: targets.list()}
+}
+
+// score returns the source and the highest base score of the latest CVSS version
+func (v GrypeVulnerability) score() (source string, score float64) {
+	version := ""
+	for _, cvss := range v.CVSS {
+		switch {
+		case cvss.Version > version:
+			version, source, score = cvss.Version, cvss.Source, cvss.Metrics.BaseScore
+		case cvss.Version == version && cvss.Metrics.BaseScore > score:
+			source, score = cvss.Source, cvss.Metrics.BaseScore
+		}
+	}
+	if source == "" {
+		source = v.Namespace
+	}
+	return source, score
+}
+
+// uri returns the uri of the scanned source
+func (s *GrypeSource) uri() string {
+	var target string
+	if err := json.Unmarshal(s.Target, &target); err == nil {
+		return target
+	}
+	image := struct {
+		UserInput string `json:"userInput"`
+	}{}
+	if err := json.Unmarshal(s.Target, &image); err == nil {
+		return image.UserInput
+	}
+	return strings.TrimSpace(string(s.Target))
+}
+
+// targetType returns the target type of the scanned source, directories
+// and files are treated as file system
+func (s *GrypeSource) targetType() securityv1alpha1.VulnScanTargetType {
+	if s.Type == grypeSourceTypeImage {
+		return securityv1alpha1.VulnScanTargetTypeImage
+	}
+	return securityv1alpha1.VulnScanTargetTypeFileSystem
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+func TestGrypeJsonParser_VulnScanResult(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		want    securityv1alpha1.VulnScanResult
+		wantErr error
+	}{
+		"image report": {
+			path: "./testdata/grypejsonparser-image.json",
+			want: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{{
+					Uri:           "alpine:3.17",
+					Type:          securityv1alpha1.VulnScanTargetTypeImage,
+					Cvss:          securityv1alpha1.CVSS{Source: "github", Severity: "Critical", Score: "9.8"},
+					VulnStatistic: securityv1alpha1.VulnStatistic{CriticalCount: 1, HighCount: 1, LowCount: 1},
+				}},
+			},
+		},
+		"directory report": {
+			path: "./testdata/grypejsonparser-dir.json",
+			want: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{{
+					Uri:           "/workspace/source",
+					Type:          securityv1alpha1.VulnScanTargetTypeFileSystem,
+					Cvss:          securityv1alpha1.CVSS{Source: "nvd", Severity: "Medium", Score: "6.5"},
+					VulnStatistic: securityv1alpha1.VulnStatistic{MediumCount: 1},
+				}},
+			},
+		},
+		"grype file not found": {
+			path:    "./testdata/grypejsonparser-not-found.json",
+			wantErr: fmt.Errorf("open ./testdata/grypejsonparser-not-found.json: no such file or directory"),
+		},
+		"grype invalid json": {
+			path:    "./testdata/grypejsonparser-failed.json",
+			wantErr: fmt.Errorf("invalid grype json: unexpected end of JSON input"),
+		},
+		"grype report without source": {
+			path:    "./testdata/grypejsonparser-nosource.json",
+			wantErr: fmt.Errorf("invalid grype json: source is missing"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &GrypeJsonParser{}
+			result, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("GrypeJsonParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if result == nil {
+				return
+			}
+
+			got := result.(ConvertToVulnScanResult).ConvertToVulnScanResult()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrypeJsonParser.ConvertToVulnScanResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
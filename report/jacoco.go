@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeJacocoXml is the type of jacoco-xml
+	TypeJacocoXml ReportType = "jacoco-xml"
+)
+
+const (
+	// detail: https://www.jacoco.org/jacoco/trunk/doc/counters.html
+	// jacocoCounterLine counter of the lines
+	jacocoCounterLine = "LINE"
+	// jacocoCounterBranch counter of the branches
+	jacocoCounterBranch = "BRANCH"
+)
+
+// JacocoParser jacoco xml report parser
+type JacocoParser struct {
+	CoverageSummary `json:",inline"`
+
+	// Files coverage of each source file
+	Files []FileCoverage `json:"files,omitempty"`
+}
+
+// jacocoGroup is the root element of jacoco xml report,
+// reports of multi-module projects contain nested groups.
+type jacocoGroup struct {
+	Groups   []jacocoGroup   `xml:"group"`
+	Packages []jacocoPackage `xml:"package"`
+	Counters []jacocoCounter `xml:"counter"`
+}
+
+type jacocoPackage struct {
+	Name        string             `xml:"name,attr"`
+	SourceFiles []jacocoSourceFile `xml:"sourcefile"`
+}
+
+type jacocoSourceFile struct {
+	Name     string          `xml:"name,attr"`
+	Counters []jacocoCounter `xml:"counter"`
+}
+
+type jacocoCounter struct {
+	Type    string `xml:"type,attr"`
+	Missed  int    `xml:"missed,attr"`
+	Covered int    `xml:"covered,attr"`
+}
+
+// Parse parse jacoco xml report.
+func (p *JacocoParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := jacocoGroup{}
+	if err = xml.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("invalid jacoco xml: %s", err.Error())
+	}
+
+	*p = JacocoParser{}
+	files := fileCoverages{}
+	var sum CoverageSummary
+	report.collectFiles(files)
+	for _, file := range files {
+		sum.add(file.CoverageSummary)
+	}
+	p.Files = files.list()
+
+	p.CoverageSummary = jacocoSummary(report.Counters)
+	if len(report.Counters) == 0 {
+		p.CoverageSummary = sum
+	}
+	return p, nil
+}
+
+// ConvertToTestCoverage convert to TestCoverage
+func (p *JacocoParser) ConvertToTestCoverage() v1alpha1.TestCoverage {
+	return p.CoverageSummary.ConvertToTestCoverage()
+}
+
+// collectFiles collects the coverage of source files in the group and its sub groups
+func (g jacocoGroup) collectFiles(files fileCoverages) {
+	for _, group := range g.Groups {
+		group.collectFiles(files)
+	}
+	for _, pkg := range g.Packages {
+		for _, sourceFile := range pkg.SourceFiles {
+			files.get(path.Join(pkg.Name, sourceFile.Name)).add(jacocoSummary(sourceFile.Counters))
+		}
+	}
+}
+
+// jacocoSummary converts the line and branch counters to CoverageSummary
+func jacocoSummary(counters []jacocoCounter) (summary CoverageSummary) {
+	for _, counter := range counters {
+		switch counter.Type {
+		case jacocoCounterLine:
+			summary.LineFound += counter.Missed + counter.Covered
+			summary.LineHit += counter.Covered
+		case jacocoCounterBranch:
+			summary.BranchFound += counter.Missed + counter.Covered
+			summary.BranchHit += counter.Covered
+		default:
+			// no action
+		}
+	}
+	return
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestJacocoParser_Coverage(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		wantTestCoverage v1alpha1.TestCoverage
+		wantFiles        []FileCoverage
+		wantErr          error
+	}{
+		"parse jacoco success": {
+			path: "./testdata/jacocoparser-success.xml",
+			wantTestCoverage: v1alpha1.TestCoverage{
+				Lines:    "70.00",
+				Branches: "50.00",
+			},
+			wantFiles: []FileCoverage{
+				{Name: "com/example/Calc.java", CoverageSummary: CoverageSummary{LineFound: 5, LineHit: 4, BranchFound: 2, BranchHit: 1}},
+				{Name: "com/example/util/Util.java", CoverageSummary: CoverageSummary{LineFound: 5, LineHit: 3, BranchFound: 2, BranchHit: 1}},
+			},
+		},
+		"jacoco file not found": {
+			path:    "./testdata/jacocoparser-not-found.xml",
+			wantErr: fmt.Errorf("open ./testdata/jacocoparser-not-found.xml: no such file or directory"),
+		},
+		"jacoco invalid counter": {
+			path:    "./testdata/jacocoparser-failed.xml",
+			wantErr: fmt.Errorf(`invalid jacoco xml: strconv.ParseInt: parsing "three": invalid syntax`),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &JacocoParser{}
+			testCoverage, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("JacocoParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if testCoverage == nil {
+				return
+			}
+
+			converter, _ := testCoverage.(ConvertToTestCoverage)
+			outResult := converter.ConvertToTestCoverage()
+			if !reflect.DeepEqual(outResult, tt.wantTestCoverage) {
+				t.Errorf("JacocoParser.ConvertToTestCoverage() = %v, want %v", outResult, tt.wantTestCoverage)
+			}
+			if !reflect.DeepEqual(p.Files, tt.wantFiles) {
+				t.Errorf("JacocoParser.Files = %v, want %v", p.Files, tt.wantFiles)
+			}
+		})
+	}
+}
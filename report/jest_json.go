@@ -19,6 +19,7 @@ package report
 import (
 	"encoding/json"
 	"os"
+	"strings"
 
 	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
 )
@@ -52,6 +53,25 @@ type JestJsonParser struct {
 	NumTotalTests int `json:"numTotalTests"`
 	// testsuites run status
 	Success bool `json:"success"`
+	// results of each test file
+	TestResults []jestJsonTestResult `json:"testResults"`
+}
+
+// jestJsonTestResult results of a test file
+type jestJsonTestResult struct {
+	Name             string                    `json:"name"`
+	AssertionResults []jestJsonAssertionResult `json:"assertionResults"`
+}
+
+type jestJsonAssertionResult struct {
+	AncestorTitles []string `json:"ancestorTitles"`
+	Title          string   `json:"title"`
+	Status         string   `json:"status"`
+	// Duration milliseconds taken to run the test case, null if not run
+	Duration        *float64 `json:"duration"`
+	FailureMessages []string `json:"failureMessages"`
+	// RetryReasons failures of the previous attempts when retried
+	RetryReasons []string `json:"retryReasons"`
 }
 
 // Parse pase jest json report.
@@ -61,6 +81,7 @@ func (m *JestJsonParser) Parse(path string) (result interface{}, err error) {
 		return nil, err
 	}
 
+	*m = JestJsonParser{}
 	err = json.Unmarshal(content, m)
 	if err != nil {
 		return nil, err
@@ -80,3 +101,37 @@ func (m *JestJsonParser) ConvertToTestResult() v1alpha1.TestResult {
 
 	return testResult
 }
+
+// ConvertToTestCases convert to the results of each test case,
+// a test case passed after retries is marked as flaky
+func (m *JestJsonParser) ConvertToTestCases() (cases []v1alpha1.TestCaseResult) {
+	for _, file := range m.TestResults {
+		for _, item := range file.AssertionResults {
+			testCase := v1alpha1.TestCaseResult{
+				Suite:     file.Name,
+				Name:      item.Title,
+				ClassName: strings.Join(item.AncestorTitles, " "),
+				Retries:   len(item.RetryReasons),
+			}
+			if item.Duration != nil {
+				testCase.Duration = durationOfSeconds(*item.Duration / 1000)
+			}
+			switch item.Status {
+			case "passed":
+				testCase.Status = v1alpha1.TestCaseStatusPassed
+				testCase.Flaky = testCase.Retries > 0
+			case "failed":
+				testCase.Status = v1alpha1.TestCaseStatusFailed
+				testCase.Stack = strings.Join(item.FailureMessages, "\n")
+				if len(item.FailureMessages) > 0 {
+					testCase.Message, _, _ = strings.Cut(item.FailureMessages[0], "\n")
+				}
+			default:
+				// pending, todo, skipped and disabled test cases are not run
+				testCase.Status = v1alpha1.TestCaseStatusSkipped
+			}
+			cases = append(cases, testCase)
+		}
+	}
+	return cases
+}
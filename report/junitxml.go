@@ -19,6 +19,7 @@ package report
 import (
 	"github.com/joshdk/go-junit"
 	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -78,3 +79,42 @@ func (m *JunitParser) ConvertToAutomatedTestResult() v1alpha1.AutomatedTestResul
 	}
 	return summary
 }
+
+// ConvertToTestCases convert to the results of each test case
+func (m *JunitParser) ConvertToTestCases() []v1alpha1.TestCaseResult {
+	return junitTestCases(m.Suites)
+}
+
+// junitTestCases collects test cases of the suites and their nested suites
+func junitTestCases(suites []junit.Suite) (cases []v1alpha1.TestCaseResult) {
+	for _, suite := range suites {
+		cases = append(cases, junitTestCases(suite.Suites)...)
+		for _, test := range suite.Tests {
+			testCase := v1alpha1.TestCaseResult{
+				Suite:     suite.Name,
+				Name:      test.Name,
+				ClassName: test.Classname,
+				Duration:  metav1.Duration{Duration: test.Duration},
+				Message:   test.Message,
+			}
+			switch test.Status {
+			case junit.StatusPassed:
+				testCase.Status = v1alpha1.TestCaseStatusPassed
+			case junit.StatusFailed:
+				testCase.Status = v1alpha1.TestCaseStatusFailed
+			case junit.StatusError:
+				testCase.Status = v1alpha1.TestCaseStatusError
+			default:
+				testCase.Status = v1alpha1.TestCaseStatusSkipped
+			}
+			if junitErr, ok := test.Error.(junit.Error); ok {
+				if testCase.Message == "" {
+					testCase.Message = junitErr.Message
+				}
+				testCase.Stack = junitErr.Body
+			}
+			cases = append(cases, testCase)
+		}
+	}
+	return cases
+}
@@ -19,8 +19,10 @@ package report
 import (
 	"encoding/json"
 	"os"
+	"time"
 
 	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -31,6 +33,28 @@ const (
 // MochaJsonParser mocha json report parser
 type MochaJsonParser struct {
 	Stats MochaJsonStats `json:"stats"`
+	// Results suites of each test file
+	Results []mochaJsonSuite `json:"results"`
+}
+
+// mochaJsonSuite a suite with its test cases and nested suites
+type mochaJsonSuite struct {
+	Title  string           `json:"title"`
+	Tests  []mochaJsonTest  `json:"tests"`
+	Suites []mochaJsonSuite `json:"suites"`
+}
+
+type mochaJsonTest struct {
+	Title     string `json:"title"`
+	FullTitle string `json:"fullTitle"`
+	// Duration milliseconds taken to run the test case
+	Duration int64 `json:"duration"`
+	Pass     bool  `json:"pass"`
+	Fail     bool  `json:"fail"`
+	Err      struct {
+		Message string `json:"message"`
+		EStack  string `json:"estack"`
+	} `json:"err"`
 }
 
 // MochaJsonStats unit test data structures for Mocha json.
@@ -58,6 +82,7 @@ func (m *MochaJsonParser) Parse(path string) (interface{}, error) {
 		return nil, err
 	}
 
+	*m = MochaJsonParser{}
 	err = json.Unmarshal(content, m)
 	if err != nil {
 		return nil, err
@@ -75,3 +100,34 @@ func (m *MochaJsonParser) ConvertToTestResult() v1alpha1.TestResult {
 	testResult.PassedTestsRate = v1alpha1.PassedTestsRate(&testResult)
 	return testResult
 }
+
+// ConvertToTestCases convert to the results of each test case
+func (m *MochaJsonParser) ConvertToTestCases() []v1alpha1.TestCaseResult {
+	return mochaTestCases(m.Results)
+}
+
+// mochaTestCases collects test cases of the suites and their nested suites
+func mochaTestCases(suites []mochaJsonSuite) (cases []v1alpha1.TestCaseResult) {
+	for _, suite := range suites {
+		for _, test := range suite.Tests {
+			testCase := v1alpha1.TestCaseResult{
+				Suite:     suite.Title,
+				Name:      test.FullTitle,
+				ClassName: test.Title,
+				Duration:  metav1.Duration{Duration: time.Duration(test.Duration) * time.Millisecond},
+				Status:    v1alpha1.TestCaseStatusSkipped,
+			}
+			switch {
+			case test.Fail:
+				testCase.Status = v1alpha1.TestCaseStatusFailed
+				testCase.Message = test.Err.Message
+				testCase.Stack = test.Err.EStack
+			case test.Pass:
+				testCase.Status = v1alpha1.TestCaseStatusPassed
+			}
+			cases = append(cases, testCase)
+		}
+		cases = append(cases, mochaTestCases(suite.Suites)...)
+	}
+	return cases
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeNUnitXml is the type of nunit-xml, the NUnit 3 test result format
+	TypeNUnitXml ReportType = "nunit-xml"
+)
+
+// results and labels of NUnit 3 test cases
+// detail: https://docs.nunit.org/articles/nunit/technical-notes/usage/Test-Result-XML-Format.html
+const (
+	nunitResultPassed       = "Passed"
+	nunitResultFailed       = "Failed"
+	nunitResultSkipped      = "Skipped"
+	nunitResultInconclusive = "Inconclusive"
+	nunitResultWarning      = "Warning"
+
+	nunitLabelError     = "Error"
+	nunitLabelInvalid   = "Invalid"
+	nunitLabelCancelled = "Cancelled"
+)
+
+// NUnitParser NUnit 3 xml report parser
+type NUnitParser struct {
+	TestSummary `json:",inline"`
+	TestCases   `json:",inline"`
+}
+
+// nunitTestSuite is a test-suite or the root test-run element
+type nunitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	TestSuites []nunitTestSuite `xml:"test-suite"`
+	TestCases  []nunitTestCase  `xml:"test-case"`
+}
+
+type nunitTestCase struct {
+	Name      string  `xml:"name,attr"`
+	ClassName string  `xml:"classname,attr"`
+	Result    string  `xml:"result,attr"`
+	Label     string  `xml:"label,attr"`
+	Duration  float64 `xml:"duration,attr"`
+	// Failure failure detail of a failed test case
+	Failure nunitMessage `xml:"failure"`
+	// Reason reason of a skipped or inconclusive test case
+	Reason nunitMessage `xml:"reason"`
+}
+
+type nunitMessage struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+// Parse parse NUnit 3 xml report.
+func (p *NUnitParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	run := nunitTestSuite{}
+	if err = xml.Unmarshal(content, &run); err != nil {
+		return nil, fmt.Errorf("invalid nunit xml: %s", err.Error())
+	}
+
+	*p = NUnitParser{}
+	if err = p.collect(run); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// collect counts the test cases in the suite and its sub suites
+func (p *NUnitParser) collect(suite nunitTestSuite) error {
+	for _, item := range suite.TestSuites {
+		if err := p.collect(item); err != nil {
+			return err
+		}
+	}
+	for _, item := range suite.TestCases {
+		testCase := v1alpha1.TestCaseResult{
+			Suite:     suite.Name,
+			Name:      item.Name,
+			ClassName: item.ClassName,
+			Duration:  durationOfSeconds(item.Duration),
+			Message:   strings.TrimSpace(item.Failure.Message),
+			Stack:     strings.TrimSpace(item.Failure.StackTrace),
+		}
+		switch item.Result {
+		case nunitResultPassed, nunitResultWarning:
+			testCase.Status = v1alpha1.TestCaseStatusPassed
+		case nunitResultFailed:
+			if item.Label == nunitLabelError || item.Label == nunitLabelInvalid || item.Label == nunitLabelCancelled {
+				testCase.Status = v1alpha1.TestCaseStatusError
+			} else {
+				testCase.Status = v1alpha1.TestCaseStatusFailed
+			}
+		case nunitResultSkipped, nunitResultInconclusive:
+			testCase.Status = v1alpha1.TestCaseStatusSkipped
+			testCase.Message = strings.TrimSpace(item.Reason.Message)
+		default:
+			return fmt.Errorf("unknown result %q of test case %q", item.Result, item.Name)
+		}
+		p.addStatus(testCase.Status)
+		p.addCase(testCase)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestNUnitParser_Result(t *testing.T) {
+	tests := map[string]struct {
+		path                    string
+		wantTestResult          v1alpha1.TestResult
+		wantAutomatedTestResult v1alpha1.AutomatedTestResult
+		wantErr                 error
+	}{
+		"nunit success": {
+			path: "./testdata/nunitparser-success.xml",
+			wantTestResult: v1alpha1.TestResult{
+				Passed:          2,
+				Failed:          2,
+				Skipped:         2,
+				PassedTestsRate: "50.00",
+			},
+			wantAutomatedTestResult: v1alpha1.AutomatedTestResult{
+				Total:           6,
+				Passed:          2,
+				Failed:          1,
+				Error:           1,
+				Skipped:         2,
+				PassedTestsRate: 0.5,
+			},
+		},
+		"nunit file not found": {
+			path:    "./testdata/nunitparser-not-found.xml",
+			wantErr: fmt.Errorf("open ./testdata/nunitparser-not-found.xml: no such file or directory"),
+		},
+		"nunit parse failed": {
+			path:    "./testdata/nunitparser-failed.xml",
+			wantErr: fmt.Errorf(`unknown result "Unknown" of test case "Add"`),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &NUnitParser{}
+			testResult, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("NUnitParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if testResult == nil {
+				return
+			}
+
+			outResult := testResult.(ConvertToTestResult).ConvertToTestResult()
+			if !reflect.DeepEqual(outResult, tt.wantTestResult) {
+				t.Errorf("NUnitParser.ConvertToTestResult() = %v, want %v", outResult, tt.wantTestResult)
+			}
+			outAutomatedResult := testResult.(ConvertToAutomatedTestResult).ConvertToAutomatedTestResult()
+			if !reflect.DeepEqual(outAutomatedResult, tt.wantAutomatedTestResult) {
+				t.Errorf("NUnitParser.ConvertToAutomatedTestResult() = %v, want %v", outAutomatedResult, tt.wantAutomatedTestResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+const (
+	// TypeSarif is the type of sarif, the Static Analysis Results Interchange Format 2.1.0
+	TypeSarif ReportType = "sarif"
+)
+
+const (
+	// SarifVersion the supported version of sarif
+	SarifVersion = "2.1.0"
+	// SarifSchema the json schema of sarif 2.1.0
+	SarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// levels of sarif results
+const (
+	SarifLevelError   = "error"
+	SarifLevelWarning = "warning"
+	SarifLevelNote    = "note"
+	SarifLevelNone    = "none"
+)
+
+// well-known properties of sarif rules and results
+const (
+	// sarifPropertySecuritySeverity CVSS score of a security issue, used by GitHub code scanning
+	sarifPropertySecuritySeverity = "security-severity"
+	// sarifPropertyTags tags of a rule, scanners such as trivy put the severity in tags
+	sarifPropertyTags = "tags"
+	// sarifPropertyCount number of issues a result stands for, rendered by the sarif writer
+	sarifPropertyCount = "count"
+	// sarifPropertyImageName name of the scanned image, reported by trivy
+	sarifPropertyImageName = "imageName"
+	// sarifBaseIDRootPath uri base id of the scanned path
+	sarifBaseIDRootPath = "ROOTPATH"
+)
+
+// SarifParser sarif report parser, only the properties used for counting are parsed
+// detail: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type SarifParser struct {
+	SarifLog `json:",inline"`
+}
+
+// SarifLog the root object of sarif
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema,omitempty"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun a run of an analysis tool
+type SarifRun struct {
+	Tool                     SarifTool                        `json:"tool"`
+	Results                  []SarifResult                    `json:"results"`
+	OriginalURIBaseIDs       map[string]SarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	VersionControlProvenance []SarifVersionControlDetails     `json:"versionControlProvenance,omitempty"`
+	Properties               map[string]interface{}           `json:"properties,omitempty"`
+}
+
+// SarifTool the analysis tool of a run
+type SarifTool struct {
+	Driver SarifToolComponent `json:"driver"`
+}
+
+// SarifToolComponent a component of the analysis tool
+type SarifToolComponent struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version,omitempty"`
+	InformationURI string                     `json:"informationUri,omitempty"`
+	Rules          []SarifReportingDescriptor `json:"rules,omitempty"`
+}
+
+// SarifReportingDescriptor a rule of the analysis tool
+type SarifReportingDescriptor struct {
+	ID                   string                       `json:"id"`
+	Name                 string                       `json:"name,omitempty"`
+	ShortDescription     *SarifMessage                `json:"shortDescription,omitempty"`
+	DefaultConfiguration *SarifReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{}       `json:"properties,omitempty"`
+}
+
+// SarifReportingConfiguration the configuration of a rule
+type SarifReportingConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+// SarifReportingDescriptorReference a reference to a rule
+type SarifReportingDescriptorReference struct {
+	ID    string `json:"id,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// SarifResult a result detected by the analysis tool
+type SarifResult struct {
+	RuleID       string                             `json:"ruleId,omitempty"`
+	RuleIndex    *int                               `json:"ruleIndex,omitempty"`
+	Rule         *SarifReportingDescriptorReference `json:"rule,omitempty"`
+	Kind         string                             `json:"kind,omitempty"`
+	Level        string                             `json:"level,omitempty"`
+	Message      SarifMessage                       `json:"message"`
+	Locations    []SarifLocation                    `json:"locations,omitempty"`
+	Suppressions []SarifSuppression                 `json:"suppressions,omitempty"`
+	Properties   map[string]interface{}             `json:"properties,omitempty"`
+}
+
+// SarifMessage a message of a rule or result
+type SarifMessage struct {
+	Text string `json:"text,omitempty"`
+}
+
+// SarifLocation a location of a result
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+}
+
+// SarifPhysicalLocation a physical location of a result
+type SarifPhysicalLocation struct {
+	ArtifactLocation *SarifArtifactLocation `json:"artifactLocation,omitempty"`
+	Region           *SarifRegion           `json:"region,omitempty"`
+}
+
+// SarifArtifactLocation the location of an artifact
+type SarifArtifactLocation struct {
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SarifRegion a region of an artifact
+type SarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SarifSuppression a suppression of a result
+type SarifSuppression struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status,omitempty"`
+}
+
+// SarifVersionControlDetails the version control information of the scanned repository
+type SarifVersionControlDetails struct {
+	RepositoryURI string `json:"repositoryUri"`
+	RevisionID    string `json:"revisionId,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+}
+
+// Parse parse sarif report.
+func (p *SarifParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	*p = SarifParser{}
+	if err = json.Unmarshal(content, &p.SarifLog); err != nil {
+		return nil, fmt.Errorf("invalid sarif json: %s", err.Error())
+	}
+	if p.Version != SarifVersion {
+		return nil, fmt.Errorf("unsupported sarif version %q, expected %q", p.Version, SarifVersion)
+	}
+	return p, nil
+}
+
+// ConvertToCodeLintResult convert to CodeLintResult.
+// Issues are counted by rule and level, suppressed results and results
+// which are not a problem, e.g. kind is pass, are excluded.
+func (p *SarifParser) ConvertToCodeLintResult() v1alpha1.CodeLintResult {
+	issues := &v1alpha1.CodeLintIssues{}
+	for _, run := range p.Runs {
+		for _, item := range run.Results {
+			if !item.isIssue() {
+				continue
+			}
+			issues.Add(run.ruleID(item), run.level(item), item.count())
+		}
+	}
+	return v1alpha1.CodeLintResult{Issues: issues}
+}
+
+// ConvertToVulnScanResult convert to VulnScanResult.
+// Each run is a target: an image if the run has the imageName property, a repository if the run has
+// version control provenance, otherwise the scanned file system.
+// The severity of a result is taken from the severity tag of its rule, its security-severity score
+// or its level in order, results of the same rule at the same location are counted once.
+func (p *SarifParser) ConvertToVulnScanResult() securityv1alpha1.VulnScanResult {
+	targets := &vulnTargets{}
+	for _, run := range p.Runs {
+		uri, targetType := run.target()
+		target := targets.get(uri, targetType)
+		for _, item := range run.Results {
+			if !item.isIssue() {
+				continue
+			}
+			rule := run.rule(item)
+			score := sarifScore(item.Properties)
+			if score == 0 && rule != nil {
+				score = sarifScore(rule.Properties)
+			}
+			severity := sarifSeverity(rule, score, run.level(item))
+			id := run.ruleID(item) + "@" + item.location()
+			target.add(id, severity, score, run.Tool.Driver.Name, item.count())
+		}
+	}
+	return securityv1alpha1.VulnScanResult{Targets: targets.list()}
+}
+
+// rule returns the rule of the result, nil if not found
+func (r SarifRun) rule(result SarifResult) *SarifReportingDescriptor {
+	rules := r.Tool.Driver.Rules
+	index := result.RuleIndex
+	if index == nil && result.Rule != nil {
+		index = result.Rule.Index
+	}
+	if index != nil && *index >= 0 && *index < len(rules) {
+		return &rules[*index]
+	}
+
+	id := result.RuleID
+	if id == "" && result.Rule != nil {
+		id = result.Rule.ID
+	}
+	for i := range rules {
+		if rules[i].ID == id {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// ruleID returns the rule id of the result
+func (r SarifRun) ruleID(result SarifResult) string {
+	switch {
+	case result.RuleID != "":
+		return result.RuleID
+	case result.Rule != nil && result.Rule.ID != "":
+		return result.Rule.ID
+	}
+	if rule := r.rule(result); rule != nil {
+		return rule.ID
+	}
+	return ""
+}
+
+// level returns the level of the result, defaults to the level of its rule or warning
+func (r SarifRun) level(result SarifResult) string {
+	if result.Level != "" {
+		return result.Level
+	}
+	if rule := r.rule(result); rule != nil && rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != "" {
+		return rule.DefaultConfiguration.Level
+	}
+	return SarifLevelWarning
+}
+
+// target returns the uri and type of the scanned target of the run
+func (r SarifRun) target() (string, securityv1alpha1.VulnScanTargetType) {
+	if imageName, ok := r.Properties[sarifPropertyImageName].(string); ok && imageName != "" {
+		return imageName, securityv1alpha1.VulnScanTargetTypeImage
+	}
+	if len(r.VersionControlProvenance) > 0 {
+		return r.VersionControlProvenance[0].RepositoryURI, securityv1alpha1.VulnScanTargetTypeRepository
+	}
+	return r.OriginalURIBaseIDs[sarifBaseIDRootPath].URI, securityv1alpha1.VulnScanTargetTypeFileSystem
+}
+
+// isIssue returns true if the result is a problem which is not suppressed
+func (r SarifResult) isIssue() bool {
+	if r.Kind != "" && r.Kind != "fail" {
+		return false
+	}
+	for _, item := range r.Suppressions {
+		if item.Status == "" || item.Status == "accepted" {
+			return false
+		}
+	}
+	return true
+}
+
+// count returns the number of issues the result stands for
+func (r SarifResult) count() int {
+	if count, ok := r.Properties[sarifPropertyCount].(float64); ok && count > 0 {
+		return int(count)
+	}
+	return 1
+}
+
+// location returns the uri of the first location of the result
+func (r SarifResult) location() string {
+	for _, item := range r.Locations {
+		if item.PhysicalLocation != nil && item.PhysicalLocation.ArtifactLocation != nil {
+			return item.PhysicalLocation.ArtifactLocation.URI
+		}
+	}
+	return ""
+}
+
+// sarifScore returns the security-severity score in properties, 0 if not set
+func sarifScore(properties map[string]interface{}) float64 {
+	switch value := properties[sarifPropertySecuritySeverity].(type) {
+	case string:
+		score, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return score
+	case float64:
+		return value
+	}
+	return 0
+}
+
+// sarifSeverity returns the severity of a vulnerability result
+func sarifSeverity(rule *SarifReportingDescriptor, score float64, level string) securityv1alpha1.VulnSeverity {
+	if rule != nil {
+		tags, _ := rule.Properties[sarifPropertyTags].([]interface{})
+		for _, tag := range tags {
+			if value, ok := tag.(string); ok {
+				severity := ParseVulnSeverity(value)
+				if severity != securityv1alpha1.VulnSeverityUnknown || strings.EqualFold(value, string(severity)) {
+					return severity
+				}
+			}
+		}
+	}
+	if score > 0 {
+		return VulnSeverityOfScore(score)
+	}
+	switch level {
+	case SarifLevelError:
+		return securityv1alpha1.VulnSeverityHigh
+	case SarifLevelWarning:
+		return securityv1alpha1.VulnSeverityMedium
+	case SarifLevelNote:
+		return securityv1alpha1.VulnSeverityLow
+	default:
+		return securityv1alpha1.VulnSeverityUnknown
+	}
+}
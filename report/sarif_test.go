@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+func TestSarifParser_Result(t *testing.T) {
+	tests := map[string]struct {
+		path               string
+		wantCodeLintResult v1alpha1.CodeLintResult
+		wantVulnScanResult securityv1alpha1.VulnScanResult
+		wantErr            error
+	}{
+		"lint report": {
+			path: "./testdata/sarifparser-lint.sarif",
+			wantCodeLintResult: v1alpha1.CodeLintResult{
+				Issues: &v1alpha1.CodeLintIssues{
+					Count: 5,
+					Rules: []v1alpha1.CodeLintRuleIssues{
+						{Rule: "no-unused-vars", Level: SarifLevelWarning, Count: 1},
+						{Rule: "no-unused-vars", Level: SarifLevelError, Count: 1},
+						{Rule: "no-undef", Level: SarifLevelError, Count: 1},
+						{Rule: "color-no-invalid-hex", Level: SarifLevelNote, Count: 1},
+						{Rule: "", Level: SarifLevelWarning, Count: 1},
+					},
+				},
+			},
+			wantVulnScanResult: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{
+					{
+						Type:          securityv1alpha1.VulnScanTargetTypeFileSystem,
+						Cvss:          securityv1alpha1.CVSS{Source: "ESLint", Severity: "High"},
+						VulnStatistic: securityv1alpha1.VulnStatistic{HighCount: 2, MediumCount: 2, LowCount: 1},
+					},
+				},
+			},
+		},
+		"vulnerability report": {
+			path: "./testdata/sarifparser-trivy.sarif",
+			wantCodeLintResult: v1alpha1.CodeLintResult{
+				Issues: &v1alpha1.CodeLintIssues{
+					Count: 6,
+					Rules: []v1alpha1.CodeLintRuleIssues{
+						{Rule: "CVE-2023-0001", Level: SarifLevelError, Count: 2},
+						{Rule: "CVE-2023-0002", Level: SarifLevelWarning, Count: 1},
+						{Rule: "CVE-2023-0003", Level: SarifLevelError, Count: 1},
+						{Rule: "js/sql-injection", Level: SarifLevelWarning, Count: 1},
+						{Rule: "js/unused-local", Level: SarifLevelNote, Count: 1},
+					},
+				},
+			},
+			wantVulnScanResult: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{
+					{
+						Uri:           "alpine:3.17",
+						Type:          securityv1alpha1.VulnScanTargetTypeImage,
+						Cvss:          securityv1alpha1.CVSS{Source: "Trivy", Severity: "Critical", Score: "9.8"},
+						VulnStatistic: securityv1alpha1.VulnStatistic{CriticalCount: 1, HighCount: 1, MediumCount: 1},
+					},
+					{
+						Uri:           "https://github.com/katanomi/demo",
+						Type:          securityv1alpha1.VulnScanTargetTypeRepository,
+						Cvss:          securityv1alpha1.CVSS{Source: "CodeQL", Severity: "Medium"},
+						VulnStatistic: securityv1alpha1.VulnStatistic{MediumCount: 1, LowCount: 1},
+					},
+					{
+						Uri:  "file:///workspace/",
+						Type: securityv1alpha1.VulnScanTargetTypeFileSystem,
+					},
+				},
+			},
+		},
+		"sarif file not found": {
+			path:    "./testdata/sarifparser-not-found.sarif",
+			wantErr: fmt.Errorf("open ./testdata/sarifparser-not-found.sarif: no such file or directory"),
+		},
+		"sarif invalid json": {
+			path:    "./testdata/sarifparser-failed.sarif",
+			wantErr: fmt.Errorf("invalid sarif json: unexpected end of JSON input"),
+		},
+		"sarif unsupported version": {
+			path:    "./testdata/sarifparser-version.sarif",
+			wantErr: fmt.Errorf(`unsupported sarif version "2.0.0", expected "2.1.0"`),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &SarifParser{}
+			result, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("SarifParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if result == nil {
+				return
+			}
+
+			codeLintResult := result.(ConvertToCodeLintResult).ConvertToCodeLintResult()
+			if !reflect.DeepEqual(codeLintResult, tt.wantCodeLintResult) {
+				t.Errorf("SarifParser.ConvertToCodeLintResult() = %v, want %v", codeLintResult, tt.wantCodeLintResult)
+			}
+			vulnScanResult := result.(ConvertToVulnScanResult).ConvertToVulnScanResult()
+			if !reflect.DeepEqual(vulnScanResult, tt.wantVulnScanResult) {
+				t.Errorf("SarifParser.ConvertToVulnScanResult() = %v, want %v", vulnScanResult, tt.wantVulnScanResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+// NewSarifLog returns an empty sarif log
+func NewSarifLog() *SarifLog {
+	return &SarifLog{
+		Version: SarifVersion,
+		Schema:  SarifSchema,
+		Runs:    []SarifRun{},
+	}
+}
+
+// Write writes the sarif log as indented json
+func (l *SarifLog) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(l)
+}
+
+// NewSarifLogFromCodeLintResult renders the issues of CodeLintResult as a sarif log with a single run.
+// Only the number of issues is known, so each rule and level is rendered as one result
+// whose count property holds the number of issues, issues without rule are rendered
+// as a result without rule id.
+func NewSarifLogFromCodeLintResult(tool SarifToolComponent, result v1alpha1.CodeLintResult) *SarifLog {
+	run := SarifRun{Results: []SarifResult{}}
+	// avoid changing the rules of the caller
+	tool.Rules = append([]SarifReportingDescriptor{}, tool.Rules...)
+	ruleIndexes := map[string]int{}
+	for i, rule := range tool.Rules {
+		ruleIndexes[rule.ID] = i
+	}
+	ruleCount := 0
+	if result.Issues != nil {
+		for _, item := range result.Issues.Rules {
+			ruleCount += item.Count
+			level := item.Level
+			if level == "" {
+				level = SarifLevelWarning
+			}
+			sarifResult := SarifResult{
+				RuleID:     item.Rule,
+				Level:      level,
+				Message:    SarifMessage{Text: fmt.Sprintf("%d issues detected by rule %s", item.Count, item.Rule)},
+				Properties: map[string]interface{}{sarifPropertyCount: item.Count},
+			}
+			if item.Rule == "" {
+				sarifResult.Message.Text = fmt.Sprintf("%d issues detected", item.Count)
+				run.Results = append(run.Results, sarifResult)
+				continue
+			}
+			index, ok := ruleIndexes[item.Rule]
+			if !ok {
+				index = len(tool.Rules)
+				ruleIndexes[item.Rule] = index
+				tool.Rules = append(tool.Rules, SarifReportingDescriptor{ID: item.Rule})
+			}
+			sarifResult.RuleIndex = &index
+			run.Results = append(run.Results, sarifResult)
+		}
+		if count := result.Issues.Count - ruleCount; count > 0 {
+			run.Results = append(run.Results, SarifResult{
+				Level:      SarifLevelWarning,
+				Message:    SarifMessage{Text: fmt.Sprintf("%d issues detected", count)},
+				Properties: map[string]interface{}{sarifPropertyCount: count},
+			})
+		}
+	}
+	run.Tool.Driver = tool
+
+	log := NewSarifLog()
+	log.Runs = append(log.Runs, run)
+	return log
+}
+
+// NewSarifLogFromVulnScanResult renders VulnScanResult as a sarif log with a run for each target.
+// Each severity is rendered as a rule tagged with the severity, the vulnerabilities of a target
+// with the same severity are rendered as one result whose count property holds the number of vulnerabilities.
+// The security-severity property of the result with the severity of Cvss holds the Cvss score.
+func NewSarifLogFromVulnScanResult(tool SarifToolComponent, result securityv1alpha1.VulnScanResult) *SarifLog {
+	// avoid changing the rules of the caller
+	tool.Rules = append([]SarifReportingDescriptor{}, tool.Rules...)
+	ruleIndexes := map[securityv1alpha1.VulnSeverity]int{}
+	for _, severity := range securityv1alpha1.AvailableVulnSeverities {
+		ruleIndexes[severity] = len(tool.Rules)
+		tool.Rules = append(tool.Rules, SarifReportingDescriptor{
+			ID:                   sarifVulnRuleID(severity),
+			ShortDescription:     &SarifMessage{Text: fmt.Sprintf("%s severity vulnerabilities", severity)},
+			DefaultConfiguration: &SarifReportingConfiguration{Level: sarifLevelOfVulnSeverity(severity)},
+			Properties: map[string]interface{}{
+				sarifPropertyTags: []string{"vulnerability", "security", string(severity)},
+			},
+		})
+	}
+
+	log := NewSarifLog()
+	for _, target := range result.Targets {
+		run := SarifRun{
+			Tool:    SarifTool{Driver: tool},
+			Results: []SarifResult{},
+		}
+		switch target.Type {
+		case securityv1alpha1.VulnScanTargetTypeImage:
+			run.Properties = map[string]interface{}{sarifPropertyImageName: target.Uri}
+		case securityv1alpha1.VulnScanTargetTypeRepository:
+			run.VersionControlProvenance = []SarifVersionControlDetails{{RepositoryURI: target.Uri}}
+		default:
+			run.OriginalURIBaseIDs = map[string]SarifArtifactLocation{sarifBaseIDRootPath: {URI: target.Uri}}
+		}
+
+		counts := []int{target.CriticalCount, target.HighCount, target.MediumCount, target.LowCount, target.UnknownCount}
+		for i, severity := range securityv1alpha1.AvailableVulnSeverities {
+			if counts[i] == 0 {
+				continue
+			}
+			index := ruleIndexes[severity]
+			sarifResult := SarifResult{
+				RuleID:    sarifVulnRuleID(severity),
+				RuleIndex: &index,
+				Level:     sarifLevelOfVulnSeverity(severity),
+				Message: SarifMessage{
+					Text: fmt.Sprintf("%d %s severity vulnerabilities found in %s", counts[i], severity, target.Uri),
+				},
+				Locations: []SarifLocation{{
+					PhysicalLocation: &SarifPhysicalLocation{ArtifactLocation: &SarifArtifactLocation{URI: target.Uri}},
+				}},
+				Properties: map[string]interface{}{sarifPropertyCount: counts[i]},
+			}
+			if target.Cvss.Score != "" && target.Cvss.Severity == string(severity) {
+				sarifResult.Properties[sarifPropertySecuritySeverity] = target.Cvss.Score
+			}
+			run.Results = append(run.Results, sarifResult)
+		}
+		log.Runs = append(log.Runs, run)
+	}
+	return log
+}
+
+// sarifVulnRuleID returns the rule id of vulnerabilities with the severity
+func sarifVulnRuleID(severity securityv1alpha1.VulnSeverity) string {
+	return strings.ToLower(string(severity)) + "-vulnerabilities"
+}
+
+// sarifLevelOfVulnSeverity returns the sarif level of the severity
+func sarifLevelOfVulnSeverity(severity securityv1alpha1.VulnSeverity) string {
+	switch severity {
+	case securityv1alpha1.VulnSeverityCritical, securityv1alpha1.VulnSeverityHigh:
+		return SarifLevelError
+	case securityv1alpha1.VulnSeverityMedium:
+		return SarifLevelWarning
+	default:
+		return SarifLevelNote
+	}
+}
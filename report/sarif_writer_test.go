@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+// parseSarifLog writes the sarif log to a file and parses it
+func parseSarifLog(t *testing.T, log *SarifLog) *SarifParser {
+	buf := &bytes.Buffer{}
+	if err := log.Write(buf); err != nil {
+		t.Fatalf("SarifLog.Write() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "result.sarif")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := &SarifParser{}
+	if _, err := p.Parse(path); err != nil {
+		t.Fatalf("SarifParser.Parse() error = %v", err)
+	}
+	return p
+}
+
+func TestNewSarifLogFromCodeLintResult(t *testing.T) {
+	tests := map[string]struct {
+		result v1alpha1.CodeLintResult
+		want   v1alpha1.CodeLintResult
+	}{
+		"issues by rule": {
+			result: v1alpha1.CodeLintResult{
+				Result: v1alpha1.Failed,
+				Issues: &v1alpha1.CodeLintIssues{
+					Count: 6,
+					Rules: []v1alpha1.CodeLintRuleIssues{
+						{Rule: "no-unused-vars", Level: SarifLevelWarning, Count: 2},
+						{Rule: "no-undef", Level: SarifLevelError, Count: 1},
+						{Rule: "no-unused-vars", Level: SarifLevelError, Count: 3},
+					},
+				},
+			},
+			want: v1alpha1.CodeLintResult{
+				Issues: &v1alpha1.CodeLintIssues{
+					Count: 6,
+					Rules: []v1alpha1.CodeLintRuleIssues{
+						{Rule: "no-unused-vars", Level: SarifLevelWarning, Count: 2},
+						{Rule: "no-undef", Level: SarifLevelError, Count: 1},
+						{Rule: "no-unused-vars", Level: SarifLevelError, Count: 3},
+					},
+				},
+			},
+		},
+		"issues without rule": {
+			result: v1alpha1.CodeLintResult{Issues: &v1alpha1.CodeLintIssues{Count: 3}},
+			want: v1alpha1.CodeLintResult{
+				Issues: &v1alpha1.CodeLintIssues{
+					Count: 3,
+					Rules: []v1alpha1.CodeLintRuleIssues{{Level: SarifLevelWarning, Count: 3}},
+				},
+			},
+		},
+		"no issues": {
+			result: v1alpha1.CodeLintResult{Result: v1alpha1.Succeeded},
+			want:   v1alpha1.CodeLintResult{Issues: &v1alpha1.CodeLintIssues{}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tool := SarifToolComponent{Name: "linter", Rules: []SarifReportingDescriptor{{ID: "no-undef"}}}
+			log := NewSarifLogFromCodeLintResult(tool, tt.result)
+			if len(tool.Rules) != 1 {
+				t.Errorf("NewSarifLogFromCodeLintResult() changed the rules of the tool: %v", tool.Rules)
+			}
+			if log.Version != SarifVersion || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "linter" {
+				t.Errorf("NewSarifLogFromCodeLintResult() = %v, want a single run of linter", log)
+			}
+
+			got := parseSarifLog(t, log).ConvertToCodeLintResult()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SarifParser.ConvertToCodeLintResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSarifLogFromVulnScanResult(t *testing.T) {
+	result := securityv1alpha1.VulnScanResult{
+		Result: "Failed",
+		Targets: []securityv1alpha1.VulnScanTarget{
+			{
+				Uri:           "alpine:3.17",
+				Type:          securityv1alpha1.VulnScanTargetTypeImage,
+				Cvss:          securityv1alpha1.CVSS{Source: "scanner", Severity: "Critical", Score: "9.8"},
+				VulnStatistic: securityv1alpha1.VulnStatistic{CriticalCount: 2, HighCount: 1, UnknownCount: 3},
+			},
+			{
+				Uri:           "https://github.com/katanomi/demo",
+				Type:          securityv1alpha1.VulnScanTargetTypeRepository,
+				Cvss:          securityv1alpha1.CVSS{Source: "scanner", Severity: "Medium", Score: "5.3"},
+				VulnStatistic: securityv1alpha1.VulnStatistic{MediumCount: 1, LowCount: 4},
+			},
+			{
+				Uri:  "/workspace/source",
+				Type: securityv1alpha1.VulnScanTargetTypeFileSystem,
+			},
+		},
+	}
+
+	log := NewSarifLogFromVulnScanResult(SarifToolComponent{Name: "scanner"}, result)
+	if len(log.Runs) != 3 {
+		t.Fatalf("NewSarifLogFromVulnScanResult() returns %d runs, want 3", len(log.Runs))
+	}
+	if rules := log.Runs[0].Tool.Driver.Rules; len(rules) != len(securityv1alpha1.AvailableVulnSeverities) {
+		t.Errorf("NewSarifLogFromVulnScanResult() returns %d rules, want one for each severity", len(rules))
+	}
+
+	got := parseSarifLog(t, log).ConvertToVulnScanResult()
+	want := result
+	want.Result = ""
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SarifParser.ConvertToVulnScanResult() = %v, want %v", got, want)
+	}
+}
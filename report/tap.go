@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeTap is the type of tap, the Test Anything Protocol
+	TypeTap ReportType = "tap"
+)
+
+var (
+	// tapPlanRegexp matches the plan line, e.g. "1..4"
+	tapPlanRegexp = regexp.MustCompile(`^1\.\.(\d+)`)
+	// tapTestRegexp matches the test line, e.g. "not ok 2 - description # TODO reason"
+	tapTestRegexp = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?([^#]*)(#\s*(\w+).*)?$`)
+)
+
+// TapParser tap report parser
+// detail: https://testanything.org/tap-version-14-specification.html
+type TapParser struct {
+	TestSummary `json:",inline"`
+	TestCases   `json:",inline"`
+
+	// Planned number of planned tests
+	Planned int `json:"planned"`
+	// BailedOut the tests are aborted by "Bail out!"
+	BailedOut bool `json:"bailedOut,omitempty"`
+
+	// diagnostic yaml block of the last test line
+	diagnostic []string
+	// inDiagnostic is true when parsing the yaml block of the last test line
+	inDiagnostic bool
+}
+
+// Parse parse tap report.
+// Indented lines of subtests are ignored because their results are reported by the parent test line,
+// the indented yaml diagnostic block of a test line is used as its failure stack.
+// Tests with a SKIP or TODO directive are counted as skipped, planned but not run tests
+// are counted as errored.
+func (p *TapParser) Parse(path string) (result interface{}, err error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fi.Close()
+
+	*p = TapParser{}
+	scanner := bufio.NewScanner(fi)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			p.parseDiagnostic(line)
+			continue
+		}
+		p.flushDiagnostic()
+		if err = p.parseLine(strings.TrimSpace(line)); err != nil {
+			return nil, fmt.Errorf("invalid tap text:%s. error: %s", line, err.Error())
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	p.flushDiagnostic()
+
+	for p.Total < p.Planned {
+		p.addStatus(v1alpha1.TestCaseStatusError)
+		p.addCase(v1alpha1.TestCaseResult{
+			Name:    fmt.Sprintf("test %d", p.Total),
+			Status:  v1alpha1.TestCaseStatusError,
+			Message: "planned test was not run",
+		})
+	}
+	return p, nil
+}
+
+// parseLine parse a line which is not indented
+func (p *TapParser) parseLine(line string) (err error) {
+	if strings.HasPrefix(line, "Bail out!") {
+		p.BailedOut = true
+		return nil
+	}
+
+	if matches := tapPlanRegexp.FindStringSubmatch(line); matches != nil {
+		p.Planned, err = strconv.Atoi(matches[1])
+		return err
+	}
+
+	matches := tapTestRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		// comments, version, yaml blocks and unknown lines
+		return nil
+	}
+
+	testCase := v1alpha1.TestCaseResult{
+		Name: strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(matches[3]), "-")),
+	}
+	if testCase.Name == "" {
+		testCase.Name = fmt.Sprintf("test %s", matches[2])
+	}
+	switch directive := strings.ToUpper(matches[5]); {
+	case strings.HasPrefix(directive, "SKIP") || strings.HasPrefix(directive, "TODO"):
+		testCase.Status = v1alpha1.TestCaseStatusSkipped
+		testCase.Message = strings.TrimSpace(strings.TrimPrefix(matches[4], "#"))
+	case matches[1] == "ok":
+		testCase.Status = v1alpha1.TestCaseStatusPassed
+	default:
+		testCase.Status = v1alpha1.TestCaseStatusFailed
+	}
+	p.addStatus(testCase.Status)
+	p.addCase(testCase)
+	return nil
+}
+
+// parseDiagnostic collects the yaml diagnostic block following a test line
+func (p *TapParser) parseDiagnostic(line string) {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case !p.inDiagnostic:
+		// the block must directly follow the test line
+		if trimmed == "---" && p.diagnostic == nil && len(p.Cases) > 0 {
+			p.inDiagnostic = true
+			p.diagnostic = []string{}
+		} else {
+			p.diagnostic = []string{}
+		}
+	case trimmed == "...":
+		p.inDiagnostic = false
+	default:
+		p.diagnostic = append(p.diagnostic, line)
+	}
+}
+
+// flushDiagnostic attaches the collected yaml diagnostic block to the last test case
+func (p *TapParser) flushDiagnostic() {
+	defer func() {
+		p.diagnostic, p.inDiagnostic = nil, false
+	}()
+	if len(p.diagnostic) == 0 {
+		return
+	}
+	testCase := &p.Cases[len(p.Cases)-1]
+	if testCase.Status == v1alpha1.TestCaseStatusPassed {
+		return
+	}
+	testCase.Stack = strings.Join(p.diagnostic, "\n")
+	for _, line := range p.diagnostic {
+		if message, ok := strings.CutPrefix(strings.TrimSpace(line), "message:"); ok && testCase.Message == "" {
+			testCase.Message = strings.Trim(strings.TrimSpace(message), `"'`)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+func TestTapParser_Result(t *testing.T) {
+	tests := map[string]struct {
+		path                    string
+		wantTestResult          v1alpha1.TestResult
+		wantAutomatedTestResult v1alpha1.AutomatedTestResult
+		wantErr                 error
+	}{
+		"tap success": {
+			path: "./testdata/tapparser-success.tap",
+			wantTestResult: v1alpha1.TestResult{
+				Passed:          1,
+				Failed:          3,
+				Skipped:         3,
+				PassedTestsRate: "25.00",
+			},
+			wantAutomatedTestResult: v1alpha1.AutomatedTestResult{
+				Total:           7,
+				Passed:          1,
+				Failed:          2,
+				Error:           1,
+				Skipped:         3,
+				PassedTestsRate: 0.25,
+			},
+		},
+		"tap file not found": {
+			path:    "./testdata/tapparser-not-found.tap",
+			wantErr: fmt.Errorf("open ./testdata/tapparser-not-found.tap: no such file or directory"),
+		},
+		"tap parse failed": {
+			path:    "./testdata/tapparser-failed.tap",
+			wantErr: fmt.Errorf(`invalid tap text:1..99999999999999999999. error: strconv.Atoi: parsing "99999999999999999999": value out of range`),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &TapParser{}
+			testResult, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("TapParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if testResult == nil {
+				return
+			}
+
+			outResult := testResult.(ConvertToTestResult).ConvertToTestResult()
+			if !reflect.DeepEqual(outResult, tt.wantTestResult) {
+				t.Errorf("TapParser.ConvertToTestResult() = %v, want %v", outResult, tt.wantTestResult)
+			}
+			outAutomatedResult := testResult.(ConvertToAutomatedTestResult).ConvertToAutomatedTestResult()
+			if !reflect.DeepEqual(outAutomatedResult, tt.wantAutomatedTestResult) {
+				t.Errorf("TapParser.ConvertToAutomatedTestResult() = %v, want %v", outAutomatedResult, tt.wantAutomatedTestResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestCases stores the results of each test case in a report
+type TestCases struct {
+	// Cases results of test cases in the order they appear in the report,
+	// a rerun test case appears once for each attempt
+	Cases []v1alpha1.TestCaseResult `json:"cases,omitempty"`
+}
+
+// ConvertToTestCases returns the results of each test case
+func (c TestCases) ConvertToTestCases() []v1alpha1.TestCaseResult {
+	return c.Cases
+}
+
+// addCase adds a test case
+func (c *TestCases) addCase(testCase v1alpha1.TestCaseResult) {
+	c.Cases = append(c.Cases, testCase)
+}
+
+// MarkFlakyTestCases merges the results of the same test case across reruns.
+// Runs are compared in the given order, test cases are matched by suite, class name and name.
+// The merged test case takes the result of its last attempt, with Retries set to the number
+// of additional attempts, and is marked as flaky when it both passed and failed.
+// When the last attempt of a flaky test case passed, the message and stack of its last failure are kept.
+func MarkFlakyTestCases(runs ...[]v1alpha1.TestCaseResult) []v1alpha1.TestCaseResult {
+	var keys []string
+	merged := map[string]*flakyTestCase{}
+	for _, run := range runs {
+		for _, item := range run {
+			key := item.Key()
+			if existing, ok := merged[key]; ok {
+				existing.add(item)
+				continue
+			}
+			keys = append(keys, key)
+			merged[key] = newFlakyTestCase(item)
+		}
+	}
+
+	result := make([]v1alpha1.TestCaseResult, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, merged[key].result())
+	}
+	return result
+}
+
+// flakyTestCase tracks the attempts of a test case
+type flakyTestCase struct {
+	last        v1alpha1.TestCaseResult
+	lastFailure *v1alpha1.TestCaseResult
+	attempts    int
+	passed      bool
+	failed      bool
+}
+
+func newFlakyTestCase(testCase v1alpha1.TestCaseResult) *flakyTestCase {
+	f := &flakyTestCase{}
+	f.add(testCase)
+	return f
+}
+
+func (f *flakyTestCase) add(testCase v1alpha1.TestCaseResult) {
+	// a test case may already carry retries reported by the test framework
+	f.attempts += testCase.Retries + 1
+	f.last = testCase
+	switch {
+	case testCase.Status == v1alpha1.TestCaseStatusPassed:
+		f.passed = true
+	case testCase.IsFailed():
+		f.failed = true
+		failure := testCase
+		f.lastFailure = &failure
+	}
+	if testCase.Flaky {
+		f.passed, f.failed = true, true
+	}
+}
+
+func (f *flakyTestCase) result() v1alpha1.TestCaseResult {
+	result := f.last
+	result.Retries = f.attempts - 1
+	result.Flaky = f.passed && f.failed
+	if result.Flaky && !result.IsFailed() && f.lastFailure != nil {
+		result.Message = f.lastFailure.Message
+		result.Stack = f.lastFailure.Stack
+	}
+	return result
+}
+
+// SlowestTestCases returns at most limit executed test cases ordered by duration descending.
+// Skipped test cases are excluded, a non-positive limit returns all executed test cases.
+func SlowestTestCases(cases []v1alpha1.TestCaseResult, limit int) []v1alpha1.TestCaseResult {
+	result := make([]v1alpha1.TestCaseResult, 0, len(cases))
+	for _, item := range cases {
+		if item.Status != v1alpha1.TestCaseStatusSkipped {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Duration.Duration > result[j].Duration.Duration
+	})
+	return truncateTestCases(result, limit)
+}
+
+// FlakyTestCases returns at most limit flaky test cases ordered by retries descending.
+// A non-positive limit returns all flaky test cases.
+func FlakyTestCases(cases []v1alpha1.TestCaseResult, limit int) []v1alpha1.TestCaseResult {
+	var result []v1alpha1.TestCaseResult
+	for _, item := range cases {
+		if item.Flaky {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Retries > result[j].Retries
+	})
+	return truncateTestCases(result, limit)
+}
+
+// NewTestCaseHighlights merges the test cases of all runs and returns
+// at most limit of the slowest and of the flaky test cases
+func NewTestCaseHighlights(limit int, runs ...[]v1alpha1.TestCaseResult) v1alpha1.TestCaseHighlights {
+	cases := MarkFlakyTestCases(runs...)
+	return v1alpha1.TestCaseHighlights{
+		Slowest: SlowestTestCases(cases, limit),
+		Flaky:   FlakyTestCases(cases, limit),
+	}
+}
+
+func truncateTestCases(cases []v1alpha1.TestCaseResult, limit int) []v1alpha1.TestCaseResult {
+	if limit > 0 && len(cases) > limit {
+		return cases[:limit]
+	}
+	return cases
+}
+
+// durationOfSeconds converts seconds reported by test frameworks to duration
+func durationOfSeconds(seconds float64) metav1.Duration {
+	return metav1.Duration{Duration: time.Duration(seconds * float64(time.Second))}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertToTestCases(t *testing.T) {
+	tests := map[string]struct {
+		parser      ReportParser
+		path        string
+		wantTotal   int
+		wantFailed  []v1alpha1.TestCaseResult
+		wantSkipped int
+		wantFlaky   []string
+	}{
+		"junit": {
+			parser:      &JunitParser{},
+			path:        "./testdata/junitparser-mocha-success.xml",
+			wantTotal:   6,
+			wantSkipped: 2,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "减法函数的测试", Name: "减法函数的测试 failed 1 减 1 应该等于 2", ClassName: "failed 1 减 1 应该等于 2",
+					Duration: metav1.Duration{Duration: time.Millisecond}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "expected +0 to equal 2",
+					Stack: "AssertionError: expected +0 to equal 2\n    at Context.<anonymous> (test/sub.test.js:11:29)\n" +
+						"    at process.processImmediate (node:internal/timers:471:21)\n\n      + expected - actual\n\n      -0\n      +2\n      "},
+			},
+		},
+		"mocha json": {
+			parser:      &MochaJsonParser{},
+			path:        "./testdata/mochajsonparser-success.json",
+			wantTotal:   6,
+			wantSkipped: 2,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "减法函数的测试", Name: "减法函数的测试 failed 1 减 1 应该等于 2", ClassName: "failed 1 减 1 应该等于 2",
+					Duration: metav1.Duration{Duration: time.Millisecond}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "AssertionError: expected +0 to equal 2",
+					Stack: "AssertionError: expected +0 to equal 2\n    at Context.<anonymous> (test/sub.test.js:11:29)\n" +
+						"    at process.processImmediate (node:internal/timers:471:21)"},
+			},
+		},
+		"jest json": {
+			parser:      &JestJsonParser{},
+			path:        "./testdata/jestjsonparser-testcases.json",
+			wantTotal:   5,
+			wantSkipped: 2,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "/src/calc.test.js", Name: "subtracts", ClassName: "calc",
+					Duration: metav1.Duration{Duration: 5 * time.Millisecond}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "Error: expected -1, got 1",
+					Stack:   "Error: expected -1, got 1\n    at Object.<anonymous> (/src/calc.test.js:9:11)"},
+			},
+			wantFlaky: []string{"divides"},
+		},
+		"go test json": {
+			parser:      &GoTestJsonParser{},
+			path:        "./testdata/gotestjsonparser-success.json",
+			wantTotal:   5,
+			wantSkipped: 1,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "example.com/demo", Name: "TestSub/negative", Status: v1alpha1.TestCaseStatusFailed,
+					Message: "calc_test.go:20: expected -1, got 1", Stack: "    calc_test.go:20: expected -1, got 1"},
+				{Suite: "example.com/demo", Name: "TestSub", Status: v1alpha1.TestCaseStatusFailed},
+				{Suite: "example.com/broken", Name: "example.com/broken", Status: v1alpha1.TestCaseStatusError,
+					Message: "FAIL\texample.com/broken [build failed]", Stack: "FAIL\texample.com/broken [build failed]"},
+			},
+		},
+		"tap": {
+			parser:      &TapParser{},
+			path:        "./testdata/tapparser-success.tap",
+			wantTotal:   7,
+			wantSkipped: 3,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Name: "subtract two numbers", Status: v1alpha1.TestCaseStatusFailed,
+					Message: "expected -1, got 1", Stack: "  message: expected -1, got 1"},
+				{Name: "nested", Status: v1alpha1.TestCaseStatusFailed},
+				{Name: "test 7", Status: v1alpha1.TestCaseStatusError, Message: "planned test was not run"},
+			},
+		},
+		"nunit": {
+			parser:      &NUnitParser{},
+			path:        "./testdata/nunitparser-success.xml",
+			wantTotal:   6,
+			wantSkipped: 2,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "CalcTests", Name: "Sub", ClassName: "Demo.CalcTests", Duration: metav1.Duration{Duration: 20 * time.Millisecond},
+					Status: v1alpha1.TestCaseStatusFailed, Message: "Expected: -1 But was: 1",
+					Stack: "at Demo.CalcTests.Sub() in /src/CalcTests.cs:line 20"},
+				{Suite: "CalcTests", Name: "Div", ClassName: "Demo.CalcTests", Duration: metav1.Duration{Duration: 10 * time.Millisecond},
+					Status: v1alpha1.TestCaseStatusError, Message: "System.DivideByZeroException : Attempted to divide by zero."},
+			},
+		},
+		"xunit": {
+			parser:      &XUnitParser{},
+			path:        "./testdata/xunitparser-success.xml",
+			wantTotal:   6,
+			wantSkipped: 2,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "/src/Demo.Tests.dll", Name: "Demo.CalcTests.Sub", ClassName: "Demo.CalcTests",
+					Duration: metav1.Duration{Duration: 20 * time.Millisecond}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "Assert.Equal() Failure Expected: -1 Actual: 1",
+					Stack:   "at Demo.CalcTests.Sub() in /src/CalcTests.cs:line 20"},
+				{Suite: "/src/Demo.Tests.dll", Name: "Demo.CalcTests", Status: v1alpha1.TestCaseStatusError, Message: "cleanup failed"},
+			},
+		},
+		"trx": {
+			parser:      &TrxParser{},
+			path:        "./testdata/trxparser-success.trx",
+			wantTotal:   5,
+			wantSkipped: 1,
+			wantFailed: []v1alpha1.TestCaseResult{
+				{Suite: "/src/Demo.Tests.dll", Name: "Sub", ClassName: "Demo.CalcTests",
+					Duration: metav1.Duration{Duration: 20 * time.Millisecond}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "Assert.AreEqual failed. Expected:<-1>. Actual:<1>.",
+					Stack:   "at Demo.CalcTests.Sub() in /src/CalcTests.cs:line 20"},
+				{Name: "Div", Duration: metav1.Duration{Duration: 30 * time.Second}, Status: v1alpha1.TestCaseStatusError},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := tt.parser.Parse(tt.path)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			cases := result.(ConvertToTestCases).ConvertToTestCases()
+			if len(cases) != tt.wantTotal {
+				t.Errorf("ConvertToTestCases() returns %d test cases, want %d", len(cases), tt.wantTotal)
+			}
+			var failed []v1alpha1.TestCaseResult
+			var flaky []string
+			skipped := 0
+			for _, item := range cases {
+				if item.IsFailed() {
+					failed = append(failed, item)
+				}
+				if item.Status == v1alpha1.TestCaseStatusSkipped {
+					skipped++
+				}
+				if item.Flaky {
+					flaky = append(flaky, item.Name)
+				}
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("ConvertToTestCases() failed test cases = %#v, want %#v", failed, tt.wantFailed)
+			}
+			if skipped != tt.wantSkipped {
+				t.Errorf("ConvertToTestCases() returns %d skipped test cases, want %d", skipped, tt.wantSkipped)
+			}
+			if !reflect.DeepEqual(flaky, tt.wantFlaky) {
+				t.Errorf("ConvertToTestCases() flaky test cases = %v, want %v", flaky, tt.wantFlaky)
+			}
+		})
+	}
+}
+
+func TestMarkFlakyTestCases(t *testing.T) {
+	passed := func(name string, d time.Duration) v1alpha1.TestCaseResult {
+		return v1alpha1.TestCaseResult{Name: name, Duration: metav1.Duration{Duration: d}, Status: v1alpha1.TestCaseStatusPassed}
+	}
+	failed := func(name string, d time.Duration) v1alpha1.TestCaseResult {
+		return v1alpha1.TestCaseResult{Name: name, Duration: metav1.Duration{Duration: d}, Status: v1alpha1.TestCaseStatusFailed,
+			Message: name + " failed", Stack: "stack of " + name}
+	}
+
+	tests := map[string]struct {
+		runs [][]v1alpha1.TestCaseResult
+		want []v1alpha1.TestCaseResult
+	}{
+		"single run": {
+			runs: [][]v1alpha1.TestCaseResult{{passed("a", time.Second), failed("b", time.Second)}},
+			want: []v1alpha1.TestCaseResult{passed("a", time.Second), failed("b", time.Second)},
+		},
+		"passed on rerun": {
+			runs: [][]v1alpha1.TestCaseResult{
+				{passed("a", time.Second), failed("b", time.Second)},
+				{passed("b", 2*time.Second)},
+			},
+			want: []v1alpha1.TestCaseResult{
+				passed("a", time.Second),
+				{Name: "b", Duration: metav1.Duration{Duration: 2 * time.Second}, Status: v1alpha1.TestCaseStatusPassed,
+					Message: "b failed", Stack: "stack of b", Retries: 1, Flaky: true},
+			},
+		},
+		"failed on every attempt": {
+			runs: [][]v1alpha1.TestCaseResult{{failed("b", time.Second), failed("b", time.Second)}},
+			want: []v1alpha1.TestCaseResult{
+				{Name: "b", Duration: metav1.Duration{Duration: time.Second}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "b failed", Stack: "stack of b", Retries: 1},
+			},
+		},
+		"failed after passed": {
+			runs: [][]v1alpha1.TestCaseResult{{passed("a", time.Second)}, {failed("a", time.Second)}, {failed("a", time.Second)}},
+			want: []v1alpha1.TestCaseResult{
+				{Name: "a", Duration: metav1.Duration{Duration: time.Second}, Status: v1alpha1.TestCaseStatusFailed,
+					Message: "a failed", Stack: "stack of a", Retries: 2, Flaky: true},
+			},
+		},
+		"same name in different suites": {
+			runs: [][]v1alpha1.TestCaseResult{{
+				{Suite: "x", Name: "a", Status: v1alpha1.TestCaseStatusPassed},
+				{Suite: "y", Name: "a", Status: v1alpha1.TestCaseStatusFailed},
+			}},
+			want: []v1alpha1.TestCaseResult{
+				{Suite: "x", Name: "a", Status: v1alpha1.TestCaseStatusPassed},
+				{Suite: "y", Name: "a", Status: v1alpha1.TestCaseStatusFailed},
+			},
+		},
+		"empty": {
+			want: []v1alpha1.TestCaseResult{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := MarkFlakyTestCases(tt.runs...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MarkFlakyTestCases() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTestCaseHighlights(t *testing.T) {
+	seconds := func(n int) metav1.Duration {
+		return metav1.Duration{Duration: time.Duration(n) * time.Second}
+	}
+	runs := [][]v1alpha1.TestCaseResult{
+		{
+			{Name: "a", Duration: seconds(1), Status: v1alpha1.TestCaseStatusPassed},
+			{Name: "b", Duration: seconds(3), Status: v1alpha1.TestCaseStatusFailed},
+			{Name: "c", Duration: seconds(2), Status: v1alpha1.TestCaseStatusError},
+			{Name: "d", Duration: seconds(9), Status: v1alpha1.TestCaseStatusSkipped},
+		},
+		{
+			{Name: "b", Duration: seconds(4), Status: v1alpha1.TestCaseStatusFailed},
+			{Name: "c", Duration: seconds(1), Status: v1alpha1.TestCaseStatusPassed},
+		},
+		{
+			{Name: "b", Duration: seconds(5), Status: v1alpha1.TestCaseStatusPassed},
+		},
+	}
+
+	got := NewTestCaseHighlights(2, runs...)
+	want := v1alpha1.TestCaseHighlights{
+		Slowest: []v1alpha1.TestCaseResult{
+			{Name: "b", Duration: seconds(5), Status: v1alpha1.TestCaseStatusPassed, Retries: 2, Flaky: true},
+			{Name: "a", Duration: seconds(1), Status: v1alpha1.TestCaseStatusPassed},
+		},
+		Flaky: []v1alpha1.TestCaseResult{
+			{Name: "b", Duration: seconds(5), Status: v1alpha1.TestCaseStatusPassed, Retries: 2, Flaky: true},
+			{Name: "c", Duration: seconds(1), Status: v1alpha1.TestCaseStatusPassed, Retries: 1, Flaky: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTestCaseHighlights() = %#v, want %#v", got, want)
+	}
+
+	if all := NewTestCaseHighlights(0, runs...); len(all.Slowest) != 3 || len(all.Flaky) != 2 {
+		t.Errorf("NewTestCaseHighlights() without limit = %#v", all)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+// TestSummary stores the number of test cases by status
+type TestSummary struct {
+	// Total test cases number
+	Total int `json:"total"`
+	// Passed test cases number
+	Passed int `json:"passed"`
+	// Failed test cases number
+	Failed int `json:"failed"`
+	// Error test cases number
+	Error int `json:"error"`
+	// Skipped test cases number
+	Skipped int `json:"skipped"`
+}
+
+// ConvertToTestResult convert to TestResult, errored test cases are counted as failed
+func (s TestSummary) ConvertToTestResult() v1alpha1.TestResult {
+	result := v1alpha1.TestResult{
+		Passed:  s.Passed,
+		Failed:  s.Failed + s.Error,
+		Skipped: s.Skipped,
+	}
+	result.PassedTestsRate = v1alpha1.PassedTestsRate(&result)
+	return result
+}
+
+// ConvertToAutomatedTestResult convert to AutomatedTestResult
+func (s TestSummary) ConvertToAutomatedTestResult() v1alpha1.AutomatedTestResult {
+	summary := v1alpha1.AutomatedTestResult{
+		Total:   s.Total,
+		Passed:  s.Passed,
+		Failed:  s.Failed,
+		Error:   s.Error,
+		Skipped: s.Skipped,
+	}
+	if divider := s.Passed + s.Failed + s.Error; divider > 0 {
+		summary.PassedTestsRate = float64(s.Passed) / float64(divider)
+	}
+	return summary
+}
+
+// addPassed adds a passed test case
+func (s *TestSummary) addPassed() {
+	s.Total++
+	s.Passed++
+}
+
+// addFailed adds a failed test case
+func (s *TestSummary) addFailed() {
+	s.Total++
+	s.Failed++
+}
+
+// addError adds an errored test case
+func (s *TestSummary) addError() {
+	s.Total++
+	s.Error++
+}
+
+// addSkipped adds a skipped test case
+func (s *TestSummary) addSkipped() {
+	s.Total++
+	s.Skipped++
+}
+
+// addStatus adds a test case by its status
+func (s *TestSummary) addStatus(status v1alpha1.TestCaseStatus) {
+	switch status {
+	case v1alpha1.TestCaseStatusPassed:
+		s.addPassed()
+	case v1alpha1.TestCaseStatusFailed:
+		s.addFailed()
+	case v1alpha1.TestCaseStatusError:
+		s.addError()
+	default:
+		s.addSkipped()
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+const (
+	// TypeTrivyJson is the type of trivy-json, generated by trivy --format json
+	TypeTrivyJson ReportType = "trivy-json"
+)
+
+// artifact types of trivy reports
+const (
+	trivyArtifactTypeContainerImage = "container_image"
+	trivyArtifactTypeRepository     = "repository"
+)
+
+// TrivyJsonParser trivy json report parser
+// detail: https://aquasecurity.github.io/trivy/latest/docs/configuration/reporting/#json
+type TrivyJsonParser struct {
+	TrivyReport `json:",inline"`
+}
+
+// TrivyReport the report of trivy, only the properties used for counting are parsed
+type TrivyReport struct {
+	SchemaVersion int           `json:"SchemaVersion"`
+	ArtifactName  string        `json:"ArtifactName"`
+	ArtifactType  string        `json:"ArtifactType"`
+	Results       []TrivyResult `json:"Results"`
+}
+
+// TrivyResult the scan result of a target in the artifact, e.g. os packages or a lock file
+type TrivyResult struct {
+	Target          string               `json:"Target"`
+	Class           string               `json:"Class,omitempty"`
+	Type            string               `json:"Type,omitempty"`
+	Vulnerabilities []TrivyVulnerability `json:"Vulnerabilities,omitempty"`
+}
+
+// TrivyVulnerability a detected vulnerability
+type TrivyVulnerability struct {
+	VulnerabilityID  string               `json:"VulnerabilityID"`
+	PkgName          string               `json:"PkgName"`
+	InstalledVersion string               `json:"InstalledVersion,omitempty"`
+	FixedVersion     string               `json:"FixedVersion,omitempty"`
+	Layer            *TrivyLayer          `json:"Layer,omitempty"`
+	SeveritySource   string               `json:"SeveritySource,omitempty"`
+	Severity         string               `json:"Severity"`
+	CVSS             map[string]TrivyCVSS `json:"CVSS,omitempty"`
+}
+
+// TrivyLayer the image layer a vulnerability is detected in
+type TrivyLayer struct {
+	Digest string `json:"Digest,omitempty"`
+	DiffID string `json:"DiffID,omitempty"`
+}
+
+// TrivyCVSS the CVSS of a vulnerability from a source
+type TrivyCVSS struct {
+	V2Vector string  `json:"V2Vector,omitempty"`
+	V3Vector string  `json:"V3Vector,omitempty"`
+	V2Score  float64 `json:"V2Score,omitempty"`
+	V3Score  float64 `json:"V3Score,omitempty"`
+}
+
+// Parse parse trivy json report.
+func (p *TrivyJsonParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	*p = TrivyJsonParser{}
+	if err = json.Unmarshal(content, &p.TrivyReport); err != nil {
+		return nil, fmt.Errorf("invalid trivy json: %s", err.Error())
+	}
+	if p.SchemaVersion != 2 {
+		return nil, fmt.Errorf("unsupported trivy schema version %d, expected 2", p.SchemaVersion)
+	}
+	return p, nil
+}
+
+// ConvertToVulnScanResult convert to VulnScanResult.
+// The scanned artifact is the only target, the same vulnerability of the same package
+// version is counted once even if it is reported in several layers or results.
+// The score is the CVSS v3 score, or the v2 score if v3 is missing, of the severity source.
+func (p *TrivyJsonParser) ConvertToVulnScanResult() securityv1alpha1.VulnScanResult {
+	targets := &vulnTargets{}
+	target := targets.get(p.ArtifactName, p.targetType())
+	for _, result := range p.Results {
+		for _, vuln := range result.Vulnerabilities {
+			source, score := vuln.score()
+			id := vuln.VulnerabilityID + "@" + vuln.PkgName + "@" + vuln.InstalledVersion
+			target.add(id, ParseVulnSeverity(vuln.Severity), score, source, 1)
+		}
+	}
+	return securityv1alpha1.VulnScanResult{Targets: targets.list()}
+}
+
+// targetType returns the target type of the artifact, other artifacts such as
+// filesystem and rootfs are treated as file system
+func (p *TrivyJsonParser) targetType() securityv1alpha1.VulnScanTargetType {
+	switch p.ArtifactType {
+	case trivyArtifactTypeContainerImage:
+		return securityv1alpha1.VulnScanTargetTypeImage
+	case trivyArtifactTypeRepository:
+		return securityv1alpha1.VulnScanTargetTypeRepository
+	default:
+		return securityv1alpha1.VulnScanTargetTypeFileSystem
+	}
+}
+
+// score returns the source and score of the vulnerability
+// the severity source is preferred, otherwise the source with the highest score is used
+func (v TrivyVulnerability) score() (source string, score float64) {
+	if cvss, ok := v.CVSS[v.SeveritySource]; ok && cvss.score() > 0 {
+		return v.SeveritySource, cvss.score()
+	}
+	for name, cvss := range v.CVSS {
+		if cvss.score() > score || (cvss.score() == score && score > 0 && name < source) {
+			source, score = name, cvss.score()
+		}
+	}
+	if source == "" {
+		source = v.SeveritySource
+	}
+	return source, score
+}
+
+// score returns the v3 score, or the v2 score if v3 is missing
+func (c TrivyCVSS) score() float64 {
+	if c.V3Score > 0 {
+		return c.V3Score
+	}
+	return c.V2Score
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+func TestTrivyJsonParser_VulnScanResult(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		want    securityv1alpha1.VulnScanResult
+		wantErr error
+	}{
+		"image report": {
+			path: "./testdata/trivyjsonparser-image.json",
+			want: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{{
+					Uri:  "alpine:3.17",
+					Type: securityv1alpha1.VulnScanTargetTypeImage,
+					Cvss: securityv1alpha1.CVSS{Source: "ghsa", Severity: "Critical", Score: "9.8"},
+					VulnStatistic: securityv1alpha1.VulnStatistic{
+						CriticalCount: 1, HighCount: 2, MediumCount: 1, UnknownCount: 1,
+					},
+				}},
+			},
+		},
+		"filesystem report": {
+			path: "./testdata/trivyjsonparser-fs.json",
+			want: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{{
+					Uri:           ".",
+					Type:          securityv1alpha1.VulnScanTargetTypeFileSystem,
+					Cvss:          securityv1alpha1.CVSS{Source: "nvd", Severity: "Low", Score: "7.5"},
+					VulnStatistic: securityv1alpha1.VulnStatistic{LowCount: 1},
+				}},
+			},
+		},
+		"repository report": {
+			path: "./testdata/trivyjsonparser-repo.json",
+			want: securityv1alpha1.VulnScanResult{
+				Targets: []securityv1alpha1.VulnScanTarget{{
+					Uri:  "https://github.com/katanomi/demo",
+					Type: securityv1alpha1.VulnScanTargetTypeRepository,
+				}},
+			},
+		},
+		"trivy file not found": {
+			path:    "./testdata/trivyjsonparser-not-found.json",
+			wantErr: fmt.Errorf("open ./testdata/trivyjsonparser-not-found.json: no such file or directory"),
+		},
+		"trivy invalid json": {
+			path:    "./testdata/trivyjsonparser-failed.json",
+			wantErr: fmt.Errorf("invalid trivy json: json: cannot unmarshal object into Go struct field TrivyReport.Results of type []report.TrivyResult"),
+		},
+		"trivy unsupported schema version": {
+			path:    "./testdata/trivyjsonparser-version.json",
+			wantErr: fmt.Errorf("unsupported trivy schema version 1, expected 2"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &TrivyJsonParser{}
+			result, err := p.Parse(tt.path)
+			if err != tt.wantErr && err.Error() != tt.wantErr.Error() {
+				t.Errorf("TrivyJsonParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if result == nil {
+				return
+			}
+
+			got := result.(ConvertToVulnScanResult).ConvertToVulnScanResult()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TrivyJsonParser.ConvertToVulnScanResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
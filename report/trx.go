@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TypeTrx is the type of trx, the Visual Studio test result format
+	TypeTrx ReportType = "trx"
+)
+
+// TrxParser Visual Studio trx report parser
+type TrxParser struct {
+	TestSummary `json:",inline"`
+	TestCases   `json:",inline"`
+}
+
+type trxTestRun struct {
+	Results     []trxUnitTestResult `xml:"Results>UnitTestResult"`
+	Definitions []trxUnitTest       `xml:"TestDefinitions>UnitTest"`
+}
+
+type trxUnitTestResult struct {
+	TestID    string `xml:"testId,attr"`
+	TestName  string `xml:"testName,attr"`
+	Outcome   string `xml:"outcome,attr"`
+	Duration  string `xml:"duration,attr"`
+	ErrorInfo struct {
+		Message    string `xml:"Message"`
+		StackTrace string `xml:"StackTrace"`
+	} `xml:"Output>ErrorInfo"`
+}
+
+type trxUnitTest struct {
+	ID         string `xml:"id,attr"`
+	Storage    string `xml:"storage,attr"`
+	TestMethod struct {
+		ClassName string `xml:"className,attr"`
+	} `xml:"TestMethod"`
+}
+
+// Parse parse trx report.
+// Results of data-driven tests are counted by the parent result.
+func (p *TrxParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	run := trxTestRun{}
+	if err = xml.Unmarshal(content, &run); err != nil {
+		return nil, fmt.Errorf("invalid trx xml: %s", err.Error())
+	}
+
+	definitions := make(map[string]trxUnitTest, len(run.Definitions))
+	for _, item := range run.Definitions {
+		definitions[item.ID] = item
+	}
+
+	*p = TrxParser{}
+	for _, item := range run.Results {
+		testCase := v1alpha1.TestCaseResult{
+			Suite:     definitions[item.TestID].Storage,
+			Name:      item.TestName,
+			ClassName: definitions[item.TestID].TestMethod.ClassName,
+			Message:   strings.TrimSpace(item.ErrorInfo.Message),
+			Stack:     strings.TrimSpace(item.ErrorInfo.StackTrace),
+		}
+		if testCase.Duration, err = parseTrxDuration(item.Duration); err != nil {
+			return nil, fmt.Errorf("invalid duration %q of test %q: %s", item.Duration, item.TestName, err.Error())
+		}
+		// detail: https://learn.microsoft.com/en-us/previous-versions/visualstudio/visual-studio-2012/ms243131(v=vs.110)
+		switch item.Outcome {
+		case "Passed", "PassedButRunAborted", "Warning":
+			testCase.Status = v1alpha1.TestCaseStatusPassed
+		case "Failed":
+			testCase.Status = v1alpha1.TestCaseStatusFailed
+		case "Error", "Timeout", "Aborted":
+			testCase.Status = v1alpha1.TestCaseStatusError
+		case "NotExecuted", "Inconclusive", "NotRunnable", "Disconnected", "Pending", "InProgress", "Completed":
+			testCase.Status = v1alpha1.TestCaseStatusSkipped
+		default:
+			return nil, fmt.Errorf("unknown outcome %q of test %q", item.Outcome, item.TestName)
+		}
+		p.addStatus(testCase.Status)
+		p.addCase(testCase)
+	}
+	return p, nil
+}
+
+// parseTrxDuration parse the duration of trx, e.g. 00:00:01.2345678
+func parseTrxDuration(value string) (duration metav1.Duration, err error) {
+	if value == "" {
+		return duration, nil
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return duration, fmt.Errorf("expected format hh:mm:ss")
+	}
+	var hours, minutes int
+	var seconds float64
+	if hours, err = strconv.Atoi(parts[0]); err != nil {
+		return duration, err
+	}
+	if minutes, err = strconv.Atoi(parts[1]); err != nil {
+		return duration, err
+	}
+	if seconds, err = strconv.ParseFloat(parts[2], 64); err != nil {
+		return duration, err
+	}
+	duration = durationOfSeconds(seconds)
+	duration.Duration += time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	return duration, nil
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package report
 
-import "github.com/katanomi/pkg/apis/codequality/v1alpha1"
+import (
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
 
 // ReportType defines report type
 type ReportType string
@@ -28,10 +31,21 @@ var SupportedTypes = []ReportType{
 
 // DefaultReportParsers consists of default supported report types
 var DefaultReportParsers = map[ReportType]ReportParser{
-	TypeJunitXml:  &JunitParser{},
-	TypeMochaJson: &MochaJsonParser{},
-	TypeJestJson:  &JestJsonParser{},
-	TypeLcov:      &LcovParser{},
+	TypeJunitXml:       &JunitParser{},
+	TypeMochaJson:      &MochaJsonParser{},
+	TypeJestJson:       &JestJsonParser{},
+	TypeLcov:           &LcovParser{},
+	TypeCoberturaXml:   &CoberturaParser{},
+	TypeJacocoXml:      &JacocoParser{},
+	TypeGoCoverProfile: &GoCoverProfileParser{},
+	TypeGoTestJson:     &GoTestJsonParser{},
+	TypeTap:            &TapParser{},
+	TypeNUnitXml:       &NUnitParser{},
+	TypeXUnitXml:       &XUnitParser{},
+	TypeTrx:            &TrxParser{},
+	TypeSarif:          &SarifParser{},
+	TypeTrivyJson:      &TrivyJsonParser{},
+	TypeGrypeJson:      &GrypeJsonParser{},
 }
 
 // ReportParser provides an interface for parsing reports.
@@ -53,3 +67,20 @@ type ConvertToTestCoverage interface {
 type ConvertToAutomatedTestResult interface {
 	ConvertToAutomatedTestResult() v1alpha1.AutomatedTestResult
 }
+
+// ConvertToTestCases provides an interface converted to the results of each test case.
+type ConvertToTestCases interface {
+	ConvertToTestCases() []v1alpha1.TestCaseResult
+}
+
+// ConvertToCodeLintResult provides an interface converted to CodeLintResult.
+// The Result of the converted CodeLintResult is left empty as it is decided by quality gates.
+type ConvertToCodeLintResult interface {
+	ConvertToCodeLintResult() v1alpha1.CodeLintResult
+}
+
+// ConvertToVulnScanResult provides an interface converted to VulnScanResult.
+// The Result of the converted VulnScanResult is left empty as it is decided by quality gates.
+type ConvertToVulnScanResult interface {
+	ConvertToVulnScanResult() securityv1alpha1.VulnScanResult
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"strconv"
+	"strings"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+// ParseVulnSeverity converts a severity reported by scanners to VulnSeverity, case insensitive.
+// Negligible severity is treated as low, unrecognized severities are unknown.
+func ParseVulnSeverity(severity string) securityv1alpha1.VulnSeverity {
+	for _, item := range securityv1alpha1.AvailableVulnSeverities {
+		if strings.EqualFold(string(item), severity) {
+			return item
+		}
+	}
+	if strings.EqualFold(severity, "negligible") {
+		return securityv1alpha1.VulnSeverityLow
+	}
+	return securityv1alpha1.VulnSeverityUnknown
+}
+
+// VulnSeverityOfScore returns the severity of a CVSS v3 base score
+// detail: https://nvd.nist.gov/vuln-metrics/cvss
+func VulnSeverityOfScore(score float64) securityv1alpha1.VulnSeverity {
+	switch {
+	case score >= 9:
+		return securityv1alpha1.VulnSeverityCritical
+	case score >= 7:
+		return securityv1alpha1.VulnSeverityHigh
+	case score >= 4:
+		return securityv1alpha1.VulnSeverityMedium
+	case score > 0:
+		return securityv1alpha1.VulnSeverityLow
+	default:
+		return securityv1alpha1.VulnSeverityUnknown
+	}
+}
+
+// vulnSeverityRank returns the rank of the severity, a more severe severity has a smaller rank
+func vulnSeverityRank(severity securityv1alpha1.VulnSeverity) int {
+	for i, item := range securityv1alpha1.AvailableVulnSeverities {
+		if item == severity {
+			return i
+		}
+	}
+	return len(securityv1alpha1.AvailableVulnSeverities)
+}
+
+// vulnTarget accumulates the vulnerabilities of a scan target
+type vulnTarget struct {
+	securityv1alpha1.VulnScanTarget
+
+	// ids of the counted vulnerabilities
+	ids map[string]struct{}
+	// score of the vulnerability in Cvss
+	score float64
+}
+
+// add adds count vulnerabilities identified by id, vulnerabilities with the same id are counted once.
+// Cvss of the target describes the vulnerability with the highest score,
+// the severity is compared when scores are the same.
+func (t *vulnTarget) add(id string, severity securityv1alpha1.VulnSeverity, score float64, source string, count int) {
+	if _, ok := t.ids[id]; ok {
+		return
+	}
+	t.ids[id] = struct{}{}
+
+	switch severity {
+	case securityv1alpha1.VulnSeverityCritical:
+		t.CriticalCount += count
+	case securityv1alpha1.VulnSeverityHigh:
+		t.HighCount += count
+	case securityv1alpha1.VulnSeverityMedium:
+		t.MediumCount += count
+	case securityv1alpha1.VulnSeverityLow:
+		t.LowCount += count
+	default:
+		severity = securityv1alpha1.VulnSeverityUnknown
+		t.UnknownCount += count
+	}
+
+	if len(t.ids) > 1 && (score < t.score ||
+		(score == t.score && vulnSeverityRank(severity) >= vulnSeverityRank(securityv1alpha1.VulnSeverity(t.Cvss.Severity)))) {
+		return
+	}
+	t.score = score
+	t.Cvss = securityv1alpha1.CVSS{Source: source, Severity: string(severity)}
+	if score > 0 {
+		t.Cvss.Score = strconv.FormatFloat(score, 'f', -1, 64)
+	}
+}
+
+// vulnTargets accumulates the vulnerabilities by target
+type vulnTargets struct {
+	keys    []string
+	targets map[string]*vulnTarget
+}
+
+// get returns the target, it is created if not exist
+func (v *vulnTargets) get(uri string, targetType securityv1alpha1.VulnScanTargetType) *vulnTarget {
+	key := string(targetType) + "/" + uri
+	if v.targets == nil {
+		v.targets = map[string]*vulnTarget{}
+	}
+	target, ok := v.targets[key]
+	if !ok {
+		target = &vulnTarget{
+			VulnScanTarget: securityv1alpha1.VulnScanTarget{Uri: uri, Type: targetType},
+			ids:            map[string]struct{}{},
+		}
+		v.targets[key] = target
+		v.keys = append(v.keys, key)
+	}
+	return target
+}
+
+// list returns the targets in the order they are added
+func (v *vulnTargets) list() []securityv1alpha1.VulnScanTarget {
+	targets := make([]securityv1alpha1.VulnScanTarget, 0, len(v.keys))
+	for _, key := range v.keys {
+		targets = append(targets, v.targets[key].VulnScanTarget)
+	}
+	return targets
+}
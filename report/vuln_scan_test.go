@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"testing"
+
+	securityv1alpha1 "github.com/katanomi/pkg/apis/security/v1alpha1"
+)
+
+func TestParseVulnSeverity(t *testing.T) {
+	tests := map[string]securityv1alpha1.VulnSeverity{
+		"CRITICAL":   securityv1alpha1.VulnSeverityCritical,
+		"high":       securityv1alpha1.VulnSeverityHigh,
+		"Medium":     securityv1alpha1.VulnSeverityMedium,
+		"low":        securityv1alpha1.VulnSeverityLow,
+		"Negligible": securityv1alpha1.VulnSeverityLow,
+		"UNKNOWN":    securityv1alpha1.VulnSeverityUnknown,
+		"moderate":   securityv1alpha1.VulnSeverityUnknown,
+		"":           securityv1alpha1.VulnSeverityUnknown,
+	}
+	for severity, want := range tests {
+		t.Run(severity, func(t *testing.T) {
+			if got := ParseVulnSeverity(severity); got != want {
+				t.Errorf("ParseVulnSeverity() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestVulnSeverityOfScore(t *testing.T) {
+	tests := map[float64]securityv1alpha1.VulnSeverity{
+		10:  securityv1alpha1.VulnSeverityCritical,
+		9:   securityv1alpha1.VulnSeverityCritical,
+		8.9: securityv1alpha1.VulnSeverityHigh,
+		7:   securityv1alpha1.VulnSeverityHigh,
+		6.9: securityv1alpha1.VulnSeverityMedium,
+		4:   securityv1alpha1.VulnSeverityMedium,
+		3.9: securityv1alpha1.VulnSeverityLow,
+		0.1: securityv1alpha1.VulnSeverityLow,
+		0:   securityv1alpha1.VulnSeverityUnknown,
+	}
+	for score, want := range tests {
+		if got := VulnSeverityOfScore(score); got != want {
+			t.Errorf("VulnSeverityOfScore(%v) = %v, want %v", score, got, want)
+		}
+	}
+}
+
+func TestVulnTargets(t *testing.T) {
+	targets := &vulnTargets{}
+	image := targets.get("alpine:3.17", securityv1alpha1.VulnScanTargetTypeImage)
+	image.add("CVE-1", securityv1alpha1.VulnSeverityHigh, 7.5, "nvd", 1)
+	// duplicated vulnerabilities are counted once
+	image.add("CVE-1", securityv1alpha1.VulnSeverityHigh, 7.5, "nvd", 1)
+	image.add("CVE-2", securityv1alpha1.VulnSeverityMedium, 5, "nvd", 1)
+	// the severity is compared when scores are the same
+	image.add("CVE-3", securityv1alpha1.VulnSeverityCritical, 7.5, "ghsa", 1)
+	image.add("CVE-4", "", 0, "", 2)
+	targets.get("/workspace", securityv1alpha1.VulnScanTargetTypeFileSystem)
+	if targets.get("alpine:3.17", securityv1alpha1.VulnScanTargetTypeImage) != image {
+		t.Errorf("vulnTargets.get() should return the existing target")
+	}
+
+	want := []securityv1alpha1.VulnScanTarget{
+		{
+			Uri:           "alpine:3.17",
+			Type:          securityv1alpha1.VulnScanTargetTypeImage,
+			Cvss:          securityv1alpha1.CVSS{Source: "ghsa", Severity: "Critical", Score: "7.5"},
+			VulnStatistic: securityv1alpha1.VulnStatistic{CriticalCount: 1, HighCount: 1, MediumCount: 1, UnknownCount: 2},
+		},
+		{
+			Uri:  "/workspace",
+			Type: securityv1alpha1.VulnScanTargetTypeFileSystem,
+		},
+	}
+	got := targets.list()
+	if len(got) != len(want) {
+		t.Fatalf("vulnTargets.list() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("vulnTargets.list()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
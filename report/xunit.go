@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/katanomi/pkg/apis/codequality/v1alpha1"
+)
+
+const (
+	// TypeXUnitXml is the type of xunit-xml, the xUnit.net v2 test result format
+	TypeXUnitXml ReportType = "xunit-xml"
+)
+
+// results of xUnit.net tests
+// detail: https://xunit.net/docs/format-xml-v2
+const (
+	xunitResultPass   = "Pass"
+	xunitResultFail   = "Fail"
+	xunitResultSkip   = "Skip"
+	xunitResultNotRun = "NotRun"
+)
+
+// XUnitParser xUnit.net v2 xml report parser
+type XUnitParser struct {
+	TestSummary `json:",inline"`
+	TestCases   `json:",inline"`
+}
+
+type xunitAssemblies struct {
+	Assemblies []xunitAssembly `xml:"assembly"`
+}
+
+type xunitAssembly struct {
+	Name        string            `xml:"name,attr"`
+	Collections []xunitCollection `xml:"collection"`
+	// Errors errors of the assembly not attributed to a test, e.g. fixture cleanup failures
+	Errors []xunitError `xml:"errors>error"`
+}
+
+type xunitCollection struct {
+	Tests []xunitTest `xml:"test"`
+}
+
+type xunitTest struct {
+	Name    string       `xml:"name,attr"`
+	Type    string       `xml:"type,attr"`
+	Result  string       `xml:"result,attr"`
+	Time    float64      `xml:"time,attr"`
+	Failure xunitFailure `xml:"failure"`
+	Reason  string       `xml:"reason"`
+}
+
+type xunitError struct {
+	Type    string       `xml:"type,attr"`
+	Name    string       `xml:"name,attr"`
+	Failure xunitFailure `xml:"failure"`
+}
+
+type xunitFailure struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+// Parse parse xUnit.net v2 xml report.
+// Errors of the assemblies are counted as errored test cases.
+func (p *XUnitParser) Parse(path string) (result interface{}, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := xunitAssemblies{}
+	if err = xml.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("invalid xunit xml: %s", err.Error())
+	}
+
+	*p = XUnitParser{}
+	for _, assembly := range report.Assemblies {
+		for _, collection := range assembly.Collections {
+			for _, test := range collection.Tests {
+				testCase := v1alpha1.TestCaseResult{
+					Suite:     assembly.Name,
+					Name:      test.Name,
+					ClassName: test.Type,
+					Duration:  durationOfSeconds(test.Time),
+					Message:   strings.TrimSpace(test.Failure.Message),
+					Stack:     strings.TrimSpace(test.Failure.StackTrace),
+				}
+				switch test.Result {
+				case xunitResultPass:
+					testCase.Status = v1alpha1.TestCaseStatusPassed
+				case xunitResultFail:
+					testCase.Status = v1alpha1.TestCaseStatusFailed
+				case xunitResultSkip, xunitResultNotRun:
+					testCase.Status = v1alpha1.TestCaseStatusSkipped
+					testCase.Message = strings.TrimSpace(test.Reason)
+				default:
+					return nil, fmt.Errorf("unknown result %q of test %q", test.Result, test.Name)
+				}
+				p.addStatus(testCase.Status)
+				p.addCase(testCase)
+			}
+		}
+		for _, item := range assembly.Errors {
+			name := item.Name
+			if name == "" {
+				name = item.Type
+			}
+			p.addStatus(v1alpha1.TestCaseStatusError)
+			p.addCase(v1alpha1.TestCaseResult{
+				Suite:   assembly.Name,
+				Name:    name,
+				Status:  v1alpha1.TestCaseStatusError,
+				Message: strings.TrimSpace(item.Failure.Message),
+				Stack:   strings.TrimSpace(item.Failure.StackTrace),
+			})
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitplugin provides a conformance suite for git plugins.
+//
+// The suite exercises every method of GitPluginClientSet through the v2
+// plugin client against a seeded repository, checks pagination, error
+// mapping and field population, and produces a report listing the
+// capabilities supported by the plugin.
+package gitplugin
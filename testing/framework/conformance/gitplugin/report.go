@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitplugin
+
+import (
+	"encoding/json"
+	"io"
+
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+)
+
+// Capability is a method of GitPluginClientSet verified by the suite
+type Capability string
+
+const (
+	CapabilityGetGitRepository         Capability = "GetGitRepository"
+	CapabilityListGitRepository        Capability = "ListGitRepository"
+	CapabilityListGitBranch            Capability = "ListGitBranch"
+	CapabilityGetGitBranch             Capability = "GetGitBranch"
+	CapabilityCreateGitBranch          Capability = "CreateGitBranch"
+	CapabilityGetGitCommit             Capability = "GetGitCommit"
+	CapabilityListGitCommit            Capability = "ListGitCommit"
+	CapabilityCreateGitCommit          Capability = "CreateGitCommit"
+	CapabilityGetGitRepoFile           Capability = "GetGitRepoFile"
+	CapabilityCreateGitRepoFile        Capability = "CreateGitRepoFile"
+	CapabilityGetGitRepositoryFileTree Capability = "GetGitRepositoryFileTree"
+	CapabilityListGitRepositoryTag     Capability = "ListGitRepositoryTag"
+	CapabilityGetGitRepositoryTag      Capability = "GetGitRepositoryTag"
+	CapabilityCreateGitCommitStatus    Capability = "CreateGitCommitStatus"
+	CapabilityListGitCommitStatus      Capability = "ListGitCommitStatus"
+	CapabilityCreateGitCommitComment   Capability = "CreateGitCommitComment"
+	CapabilityListGitCommitComment     Capability = "ListGitCommitComment"
+	CapabilityCreatePullRequest        Capability = "CreatePullRequest"
+	CapabilityGetGitPullRequest        Capability = "GetGitPullRequest"
+	CapabilityListGitPullRequest       Capability = "ListGitPullRequest"
+	CapabilityCreatePullRequestComment Capability = "CreatePullRequestComment"
+	CapabilityUpdatePullRequestComment Capability = "UpdatePullRequestComment"
+	CapabilityListPullRequestComment   Capability = "ListPullRequestComment"
+
+	CapabilityListPullRequestFiles        Capability = "ListPullRequestFiles"
+	CapabilityGetPullRequestDiff          Capability = "GetPullRequestDiff"
+	CapabilityCreatePullRequestReview     Capability = "CreatePullRequestReview"
+	CapabilityListPullRequestReviews      Capability = "ListPullRequestReviews"
+	CapabilityRequestPullRequestReviewers Capability = "RequestPullRequestReviewers"
+	CapabilityGetPullRequestMergeability  Capability = "GetPullRequestMergeability"
+	CapabilityMergePullRequest            Capability = "MergePullRequest"
+)
+
+// Status is the conclusion of the checks of a capability
+type Status string
+
+const (
+	// StatusSupported all the checks of the capability passed
+	StatusSupported Status = "Supported"
+	// StatusUnsupported the plugin does not implement the capability
+	StatusUnsupported Status = "Unsupported"
+	// StatusFailed the plugin implements the capability but the checks failed
+	StatusFailed Status = "Failed"
+	// StatusSkipped the capability was not checked because a capability it depends on is not supported
+	StatusSkipped Status = "Skipped"
+)
+
+// Result the result of the checks of a capability
+type Result struct {
+	Capability Capability `json:"capability"`
+	Status     Status     `json:"status"`
+	// Message describes why the capability is not supported
+	Message string `json:"message,omitempty"`
+}
+
+// ConformanceReport the machine-readable result of a conformance run
+type ConformanceReport struct {
+	Repository metav1alpha1.GitRepo `json:"repository"`
+	Results    []Result             `json:"results"`
+}
+
+// Result returns the result of a capability
+func (r *ConformanceReport) Result(capability Capability) (Result, bool) {
+	for _, item := range r.Results {
+		if item.Capability == capability {
+			return item, true
+		}
+	}
+	return Result{}, false
+}
+
+// Supported returns the capabilities supported by the plugin
+func (r *ConformanceReport) Supported() []Capability {
+	return r.capabilities(StatusSupported)
+}
+
+// Failed returns the results of the capabilities that failed the checks
+func (r *ConformanceReport) Failed() []Result {
+	var results []Result
+	for _, item := range r.Results {
+		if item.Status == StatusFailed {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
+// IsSupported returns true if all the capabilities are supported
+func (r *ConformanceReport) IsSupported(capabilities ...Capability) bool {
+	for _, capability := range capabilities {
+		if result, ok := r.Result(capability); !ok || result.Status != StatusSupported {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteJSON writes the report as indented json
+func (r *ConformanceReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r *ConformanceReport) capabilities(status Status) []Capability {
+	var capabilities []Capability
+	for _, item := range r.Results {
+		if item.Status == status {
+			capabilities = append(capabilities, item.Capability)
+		}
+	}
+	return capabilities
+}
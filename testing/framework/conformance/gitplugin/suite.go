@@ -0,0 +1,756 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitplugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	coderepositoryv1alpha1 "github.com/katanomi/pkg/apis/coderepository/v1alpha1"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	v2 "github.com/katanomi/pkg/plugin/client/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultFilePath the file expected to exist in the seeded repository
+	DefaultFilePath = "README.md"
+	// DefaultNamePrefix the prefix of the branches and files created by the suite
+	DefaultNamePrefix = "conformance"
+
+	// missingName a name that should not exist in any repository
+	missingName = "conformance-not-exist-0d1c3b"
+	// missingSHA a commit sha that should not exist in any repository
+	missingSHA = "0000000000000000000000000000000000000000"
+	// missingIndex a pull request index that should not exist in any repository
+	missingIndex = 999999
+	// invalidBranchName a branch name rejected by git
+	invalidBranchName = "conformance..invalid"
+)
+
+// Options options of the conformance suite
+type Options struct {
+	// Repo the seeded repository, it should contain at least two commits
+	// on Branch and the file FilePath.
+	Repo metav1alpha1.GitRepo
+	// Branch the base branch used by the suite.
+	// Defaults to the default branch of the repository.
+	Branch string
+	// FilePath the path of a file existing on Branch. Defaults to DefaultFilePath.
+	FilePath string
+	// Tag an existing tag of the repository.
+	// Defaults to the first tag listed, only error mapping is verified if there is no tag.
+	Tag string
+	// NamePrefix the prefix of the branches and files created by the suite.
+	// Defaults to DefaultNamePrefix.
+	NamePrefix string
+	// SubType the sub type of the project of Repo. Defaults to GitGroupProjectSubType.
+	SubType metav1alpha1.ProjectSubType
+	// Reviewers the users requested to review the created pull request,
+	// only error mapping is verified if it is empty.
+	Reviewers []metav1alpha1.GitUserBaseInfo
+	// Merge merges the created pull request into Branch using MergeStrategy,
+	// only error mapping is verified if it is false.
+	Merge bool
+	// MergeStrategy the strategy to merge the created pull request. Defaults to MergeStrategyMerge.
+	MergeStrategy metav1alpha1.MergeStrategy
+}
+
+// Suite runs the conformance checks of a git plugin
+type Suite struct {
+	client  *v2.PluginClient
+	options Options
+	report  *ConformanceReport
+
+	// state shared between the checks
+	branch            string
+	headSHA           string
+	newBranch         string
+	newSHA            string
+	tag               string
+	pullRequest       int
+	pullRequestNote   int
+	pullRequestReview int
+}
+
+// NewSuite constructs a conformance suite for the plugin served by client
+func NewSuite(client *v2.PluginClient, options Options) *Suite {
+	if options.FilePath == "" {
+		options.FilePath = DefaultFilePath
+	}
+	if options.NamePrefix == "" {
+		options.NamePrefix = DefaultNamePrefix
+	}
+	if options.SubType == "" {
+		options.SubType = metav1alpha1.GitGroupProjectSubType
+	}
+	if options.MergeStrategy == "" {
+		options.MergeStrategy = metav1alpha1.MergeStrategyMerge
+	}
+	return &Suite{client: client, options: options}
+}
+
+// Run runs all the checks and returns the report.
+// Write checks create a branch, commits, a pull request, comments and a review in the repository,
+// the pull request is only merged if Options.Merge is true.
+// The created branch is deleted after the checks, see cleanup.
+func (s *Suite) Run(ctx context.Context) *ConformanceReport {
+	s.report = &ConformanceReport{Repository: s.options.Repo}
+	s.branch, s.tag = s.options.Branch, s.options.Tag
+	s.newBranch = fmt.Sprintf("%s-%d", s.options.NamePrefix, time.Now().Unix())
+
+	s.check(ctx, CapabilityGetGitRepository, s.getRepository)
+	s.check(ctx, CapabilityListGitRepository, s.listRepository)
+	s.check(ctx, CapabilityListGitBranch, s.listBranch)
+	s.check(ctx, CapabilityGetGitBranch, s.getBranch)
+	s.check(ctx, CapabilityCreateGitBranch, s.createBranch, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityGetGitCommit, s.getCommit, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityListGitCommit, s.listCommit, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityCreateGitCommit, s.createCommit, CapabilityCreateGitBranch)
+	s.check(ctx, CapabilityGetGitRepoFile, s.getRepoFile, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityCreateGitRepoFile, s.createRepoFile, CapabilityCreateGitBranch)
+	s.check(ctx, CapabilityGetGitRepositoryFileTree, s.getFileTree, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityListGitRepositoryTag, s.listTag)
+	s.check(ctx, CapabilityGetGitRepositoryTag, s.getTag, CapabilityListGitRepositoryTag)
+	s.check(ctx, CapabilityCreateGitCommitStatus, s.createCommitStatus, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityListGitCommitStatus, s.listCommitStatus, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityCreateGitCommitComment, s.createCommitComment, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityListGitCommitComment, s.listCommitComment, CapabilityGetGitBranch)
+	s.check(ctx, CapabilityCreatePullRequest, s.createPullRequest, CapabilityCreateGitCommit)
+	s.check(ctx, CapabilityGetGitPullRequest, s.getPullRequest, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityListGitPullRequest, s.listPullRequest)
+	s.check(ctx, CapabilityCreatePullRequestComment, s.createPullRequestComment, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityUpdatePullRequestComment, s.updatePullRequestComment, CapabilityCreatePullRequestComment)
+	s.check(ctx, CapabilityListPullRequestComment, s.listPullRequestComment, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityListPullRequestFiles, s.listPullRequestFiles, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityGetPullRequestDiff, s.getPullRequestDiff, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityCreatePullRequestReview, s.createPullRequestReview, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityListPullRequestReviews, s.listPullRequestReviews, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityRequestPullRequestReviewers, s.requestPullRequestReviewers, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityGetPullRequestMergeability, s.getPullRequestMergeability, CapabilityCreatePullRequest)
+	s.check(ctx, CapabilityMergePullRequest, s.mergePullRequest, CapabilityGetPullRequestMergeability)
+	s.cleanup(ctx)
+	return s.report
+}
+
+// cleanup removes what the checks created as far as the plugin allows, errors are ignored.
+// Deleting the branch drops the commits and files not merged, pull requests and their comments
+// could not be deleted by plugins, but most providers close pull requests whose source branch is deleted.
+func (s *Suite) cleanup(ctx context.Context) {
+	_ = s.client.DeleteGitBranch(ctx, s.options.Repo, s.newBranch)
+}
+
+// check runs the check of a capability if all the capabilities it depends on are supported
+func (s *Suite) check(ctx context.Context, capability Capability, check func(context.Context) error, requires ...Capability) {
+	result := Result{Capability: capability, Status: StatusSupported}
+	var missing []string
+	for _, item := range requires {
+		if !s.report.IsSupported(item) {
+			missing = append(missing, string(item))
+		}
+	}
+	if len(missing) > 0 {
+		result.Status = StatusSkipped
+		result.Message = fmt.Sprintf("requires %s", strings.Join(missing, ", "))
+	} else if err := check(ctx); err != nil {
+		result.Status, result.Message = StatusFailed, err.Error()
+		if isUnsupported(err) {
+			result.Status = StatusUnsupported
+		}
+	}
+	s.report.Results = append(s.report.Results, result)
+}
+
+func (s *Suite) getRepository(ctx context.Context) error {
+	repository, err := s.client.GetGitRepository(ctx, s.options.Repo)
+	if err != nil {
+		return err
+	}
+	if s.branch == "" {
+		s.branch = repository.Spec.DefaultBranch
+	}
+	if err := requireFields(field{"spec.name", repository.Spec.Name == s.options.Repo.Repository},
+		field{"spec.defaultBranch", repository.Spec.DefaultBranch != ""}); err != nil {
+		return err
+	}
+	_, err = s.client.GetGitRepository(ctx, metav1alpha1.GitRepo{Project: s.options.Repo.Project, Repository: missingName})
+	return requireNotFound("repository", err)
+}
+
+func (s *Suite) listRepository(ctx context.Context) error {
+	repo := s.options.Repo
+	list, err := s.client.ListGitRepository(ctx, repo.Project, repo.Repository, s.options.SubType, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, item := range list.Items {
+		found = found || item.Spec.Name == repo.Repository
+	}
+	if !found {
+		return fmt.Errorf("repository %q is not listed", repo.Repository)
+	}
+	return checkPagination(func(option metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListGitRepository(ctx, repo.Project, "", s.options.SubType, option)
+		return keys(list.Items, func(item metav1alpha1.GitRepository) string { return item.Spec.Name }), err
+	})
+}
+
+func (s *Suite) listBranch(ctx context.Context) error {
+	return checkPagination(func(option metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListGitBranch(ctx, metav1alpha1.GitBranchOption{GitRepo: s.options.Repo}, option)
+		return keys(list.Items, func(item metav1alpha1.GitBranch) string { return item.Spec.Name }), err
+	})
+}
+
+func (s *Suite) getBranch(ctx context.Context) error {
+	if s.branch == "" {
+		return fmt.Errorf("base branch is unknown, the repository has no default branch")
+	}
+	branch, err := s.client.GetGitBranch(ctx, s.options.Repo, s.branch)
+	if err != nil {
+		return err
+	}
+	s.headSHA = stringValue(branch.Spec.Commit.SHA)
+	if err := requireFields(field{"spec.name", branch.Spec.Name == s.branch},
+		field{"spec.commit.sha", s.headSHA != ""}); err != nil {
+		return err
+	}
+	_, err = s.client.GetGitBranch(ctx, s.options.Repo, missingName)
+	return requireNotFound("branch", err)
+}
+
+func (s *Suite) createBranch(ctx context.Context) error {
+	branch, err := s.client.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+		GitRepo:            s.options.Repo,
+		CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: s.newBranch, Ref: s.branch},
+	})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.name", branch.Spec.Name == s.newBranch},
+		field{"spec.commit.sha", stringValue(branch.Spec.Commit.SHA) == s.headSHA}); err != nil {
+		return err
+	}
+	_, err = s.client.CreateGitBranch(ctx, metav1alpha1.CreateBranchPayload{
+		GitRepo:            s.options.Repo,
+		CreateBranchParams: metav1alpha1.CreateBranchParams{Branch: invalidBranchName, Ref: s.branch},
+	})
+	return requireReason("creation of an invalid branch", err, metav1.StatusReasonBadRequest)
+}
+
+func (s *Suite) getCommit(ctx context.Context) error {
+	commit, err := s.client.GetGitCommit(ctx, metav1alpha1.GitCommitOption{
+		GitRepo: s.options.Repo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: &s.headSHA},
+	})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.sha", stringValue(commit.Spec.SHA) == s.headSHA},
+		field{"spec.message", stringValue(commit.Spec.Message) != ""},
+		field{"spec.createdAt", !commit.Spec.CreatedAt.IsZero()}); err != nil {
+		return err
+	}
+	sha := missingSHA
+	_, err = s.client.GetGitCommit(ctx, metav1alpha1.GitCommitOption{
+		GitRepo: s.options.Repo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: &sha},
+	})
+	return requireNotFound("commit", err)
+}
+
+func (s *Suite) listCommit(ctx context.Context) error {
+	return checkPagination(func(option metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListGitCommit(ctx, metav1alpha1.GitCommitListOption{GitRepo: s.options.Repo, Ref: s.branch}, option)
+		return keys(list.Items, func(item metav1alpha1.GitCommit) string { return stringValue(item.Spec.SHA) }), err
+	})
+}
+
+func (s *Suite) createCommit(ctx context.Context) error {
+	commit, err := s.client.CreateGitCommit(ctx, coderepositoryv1alpha1.CreateGitCommitOption{
+		GitRepo: s.options.Repo,
+		GitCreateCommit: coderepositoryv1alpha1.GitCreateCommit{Spec: coderepositoryv1alpha1.GitCreateCommitSpec{
+			Branch:  s.newBranch,
+			Message: "conformance: create commit",
+			Actions: []coderepositoryv1alpha1.CreateCommitAction{{
+				Action:   "create",
+				FilePath: s.newFilePath("commit"),
+				Content:  "created by the conformance suite\n",
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	s.newSHA = stringValue(commit.Spec.SHA)
+	return requireFields(field{"spec.sha", s.newSHA != "" && s.newSHA != s.headSHA})
+}
+
+func (s *Suite) getRepoFile(ctx context.Context) error {
+	file, err := s.client.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{
+		GitRepo: s.options.Repo, Ref: s.branch, Path: s.options.FilePath,
+	})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.content", len(file.Spec.Content) > 0}); err != nil {
+		return err
+	}
+	_, err = s.client.GetGitRepoFile(ctx, metav1alpha1.GitRepoFileOption{
+		GitRepo: s.options.Repo, Ref: s.branch, Path: missingName,
+	})
+	return requireNotFound("file", err)
+}
+
+func (s *Suite) createRepoFile(ctx context.Context) error {
+	commit, err := s.client.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+		GitRepo:  s.options.Repo,
+		FilePath: s.newFilePath("file"),
+		CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{
+			Branch:  s.newBranch,
+			Message: "conformance: create file",
+			Content: []byte("created by the conformance suite\n"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if sha := stringValue(commit.Spec.SHA); sha != "" {
+		s.newSHA = sha
+	}
+	return requireFields(field{"spec.sha", stringValue(commit.Spec.SHA) != ""})
+}
+
+func (s *Suite) getFileTree(ctx context.Context) error {
+	dir := path.Dir(s.options.FilePath)
+	if dir == "." {
+		dir = "/"
+	}
+	tree, err := s.client.GetGitRepositoryFileTree(ctx, metav1alpha1.GitRepoFileTreeOption{
+		GitRepo: s.options.Repo, Path: dir, TreeSha: s.branch,
+	}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, node := range tree.Spec.Tree {
+		if node.Path == s.options.FilePath {
+			return requireFields(field{"spec.tree.sha", node.Sha != ""}, field{"spec.tree.type", node.Type != ""})
+		}
+	}
+	return fmt.Errorf("file %q is not listed in the file tree of %q", s.options.FilePath, dir)
+}
+
+func (s *Suite) listTag(ctx context.Context) error {
+	list, err := s.client.ListGitRepositoryTag(ctx, metav1alpha1.GitRepositoryTagListOption{GitRepo: s.options.Repo}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if s.tag == "" && len(list.Items) > 0 {
+		s.tag = list.Items[0].Spec.Name
+	}
+	return checkPagination(func(option metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListGitRepositoryTag(ctx, metav1alpha1.GitRepositoryTagListOption{GitRepo: s.options.Repo}, option)
+		return keys(list.Items, func(item metav1alpha1.GitRepositoryTag) string { return item.Spec.Name }), err
+	})
+}
+
+func (s *Suite) getTag(ctx context.Context) error {
+	if s.tag != "" {
+		tag, err := s.client.GetGitRepositoryTag(ctx, metav1alpha1.GitTag{GitRepo: s.options.Repo, Tag: s.tag})
+		if err != nil {
+			return err
+		}
+		if err := requireFields(field{"spec.name", tag.Spec.Name == s.tag},
+			field{"spec.sha", stringValue(tag.Spec.SHA) != ""}); err != nil {
+			return err
+		}
+	}
+	_, err := s.client.GetGitRepositoryTag(ctx, metav1alpha1.GitTag{GitRepo: s.options.Repo, Tag: missingName})
+	return requireNotFound("tag", err)
+}
+
+func (s *Suite) createCommitStatus(ctx context.Context) error {
+	name, description := s.options.NamePrefix, "created by the conformance suite"
+	status, err := s.client.CreateGitCommitStatus(ctx, metav1alpha1.CreateCommitStatusPayload{
+		GitRepo:            s.options.Repo,
+		GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: &s.headSHA},
+		CreateCommitStatusParam: metav1alpha1.CreateCommitStatusParam{
+			State: "success", Name: &name, Context: &name, Description: &description,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return requireFields(field{"spec.status", status.Spec.Status != ""})
+}
+
+func (s *Suite) listCommitStatus(ctx context.Context) error {
+	list, err := s.client.ListGitCommitStatus(ctx, metav1alpha1.GitCommitOption{
+		GitRepo: s.options.Repo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: &s.headSHA},
+	}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if s.report.IsSupported(CapabilityCreateGitCommitStatus) && len(list.Items) == 0 {
+		return fmt.Errorf("the created commit status is not listed")
+	}
+	return nil
+}
+
+func (s *Suite) createCommitComment(ctx context.Context) error {
+	note := "created by the conformance suite"
+	comment, err := s.client.CreateGitCommitComment(ctx, metav1alpha1.CreateCommitCommentPayload{
+		GitRepo:                  s.options.Repo,
+		GitCommitBasicInfo:       metav1alpha1.GitCommitBasicInfo{SHA: &s.headSHA},
+		CreateCommitCommentParam: metav1alpha1.CreateCommitCommentParam{Note: &note},
+	})
+	if err != nil {
+		return err
+	}
+	return requireFields(field{"spec.note", comment.Spec.Note == note})
+}
+
+func (s *Suite) listCommitComment(ctx context.Context) error {
+	list, err := s.client.ListGitCommitComment(ctx, metav1alpha1.GitCommitOption{
+		GitRepo: s.options.Repo, GitCommitBasicInfo: metav1alpha1.GitCommitBasicInfo{SHA: &s.headSHA},
+	}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if s.report.IsSupported(CapabilityCreateGitCommitComment) && len(list.Items) == 0 {
+		return fmt.Errorf("the created commit comment is not listed")
+	}
+	return nil
+}
+
+func (s *Suite) createPullRequest(ctx context.Context) error {
+	title := fmt.Sprintf("%s: %s", s.options.NamePrefix, s.newBranch)
+	pr, err := s.client.CreatePullRequest(ctx, metav1alpha1.CreatePullRequestPayload{
+		Source: metav1alpha1.GitBranchBaseInfo{GitRepo: s.options.Repo, Name: s.newBranch},
+		Target: metav1alpha1.GitBranchBaseInfo{GitRepo: s.options.Repo, Name: s.branch},
+		Title:  title,
+	})
+	if err != nil {
+		return err
+	}
+	s.pullRequest = int(pr.Spec.Number)
+	return requireFields(field{"spec.num", pr.Spec.Number > 0},
+		field{"spec.title", pr.Spec.Title == title},
+		field{"spec.source.name", pr.Spec.Source.Name == s.newBranch},
+		field{"spec.target.name", pr.Spec.Target.Name == s.branch})
+}
+
+func (s *Suite) getPullRequest(ctx context.Context) error {
+	pr, err := s.client.GetGitPullRequest(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.num", int(pr.Spec.Number) == s.pullRequest},
+		field{"spec.state", pr.Spec.State != ""},
+		field{"spec.source.name", pr.Spec.Source.Name == s.newBranch}); err != nil {
+		return err
+	}
+	_, err = s.client.GetGitPullRequest(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: missingIndex})
+	return requireNotFound("pull request", err)
+}
+
+func (s *Suite) listPullRequest(ctx context.Context) error {
+	return checkPagination(func(option metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListGitPullRequest(ctx, metav1alpha1.GitPullRequestListOption{GitRepo: s.options.Repo}, option)
+		return keys(list.Items, func(item metav1alpha1.GitPullRequest) string { return strconv.FormatInt(item.Spec.Number, 10) }), err
+	})
+}
+
+func (s *Suite) createPullRequestComment(ctx context.Context) error {
+	body := "created by the conformance suite"
+	note, err := s.client.CreatePullRequestComment(ctx, metav1alpha1.CreatePullRequestCommentPayload{
+		GitRepo:                       s.options.Repo,
+		CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: body},
+		Index:                         s.pullRequest,
+	})
+	if err != nil {
+		return err
+	}
+	s.pullRequestNote = note.Spec.ID
+	return requireFields(field{"spec.id", note.Spec.ID != 0}, field{"spec.body", note.Spec.Body == body})
+}
+
+func (s *Suite) updatePullRequestComment(ctx context.Context) error {
+	body := "updated by the conformance suite"
+	note, err := s.client.UpdatePullRequestComment(ctx, metav1alpha1.UpdatePullRequestCommentPayload{
+		GitRepo:                       s.options.Repo,
+		CreatePullRequestCommentParam: metav1alpha1.CreatePullRequestCommentParam{Body: body},
+		Index:                         s.pullRequest,
+		CommentID:                     s.pullRequestNote,
+	})
+	if err != nil {
+		return err
+	}
+	return requireFields(field{"spec.id", note.Spec.ID == s.pullRequestNote}, field{"spec.body", note.Spec.Body == body})
+}
+
+func (s *Suite) listPullRequestComment(ctx context.Context) error {
+	list, err := s.client.ListPullRequestComment(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if !s.report.IsSupported(CapabilityCreatePullRequestComment) {
+		return nil
+	}
+	for _, item := range list.Items {
+		if item.Spec.ID == s.pullRequestNote {
+			return nil
+		}
+	}
+	return fmt.Errorf("the created pull request comment %d is not listed", s.pullRequestNote)
+}
+
+func (s *Suite) listPullRequestFiles(ctx context.Context) error {
+	option := metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest}
+	list, err := s.client.ListPullRequestFiles(ctx, option, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		if err := requireFields(field{"spec.path", item.Spec.Path != ""}, field{"spec.status", item.Spec.Status != ""}); err != nil {
+			return err
+		}
+	}
+	if s.report.IsSupported(CapabilityCreateGitCommit) {
+		files := keys(list.Items, func(item metav1alpha1.GitPullRequestFile) string { return item.Spec.Path })
+		if !slices.Contains(files, s.newFilePath("commit")) {
+			return fmt.Errorf("the committed file %q is not listed", s.newFilePath("commit"))
+		}
+	}
+	return checkPagination(func(listOption metav1alpha1.ListOptions) ([]string, error) {
+		list, err := s.client.ListPullRequestFiles(ctx, option, listOption)
+		return keys(list.Items, func(item metav1alpha1.GitPullRequestFile) string { return item.Spec.Path }), err
+	})
+}
+
+func (s *Suite) getPullRequestDiff(ctx context.Context) error {
+	diff, err := s.client.GetPullRequestDiff(ctx, metav1alpha1.GitPullRequestDiffOption{
+		GitPullRequestOption: metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest},
+	})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.diff", diff.Spec.Diff != ""},
+		field{"spec.baseSha", diff.Spec.BaseSHA != ""},
+		field{"spec.headSha", s.newSHA == "" || diff.Spec.HeadSHA == s.newSHA}); err != nil {
+		return err
+	}
+	_, err = s.client.GetPullRequestDiff(ctx, metav1alpha1.GitPullRequestDiffOption{
+		GitPullRequestOption: metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: missingIndex},
+	})
+	return requireNotFound("pull request", err)
+}
+
+func (s *Suite) createPullRequestReview(ctx context.Context) error {
+	payload := metav1alpha1.CreatePullRequestReviewPayload{
+		GitRepo: s.options.Repo,
+		Index:   s.pullRequest,
+		CreatePullRequestReviewParam: metav1alpha1.CreatePullRequestReviewParam{
+			State: metav1alpha1.PullRequestReviewCommented,
+			Body:  "reviewed by the conformance suite",
+		},
+	}
+	review, err := s.client.CreatePullRequestReview(ctx, payload)
+	if err != nil {
+		return err
+	}
+	s.pullRequestReview = review.Spec.ID
+	if err := requireFields(field{"spec.id", review.Spec.ID != 0},
+		field{"spec.state", review.Spec.State == metav1alpha1.PullRequestReviewCommented}); err != nil {
+		return err
+	}
+	payload.State = metav1alpha1.PullRequestReviewDismissed
+	_, err = s.client.CreatePullRequestReview(ctx, payload)
+	return requireReason("review with a state which could not be submitted", err, metav1.StatusReasonBadRequest)
+}
+
+func (s *Suite) listPullRequestReviews(ctx context.Context) error {
+	list, err := s.client.ListPullRequestReviews(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest}, metav1alpha1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if !s.report.IsSupported(CapabilityCreatePullRequestReview) {
+		return nil
+	}
+	for _, item := range list.Items {
+		if item.Spec.ID == s.pullRequestReview {
+			return nil
+		}
+	}
+	return fmt.Errorf("the created pull request review %d is not listed", s.pullRequestReview)
+}
+
+func (s *Suite) requestPullRequestReviewers(ctx context.Context) error {
+	payload := metav1alpha1.RequestPullRequestReviewersPayload{GitRepo: s.options.Repo, Index: s.pullRequest}
+	if len(s.options.Reviewers) > 0 {
+		payload.Reviewers = s.options.Reviewers
+		pr, err := s.client.RequestPullRequestReviewers(ctx, payload)
+		if err != nil {
+			return err
+		}
+		reviewers := keys(pr.Spec.Reviewers, func(item metav1alpha1.GitUserBaseInfo) string { return item.Name })
+		for _, reviewer := range s.options.Reviewers {
+			if !slices.Contains(reviewers, reviewer.Name) {
+				return fmt.Errorf("the requested reviewer %q is not listed in spec.reviewers", reviewer.Name)
+			}
+		}
+	}
+	payload.Reviewers = nil
+	_, err := s.client.RequestPullRequestReviewers(ctx, payload)
+	return requireReason("request without reviewers", err, metav1.StatusReasonBadRequest)
+}
+
+func (s *Suite) getPullRequestMergeability(ctx context.Context) error {
+	mergeability, err := s.client.GetPullRequestMergeability(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: s.pullRequest})
+	if err != nil {
+		return err
+	}
+	if err := requireFields(field{"spec.mergeStatus", mergeability.Spec.MergeStatus.IsValid()},
+		field{"spec.sourceSha", s.newSHA == "" || mergeability.Spec.SourceSHA == s.newSHA}); err != nil {
+		return err
+	}
+	_, err = s.client.GetPullRequestMergeability(ctx, metav1alpha1.GitPullRequestOption{GitRepo: s.options.Repo, Index: missingIndex})
+	return requireNotFound("pull request", err)
+}
+
+func (s *Suite) mergePullRequest(ctx context.Context) error {
+	payload := metav1alpha1.MergePullRequestPayload{
+		GitRepo: s.options.Repo,
+		Index:   s.pullRequest,
+		MergePullRequestParam: metav1alpha1.MergePullRequestParam{
+			Strategy:      s.options.MergeStrategy,
+			CommitMessage: fmt.Sprintf("%s: merge %s", s.options.NamePrefix, s.newBranch),
+			SHA:           missingSHA,
+		},
+	}
+	// the source branch is never at the missing sha
+	_, err := s.client.MergePullRequest(ctx, payload)
+	if err := requireReason("merge of an outdated commit", err, metav1.StatusReasonConflict); err != nil || !s.options.Merge {
+		return err
+	}
+
+	payload.SHA = ""
+	pr, err := s.client.MergePullRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+	return requireFields(field{"spec.state", pr.Spec.State == metav1alpha1.PullRequestMergedState})
+}
+
+// newFilePath returns the path of a file created by the suite
+func (s *Suite) newFilePath(name string) string {
+	return fmt.Sprintf("%s/%s/%s.txt", s.options.NamePrefix, s.newBranch, name)
+}
+
+// checkPagination lists the first two pages with one item per page,
+// each page should contain at most one item and the pages should be different.
+func checkPagination(list func(option metav1alpha1.ListOptions) ([]string, error)) error {
+	first, err := list(metav1alpha1.ListOptions{ItemsPerPage: 1, Page: 1})
+	if err != nil {
+		return err
+	}
+	if len(first) > 1 {
+		return fmt.Errorf("pagination is ignored: %d items returned for one item per page", len(first))
+	}
+	if len(first) == 0 {
+		return nil
+	}
+	second, err := list(metav1alpha1.ListOptions{ItemsPerPage: 1, Page: 2})
+	if err != nil {
+		return err
+	}
+	if len(second) > 1 {
+		return fmt.Errorf("pagination is ignored: %d items returned for one item per page", len(second))
+	}
+	if len(second) == 1 && second[0] == first[0] {
+		return fmt.Errorf("pagination is ignored: page 2 returns the same item %q as page 1", first[0])
+	}
+	return nil
+}
+
+// requireNotFound checks the error of a missing resource is mapped to a NotFound status
+func requireNotFound(resource string, err error) error {
+	return requireReason("missing "+resource, err, metav1.StatusReasonNotFound)
+}
+
+// requireReason checks the error of an invalid request is mapped to a status with the reason
+func requireReason(request string, err error, reason metav1.StatusReason) error {
+	if err == nil {
+		return fmt.Errorf("no error returned for %s", request)
+	}
+	if isUnsupported(err) {
+		return err
+	}
+	if actual := errors.ReasonForError(err); actual != reason {
+		return fmt.Errorf("%s returns reason %q instead of %q: %w", request, actual, reason, err)
+	}
+	return nil
+}
+
+// isUnsupported returns true if the plugin does not implement the method
+func isUnsupported(err error) bool {
+	if errors.IsMethodNotSupported(err) {
+		return true
+	}
+	status, ok := err.(errors.APIStatus)
+	return ok && status.Status().Code == http.StatusNotImplemented
+}
+
+type field struct {
+	name string
+	ok   bool
+}
+
+// requireFields returns an error listing the fields not populated as expected
+func requireFields(fields ...field) error {
+	var invalid []string
+	for _, item := range fields {
+		if !item.ok {
+			invalid = append(invalid, item.name)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("fields are not populated as expected: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+func keys[T any](items []T, key func(T) string) []string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, key(item))
+	}
+	return list
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
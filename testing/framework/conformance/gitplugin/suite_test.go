@@ -0,0 +1,198 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/go-resty/resty/v2"
+	metav1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	"github.com/katanomi/pkg/plugin/client"
+	"github.com/katanomi/pkg/plugin/client/base"
+	v2 "github.com/katanomi/pkg/plugin/client/v2"
+	"github.com/katanomi/pkg/plugin/localgit"
+	"github.com/katanomi/pkg/plugin/route"
+	"github.com/katanomi/pkg/testing/framework/conformance"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var testRepo = metav1alpha1.GitRepo{Project: "group", Repository: "demo"}
+
+// newTestStore returns a local git store with a seeded repository
+func newTestStore(ctx context.Context) *localgit.GitStore {
+	gitStore := localgit.NewGitStore("local-git", GinkgoT().TempDir())
+	Expect(gitStore.Setup(ctx, nil)).To(Succeed())
+	_, err := gitStore.CreateGitRepository(ctx, metav1alpha1.CreateGitRepositoryPayload{GitRepo: testRepo, AutoInit: true})
+	Expect(err).To(BeNil())
+	_, err = gitStore.CreateGitRepoFile(ctx, metav1alpha1.CreateRepoFilePayload{
+		GitRepo:              testRepo,
+		FilePath:             "docs/guide.md",
+		CreateRepoFileParams: metav1alpha1.CreateRepoFileParams{Branch: "main", Message: "add guide", Content: []byte(base64.StdEncoding.EncodeToString([]byte("# guide\n")))},
+	})
+	Expect(err).To(BeNil())
+	return gitStore
+}
+
+// newTestClient serves the plugin and returns a v2 client of it
+func newTestClient(plugin client.Interface) *v2.PluginClient {
+	ws, err := route.NewService(plugin, base.MetaFilter)
+	Expect(err).To(BeNil())
+	container := restful.NewContainer()
+	container.Router(restful.RouterJSR311{})
+	container.Add(ws)
+	server := httptest.NewServer(container)
+	DeferCleanup(server.Close)
+
+	address, err := apis.ParseURL(server.URL + "/plugins/v1alpha1/local-git/")
+	Expect(err).To(BeNil())
+	return v2.NewPluginClient(&duckv1.Addressable{URL: address}, base.Meta{}, corev1.Secret{}, base.ClientOpts(resty.New()))
+}
+
+// unsupportedGitStore a git store which does not support creating branches and commit statuses
+type unsupportedGitStore struct {
+	*localgit.GitStore
+}
+
+func (p unsupportedGitStore) CreateGitBranch(_ context.Context, payload metav1alpha1.CreateBranchPayload) (metav1alpha1.GitBranch, error) {
+	return metav1alpha1.GitBranch{}, errors.NewMethodNotSupported(metav1alpha1.GroupVersion.WithResource("gitbranches").GroupResource(), "create")
+}
+
+func (p unsupportedGitStore) CreateGitCommitStatus(_ context.Context, payload metav1alpha1.CreateCommitStatusPayload) (metav1alpha1.GitCommitStatus, error) {
+	return metav1alpha1.GitCommitStatus{}, errors.NewMethodNotSupported(metav1alpha1.GroupVersion.WithResource("gitcommitstatuses").GroupResource(), "create")
+}
+
+// invalidGitStore a git store which does not map invalid branch names to bad requests
+type invalidGitStore struct {
+	*localgit.GitStore
+}
+
+func (p invalidGitStore) CreateGitBranch(ctx context.Context, payload metav1alpha1.CreateBranchPayload) (metav1alpha1.GitBranch, error) {
+	if payload.Branch == invalidBranchName {
+		return metav1alpha1.GitBranch{}, errors.NewInternalError(fmt.Errorf("invalid branch name"))
+	}
+	return p.GitStore.CreateGitBranch(ctx, payload)
+}
+
+var _ = Describe("Test.Suite.Run", func() {
+	var (
+		ctx          context.Context
+		gitStore     *localgit.GitStore
+		pluginClient *v2.PluginClient
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gitStore = newTestStore(ctx)
+		pluginClient = newTestClient(gitStore)
+	})
+
+	It("reports all the capabilities of the local git plugin as supported", func() {
+		suite := NewSuite(pluginClient, Options{Repo: testRepo})
+		report := suite.Run(ctx)
+		Expect(report.Failed()).To(BeEmpty())
+		// the created branch is cleaned up
+		_, err := gitStore.GetGitBranch(ctx, testRepo, suite.newBranch)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(report.Results).To(HaveLen(len(report.Supported())))
+		for _, item := range testPointCapabilities {
+			Expect(report.IsSupported(item.capabilities...)).To(BeTrue(), item.name)
+		}
+
+		buf := &bytes.Buffer{}
+		Expect(report.WriteJSON(buf)).To(Succeed())
+		decoded := &ConformanceReport{}
+		Expect(json.Unmarshal(buf.Bytes(), decoded)).To(Succeed())
+		Expect(decoded).To(Equal(report))
+	})
+
+	It("requests reviewers and merges the pull request when enabled", func() {
+		reviewer := metav1alpha1.GitUserBaseInfo{Name: "reviewer", Email: "reviewer@example.com"}
+		report := NewSuite(pluginClient, Options{
+			Repo: testRepo, Reviewers: []metav1alpha1.GitUserBaseInfo{reviewer},
+			Merge: true, MergeStrategy: metav1alpha1.MergeStrategySquash,
+		}).Run(ctx)
+		Expect(report.Failed()).To(BeEmpty())
+		Expect(report.IsSupported(CapabilityRequestPullRequestReviewers, CapabilityMergePullRequest)).To(BeTrue())
+
+		list, err := gitStore.ListGitPullRequest(ctx, metav1alpha1.GitPullRequestListOption{GitRepo: testRepo}, metav1alpha1.ListOptions{})
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Spec.State).To(Equal(metav1alpha1.PullRequestMergedState))
+		Expect(list.Items[0].Spec.Reviewers).To(ContainElement(reviewer))
+	})
+
+	It("reports unsupported and skipped capabilities", func() {
+		report := NewSuite(pluginClient, Options{Repo: testRepo, Branch: "missing"}).Run(ctx)
+		result, _ := report.Result(CapabilityGetGitBranch)
+		Expect(result.Status).To(Equal(StatusFailed))
+		result, _ = report.Result(CapabilityCreateGitBranch)
+		Expect(result.Status).To(Equal(StatusSkipped))
+		Expect(result.Message).To(Equal("requires GetGitBranch"))
+		result, _ = report.Result(CapabilityGetGitPullRequest)
+		Expect(result.Status).To(Equal(StatusSkipped))
+		Expect(report.IsSupported(CapabilityGetGitRepository, CapabilityListGitBranch)).To(BeTrue())
+	})
+
+	It("reports invalid requests not rejected as bad requests as failed", func() {
+		report := NewSuite(newTestClient(invalidGitStore{gitStore}), Options{Repo: testRepo}).Run(ctx)
+		result, _ := report.Result(CapabilityCreateGitBranch)
+		Expect(result.Status).To(Equal(StatusFailed))
+		Expect(result.Message).To(ContainSubstring(`creation of an invalid branch returns reason "InternalError" instead of "BadRequest"`))
+	})
+
+	It("reports methods not implemented by the plugin as unsupported", func() {
+		report := NewSuite(newTestClient(unsupportedGitStore{gitStore}), Options{Repo: testRepo}).Run(ctx)
+		Expect(report.Failed()).To(BeEmpty())
+		result, _ := report.Result(CapabilityCreateGitBranch)
+		Expect(result.Status).To(Equal(StatusUnsupported))
+		result, _ = report.Result(CapabilityCreateGitCommitStatus)
+		Expect(result.Status).To(Equal(StatusUnsupported))
+		result, _ = report.Result(CapabilityCreatePullRequest)
+		Expect(result.Status).To(Equal(StatusSkipped))
+		Expect(report.IsSupported(CapabilityGetGitBranch, CapabilityListGitCommitStatus)).To(BeTrue())
+	})
+})
+
+var _ = func() bool {
+	SetSuiteProvider(func(ctx context.Context) (*Suite, error) {
+		return NewSuite(newTestClient(newTestStore(ctx)), Options{Repo: testRepo}), nil
+	})
+	m := conformance.NewModuleCase("LocalGit")
+	m.AddFeatureCase(
+		conformance.NewFeatureCase("GitPlugin", CaseSet.New().Focus(
+			TestPointRepository, TestPointBranch, TestPointCommit, TestPointFile, TestPointTag,
+			TestPointCommitStatus, TestPointCommitComment, TestPointPullRequest,
+			TestPointPullRequestComment.AddAssertion(func(results []Result) {
+				Expect(results).To(HaveLen(3))
+			}),
+			TestPointPullRequestDiff, TestPointPullRequestReview, TestPointPullRequestMerge,
+		)),
+	)
+	m.RegisterTestCase()
+	return false
+}()
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Katanomi Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitplugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katanomi/pkg/testing/framework/conformance"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// TestCase the conformance test case of git plugins
+var TestCase = conformance.NewTestCase("GitPlugin")
+
+var (
+	TestPointRepository         = TestCase.NewTestPoint("Repository")
+	TestPointBranch             = TestCase.NewTestPoint("Branch")
+	TestPointCommit             = TestCase.NewTestPoint("Commit")
+	TestPointFile               = TestCase.NewTestPoint("File")
+	TestPointTag                = TestCase.NewTestPoint("Tag")
+	TestPointCommitStatus       = TestCase.NewTestPoint("CommitStatus")
+	TestPointCommitComment      = TestCase.NewTestPoint("CommitComment")
+	TestPointPullRequest        = TestCase.NewTestPoint("PullRequest")
+	TestPointPullRequestComment = TestCase.NewTestPoint("PullRequestComment")
+	TestPointPullRequestDiff    = TestCase.NewTestPoint("PullRequestDiff")
+	TestPointPullRequestReview  = TestCase.NewTestPoint("PullRequestReview")
+	TestPointPullRequestMerge   = TestCase.NewTestPoint("PullRequestMerge")
+)
+
+// testPoint is implemented by the test points of the conformance framework
+type testPoint interface {
+	Labels(ctx context.Context) ginkgo.Labels
+	CheckExternalAssertion(args ...interface{})
+}
+
+// testPointCapabilities the capabilities verified by each test point
+var testPointCapabilities = []struct {
+	name         string
+	testPoint    testPoint
+	capabilities []Capability
+}{
+	{"Repository", TestPointRepository, []Capability{CapabilityGetGitRepository, CapabilityListGitRepository}},
+	{"Branch", TestPointBranch, []Capability{CapabilityListGitBranch, CapabilityGetGitBranch, CapabilityCreateGitBranch}},
+	{"Commit", TestPointCommit, []Capability{CapabilityGetGitCommit, CapabilityListGitCommit, CapabilityCreateGitCommit}},
+	{"File", TestPointFile, []Capability{CapabilityGetGitRepoFile, CapabilityCreateGitRepoFile, CapabilityGetGitRepositoryFileTree}},
+	{"Tag", TestPointTag, []Capability{CapabilityListGitRepositoryTag, CapabilityGetGitRepositoryTag}},
+	{"CommitStatus", TestPointCommitStatus, []Capability{CapabilityCreateGitCommitStatus, CapabilityListGitCommitStatus}},
+	{"CommitComment", TestPointCommitComment, []Capability{CapabilityCreateGitCommitComment, CapabilityListGitCommitComment}},
+	{"PullRequest", TestPointPullRequest, []Capability{CapabilityCreatePullRequest, CapabilityGetGitPullRequest, CapabilityListGitPullRequest}},
+	{"PullRequestComment", TestPointPullRequestComment, []Capability{CapabilityCreatePullRequestComment, CapabilityUpdatePullRequestComment, CapabilityListPullRequestComment}},
+	{"PullRequestDiff", TestPointPullRequestDiff, []Capability{CapabilityListPullRequestFiles, CapabilityGetPullRequestDiff}},
+	{"PullRequestReview", TestPointPullRequestReview, []Capability{CapabilityCreatePullRequestReview, CapabilityListPullRequestReviews, CapabilityRequestPullRequestReviewers}},
+	{"PullRequestMerge", TestPointPullRequestMerge, []Capability{CapabilityGetPullRequestMergeability, CapabilityMergePullRequest}},
+}
+
+// SuiteProvider returns the suite used by CaseSet
+type SuiteProvider func(ctx context.Context) (*Suite, error)
+
+var suiteProvider SuiteProvider
+
+// SetSuiteProvider sets the provider of the suite used by CaseSet,
+// it should be called before the specs run.
+func SetSuiteProvider(provider SuiteProvider) {
+	suiteProvider = provider
+}
+
+// CaseSet registers the specs of the test points,
+// the suite runs once and the report is attached to the ginkgo report as "GitPluginConformance".
+// Custom assertions of the test points receive the []Result of their capabilities.
+var CaseSet = TestCase.Build(func(ctx context.Context) {
+	ginkgo.Describe("git plugin conformance", ginkgo.Ordered, func() {
+		var report *ConformanceReport
+
+		ginkgo.BeforeAll(func() {
+			gomega.Expect(suiteProvider).NotTo(gomega.BeNil(), "suite provider is not set, call SetSuiteProvider first")
+			suite, err := suiteProvider(ctx)
+			gomega.Expect(err).To(gomega.BeNil())
+			report = suite.Run(ctx)
+			ginkgo.AddReportEntry("GitPluginConformance", report)
+		})
+
+		for _, item := range testPointCapabilities {
+			item := item
+			ginkgo.Context(item.name, item.testPoint.Labels(ctx), func() {
+				ginkgo.It("should conform to the plugin specification", func() {
+					var results []Result
+					unsupported := 0
+					for _, capability := range item.capabilities {
+						result, ok := report.Result(capability)
+						gomega.Expect(ok).To(gomega.BeTrue(), fmt.Sprintf("capability %s is not checked", capability))
+						gomega.Expect(result.Status).NotTo(gomega.Equal(StatusFailed), fmt.Sprintf("%s: %s", capability, result.Message))
+						if result.Status != StatusSupported {
+							unsupported++
+						}
+						results = append(results, result)
+					}
+					item.testPoint.CheckExternalAssertion(results)
+					if unsupported == len(results) {
+						ginkgo.Skip(fmt.Sprintf("%s is not supported by the plugin", item.name))
+					}
+				})
+			})
+		}
+	})
+})
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitpullrequestreview.go
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	client "github.com/katanomi/pkg/plugin/client"
+	v1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// MockClientGitPullRequestReview is a mock of ClientGitPullRequestReview interface.
+type MockClientGitPullRequestReview struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientGitPullRequestReviewMockRecorder
+}
+
+// MockClientGitPullRequestReviewMockRecorder is the mock recorder for MockClientGitPullRequestReview.
+type MockClientGitPullRequestReviewMockRecorder struct {
+	mock *MockClientGitPullRequestReview
+}
+
+// NewMockClientGitPullRequestReview creates a new mock instance.
+func NewMockClientGitPullRequestReview(ctrl *gomock.Controller) *MockClientGitPullRequestReview {
+	mock := &MockClientGitPullRequestReview{ctrl: ctrl}
+	mock.recorder = &MockClientGitPullRequestReviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientGitPullRequestReview) EXPECT() *MockClientGitPullRequestReviewMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockClientGitPullRequestReview) CreateReview(ctx context.Context, baseURL *v1.Addressable, payload v1alpha1.CreatePullRequestReviewPayload, options ...client.OptionFunc) (*v1alpha1.GitPullRequestReview, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, payload}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateReview", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockClientGitPullRequestReviewMockRecorder) CreateReview(ctx, baseURL, payload interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, payload}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).CreateReview), varargs...)
+}
+
+// GetDiff mocks base method.
+func (m *MockClientGitPullRequestReview) GetDiff(ctx context.Context, baseURL *v1.Addressable, option v1alpha1.GitPullRequestDiffOption, options ...client.OptionFunc) (*v1alpha1.GitPullRequestDiff, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, option}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDiff", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequestDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiff indicates an expected call of GetDiff.
+func (mr *MockClientGitPullRequestReviewMockRecorder) GetDiff(ctx, baseURL, option interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, option}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiff", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).GetDiff), varargs...)
+}
+
+// GetMergeability mocks base method.
+func (m *MockClientGitPullRequestReview) GetMergeability(ctx context.Context, baseURL *v1.Addressable, option v1alpha1.GitPullRequestOption, options ...client.OptionFunc) (*v1alpha1.GitPullRequestMergeability, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, option}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMergeability", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequestMergeability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeability indicates an expected call of GetMergeability.
+func (mr *MockClientGitPullRequestReviewMockRecorder) GetMergeability(ctx, baseURL, option interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, option}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeability", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).GetMergeability), varargs...)
+}
+
+// ListFiles mocks base method.
+func (m *MockClientGitPullRequestReview) ListFiles(ctx context.Context, baseURL *v1.Addressable, option v1alpha1.GitPullRequestOption, options ...client.OptionFunc) (*v1alpha1.GitPullRequestFileList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, option}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFiles", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequestFileList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockClientGitPullRequestReviewMockRecorder) ListFiles(ctx, baseURL, option interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, option}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).ListFiles), varargs...)
+}
+
+// ListReviews mocks base method.
+func (m *MockClientGitPullRequestReview) ListReviews(ctx context.Context, baseURL *v1.Addressable, option v1alpha1.GitPullRequestOption, options ...client.OptionFunc) (*v1alpha1.GitPullRequestReviewList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, option}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListReviews", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequestReviewList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockClientGitPullRequestReviewMockRecorder) ListReviews(ctx, baseURL, option interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, option}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).ListReviews), varargs...)
+}
+
+// Merge mocks base method.
+func (m *MockClientGitPullRequestReview) Merge(ctx context.Context, baseURL *v1.Addressable, payload v1alpha1.MergePullRequestPayload, options ...client.OptionFunc) (*v1alpha1.GitPullRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, payload}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Merge", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockClientGitPullRequestReviewMockRecorder) Merge(ctx, baseURL, payload interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, payload}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).Merge), varargs...)
+}
+
+// RequestReviewers mocks base method.
+func (m *MockClientGitPullRequestReview) RequestReviewers(ctx context.Context, baseURL *v1.Addressable, payload v1alpha1.RequestPullRequestReviewersPayload, options ...client.OptionFunc) (*v1alpha1.GitPullRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, baseURL, payload}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestReviewers", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GitPullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestReviewers indicates an expected call of RequestReviewers.
+func (mr *MockClientGitPullRequestReviewMockRecorder) RequestReviewers(ctx, baseURL, payload interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, baseURL, payload}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReviewers", reflect.TypeOf((*MockClientGitPullRequestReview)(nil).RequestReviewers), varargs...)
+}
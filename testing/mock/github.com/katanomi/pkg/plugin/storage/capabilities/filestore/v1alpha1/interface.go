@@ -229,3 +229,100 @@ func (mr *MockFileMetaInterfaceMockRecorder) ListFileMetas(ctx, opt interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileMetas", reflect.TypeOf((*MockFileMetaInterface)(nil).ListFileMetas), ctx, opt)
 }
+
+// MockFileUploadCapable is a mock of FileUploadCapable interface.
+type MockFileUploadCapable struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileUploadCapableMockRecorder
+}
+
+// MockFileUploadCapableMockRecorder is the mock recorder for MockFileUploadCapable.
+type MockFileUploadCapableMockRecorder struct {
+	mock *MockFileUploadCapable
+}
+
+// NewMockFileUploadCapable creates a new mock instance.
+func NewMockFileUploadCapable(ctrl *gomock.Controller) *MockFileUploadCapable {
+	mock := &MockFileUploadCapable{ctrl: ctrl}
+	mock.recorder = &MockFileUploadCapableMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileUploadCapable) EXPECT() *MockFileUploadCapableMockRecorder {
+	return m.recorder
+}
+
+// AbortFileUpload mocks base method.
+func (m *MockFileUploadCapable) AbortFileUpload(ctx context.Context, uploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortFileUpload", ctx, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortFileUpload indicates an expected call of AbortFileUpload.
+func (mr *MockFileUploadCapableMockRecorder) AbortFileUpload(ctx, uploadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortFileUpload", reflect.TypeOf((*MockFileUploadCapable)(nil).AbortFileUpload), ctx, uploadID)
+}
+
+// CompleteFileUpload mocks base method.
+func (m *MockFileUploadCapable) CompleteFileUpload(ctx context.Context, uploadID string, opts v1alpha1.FileUploadCompleteOptions) (*v1alpha1.FileMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteFileUpload", ctx, uploadID, opts)
+	ret0, _ := ret[0].(*v1alpha1.FileMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteFileUpload indicates an expected call of CompleteFileUpload.
+func (mr *MockFileUploadCapableMockRecorder) CompleteFileUpload(ctx, uploadID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteFileUpload", reflect.TypeOf((*MockFileUploadCapable)(nil).CompleteFileUpload), ctx, uploadID, opts)
+}
+
+// CreateFileUpload mocks base method.
+func (m *MockFileUploadCapable) CreateFileUpload(ctx context.Context, meta v1alpha1.FileMeta) (*v1alpha1.FileUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFileUpload", ctx, meta)
+	ret0, _ := ret[0].(*v1alpha1.FileUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFileUpload indicates an expected call of CreateFileUpload.
+func (mr *MockFileUploadCapableMockRecorder) CreateFileUpload(ctx, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUpload", reflect.TypeOf((*MockFileUploadCapable)(nil).CreateFileUpload), ctx, meta)
+}
+
+// GetFileUpload mocks base method.
+func (m *MockFileUploadCapable) GetFileUpload(ctx context.Context, uploadID string) (*v1alpha1.FileUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileUpload", ctx, uploadID)
+	ret0, _ := ret[0].(*v1alpha1.FileUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileUpload indicates an expected call of GetFileUpload.
+func (mr *MockFileUploadCapableMockRecorder) GetFileUpload(ctx, uploadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUpload", reflect.TypeOf((*MockFileUploadCapable)(nil).GetFileUpload), ctx, uploadID)
+}
+
+// PutFileUploadChunk mocks base method.
+func (m *MockFileUploadCapable) PutFileUploadChunk(ctx context.Context, uploadID string, chunk *v1alpha10.FileChunk) (*v1alpha1.FileUploadChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFileUploadChunk", ctx, uploadID, chunk)
+	ret0, _ := ret[0].(*v1alpha1.FileUploadChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFileUploadChunk indicates an expected call of PutFileUploadChunk.
+func (mr *MockFileUploadCapableMockRecorder) PutFileUploadChunk(ctx, uploadID, chunk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFileUploadChunk", reflect.TypeOf((*MockFileUploadCapable)(nil).PutFileUploadChunk), ctx, uploadID, chunk)
+}
@@ -146,6 +146,20 @@ func (mr *MockRecordInterfaceMockRecorder) ListRelatedRecords(ctx, query, opts i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRelatedRecords", reflect.TypeOf((*MockRecordInterface)(nil).ListRelatedRecords), ctx, query, opts)
 }
 
+// StreamRecords mocks base method.
+func (m *MockRecordInterface) StreamRecords(ctx context.Context, query v1alpha1.Query, opts *v1alpha1.ListOptions, fn v1alpha10.RecordFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamRecords", ctx, query, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamRecords indicates an expected call of StreamRecords.
+func (mr *MockRecordInterfaceMockRecorder) StreamRecords(ctx, query, opts, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamRecords", reflect.TypeOf((*MockRecordInterface)(nil).StreamRecords), ctx, query, opts, fn)
+}
+
 // Upsert mocks base method.
 func (m *MockRecordInterface) Upsert(ctx context.Context, record *v1alpha1.Record) error {
 	m.ctrl.T.Helper()
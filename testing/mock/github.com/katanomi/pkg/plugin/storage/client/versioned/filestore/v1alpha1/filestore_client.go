@@ -63,6 +63,20 @@ func (mr *MockFileStoreV1alpha1InterfaceMockRecorder) FileObject(pluginName inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileObject", reflect.TypeOf((*MockFileStoreV1alpha1Interface)(nil).FileObject), pluginName)
 }
 
+// FileUpload mocks base method.
+func (m *MockFileStoreV1alpha1Interface) FileUpload(pluginName string) v1alpha1.FileUploadInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileUpload", pluginName)
+	ret0, _ := ret[0].(v1alpha1.FileUploadInterface)
+	return ret0
+}
+
+// FileUpload indicates an expected call of FileUpload.
+func (mr *MockFileStoreV1alpha1InterfaceMockRecorder) FileUpload(pluginName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileUpload", reflect.TypeOf((*MockFileStoreV1alpha1Interface)(nil).FileUpload), pluginName)
+}
+
 // RESTClient mocks base method.
 func (m *MockFileStoreV1alpha1Interface) RESTClient() client.Interface {
 	m.ctrl.T.Helper()
@@ -150,3 +164,40 @@ func (mr *MockFileMetaGetterMockRecorder) FileMeta(pluginName interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileMeta", reflect.TypeOf((*MockFileMetaGetter)(nil).FileMeta), pluginName)
 }
+
+// MockFileUploadGetter is a mock of FileUploadGetter interface.
+type MockFileUploadGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileUploadGetterMockRecorder
+}
+
+// MockFileUploadGetterMockRecorder is the mock recorder for MockFileUploadGetter.
+type MockFileUploadGetterMockRecorder struct {
+	mock *MockFileUploadGetter
+}
+
+// NewMockFileUploadGetter creates a new mock instance.
+func NewMockFileUploadGetter(ctrl *gomock.Controller) *MockFileUploadGetter {
+	mock := &MockFileUploadGetter{ctrl: ctrl}
+	mock.recorder = &MockFileUploadGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileUploadGetter) EXPECT() *MockFileUploadGetterMockRecorder {
+	return m.recorder
+}
+
+// FileUpload mocks base method.
+func (m *MockFileUploadGetter) FileUpload(pluginName string) v1alpha1.FileUploadInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileUpload", pluginName)
+	ret0, _ := ret[0].(v1alpha1.FileUploadInterface)
+	return ret0
+}
+
+// FileUpload indicates an expected call of FileUpload.
+func (mr *MockFileUploadGetterMockRecorder) FileUpload(pluginName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileUpload", reflect.TypeOf((*MockFileUploadGetter)(nil).FileUpload), pluginName)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fileupload.go
+
+// Package v1alpha1 is a generated GoMock package.
+package v1alpha1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+)
+
+// MockFileUploadInterface is a mock of FileUploadInterface interface.
+type MockFileUploadInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileUploadInterfaceMockRecorder
+}
+
+// MockFileUploadInterfaceMockRecorder is the mock recorder for MockFileUploadInterface.
+type MockFileUploadInterfaceMockRecorder struct {
+	mock *MockFileUploadInterface
+}
+
+// NewMockFileUploadInterface creates a new mock instance.
+func NewMockFileUploadInterface(ctrl *gomock.Controller) *MockFileUploadInterface {
+	mock := &MockFileUploadInterface{ctrl: ctrl}
+	mock.recorder = &MockFileUploadInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileUploadInterface) EXPECT() *MockFileUploadInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockFileUploadInterface) Abort(ctx context.Context, uploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort", ctx, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockFileUploadInterfaceMockRecorder) Abort(ctx, uploadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockFileUploadInterface)(nil).Abort), ctx, uploadID)
+}
+
+// Complete mocks base method.
+func (m *MockFileUploadInterface) Complete(ctx context.Context, uploadID string, opts v1alpha1.FileUploadCompleteOptions) (*v1alpha1.FileMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, uploadID, opts)
+	ret0, _ := ret[0].(*v1alpha1.FileMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockFileUploadInterfaceMockRecorder) Complete(ctx, uploadID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockFileUploadInterface)(nil).Complete), ctx, uploadID, opts)
+}
+
+// Create mocks base method.
+func (m *MockFileUploadInterface) Create(ctx context.Context, meta v1alpha1.FileMeta) (*v1alpha1.FileUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, meta)
+	ret0, _ := ret[0].(*v1alpha1.FileUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFileUploadInterfaceMockRecorder) Create(ctx, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFileUploadInterface)(nil).Create), ctx, meta)
+}
+
+// Get mocks base method.
+func (m *MockFileUploadInterface) Get(ctx context.Context, uploadID string) (*v1alpha1.FileUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uploadID)
+	ret0, _ := ret[0].(*v1alpha1.FileUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFileUploadInterfaceMockRecorder) Get(ctx, uploadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFileUploadInterface)(nil).Get), ctx, uploadID)
+}
+
+// PutChunk mocks base method.
+func (m *MockFileUploadInterface) PutChunk(ctx context.Context, uploadID string, index int, data []byte) (*v1alpha1.FileUploadChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutChunk", ctx, uploadID, index, data)
+	ret0, _ := ret[0].(*v1alpha1.FileUploadChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutChunk indicates an expected call of PutChunk.
+func (mr *MockFileUploadInterfaceMockRecorder) PutChunk(ctx, uploadID, index, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutChunk", reflect.TypeOf((*MockFileUploadInterface)(nil).PutChunk), ctx, uploadID, index, data)
+}
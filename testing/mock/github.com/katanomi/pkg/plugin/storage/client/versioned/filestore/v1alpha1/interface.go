@@ -66,6 +66,21 @@ func (mr *MockFileObjectInterfaceMockRecorder) GET(ctx, fileObjectName interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GET", reflect.TypeOf((*MockFileObjectInterface)(nil).GET), ctx, fileObjectName)
 }
 
+// GETRange mocks base method.
+func (m *MockFileObjectInterface) GETRange(ctx context.Context, fileObjectName string, offset, length int64) (*v1alpha10.FileObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GETRange", ctx, fileObjectName, offset, length)
+	ret0, _ := ret[0].(*v1alpha10.FileObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GETRange indicates an expected call of GETRange.
+func (mr *MockFileObjectInterfaceMockRecorder) GETRange(ctx, fileObjectName, offset, length interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GETRange", reflect.TypeOf((*MockFileObjectInterface)(nil).GETRange), ctx, fileObjectName, offset, length)
+}
+
 // PUT mocks base method.
 func (m *MockFileObjectInterface) PUT(ctx context.Context, fileObj v1alpha10.FileObject, options ...client.OptionFunc) (*v1alpha1.FileMeta, error) {
 	m.ctrl.T.Helper()
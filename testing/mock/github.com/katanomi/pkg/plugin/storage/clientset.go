@@ -5,12 +5,15 @@
 package storage
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	v1alpha1 "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
-	v1alpha10 "github.com/katanomi/pkg/plugin/storage/client/versioned/core/v1alpha1"
-	v1alpha11 "github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
+	v1alpha1 "github.com/katanomi/pkg/apis/storage/v1alpha1"
+	v1alpha10 "github.com/katanomi/pkg/plugin/storage/client/versioned/archive/v1alpha1"
+	v1alpha11 "github.com/katanomi/pkg/plugin/storage/client/versioned/core/v1alpha1"
+	v1alpha12 "github.com/katanomi/pkg/plugin/storage/client/versioned/filestore/v1alpha1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // MockInterface is a mock of Interface interface.
@@ -37,10 +40,10 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 }
 
 // ArchiveV1alpha1 mocks base method.
-func (m *MockInterface) ArchiveV1alpha1() v1alpha1.ArchiveInterface {
+func (m *MockInterface) ArchiveV1alpha1() v1alpha10.ArchiveInterface {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ArchiveV1alpha1")
-	ret0, _ := ret[0].(v1alpha1.ArchiveInterface)
+	ret0, _ := ret[0].(v1alpha10.ArchiveInterface)
 	return ret0
 }
 
@@ -51,10 +54,10 @@ func (mr *MockInterfaceMockRecorder) ArchiveV1alpha1() *gomock.Call {
 }
 
 // CoreV1alpha1 mocks base method.
-func (m *MockInterface) CoreV1alpha1() v1alpha10.CoreV1alpha1Interface {
+func (m *MockInterface) CoreV1alpha1() v1alpha11.CoreV1alpha1Interface {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CoreV1alpha1")
-	ret0, _ := ret[0].(v1alpha10.CoreV1alpha1Interface)
+	ret0, _ := ret[0].(v1alpha11.CoreV1alpha1Interface)
 	return ret0
 }
 
@@ -64,11 +67,26 @@ func (mr *MockInterfaceMockRecorder) CoreV1alpha1() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoreV1alpha1", reflect.TypeOf((*MockInterface)(nil).CoreV1alpha1))
 }
 
+// Discover mocks base method.
+func (m *MockInterface) Discover(ctx context.Context) (*v1alpha1.StorageDiscovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discover", ctx)
+	ret0, _ := ret[0].(*v1alpha1.StorageDiscovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discover indicates an expected call of Discover.
+func (mr *MockInterfaceMockRecorder) Discover(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discover", reflect.TypeOf((*MockInterface)(nil).Discover), ctx)
+}
+
 // FileStoreV1alpha1 mocks base method.
-func (m *MockInterface) FileStoreV1alpha1() v1alpha11.FileStoreV1alpha1Interface {
+func (m *MockInterface) FileStoreV1alpha1() v1alpha12.FileStoreV1alpha1Interface {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FileStoreV1alpha1")
-	ret0, _ := ret[0].(v1alpha11.FileStoreV1alpha1Interface)
+	ret0, _ := ret[0].(v1alpha12.FileStoreV1alpha1Interface)
 	return ret0
 }
 
@@ -77,3 +95,22 @@ func (mr *MockInterfaceMockRecorder) FileStoreV1alpha1() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileStoreV1alpha1", reflect.TypeOf((*MockInterface)(nil).FileStoreV1alpha1))
 }
+
+// Supports mocks base method.
+func (m *MockInterface) Supports(ctx context.Context, gv schema.GroupVersion, features ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, gv}
+	for _, a := range features {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Supports", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Supports indicates an expected call of Supports.
+func (mr *MockInterfaceMockRecorder) Supports(ctx, gv interface{}, features ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, gv}, features...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Supports", reflect.TypeOf((*MockInterface)(nil).Supports), varargs...)
+}
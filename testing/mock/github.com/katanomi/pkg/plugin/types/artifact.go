@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: ArtifactLister,ArtifactGetter,ArtifactDeleter,ProjectArtifactLister,ProjectArtifactGetter,ProjectArtifactDeleter,ProjectArtifactUploader,ProjectArtifactFileGetter,ArtifactTagDeleter,ArtifactTriggerRegister)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: ArtifactLister,ArtifactGetter,ArtifactDeleter,ProjectArtifactLister,ProjectArtifactGetter,ProjectArtifactDeleter,ProjectArtifactUploader,ProjectArtifactFileGetter,ArtifactTagDeleter,ArtifactTriggerRegister,ArtifactPromoter)
 
 // Package types is a generated GoMock package.
 package types
@@ -654,3 +654,69 @@ func (mr *MockArtifactTriggerRegisterMockRecorder) PushEventType() *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushEventType", reflect.TypeOf((*MockArtifactTriggerRegister)(nil).PushEventType))
 }
+
+// MockArtifactPromoter is a mock of ArtifactPromoter interface.
+type MockArtifactPromoter struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtifactPromoterMockRecorder
+}
+
+// MockArtifactPromoterMockRecorder is the mock recorder for MockArtifactPromoter.
+type MockArtifactPromoterMockRecorder struct {
+	mock *MockArtifactPromoter
+}
+
+// NewMockArtifactPromoter creates a new mock instance.
+func NewMockArtifactPromoter(ctrl *gomock.Controller) *MockArtifactPromoter {
+	mock := &MockArtifactPromoter{ctrl: ctrl}
+	mock.recorder = &MockArtifactPromoterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtifactPromoter) EXPECT() *MockArtifactPromoterMockRecorder {
+	return m.recorder
+}
+
+// Path mocks base method.
+func (m *MockArtifactPromoter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockArtifactPromoterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockArtifactPromoter)(nil).Path))
+}
+
+// PromoteArtifact mocks base method.
+func (m *MockArtifactPromoter) PromoteArtifact(arg0 context.Context, arg1 v1alpha1.ArtifactOptions, arg2 v1alpha1.PromoteArtifactPayload) (*v1alpha1.Artifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteArtifact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Artifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteArtifact indicates an expected call of PromoteArtifact.
+func (mr *MockArtifactPromoterMockRecorder) PromoteArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteArtifact", reflect.TypeOf((*MockArtifactPromoter)(nil).PromoteArtifact), arg0, arg1, arg2)
+}
+
+// Setup mocks base method.
+func (m *MockArtifactPromoter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockArtifactPromoterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockArtifactPromoter)(nil).Setup), arg0, arg1)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitBranchLister,GitBranchGetter,GitBranchCreator)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitBranchLister,GitBranchGetter,GitBranchCreator,GitBranchDeleter,GitBranchProtectionGetter,GitBranchProtectionUpdater,GitBranchProtectionDeleter)
 
 // Package types is a generated GoMock package.
 package types
@@ -210,3 +210,265 @@ func (mr *MockGitBranchCreatorMockRecorder) Setup(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitBranchCreator)(nil).Setup), arg0, arg1)
 }
+
+// MockGitBranchDeleter is a mock of GitBranchDeleter interface.
+type MockGitBranchDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitBranchDeleterMockRecorder
+}
+
+// MockGitBranchDeleterMockRecorder is the mock recorder for MockGitBranchDeleter.
+type MockGitBranchDeleterMockRecorder struct {
+	mock *MockGitBranchDeleter
+}
+
+// NewMockGitBranchDeleter creates a new mock instance.
+func NewMockGitBranchDeleter(ctrl *gomock.Controller) *MockGitBranchDeleter {
+	mock := &MockGitBranchDeleter{ctrl: ctrl}
+	mock.recorder = &MockGitBranchDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitBranchDeleter) EXPECT() *MockGitBranchDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteGitBranch mocks base method.
+func (m *MockGitBranchDeleter) DeleteGitBranch(arg0 context.Context, arg1 v1alpha1.GitRepo, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitBranch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitBranch indicates an expected call of DeleteGitBranch.
+func (mr *MockGitBranchDeleterMockRecorder) DeleteGitBranch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitBranch", reflect.TypeOf((*MockGitBranchDeleter)(nil).DeleteGitBranch), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockGitBranchDeleter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitBranchDeleterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitBranchDeleter)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitBranchDeleter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitBranchDeleterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitBranchDeleter)(nil).Setup), arg0, arg1)
+}
+
+// MockGitBranchProtectionGetter is a mock of GitBranchProtectionGetter interface.
+type MockGitBranchProtectionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitBranchProtectionGetterMockRecorder
+}
+
+// MockGitBranchProtectionGetterMockRecorder is the mock recorder for MockGitBranchProtectionGetter.
+type MockGitBranchProtectionGetterMockRecorder struct {
+	mock *MockGitBranchProtectionGetter
+}
+
+// NewMockGitBranchProtectionGetter creates a new mock instance.
+func NewMockGitBranchProtectionGetter(ctrl *gomock.Controller) *MockGitBranchProtectionGetter {
+	mock := &MockGitBranchProtectionGetter{ctrl: ctrl}
+	mock.recorder = &MockGitBranchProtectionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitBranchProtectionGetter) EXPECT() *MockGitBranchProtectionGetterMockRecorder {
+	return m.recorder
+}
+
+// GetGitBranchProtection mocks base method.
+func (m *MockGitBranchProtectionGetter) GetGitBranchProtection(arg0 context.Context, arg1 v1alpha1.GitRepo, arg2 string) (v1alpha1.GitBranchProtection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitBranchProtection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(v1alpha1.GitBranchProtection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitBranchProtection indicates an expected call of GetGitBranchProtection.
+func (mr *MockGitBranchProtectionGetterMockRecorder) GetGitBranchProtection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitBranchProtection", reflect.TypeOf((*MockGitBranchProtectionGetter)(nil).GetGitBranchProtection), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockGitBranchProtectionGetter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitBranchProtectionGetterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitBranchProtectionGetter)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitBranchProtectionGetter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitBranchProtectionGetterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitBranchProtectionGetter)(nil).Setup), arg0, arg1)
+}
+
+// MockGitBranchProtectionUpdater is a mock of GitBranchProtectionUpdater interface.
+type MockGitBranchProtectionUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitBranchProtectionUpdaterMockRecorder
+}
+
+// MockGitBranchProtectionUpdaterMockRecorder is the mock recorder for MockGitBranchProtectionUpdater.
+type MockGitBranchProtectionUpdaterMockRecorder struct {
+	mock *MockGitBranchProtectionUpdater
+}
+
+// NewMockGitBranchProtectionUpdater creates a new mock instance.
+func NewMockGitBranchProtectionUpdater(ctrl *gomock.Controller) *MockGitBranchProtectionUpdater {
+	mock := &MockGitBranchProtectionUpdater{ctrl: ctrl}
+	mock.recorder = &MockGitBranchProtectionUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitBranchProtectionUpdater) EXPECT() *MockGitBranchProtectionUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Path mocks base method.
+func (m *MockGitBranchProtectionUpdater) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitBranchProtectionUpdaterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitBranchProtectionUpdater)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitBranchProtectionUpdater) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitBranchProtectionUpdaterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitBranchProtectionUpdater)(nil).Setup), arg0, arg1)
+}
+
+// UpdateGitBranchProtection mocks base method.
+func (m *MockGitBranchProtectionUpdater) UpdateGitBranchProtection(arg0 context.Context, arg1 v1alpha1.UpdateBranchProtectionPayload) (v1alpha1.GitBranchProtection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitBranchProtection", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitBranchProtection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGitBranchProtection indicates an expected call of UpdateGitBranchProtection.
+func (mr *MockGitBranchProtectionUpdaterMockRecorder) UpdateGitBranchProtection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitBranchProtection", reflect.TypeOf((*MockGitBranchProtectionUpdater)(nil).UpdateGitBranchProtection), arg0, arg1)
+}
+
+// MockGitBranchProtectionDeleter is a mock of GitBranchProtectionDeleter interface.
+type MockGitBranchProtectionDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitBranchProtectionDeleterMockRecorder
+}
+
+// MockGitBranchProtectionDeleterMockRecorder is the mock recorder for MockGitBranchProtectionDeleter.
+type MockGitBranchProtectionDeleterMockRecorder struct {
+	mock *MockGitBranchProtectionDeleter
+}
+
+// NewMockGitBranchProtectionDeleter creates a new mock instance.
+func NewMockGitBranchProtectionDeleter(ctrl *gomock.Controller) *MockGitBranchProtectionDeleter {
+	mock := &MockGitBranchProtectionDeleter{ctrl: ctrl}
+	mock.recorder = &MockGitBranchProtectionDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitBranchProtectionDeleter) EXPECT() *MockGitBranchProtectionDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteGitBranchProtection mocks base method.
+func (m *MockGitBranchProtectionDeleter) DeleteGitBranchProtection(arg0 context.Context, arg1 v1alpha1.GitRepo, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitBranchProtection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitBranchProtection indicates an expected call of DeleteGitBranchProtection.
+func (mr *MockGitBranchProtectionDeleterMockRecorder) DeleteGitBranchProtection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitBranchProtection", reflect.TypeOf((*MockGitBranchProtectionDeleter)(nil).DeleteGitBranchProtection), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockGitBranchProtectionDeleter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitBranchProtectionDeleterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitBranchProtectionDeleter)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitBranchProtectionDeleter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitBranchProtectionDeleterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitBranchProtectionDeleter)(nil).Setup), arg0, arg1)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitCommitGetter,GitCommitCreator,GitCommitLister)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitCommitGetter,GitCommitCreator,GitCommitLister,GitCommitComparer)
 
 // Package types is a generated GoMock package.
 package types
@@ -211,3 +211,69 @@ func (mr *MockGitCommitListerMockRecorder) Setup(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitCommitLister)(nil).Setup), arg0, arg1)
 }
+
+// MockGitCommitComparer is a mock of GitCommitComparer interface.
+type MockGitCommitComparer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitCommitComparerMockRecorder
+}
+
+// MockGitCommitComparerMockRecorder is the mock recorder for MockGitCommitComparer.
+type MockGitCommitComparerMockRecorder struct {
+	mock *MockGitCommitComparer
+}
+
+// NewMockGitCommitComparer creates a new mock instance.
+func NewMockGitCommitComparer(ctrl *gomock.Controller) *MockGitCommitComparer {
+	mock := &MockGitCommitComparer{ctrl: ctrl}
+	mock.recorder = &MockGitCommitComparerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitCommitComparer) EXPECT() *MockGitCommitComparerMockRecorder {
+	return m.recorder
+}
+
+// CompareGitCommits mocks base method.
+func (m *MockGitCommitComparer) CompareGitCommits(arg0 context.Context, arg1 v1alpha10.GitCommitCompareOption) (v1alpha10.GitCommitComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareGitCommits", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha10.GitCommitComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareGitCommits indicates an expected call of CompareGitCommits.
+func (mr *MockGitCommitComparerMockRecorder) CompareGitCommits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareGitCommits", reflect.TypeOf((*MockGitCommitComparer)(nil).CompareGitCommits), arg0, arg1)
+}
+
+// Path mocks base method.
+func (m *MockGitCommitComparer) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitCommitComparerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitCommitComparer)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitCommitComparer) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitCommitComparerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitCommitComparer)(nil).Setup), arg0, arg1)
+}
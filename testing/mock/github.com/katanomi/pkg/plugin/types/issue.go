@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: IssueLister,IssueGetter,IssueBranchLister,IssueBranchCreator,IssueBranchDeleter,IssueAttributeGetter)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: IssueLister,IssueGetter,IssueBranchLister,IssueBranchCreator,IssueBranchDeleter,IssueAttributeGetter,IssueCreator,IssueUpdater,IssueCommentLister,IssueCommentCreator,IssueLinker)
 
 // Package types is a generated GoMock package.
 package types
@@ -407,3 +407,333 @@ func (mr *MockIssueAttributeGetterMockRecorder) Setup(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueAttributeGetter)(nil).Setup), arg0, arg1)
 }
+
+// MockIssueCreator is a mock of IssueCreator interface.
+type MockIssueCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueCreatorMockRecorder
+}
+
+// MockIssueCreatorMockRecorder is the mock recorder for MockIssueCreator.
+type MockIssueCreatorMockRecorder struct {
+	mock *MockIssueCreator
+}
+
+// NewMockIssueCreator creates a new mock instance.
+func NewMockIssueCreator(ctrl *gomock.Controller) *MockIssueCreator {
+	mock := &MockIssueCreator{ctrl: ctrl}
+	mock.recorder = &MockIssueCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueCreator) EXPECT() *MockIssueCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateIssue mocks base method.
+func (m *MockIssueCreator) CreateIssue(arg0 context.Context, arg1 v1alpha1.IssueOptions, arg2 v1alpha1.CreateIssuePayload) (*v1alpha1.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssue indicates an expected call of CreateIssue.
+func (mr *MockIssueCreatorMockRecorder) CreateIssue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssue", reflect.TypeOf((*MockIssueCreator)(nil).CreateIssue), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockIssueCreator) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockIssueCreatorMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockIssueCreator)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockIssueCreator) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockIssueCreatorMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueCreator)(nil).Setup), arg0, arg1)
+}
+
+// MockIssueUpdater is a mock of IssueUpdater interface.
+type MockIssueUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueUpdaterMockRecorder
+}
+
+// MockIssueUpdaterMockRecorder is the mock recorder for MockIssueUpdater.
+type MockIssueUpdaterMockRecorder struct {
+	mock *MockIssueUpdater
+}
+
+// NewMockIssueUpdater creates a new mock instance.
+func NewMockIssueUpdater(ctrl *gomock.Controller) *MockIssueUpdater {
+	mock := &MockIssueUpdater{ctrl: ctrl}
+	mock.recorder = &MockIssueUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueUpdater) EXPECT() *MockIssueUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Path mocks base method.
+func (m *MockIssueUpdater) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockIssueUpdaterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockIssueUpdater)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockIssueUpdater) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockIssueUpdaterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueUpdater)(nil).Setup), arg0, arg1)
+}
+
+// UpdateIssue mocks base method.
+func (m *MockIssueUpdater) UpdateIssue(arg0 context.Context, arg1 v1alpha1.IssueOptions, arg2 v1alpha1.UpdateIssuePayload) (*v1alpha1.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIssue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIssue indicates an expected call of UpdateIssue.
+func (mr *MockIssueUpdaterMockRecorder) UpdateIssue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssue", reflect.TypeOf((*MockIssueUpdater)(nil).UpdateIssue), arg0, arg1, arg2)
+}
+
+// MockIssueCommentLister is a mock of IssueCommentLister interface.
+type MockIssueCommentLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueCommentListerMockRecorder
+}
+
+// MockIssueCommentListerMockRecorder is the mock recorder for MockIssueCommentLister.
+type MockIssueCommentListerMockRecorder struct {
+	mock *MockIssueCommentLister
+}
+
+// NewMockIssueCommentLister creates a new mock instance.
+func NewMockIssueCommentLister(ctrl *gomock.Controller) *MockIssueCommentLister {
+	mock := &MockIssueCommentLister{ctrl: ctrl}
+	mock.recorder = &MockIssueCommentListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueCommentLister) EXPECT() *MockIssueCommentListerMockRecorder {
+	return m.recorder
+}
+
+// ListIssueComments mocks base method.
+func (m *MockIssueCommentLister) ListIssueComments(arg0 context.Context, arg1 v1alpha1.IssueOptions, arg2 v1alpha1.ListOptions) (*v1alpha1.IssueCommentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueComments", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.IssueCommentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssueComments indicates an expected call of ListIssueComments.
+func (mr *MockIssueCommentListerMockRecorder) ListIssueComments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueComments", reflect.TypeOf((*MockIssueCommentLister)(nil).ListIssueComments), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockIssueCommentLister) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockIssueCommentListerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockIssueCommentLister)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockIssueCommentLister) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockIssueCommentListerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueCommentLister)(nil).Setup), arg0, arg1)
+}
+
+// MockIssueCommentCreator is a mock of IssueCommentCreator interface.
+type MockIssueCommentCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueCommentCreatorMockRecorder
+}
+
+// MockIssueCommentCreatorMockRecorder is the mock recorder for MockIssueCommentCreator.
+type MockIssueCommentCreatorMockRecorder struct {
+	mock *MockIssueCommentCreator
+}
+
+// NewMockIssueCommentCreator creates a new mock instance.
+func NewMockIssueCommentCreator(ctrl *gomock.Controller) *MockIssueCommentCreator {
+	mock := &MockIssueCommentCreator{ctrl: ctrl}
+	mock.recorder = &MockIssueCommentCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueCommentCreator) EXPECT() *MockIssueCommentCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateIssueComment mocks base method.
+func (m *MockIssueCommentCreator) CreateIssueComment(arg0 context.Context, arg1 v1alpha1.IssueOptions, arg2 v1alpha1.CreateIssueCommentPayload) (*v1alpha1.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssueComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssueComment indicates an expected call of CreateIssueComment.
+func (mr *MockIssueCommentCreatorMockRecorder) CreateIssueComment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueComment", reflect.TypeOf((*MockIssueCommentCreator)(nil).CreateIssueComment), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockIssueCommentCreator) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockIssueCommentCreatorMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockIssueCommentCreator)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockIssueCommentCreator) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockIssueCommentCreatorMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueCommentCreator)(nil).Setup), arg0, arg1)
+}
+
+// MockIssueLinker is a mock of IssueLinker interface.
+type MockIssueLinker struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueLinkerMockRecorder
+}
+
+// MockIssueLinkerMockRecorder is the mock recorder for MockIssueLinker.
+type MockIssueLinkerMockRecorder struct {
+	mock *MockIssueLinker
+}
+
+// NewMockIssueLinker creates a new mock instance.
+func NewMockIssueLinker(ctrl *gomock.Controller) *MockIssueLinker {
+	mock := &MockIssueLinker{ctrl: ctrl}
+	mock.recorder = &MockIssueLinkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueLinker) EXPECT() *MockIssueLinkerMockRecorder {
+	return m.recorder
+}
+
+// LinkIssue mocks base method.
+func (m *MockIssueLinker) LinkIssue(arg0 context.Context, arg1 v1alpha1.IssueOptions, arg2 v1alpha1.IssueLinkPayload) (*v1alpha1.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIssue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkIssue indicates an expected call of LinkIssue.
+func (mr *MockIssueLinkerMockRecorder) LinkIssue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIssue", reflect.TypeOf((*MockIssueLinker)(nil).LinkIssue), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockIssueLinker) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockIssueLinkerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockIssueLinker)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockIssueLinker) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockIssueLinkerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockIssueLinker)(nil).Setup), arg0, arg1)
+}
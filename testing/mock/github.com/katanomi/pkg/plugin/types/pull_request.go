@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitPullRequestCommentCreator,GitPullRequestCommentUpdater,GitPullRequestCommentLister,GitPullRequestHandler,GitPullRequestLister,GitPullRequestGetter,GitPullRequestCreator)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: GitPullRequestCommentCreator,GitPullRequestCommentUpdater,GitPullRequestCommentLister,GitPullRequestHandler,GitPullRequestLister,GitPullRequestGetter,GitPullRequestCreator,GitPullRequestFileLister,GitPullRequestDiffGetter,GitPullRequestReviewLister,GitPullRequestReviewCreator,GitPullRequestReviewerRequester,GitPullRequestMergeabilityGetter,GitPullRequestMerger)
 
 // Package types is a generated GoMock package.
 package types
@@ -504,3 +504,465 @@ func (mr *MockGitPullRequestCreatorMockRecorder) Setup(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestCreator)(nil).Setup), arg0, arg1)
 }
+
+// MockGitPullRequestFileLister is a mock of GitPullRequestFileLister interface.
+type MockGitPullRequestFileLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestFileListerMockRecorder
+}
+
+// MockGitPullRequestFileListerMockRecorder is the mock recorder for MockGitPullRequestFileLister.
+type MockGitPullRequestFileListerMockRecorder struct {
+	mock *MockGitPullRequestFileLister
+}
+
+// NewMockGitPullRequestFileLister creates a new mock instance.
+func NewMockGitPullRequestFileLister(ctrl *gomock.Controller) *MockGitPullRequestFileLister {
+	mock := &MockGitPullRequestFileLister{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestFileListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestFileLister) EXPECT() *MockGitPullRequestFileListerMockRecorder {
+	return m.recorder
+}
+
+// ListPullRequestFiles mocks base method.
+func (m *MockGitPullRequestFileLister) ListPullRequestFiles(arg0 context.Context, arg1 v1alpha1.GitPullRequestOption, arg2 v1alpha1.ListOptions) (v1alpha1.GitPullRequestFileList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestFiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequestFileList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestFiles indicates an expected call of ListPullRequestFiles.
+func (mr *MockGitPullRequestFileListerMockRecorder) ListPullRequestFiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestFiles", reflect.TypeOf((*MockGitPullRequestFileLister)(nil).ListPullRequestFiles), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestFileLister) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestFileListerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestFileLister)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestFileLister) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestFileListerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestFileLister)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestDiffGetter is a mock of GitPullRequestDiffGetter interface.
+type MockGitPullRequestDiffGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestDiffGetterMockRecorder
+}
+
+// MockGitPullRequestDiffGetterMockRecorder is the mock recorder for MockGitPullRequestDiffGetter.
+type MockGitPullRequestDiffGetterMockRecorder struct {
+	mock *MockGitPullRequestDiffGetter
+}
+
+// NewMockGitPullRequestDiffGetter creates a new mock instance.
+func NewMockGitPullRequestDiffGetter(ctrl *gomock.Controller) *MockGitPullRequestDiffGetter {
+	mock := &MockGitPullRequestDiffGetter{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestDiffGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestDiffGetter) EXPECT() *MockGitPullRequestDiffGetterMockRecorder {
+	return m.recorder
+}
+
+// GetPullRequestDiff mocks base method.
+func (m *MockGitPullRequestDiffGetter) GetPullRequestDiff(arg0 context.Context, arg1 v1alpha1.GitPullRequestDiffOption) (v1alpha1.GitPullRequestDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestDiff", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequestDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestDiff indicates an expected call of GetPullRequestDiff.
+func (mr *MockGitPullRequestDiffGetterMockRecorder) GetPullRequestDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestDiff", reflect.TypeOf((*MockGitPullRequestDiffGetter)(nil).GetPullRequestDiff), arg0, arg1)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestDiffGetter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestDiffGetterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestDiffGetter)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestDiffGetter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestDiffGetterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestDiffGetter)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestReviewLister is a mock of GitPullRequestReviewLister interface.
+type MockGitPullRequestReviewLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestReviewListerMockRecorder
+}
+
+// MockGitPullRequestReviewListerMockRecorder is the mock recorder for MockGitPullRequestReviewLister.
+type MockGitPullRequestReviewListerMockRecorder struct {
+	mock *MockGitPullRequestReviewLister
+}
+
+// NewMockGitPullRequestReviewLister creates a new mock instance.
+func NewMockGitPullRequestReviewLister(ctrl *gomock.Controller) *MockGitPullRequestReviewLister {
+	mock := &MockGitPullRequestReviewLister{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestReviewListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestReviewLister) EXPECT() *MockGitPullRequestReviewListerMockRecorder {
+	return m.recorder
+}
+
+// ListPullRequestReviews mocks base method.
+func (m *MockGitPullRequestReviewLister) ListPullRequestReviews(arg0 context.Context, arg1 v1alpha1.GitPullRequestOption, arg2 v1alpha1.ListOptions) (v1alpha1.GitPullRequestReviewList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestReviews", arg0, arg1, arg2)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequestReviewList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestReviews indicates an expected call of ListPullRequestReviews.
+func (mr *MockGitPullRequestReviewListerMockRecorder) ListPullRequestReviews(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestReviews", reflect.TypeOf((*MockGitPullRequestReviewLister)(nil).ListPullRequestReviews), arg0, arg1, arg2)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestReviewLister) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestReviewListerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestReviewLister)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestReviewLister) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestReviewListerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestReviewLister)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestReviewCreator is a mock of GitPullRequestReviewCreator interface.
+type MockGitPullRequestReviewCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestReviewCreatorMockRecorder
+}
+
+// MockGitPullRequestReviewCreatorMockRecorder is the mock recorder for MockGitPullRequestReviewCreator.
+type MockGitPullRequestReviewCreatorMockRecorder struct {
+	mock *MockGitPullRequestReviewCreator
+}
+
+// NewMockGitPullRequestReviewCreator creates a new mock instance.
+func NewMockGitPullRequestReviewCreator(ctrl *gomock.Controller) *MockGitPullRequestReviewCreator {
+	mock := &MockGitPullRequestReviewCreator{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestReviewCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestReviewCreator) EXPECT() *MockGitPullRequestReviewCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreatePullRequestReview mocks base method.
+func (m *MockGitPullRequestReviewCreator) CreatePullRequestReview(arg0 context.Context, arg1 v1alpha1.CreatePullRequestReviewPayload) (v1alpha1.GitPullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequestReview", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequestReview indicates an expected call of CreatePullRequestReview.
+func (mr *MockGitPullRequestReviewCreatorMockRecorder) CreatePullRequestReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestReview", reflect.TypeOf((*MockGitPullRequestReviewCreator)(nil).CreatePullRequestReview), arg0, arg1)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestReviewCreator) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestReviewCreatorMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestReviewCreator)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestReviewCreator) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestReviewCreatorMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestReviewCreator)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestReviewerRequester is a mock of GitPullRequestReviewerRequester interface.
+type MockGitPullRequestReviewerRequester struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestReviewerRequesterMockRecorder
+}
+
+// MockGitPullRequestReviewerRequesterMockRecorder is the mock recorder for MockGitPullRequestReviewerRequester.
+type MockGitPullRequestReviewerRequesterMockRecorder struct {
+	mock *MockGitPullRequestReviewerRequester
+}
+
+// NewMockGitPullRequestReviewerRequester creates a new mock instance.
+func NewMockGitPullRequestReviewerRequester(ctrl *gomock.Controller) *MockGitPullRequestReviewerRequester {
+	mock := &MockGitPullRequestReviewerRequester{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestReviewerRequesterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestReviewerRequester) EXPECT() *MockGitPullRequestReviewerRequesterMockRecorder {
+	return m.recorder
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestReviewerRequester) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestReviewerRequesterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestReviewerRequester)(nil).Path))
+}
+
+// RequestPullRequestReviewers mocks base method.
+func (m *MockGitPullRequestReviewerRequester) RequestPullRequestReviewers(arg0 context.Context, arg1 v1alpha1.RequestPullRequestReviewersPayload) (v1alpha1.GitPullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPullRequestReviewers", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPullRequestReviewers indicates an expected call of RequestPullRequestReviewers.
+func (mr *MockGitPullRequestReviewerRequesterMockRecorder) RequestPullRequestReviewers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPullRequestReviewers", reflect.TypeOf((*MockGitPullRequestReviewerRequester)(nil).RequestPullRequestReviewers), arg0, arg1)
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestReviewerRequester) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestReviewerRequesterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestReviewerRequester)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestMergeabilityGetter is a mock of GitPullRequestMergeabilityGetter interface.
+type MockGitPullRequestMergeabilityGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestMergeabilityGetterMockRecorder
+}
+
+// MockGitPullRequestMergeabilityGetterMockRecorder is the mock recorder for MockGitPullRequestMergeabilityGetter.
+type MockGitPullRequestMergeabilityGetterMockRecorder struct {
+	mock *MockGitPullRequestMergeabilityGetter
+}
+
+// NewMockGitPullRequestMergeabilityGetter creates a new mock instance.
+func NewMockGitPullRequestMergeabilityGetter(ctrl *gomock.Controller) *MockGitPullRequestMergeabilityGetter {
+	mock := &MockGitPullRequestMergeabilityGetter{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestMergeabilityGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestMergeabilityGetter) EXPECT() *MockGitPullRequestMergeabilityGetterMockRecorder {
+	return m.recorder
+}
+
+// GetPullRequestMergeability mocks base method.
+func (m *MockGitPullRequestMergeabilityGetter) GetPullRequestMergeability(arg0 context.Context, arg1 v1alpha1.GitPullRequestOption) (v1alpha1.GitPullRequestMergeability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestMergeability", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequestMergeability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestMergeability indicates an expected call of GetPullRequestMergeability.
+func (mr *MockGitPullRequestMergeabilityGetterMockRecorder) GetPullRequestMergeability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestMergeability", reflect.TypeOf((*MockGitPullRequestMergeabilityGetter)(nil).GetPullRequestMergeability), arg0, arg1)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestMergeabilityGetter) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestMergeabilityGetterMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestMergeabilityGetter)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestMergeabilityGetter) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestMergeabilityGetterMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestMergeabilityGetter)(nil).Setup), arg0, arg1)
+}
+
+// MockGitPullRequestMerger is a mock of GitPullRequestMerger interface.
+type MockGitPullRequestMerger struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitPullRequestMergerMockRecorder
+}
+
+// MockGitPullRequestMergerMockRecorder is the mock recorder for MockGitPullRequestMerger.
+type MockGitPullRequestMergerMockRecorder struct {
+	mock *MockGitPullRequestMerger
+}
+
+// NewMockGitPullRequestMerger creates a new mock instance.
+func NewMockGitPullRequestMerger(ctrl *gomock.Controller) *MockGitPullRequestMerger {
+	mock := &MockGitPullRequestMerger{ctrl: ctrl}
+	mock.recorder = &MockGitPullRequestMergerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitPullRequestMerger) EXPECT() *MockGitPullRequestMergerMockRecorder {
+	return m.recorder
+}
+
+// MergePullRequest mocks base method.
+func (m *MockGitPullRequestMerger) MergePullRequest(arg0 context.Context, arg1 v1alpha1.MergePullRequestPayload) (v1alpha1.GitPullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.GitPullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePullRequest indicates an expected call of MergePullRequest.
+func (mr *MockGitPullRequestMergerMockRecorder) MergePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockGitPullRequestMerger)(nil).MergePullRequest), arg0, arg1)
+}
+
+// Path mocks base method.
+func (m *MockGitPullRequestMerger) Path() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockGitPullRequestMergerMockRecorder) Path() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockGitPullRequestMerger)(nil).Path))
+}
+
+// Setup mocks base method.
+func (m *MockGitPullRequestMerger) Setup(arg0 context.Context, arg1 *zap.SugaredLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockGitPullRequestMergerMockRecorder) Setup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockGitPullRequestMerger)(nil).Setup), arg0, arg1)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/katanomi/pkg/plugin/types (interfaces: WebhookRegister,WebhookCreator,WebhookUpdater,WebhookDeleter,WebhookLister,WebhookResourceDiffer,WebhookReceiver)
+// Source: github.com/katanomi/pkg/plugin/types (interfaces: WebhookRegister,WebhookCreator,WebhookUpdater,WebhookDeleter,WebhookLister,WebhookResourceDiffer,WebhookReceiver,WebhookVerifierGetter)
 
 // Package types is a generated GoMock package.
 package types
@@ -12,6 +12,7 @@ import (
 	restful "github.com/emicklei/go-restful/v3"
 	gomock "github.com/golang/mock/gomock"
 	v1alpha1 "github.com/katanomi/pkg/apis/meta/v1alpha1"
+	webhook "github.com/katanomi/pkg/plugin/webhook"
 	zap "go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	apis "knative.dev/pkg/apis"
@@ -352,3 +353,40 @@ func (mr *MockWebhookReceiverMockRecorder) Setup(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockWebhookReceiver)(nil).Setup), arg0, arg1)
 }
+
+// MockWebhookVerifierGetter is a mock of WebhookVerifierGetter interface.
+type MockWebhookVerifierGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookVerifierGetterMockRecorder
+}
+
+// MockWebhookVerifierGetterMockRecorder is the mock recorder for MockWebhookVerifierGetter.
+type MockWebhookVerifierGetterMockRecorder struct {
+	mock *MockWebhookVerifierGetter
+}
+
+// NewMockWebhookVerifierGetter creates a new mock instance.
+func NewMockWebhookVerifierGetter(ctrl *gomock.Controller) *MockWebhookVerifierGetter {
+	mock := &MockWebhookVerifierGetter{ctrl: ctrl}
+	mock.recorder = &MockWebhookVerifierGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookVerifierGetter) EXPECT() *MockWebhookVerifierGetterMockRecorder {
+	return m.recorder
+}
+
+// WebhookVerifier mocks base method.
+func (m *MockWebhookVerifierGetter) WebhookVerifier() webhook.Verifier {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookVerifier")
+	ret0, _ := ret[0].(webhook.Verifier)
+	return ret0
+}
+
+// WebhookVerifier indicates an expected call of WebhookVerifier.
+func (mr *MockWebhookVerifierGetterMockRecorder) WebhookVerifier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookVerifier", reflect.TypeOf((*MockWebhookVerifierGetter)(nil).WebhookVerifier))
+}